@@ -78,6 +78,7 @@ func buildSBOM(app *config.Application, si source.Input, writer sbom.Writer, err
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct source from user input %q: %w", si.UserInput, err)
 	}
+	src.IncludedMounts = app.RootFS.IncludedMounts
 
 	s, err := packages.GenerateSBOM(src, errs, app)
 	if err != nil {
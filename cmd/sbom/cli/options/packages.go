@@ -22,6 +22,7 @@ type PackagesOptions struct {
 	Exclude            []string
 	Catalogers         []string
 	Name               string
+	ListClassifiers    bool
 }
 
 var _ Interface = (*PackagesOptions)(nil)
@@ -51,6 +52,9 @@ func (o *PackagesOptions) AddFlags(cmd *cobra.Command, v *viper.Viper) error {
 	cmd.Flags().StringVarP(&o.Name, "name", "", "",
 		"set the name of the target being analyzed")
 
+	cmd.Flags().BoolVarP(&o.ListClassifiers, "list-classifiers", "", false,
+		"list the binary classifiers (including any defined in the configuration) and exit")
+
 	return bindPackageConfigOptions(cmd.Flags(), v)
 }
 
@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -41,6 +42,8 @@ const (
 `
 	nonImageSchemeHelp = `    {{.appName}} {{.command}} dir:path/to/yourproject                  read directly from a path on disk (any directory)
     {{.appName}} {{.command}} file:path/to/yourproject/file            read directly from a path on disk (any single file)
+    {{.appName}} {{.command}} rootfs:/                                 read the root filesystem of a host, VM, or exported container
+    {{.appName}} {{.command}} disk:path/to/vm.qcow2                    read the OS partition of a VM disk image (raw, qcow2, or vmdk)
 `
 	packagesSchemeHelp = "\n" + indent + schemeHelpHeader + "\n" + imageSchemeHelp + nonImageSchemeHelp
 
@@ -64,11 +67,17 @@ func Packages(v *viper.Viper, app *config.Application, ro *options.RootOptions,
 			// configure logging for command
 			newLogWrapper(app)
 			logApplicationConfig(app)
+			if po.ListClassifiers {
+				return nil
+			}
 			return validateArgs(cmd, args)
 		},
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if po.ListClassifiers {
+				return packages.ListClassifiers(os.Stdout, app)
+			}
 			if app.CheckForAppUpdate {
 				checkForApplicationUpdate()
 			}
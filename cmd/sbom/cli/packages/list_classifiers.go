@@ -0,0 +1,29 @@
+package packages
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/nextlinux/sbom/internal/config"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/binary"
+)
+
+// ListClassifiers writes a table of the binary classifiers that would be used with the given configuration.
+func ListClassifiers(w io.Writer, app *config.Application) error {
+	classifiers, err := binary.ListClassifiers(app.ToCatalogerConfig().Binary())
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CLASS\tPACKAGE\tFILE GLOB\tSOURCE")
+	for _, c := range classifiers {
+		glob := c.FileGlob
+		if glob == "" {
+			glob = "(any binary)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Class, c.Package, glob, c.Source)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,58 @@
+package packages
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/internal/config"
+)
+
+func TestListClassifiers(t *testing.T) {
+	app := &config.Application{}
+	require.NoError(t, app.LoadAllValues(viper.New(), "test-fixtures/classifiers.yaml"))
+
+	var buf bytes.Buffer
+	require.NoError(t, ListClassifiers(&buf, app))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.NotEmpty(t, lines)
+	assert.Equal(t, []string{"CLASS", "PACKAGE", "FILE", "GLOB", "SOURCE"}, strings.Fields(lines[0]))
+
+	rows := make(map[string][]string)
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		rows[fields[0]] = fields[1:]
+	}
+
+	tests := []struct {
+		class    string
+		expected []string
+	}{
+		{
+			// user-defined classifier
+			class:    "acme-agent-binary",
+			expected: []string{"acme-agent", "**/acme-agent", "config"},
+		},
+		{
+			// built-in classifier
+			class:    "python-binary",
+			expected: []string{"python", "**/python*", "default"},
+		},
+		{
+			// built-in library classifier, which is matched against every binary
+			class:    "openssl-library",
+			expected: []string{"openssl", "(any", "binary)", "default"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.class, func(t *testing.T) {
+			assert.Equal(t, test.expected, rows[test.class])
+		})
+	}
+}
@@ -74,6 +74,7 @@ func execWorker(app *config.Application, si source.Input, writer sbom.Writer) <-
 			errs <- fmt.Errorf("failed to construct source from user input %q: %w", si.UserInput, err)
 			return
 		}
+		src.IncludedMounts = app.RootFS.IncludedMounts
 
 		s, err := GenerateSBOM(src, errs, app)
 		if err != nil {
@@ -89,6 +89,7 @@ func execWorker(app *config.Application, si source.Input, writer sbom.Writer) <-
 		if cleanup != nil {
 			defer cleanup()
 		}
+		src.IncludedMounts = app.RootFS.IncludedMounts
 
 		s := sbom.SBOM{
 			Source: src.Metadata,
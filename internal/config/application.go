@@ -19,7 +19,9 @@ import (
 	"github.com/nextlinux/sbom/internal/log"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger"
 	golangCataloger "github.com/nextlinux/sbom/sbom/pkg/cataloger/golang"
+	javaCataloger "github.com/nextlinux/sbom/sbom/pkg/cataloger/java"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/kernel"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/linkage"
 )
 
 var (
@@ -51,7 +53,10 @@ type Application struct {
 	Catalogers             []string           `yaml:"catalogers" json:"catalogers" mapstructure:"catalogers"`
 	Package                pkg                `yaml:"package" json:"package" mapstructure:"package"`
 	Golang                 golang             `yaml:"golang" json:"golang" mapstructure:"golang"`
+	Java                   java               `yaml:"java" json:"java" mapstructure:"java"`
 	LinuxKernel            linuxKernel        `yaml:"linux-kernel" json:"linux-kernel" mapstructure:"linux-kernel"`
+	Binary                 binary             `yaml:"binary" json:"binary" mapstructure:"binary"`
+	BinaryLinkage          binaryLinkage      `yaml:"binary-linkage" json:"binary-linkage" mapstructure:"binary-linkage"`
 	Attest                 attest             `yaml:"attest" json:"attest" mapstructure:"attest"`
 	FileMetadata           FileMetadata       `yaml:"file-metadata" json:"file-metadata" mapstructure:"file-metadata"`
 	FileClassification     fileClassification `yaml:"file-classification" json:"file-classification" mapstructure:"file-classification"`
@@ -59,6 +64,7 @@ type Application struct {
 	Secrets                secrets            `yaml:"secrets" json:"secrets" mapstructure:"secrets"`
 	Registry               registry           `yaml:"registry" json:"registry" mapstructure:"registry"`
 	Exclusions             []string           `yaml:"exclude" json:"exclude" mapstructure:"exclude"`
+	RootFS                 rootFS             `yaml:"rootfs" json:"rootfs" mapstructure:"rootfs"`
 	Platform               string             `yaml:"platform" json:"platform" mapstructure:"platform"`
 	Name                   string             `yaml:"name" json:"name" mapstructure:"name"`
 	Parallelism            int                `yaml:"parallelism" json:"parallelism" mapstructure:"parallelism"`                                           // the number of catalog workers to run in parallel
@@ -83,6 +89,14 @@ func (cfg Application) ToCatalogerConfig() cataloger.Config {
 		LinuxKernel: kernel.LinuxCatalogerConfig{
 			CatalogModules: cfg.LinuxKernel.CatalogModules,
 		},
+		BinaryCataloger: cfg.Binary.ToCatalogerConfig(),
+		Linkage: linkage.Config{
+			Enabled: cfg.BinaryLinkage.Enabled,
+		},
+		Maven: javaCataloger.MavenConfig{
+			UseLocalRepository: cfg.Java.UseMavenLocalRepository,
+			LocalRepositoryDir: cfg.Java.MavenLocalRepositoryDir,
+		},
 	}
 }
 
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+
+	binaryCataloger "github.com/nextlinux/sbom/sbom/pkg/cataloger/binary"
+)
+
+type binary struct {
+	Classifiers []binaryClassifier `yaml:"classifiers" json:"classifiers" mapstructure:"classifiers"`
+}
+
+type binaryClassifier struct {
+	Class          string   `yaml:"class" json:"class" mapstructure:"class"`
+	FileGlob       string   `yaml:"file-glob" json:"file-glob" mapstructure:"file-glob"`
+	VersionPattern string   `yaml:"version-pattern" json:"version-pattern" mapstructure:"version-pattern"`
+	Package        string   `yaml:"package" json:"package" mapstructure:"package"`
+	PURL           string   `yaml:"purl" json:"purl" mapstructure:"purl"`
+	CPEs           []string `yaml:"cpes" json:"cpes" mapstructure:"cpes"`
+}
+
+func (cfg *binary) parseConfigValues() error {
+	if err := cfg.ToCatalogerConfig().Validate(); err != nil {
+		return fmt.Errorf("bad binary.classifiers value: %w", err)
+	}
+	return nil
+}
+
+func (cfg binary) ToCatalogerConfig() binaryCataloger.CatalogerConfig {
+	c := binaryCataloger.DefaultCatalogerConfig()
+	for _, cls := range cfg.Classifiers {
+		c.Classifiers = append(c.Classifiers, binaryCataloger.ClassifierConfig{
+			Class:          cls.Class,
+			FileGlob:       cls.FileGlob,
+			VersionPattern: cls.VersionPattern,
+			Package:        cls.Package,
+			PURL:           cls.PURL,
+			CPEs:           cls.CPEs,
+		})
+	}
+	return c
+}
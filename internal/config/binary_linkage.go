@@ -0,0 +1,14 @@
+package config
+
+import "github.com/spf13/viper"
+
+// binaryLinkage configures the analysis of the shared libraries loaded by ELF binaries (see linkage.Config). When
+// enabled (binary-linkage.enabled, or SBOM_BINARY_LINKAGE_ENABLED), relationships are added from each binary to the
+// libraries it loads, and from the packages owning the binaries to the packages owning the libraries.
+type binaryLinkage struct {
+	Enabled bool `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+}
+
+func (cfg binaryLinkage) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("binary-linkage.enabled", false)
+}
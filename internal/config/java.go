@@ -0,0 +1,13 @@
+package config
+
+import "github.com/spf13/viper"
+
+type java struct {
+	UseMavenLocalRepository bool   `json:"use-maven-local-repository" yaml:"use-maven-local-repository" mapstructure:"use-maven-local-repository"`
+	MavenLocalRepositoryDir string `json:"maven-local-repository-dir" yaml:"maven-local-repository-dir" mapstructure:"maven-local-repository-dir"`
+}
+
+func (cfg java) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("java.use-maven-local-repository", false)
+	v.SetDefault("java.maven-local-repository-dir", "")
+}
@@ -0,0 +1,13 @@
+package config
+
+import "github.com/spf13/viper"
+
+type rootFS struct {
+	// IncludedMounts are the mount points (relative to the scanned root, e.g. a separate /usr or /var) that are
+	// indexed when cataloging a root filesystem, all other mounted filesystems are skipped.
+	IncludedMounts []string `json:"included-mounts" yaml:"included-mounts" mapstructure:"included-mounts"`
+}
+
+func (cfg rootFS) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("rootfs.included-mounts", []string{})
+}
@@ -16,6 +16,11 @@ const (
 	// ContainsRelationship (supports any-to-any linkages) is a proxy for the SPDX 2.2 CONTAINS relationship.
 	ContainsRelationship RelationshipType = "contains"
 
+	// ContainedInRelationship (supports package-to-file linkages) indicates that the package has been compiled into
+	// or vendored within the file (e.g. a library statically linked into a binary). This is a proxy for the SPDX 2.2
+	// CONTAINED_BY relationship.
+	ContainedInRelationship RelationshipType = "contained-in"
+
 	// DependencyOfRelationship is a proxy for the SPDX 2.2.1 DEPENDENCY_OF	relationship.
 	DependencyOfRelationship RelationshipType = "dependency-of"
 
@@ -27,6 +32,7 @@ func AllRelationshipTypes() []RelationshipType {
 	return []RelationshipType{
 		OwnershipByFileOverlapRelationship,
 		ContainsRelationship,
+		ContainedInRelationship,
 		DependencyOfRelationship,
 		DescribedByRelationship,
 	}
@@ -253,6 +253,12 @@ func extractComponents(meta *cyclonedx.Metadata) source.Metadata {
 			Path:          c.Name,
 			ImageMetadata: image,
 		}
+	case cyclonedx.ComponentTypeOS:
+		return source.Metadata{
+			Scheme: source.RootFSScheme,
+			Path:   c.Name,
+			Base:   c.Name,
+		}
 	}
 	return source.Metadata{}
 }
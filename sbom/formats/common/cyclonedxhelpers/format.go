@@ -200,6 +200,20 @@ func toBomDescriptorComponent(srcMetadata source.Metadata) *cyclonedx.Component
 			Type:   cyclonedx.ComponentTypeFile,
 			Name:   name,
 		}
+	case source.RootFSScheme, source.DiskImageScheme:
+		if name == "" {
+			name = srcMetadata.Path
+		}
+		bomRef, err := artifact.IDByHash(srcMetadata.Path)
+		if err != nil {
+			log.Warnf("unable to get fingerprint of source metadata path=%s: %+v", srcMetadata.Path, err)
+		}
+		// a root filesystem (or disk image) describes an entire host or VM, not just a collection of files
+		return &cyclonedx.Component{
+			BOMRef: string(bomRef),
+			Type:   cyclonedx.ComponentTypeOS,
+			Name:   name,
+		}
 	}
 
 	return nil
@@ -12,7 +12,7 @@ func DocumentName(srcMetadata source.Metadata) string {
 	switch srcMetadata.Scheme {
 	case source.ImageScheme:
 		return srcMetadata.ImageMetadata.UserInput
-	case source.DirectoryScheme, source.FileScheme:
+	case source.DirectoryScheme, source.FileScheme, source.RootFSScheme, source.DiskImageScheme:
 		return srcMetadata.Path
 	default:
 		return "unknown"
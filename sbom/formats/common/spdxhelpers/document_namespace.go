@@ -16,6 +16,8 @@ const (
 	inputImage     = "image"
 	inputDirectory = "dir"
 	inputFile      = "file"
+	inputRootFS    = "rootfs"
+	inputDiskImage = "disk-image"
 )
 
 func DocumentNameAndNamespace(srcMetadata source.Metadata) (string, string) {
@@ -33,6 +35,10 @@ func DocumentNamespace(name string, srcMetadata source.Metadata) string {
 		input = inputDirectory
 	case source.FileScheme:
 		input = inputFile
+	case source.RootFSScheme:
+		input = inputRootFS
+	case source.DiskImageScheme:
+		input = inputDiskImage
 	}
 
 	uniqueID := uuid.Must(uuid.NewRandom())
@@ -12,6 +12,8 @@ func SourceInfo(p pkg.Package) string {
 	switch p.Type {
 	case pkg.AlpmPkg:
 		answer = "acquired package info from ALPM DB"
+	case pkg.AndroidPkg:
+		answer = "acquired package info from android application or library manifest"
 	case pkg.RpmPkg:
 		answer = "acquired package info from RPM DB"
 	case pkg.ApkPkg:
@@ -36,6 +38,8 @@ func SourceInfo(p pkg.Package) string {
 		answer = "acquired package info from rust cargo manifest"
 	case pkg.PhpComposerPkg:
 		answer = "acquired package info from PHP composer manifest"
+	case pkg.PhpPlatformPkg:
+		answer = "acquired package info from PHP composer platform requirements"
 	case pkg.CocoapodsPkg:
 		answer = "acquired package info from installed cocoapods manifest file"
 	case pkg.ConanPkg:
@@ -52,6 +56,28 @@ func SourceInfo(p pkg.Package) string {
 		answer = "acquired package info from linux kernel module files"
 	case pkg.NixPkg:
 		answer = "acquired package info from nix store path"
+	case pkg.SwiftPkg:
+		answer = "acquired package info from resolved Swift package manifest"
+	case pkg.GithubActionPkg:
+		answer = "acquired package info from GitHub Actions workflow or action definition"
+	case pkg.TerraformPkg:
+		answer = "acquired package info from terraform dependency lock file or provider plugin directory"
+	case pkg.VcpkgPkg:
+		answer = "acquired package info from vcpkg manifest or installed status database"
+	case pkg.WordpressCorePkg, pkg.WordpressPluginPkg, pkg.WordpressThemePkg:
+		answer = "acquired package info from WordPress version file or plugin and theme headers"
+	case pkg.DrupalPkg:
+		answer = "acquired package info from Drupal core version file or extension info file"
+	case pkg.RPkg:
+		answer = "acquired package info from renv lock file or installed R package DESCRIPTION file"
+	case pkg.JuliaPkg:
+		answer = "acquired package info from Julia manifest or project file"
+	case pkg.CpanPkg:
+		answer = "acquired package info from carton snapshot or installed Perl distribution metadata"
+	case pkg.LuaRocksPkg:
+		answer = "acquired package info from Lua rockspec or installed rock manifest"
+	case pkg.OpamPkg:
+		answer = "acquired package info from opam switch state or opam lock file"
 	default:
 		answer = "acquired package info from the following paths"
 	}
@@ -127,6 +127,14 @@ func Test_SourceInfo(t *testing.T) {
 				"from PHP composer manifest",
 			},
 		},
+		{
+			input: pkg.Package{
+				Type: pkg.PhpPlatformPkg,
+			},
+			expected: []string{
+				"from PHP composer platform requirements",
+			},
+		},
 		{
 			input: pkg.Package{
 				Type: pkg.DartPubPkg,
@@ -223,6 +231,118 @@ func Test_SourceInfo(t *testing.T) {
 				"from nix store path",
 			},
 		},
+		{
+			input: pkg.Package{
+				Type: pkg.SwiftPkg,
+			},
+			expected: []string{
+				"from resolved Swift package manifest",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.GithubActionPkg,
+			},
+			expected: []string{
+				"from GitHub Actions workflow or action definition",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.TerraformPkg,
+			},
+			expected: []string{
+				"from terraform dependency lock file or provider plugin directory",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.VcpkgPkg,
+			},
+			expected: []string{
+				"from vcpkg manifest or installed status database",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.AndroidPkg,
+			},
+			expected: []string{
+				"from android application or library manifest",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.WordpressCorePkg,
+			},
+			expected: []string{
+				"from WordPress version file or plugin and theme headers",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.WordpressPluginPkg,
+			},
+			expected: []string{
+				"from WordPress version file or plugin and theme headers",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.WordpressThemePkg,
+			},
+			expected: []string{
+				"from WordPress version file or plugin and theme headers",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.DrupalPkg,
+			},
+			expected: []string{
+				"from Drupal core version file or extension info file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.RPkg,
+			},
+			expected: []string{
+				"from renv lock file or installed R package DESCRIPTION file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.JuliaPkg,
+			},
+			expected: []string{
+				"from Julia manifest or project file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.CpanPkg,
+			},
+			expected: []string{
+				"from carton snapshot or installed Perl distribution metadata",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.LuaRocksPkg,
+			},
+			expected: []string{
+				"from Lua rockspec or installed rock manifest",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.OpamPkg,
+			},
+			expected: []string{
+				"from opam switch state or opam lock file",
+			},
+		},
 	}
 	var pkgTypes []pkg.Type
 	for _, test := range tests {
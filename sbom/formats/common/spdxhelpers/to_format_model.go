@@ -404,6 +404,8 @@ func lookupRelationship(ty artifact.RelationshipType) (bool, RelationshipType, s
 	switch ty {
 	case artifact.ContainsRelationship:
 		return true, ContainsRelationship, ""
+	case artifact.ContainedInRelationship:
+		return true, ContainedByRelationship, ""
 	case artifact.DependencyOfRelationship:
 		return true, DependencyOfRelationship, ""
 	case artifact.OwnershipByFileOverlapRelationship:
@@ -203,6 +203,11 @@ func Test_lookupRelationship(t *testing.T) {
 			exists: true,
 			ty:     ContainsRelationship,
 		},
+		{
+			input:  artifact.ContainedInRelationship,
+			exists: true,
+			ty:     ContainedByRelationship,
+		},
 		{
 			input:   artifact.OwnershipByFileOverlapRelationship,
 			exists:  true,
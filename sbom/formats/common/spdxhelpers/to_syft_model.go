@@ -68,6 +68,10 @@ func extractSchemeFromNamespace(ns string) source.Scheme {
 			return source.ImageScheme
 		case inputDirectory:
 			return source.DirectoryScheme
+		case inputRootFS:
+			return source.RootFSScheme
+		case inputDiskImage:
+			return source.DiskImageScheme
 		}
 	}
 	return source.UnknownScheme
@@ -180,6 +184,9 @@ func tosbomRelationships(spdxIDMap map[string]interface{}, doc *spdx.Document) [
 			case ContainsRelationship:
 				typ = artifact.ContainsRelationship
 				to = toLocation
+			case ContainedByRelationship:
+				typ = artifact.ContainedInRelationship
+				to = toLocation
 			case OtherRelationship:
 				// Encoding uses a specifically formatted comment...
 				if strings.Index(r.RelationshipComment, string(artifact.EvidentByRelationship)) == 0 {
@@ -88,12 +88,14 @@ func toPath(s source.Metadata, p pkg.Package) string {
 		case source.ImageScheme:
 			image := strings.ReplaceAll(s.ImageMetadata.UserInput, ":/", "//")
 			return fmt.Sprintf("%s:/%s", image, packagePath)
+		case source.DiskImageScheme:
+			return fmt.Sprintf("%s:/%s", inputPath, packagePath)
 		case source.FileScheme:
 			if isArchive(inputPath) {
 				return fmt.Sprintf("%s:/%s", inputPath, packagePath)
 			}
 			return inputPath
-		case source.DirectoryScheme:
+		case source.DirectoryScheme, source.RootFSScheme:
 			if inputPath != "" {
 				return fmt.Sprintf("%s/%s", inputPath, packagePath)
 			}
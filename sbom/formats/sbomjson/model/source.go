@@ -33,7 +33,7 @@ func (s *Source) UnmarshalJSON(b []byte) error {
 	s.ID = unpacker.ID
 
 	switch s.Type {
-	case "directory", "file":
+	case "directory", "file", "rootfs":
 		if target, err := strconv.Unquote(string(unpacker.Target)); err == nil {
 			s.Target = target
 		} else {
@@ -47,6 +47,13 @@ func (s *Source) UnmarshalJSON(b []byte) error {
 		}
 		s.Target = payload
 
+	case "disk-image":
+		var payload source.DiskImageMetadata
+		if err := json.Unmarshal(unpacker.Target, &payload); err != nil {
+			return err
+		}
+		s.Target = payload
+
 	default:
 		return fmt.Errorf("unsupported package metadata type: %+v", s.Type)
 	}
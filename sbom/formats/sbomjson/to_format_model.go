@@ -267,6 +267,18 @@ func toSourceModel(src source.Metadata) (model.Source, error) {
 			Type:   "file",
 			Target: src.Path,
 		}, nil
+	case source.RootFSScheme:
+		return model.Source{
+			ID:     src.ID,
+			Type:   "rootfs",
+			Target: src.Path,
+		}, nil
+	case source.DiskImageScheme:
+		return model.Source{
+			ID:     src.ID,
+			Type:   "disk-image",
+			Target: src.DiskImageMetadata,
+		}, nil
 	default:
 		return model.Source{}, fmt.Errorf("unsupported source: %q", src.Scheme)
 	}
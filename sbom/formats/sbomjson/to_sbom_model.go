@@ -191,7 +191,7 @@ func tosbomRelationship(idMap map[string]interface{}, relationship model.Relatio
 	typ := artifact.RelationshipType(relationship.Type)
 
 	switch typ {
-	case artifact.OwnershipByFileOverlapRelationship, artifact.ContainsRelationship, artifact.DependencyOfRelationship, artifact.EvidentByRelationship:
+	case artifact.OwnershipByFileOverlapRelationship, artifact.ContainsRelationship, artifact.ContainedInRelationship, artifact.DependencyOfRelationship, artifact.EvidentByRelationship:
 	default:
 		if !strings.Contains(string(typ), "dependency-of") {
 			log.Warnf("unknown relationship type: %s", typ)
@@ -241,6 +241,30 @@ func tosbomSourceData(s model.Source) *source.Metadata {
 			Scheme: source.FileScheme,
 			Path:   path,
 		}
+	case "rootfs":
+		path, ok := s.Target.(string)
+		if !ok {
+			log.Warnf("unable to parse source target as string: %+v", s.Target)
+			return nil
+		}
+		return &source.Metadata{
+			ID:     s.ID,
+			Scheme: source.RootFSScheme,
+			Path:   path,
+			Base:   path,
+		}
+	case "disk-image":
+		metadata, ok := s.Target.(source.DiskImageMetadata)
+		if !ok {
+			log.Warnf("unable to parse source target as disk image metadata: %+v", s.Target)
+			return nil
+		}
+		return &source.Metadata{
+			ID:                s.ID,
+			Scheme:            source.DiskImageScheme,
+			Path:              metadata.UserInput,
+			DiskImageMetadata: metadata,
+		}
 	case "image":
 		metadata, ok := s.Target.(source.ImageMetadata)
 		if !ok {
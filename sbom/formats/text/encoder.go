@@ -15,8 +15,16 @@ func encoder(output io.Writer, s sbom.SBOM) error {
 	w.Init(output, 0, 8, 0, '\t', tabwriter.AlignRight)
 
 	switch s.Source.Scheme {
-	case source.DirectoryScheme, source.FileScheme:
+	case source.DirectoryScheme, source.FileScheme, source.RootFSScheme:
 		fmt.Fprintf(w, "[Path: %s]\n", s.Source.Path)
+	case source.DiskImageScheme:
+		partition := s.Source.DiskImageMetadata.Partition
+		fmt.Fprintf(w, "[Disk Image: %s]\n", s.Source.Path)
+		fmt.Fprintln(w, " Format:\t", s.Source.DiskImageMetadata.Format)
+		fmt.Fprintln(w, " Partition:\t", partition.Number)
+		fmt.Fprintln(w, " Filesystem:\t", partition.Filesystem)
+		fmt.Fprintln(w)
+		w.Flush()
 	case source.ImageScheme:
 		fmt.Fprintln(w, "[Image]")
 
@@ -28,6 +28,7 @@ import (
 	"github.com/nextlinux/sbom/sbom/linux"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/linkage"
 	"github.com/nextlinux/sbom/sbom/source"
 )
 
@@ -64,6 +65,12 @@ func CatalogPackages(src *source.Source, cfg cataloger.Config) (*pkg.Collection,
 		case source.DirectoryScheme:
 			log.Info("cataloging directory")
 			catalogers = cataloger.DirectoryCatalogers(cfg)
+		case source.RootFSScheme:
+			log.Info("cataloging root filesystem")
+			catalogers = cataloger.ImageCatalogers(cfg)
+		case source.DiskImageScheme:
+			log.Info("cataloging disk image")
+			catalogers = cataloger.ImageCatalogers(cfg)
 		default:
 			return nil, nil, nil, fmt.Errorf("unable to determine cataloger set from scheme=%+v", src.Metadata.Scheme)
 		}
@@ -71,6 +78,10 @@ func CatalogPackages(src *source.Source, cfg cataloger.Config) (*pkg.Collection,
 
 	catalog, relationships, err := cataloger.Catalog(resolver, release, cfg.Parallelism, catalogers...)
 
+	if cfg.Linkage.Enabled {
+		relationships = append(relationships, linkage.Relationships(resolver, catalog, relationships)...)
+	}
+
 	relationships = append(relationships, newSourceRelationshipsFromCatalog(src, catalog)...)
 
 	return catalog, relationships, release, err
@@ -0,0 +1,22 @@
+package pkg
+
+// AndroidAppMetadata represents all captured data for an Android application (apk, aab) or library (aar) from the
+// AndroidManifest.xml within the archive.
+type AndroidAppMetadata struct {
+	// Package is the application ID (e.g. "com.example.app")
+	Package string `json:"package"`
+	// ArchiveType is the kind of archive the manifest was found in (apk, aab or aar)
+	ArchiveType      string `json:"archiveType"`
+	VersionCode      string `json:"versionCode,omitempty"`
+	MinSdkVersion    string `json:"minSdkVersion,omitempty"`
+	TargetSdkVersion string `json:"targetSdkVersion,omitempty"`
+}
+
+// AndroidNativeLibraryMetadata represents all captured data for a native shared library bundled within an Android
+// archive.
+type AndroidNativeLibraryMetadata struct {
+	// Path is the location of the library within the archive (e.g. "lib/arm64-v8a/libexample.so")
+	Path string `json:"path"`
+	// ABI is the application binary interface that the library was built for (e.g. "arm64-v8a")
+	ABI string `json:"abi"`
+}
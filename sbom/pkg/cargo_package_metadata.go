@@ -6,4 +6,7 @@ type CargoPackageMetadata struct {
 	Source       string   `toml:"source" json:"source"`
 	Checksum     string   `toml:"checksum" json:"checksum"`
 	Dependencies []string `toml:"dependencies" json:"dependencies"`
+	// WorkspaceMember is true when the crate is a member of the cargo workspace that the lock file belongs to (as
+	// opposed to a third-party crate).
+	WorkspaceMember bool `toml:"-" json:"workspaceMember,omitempty"`
 }
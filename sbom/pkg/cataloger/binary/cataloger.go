@@ -9,16 +9,26 @@ import (
 
 const catalogerName = "binary-cataloger"
 
-func NewCataloger() *Cataloger {
-	return &Cataloger{}
+func NewCataloger(cfg CatalogerConfig) *Cataloger {
+	classifiers, err := cfg.classifiers()
+	if err != nil {
+		// note: the configuration should have been validated before the cataloger is created
+		log.WithFields("error", err).Warn("ignoring user-defined binary classifiers")
+		classifiers = defaultClassifiers
+	}
+	return &Cataloger{
+		classifiers: classifiers,
+	}
 }
 
 // Cataloger is the cataloger responsible for surfacing evidence of a very limited set of binary files,
 // which have been identified by the classifiers. The Cataloger is _NOT_ a place to catalog any and every
 // binary, but rather the specific set that has been curated to be important, predominantly related to toolchain-
 // related runtimes like Python, Go, Java, or Node. Some exceptions can be made for widely-used binaries such
-// as busybox.
-type Cataloger struct{}
+// as busybox (as well as any classifiers defined by the user).
+type Cataloger struct {
+	classifiers []classifier
+}
 
 // Name returns a string that uniquely describes the Cataloger
 func (c Cataloger) Name() string {
@@ -29,33 +39,49 @@ func (c Cataloger) Name() string {
 // after analyzing the catalog source.
 func (c Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
 	var packages []pkg.Package
-	var relationships []artifact.Relationship
 
-	for _, cls := range defaultClassifiers {
+	for _, cls := range c.classifiers {
 		log.WithFields("classifier", cls.Class).Trace("cataloging binaries")
 		newPkgs, err := catalog(resolver, cls)
 		if err != nil {
 			log.WithFields("error", err, "classifier", cls.Class).Warn("unable to catalog binary package: %w", err)
 			continue
 		}
-	newPackages:
-		for i := range newPkgs {
-			newPkg := &newPkgs[i]
-			for j := range packages {
-				p := &packages[j]
-				// consolidate identical packages found in different locations or by different classifiers
-				if packagesMatch(p, newPkg) {
-					mergePackages(p, newPkg)
-					continue newPackages
-				}
-			}
-			packages = append(packages, *newPkg)
-		}
+		packages = consolidatePackages(packages, newPkgs)
 	}
 
+	log.Trace("cataloging libraries within binaries")
+	libraryPkgs, err := catalogLibraries(resolver, defaultLibraryClassifiers)
+	if err != nil {
+		log.WithFields("error", err).Warn("unable to catalog libraries within binaries: %w", err)
+	}
+	packages = consolidatePackages(packages, libraryPkgs)
+
+	// libraries are related to the binaries they were found within (only after consolidating packages, since the
+	// relationships must refer to the packages that are returned)
+	relationships := containedInRelationships(packages, defaultLibraryClassifiers)
+
 	return packages, relationships, nil
 }
 
+// consolidatePackages adds the new packages to the existing packages, merging identical packages found in different
+// locations or by different classifiers
+func consolidatePackages(packages []pkg.Package, newPkgs []pkg.Package) []pkg.Package {
+newPackages:
+	for i := range newPkgs {
+		newPkg := &newPkgs[i]
+		for j := range packages {
+			p := &packages[j]
+			if packagesMatch(p, newPkg) {
+				mergePackages(p, newPkg)
+				continue newPackages
+			}
+		}
+		packages = append(packages, *newPkg)
+	}
+	return packages
+}
+
 // mergePackages merges information from the extra package into the target package
 func mergePackages(target *pkg.Package, extra *pkg.Package) {
 	// add the locations
@@ -730,7 +730,7 @@ func Test_Cataloger_DefaultClassifiers_PositiveCases(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			c := NewCataloger()
+			c := NewCataloger(DefaultCatalogerConfig())
 
 			src, err := source.NewFromDirectory(test.fixtureDir)
 			require.NoError(t, err)
@@ -768,7 +768,7 @@ func Test_Cataloger_DefaultClassifiers_PositiveCases_Image(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			c := NewCataloger()
+			c := NewCataloger(DefaultCatalogerConfig())
 
 			img := imagetest.GetFixtureImage(t, "docker-archive", test.fixtureImage)
 			src, err := source.NewFromImage(img, "test-img")
@@ -799,7 +799,7 @@ func Test_Cataloger_DefaultClassifiers_PositiveCases_Image(t *testing.T) {
 }
 
 func TestClassifierCataloger_DefaultClassifiers_NegativeCases(t *testing.T) {
-	c := NewCataloger()
+	c := NewCataloger(DefaultCatalogerConfig())
 
 	src, err := source.NewFromDirectory("test-fixtures/classifiers/negative")
 	assert.NoError(t, err)
@@ -968,7 +968,7 @@ func (p *panicyResolver) FileMetadataByLocation(_ source.Location) (source.FileM
 var _ source.FileResolver = (*panicyResolver)(nil)
 
 func Test_Cataloger_ResilientToErrors(t *testing.T) {
-	c := NewCataloger()
+	c := NewCataloger(DefaultCatalogerConfig())
 
 	resolver := &panicyResolver{}
 	_, _, err := c.Catalog(resolver)
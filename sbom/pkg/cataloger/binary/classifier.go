@@ -222,6 +222,11 @@ func sharedLibraries(resolver source.FileResolver, location source.Location) ([]
 		return nil, err
 	}
 
+	return importedLibraries(location, contents), nil
+}
+
+// importedLibraries returns a list of all shared libraries imported by the given binary contents
+func importedLibraries(location source.Location, contents []byte) []string {
 	r := bytes.NewReader(contents)
 
 	e, _ := elf.NewFile(r)
@@ -230,7 +235,7 @@ func sharedLibraries(resolver source.FileResolver, location source.Location) ([]
 		if err != nil {
 			log.Debugf("unable to read elf binary at: %s -- %s", location.RealPath, err)
 		}
-		return symbols, nil
+		return symbols
 	}
 
 	m, _ := macho.NewFile(r)
@@ -239,7 +244,7 @@ func sharedLibraries(resolver source.FileResolver, location source.Location) ([]
 		if err != nil {
 			log.Debugf("unable to read macho binary at: %s -- %s", location.RealPath, err)
 		}
-		return symbols, nil
+		return symbols
 	}
 
 	p, _ := pe.NewFile(r)
@@ -248,8 +253,8 @@ func sharedLibraries(resolver source.FileResolver, location source.Location) ([]
 		if err != nil {
 			log.Debugf("unable to read pe binary at: %s -- %s", location.RealPath, err)
 		}
-		return symbols, nil
+		return symbols
 	}
 
-	return nil, nil
+	return nil
 }
@@ -0,0 +1,256 @@
+package binary
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"text/template"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/cpe"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+const (
+	// DefaultClassifierSource indicates a classifier that is built into the cataloger
+	DefaultClassifierSource = "default"
+	// ConfigClassifierSource indicates a classifier that is defined within the application configuration
+	ConfigClassifierSource = "config"
+)
+
+type CatalogerConfig struct {
+	// Classifiers are user-defined classifiers, which are used in addition to the default classifiers (replacing any
+	// default classifier with the same class)
+	Classifiers []ClassifierConfig
+}
+
+func DefaultCatalogerConfig() CatalogerConfig {
+	return CatalogerConfig{}
+}
+
+// ClassifierConfig is a user-defined classifier, which identifies a binary by a version string embedded within it.
+type ClassifierConfig struct {
+	// Class is the unique name of the classifier
+	Class string
+	// FileGlob selects the files to inspect using the **/glob* syntax
+	FileGlob string
+	// VersionPattern is a regular expression matched against the contents of the file, which must capture the version
+	// as a named "version" group (and may capture other named groups for use within the PURL and CPE templates)
+	VersionPattern string
+	// Package is the name to use for the package
+	Package string
+	// PURL is a template for the Package URL, given the named groups of the version pattern
+	// (e.g. pkg:generic/my-app@{{.version}})
+	PURL string
+	// CPEs are templates for the CPEs, given the named groups of the version pattern
+	// (e.g. cpe:2.3:a:my-org:my-app:{{.version}}:*:*:*:*:*:*:*)
+	CPEs []string
+}
+
+// ClassifierInfo describes a classifier used by the cataloger.
+type ClassifierInfo struct {
+	Class string
+	// FileGlob is the selector for the files inspected (empty for classifiers that inspect every binary)
+	FileGlob string
+	Package  string
+	// Source is where the classifier is defined (see DefaultClassifierSource and ConfigClassifierSource)
+	Source string
+}
+
+// Validate returns an error if any user-defined classifier is invalid.
+func (c CatalogerConfig) Validate() error {
+	_, err := c.classifiers()
+	return err
+}
+
+// ListClassifiers returns all classifiers used by the cataloger with the given configuration, in the order they are
+// run.
+func ListClassifiers(cfg CatalogerConfig) ([]ClassifierInfo, error) {
+	classifiers, err := cfg.classifiers()
+	if err != nil {
+		return nil, err
+	}
+
+	userClasses := internal.NewStringSet()
+	for _, c := range cfg.Classifiers {
+		userClasses.Add(c.Class)
+	}
+
+	var infos []ClassifierInfo
+	for _, cls := range classifiers {
+		origin := DefaultClassifierSource
+		if userClasses.Contains(cls.Class) {
+			origin = ConfigClassifierSource
+		}
+		infos = append(infos, ClassifierInfo{
+			Class:    cls.Class,
+			FileGlob: cls.FileGlob,
+			Package:  cls.Package,
+			Source:   origin,
+		})
+	}
+	for _, cls := range defaultLibraryClassifiers {
+		infos = append(infos, ClassifierInfo{
+			Class:   cls.Class,
+			Package: cls.Package,
+			Source:  DefaultClassifierSource,
+		})
+	}
+	return infos, nil
+}
+
+// classifiers returns the default classifiers merged with the user-defined classifiers.
+func (c CatalogerConfig) classifiers() ([]classifier, error) {
+	userClassifiers := make(map[string]classifier)
+	var order []string
+	for i, cfg := range c.Classifiers {
+		cls, err := cfg.classifier()
+		if err != nil {
+			return nil, fmt.Errorf("invalid binary classifier (index=%d class=%q): %w", i, cfg.Class, err)
+		}
+		if _, exists := userClassifiers[cls.Class]; exists {
+			return nil, fmt.Errorf("invalid binary classifier (index=%d class=%q): class is defined more than once", i, cfg.Class)
+		}
+		userClassifiers[cls.Class] = cls
+		order = append(order, cls.Class)
+	}
+
+	var classifiers []classifier
+	replaced := internal.NewStringSet()
+	for _, cls := range defaultClassifiers {
+		if userClassifier, ok := userClassifiers[cls.Class]; ok {
+			classifiers = append(classifiers, userClassifier)
+			replaced.Add(cls.Class)
+			continue
+		}
+		classifiers = append(classifiers, cls)
+	}
+	for _, class := range order {
+		if !replaced.Contains(class) {
+			classifiers = append(classifiers, userClassifiers[class])
+		}
+	}
+	return classifiers, nil
+}
+
+func (c ClassifierConfig) classifier() (classifier, error) {
+	switch {
+	case c.Class == "":
+		return classifier{}, fmt.Errorf("a class is required")
+	case c.Package == "":
+		return classifier{}, fmt.Errorf("a package name is required")
+	case c.FileGlob == "":
+		return classifier{}, fmt.Errorf("a file glob is required")
+	case !doublestar.ValidatePattern(c.FileGlob):
+		return classifier{}, fmt.Errorf("invalid file glob=%q", c.FileGlob)
+	}
+
+	pattern, err := regexp.Compile(c.VersionPattern)
+	if err != nil {
+		return classifier{}, fmt.Errorf("unable to compile version pattern=%q: %w", c.VersionPattern, err)
+	}
+
+	// the templates are checked by rendering them with every named group of the version pattern
+	example := make(map[string]string)
+	for _, name := range pattern.SubexpNames() {
+		if name != "" {
+			example[name] = "1.0.0"
+		}
+	}
+	if _, ok := example["version"]; !ok {
+		return classifier{}, fmt.Errorf("version pattern=%q must have a named \"version\" group", c.VersionPattern)
+	}
+
+	var purlTemplate *template.Template
+	if c.PURL != "" {
+		if purlTemplate, err = parseTemplate(c.PURL); err != nil {
+			return classifier{}, fmt.Errorf("unable to parse PURL template=%q: %w", c.PURL, err)
+		}
+		purl, err := renderTemplate(purlTemplate, example)
+		if err != nil {
+			return classifier{}, fmt.Errorf("unable to render PURL template=%q: %w", c.PURL, err)
+		}
+		if _, err := packageurl.FromString(purl); err != nil {
+			return classifier{}, fmt.Errorf("invalid PURL template=%q: %w", c.PURL, err)
+		}
+	}
+
+	var cpeTemplates []*template.Template
+	for _, cpeString := range c.CPEs {
+		cpeTemplate, err := parseTemplate(cpeString)
+		if err != nil {
+			return classifier{}, fmt.Errorf("unable to parse CPE template=%q: %w", cpeString, err)
+		}
+		rendered, err := renderTemplate(cpeTemplate, example)
+		if err != nil {
+			return classifier{}, fmt.Errorf("unable to render CPE template=%q: %w", cpeString, err)
+		}
+		if err := cpe.ValidateString(rendered); err != nil {
+			return classifier{}, fmt.Errorf("invalid CPE template=%q: %w", cpeString, err)
+		}
+		cpeTemplates = append(cpeTemplates, cpeTemplate)
+	}
+
+	return classifier{
+		Class:           c.Class,
+		FileGlob:        c.FileGlob,
+		EvidenceMatcher: templatedVersionMatcher(pattern, purlTemplate, cpeTemplates),
+		Package:         c.Package,
+	}, nil
+}
+
+// templatedVersionMatcher matches the version pattern against the contents of the file, rendering the PURL and CPEs
+// of the package from the named groups of the match.
+func templatedVersionMatcher(pattern *regexp.Regexp, purlTemplate *template.Template, cpeTemplates []*template.Template) evidenceMatcher {
+	return func(resolver source.FileResolver, classifier classifier, location source.Location) ([]pkg.Package, error) {
+		contents, err := getContents(resolver, location)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get read contents for file: %w", err)
+		}
+
+		matchMetadata := internal.MatchNamedCaptureGroups(pattern, string(contents))
+
+		p := newPackage(classifier, location, matchMetadata)
+		if p == nil {
+			return nil, nil
+		}
+
+		if purlTemplate != nil {
+			if p.PURL, err = renderTemplate(purlTemplate, matchMetadata); err != nil {
+				return nil, fmt.Errorf("unable to render PURL: %w", err)
+			}
+		}
+
+		for _, cpeTemplate := range cpeTemplates {
+			rendered, err := renderTemplate(cpeTemplate, matchMetadata)
+			if err != nil {
+				return nil, fmt.Errorf("unable to render CPE: %w", err)
+			}
+			c, err := cpe.New(rendered)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create CPE from %q: %w", rendered, err)
+			}
+			p.CPEs = append(p.CPEs, c)
+		}
+
+		p.SetID()
+
+		return []pkg.Package{*p}, nil
+	}
+}
+
+func parseTemplate(t string) (*template.Template, error) {
+	return template.New("").Option("missingkey=error").Parse(t)
+}
+
+func renderTemplate(t *template.Template, values map[string]string) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, values); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
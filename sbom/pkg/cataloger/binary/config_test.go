@@ -0,0 +1,188 @@
+package binary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/cpe"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func Test_CatalogerConfig_Validate(t *testing.T) {
+	valid := func() ClassifierConfig {
+		return ClassifierConfig{
+			Class:          "acme-agent-binary",
+			FileGlob:       "**/acme-agent",
+			VersionPattern: `acme-agent/(?P<version>[0-9]+\.[0-9]+\.[0-9]+)`,
+			Package:        "acme-agent",
+			PURL:           "pkg:generic/acme-agent@{{.version}}",
+			CPEs:           []string{"cpe:2.3:a:acme:agent:{{.version}}:*:*:*:*:*:*:*"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *ClassifierConfig)
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "valid",
+			modify:  func(c *ClassifierConfig) {},
+			wantErr: require.NoError,
+		},
+		{
+			name: "valid without PURL or CPEs",
+			modify: func(c *ClassifierConfig) {
+				c.PURL = ""
+				c.CPEs = nil
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "missing class",
+			modify:  func(c *ClassifierConfig) { c.Class = "" },
+			wantErr: require.Error,
+		},
+		{
+			name:    "missing package",
+			modify:  func(c *ClassifierConfig) { c.Package = "" },
+			wantErr: require.Error,
+		},
+		{
+			name:    "invalid glob",
+			modify:  func(c *ClassifierConfig) { c.FileGlob = "**/[acme" },
+			wantErr: require.Error,
+		},
+		{
+			name:    "invalid pattern",
+			modify:  func(c *ClassifierConfig) { c.VersionPattern = `acme-agent/(?P<version>[0-9.]+` },
+			wantErr: require.Error,
+		},
+		{
+			name:    "pattern without version group",
+			modify:  func(c *ClassifierConfig) { c.VersionPattern = `acme-agent/([0-9.]+)` },
+			wantErr: require.Error,
+		},
+		{
+			name:    "PURL template with an unknown group",
+			modify:  func(c *ClassifierConfig) { c.PURL = "pkg:generic/acme-agent@{{.release}}" },
+			wantErr: require.Error,
+		},
+		{
+			name:    "invalid PURL",
+			modify:  func(c *ClassifierConfig) { c.PURL = "acme-agent@{{.version}}" },
+			wantErr: require.Error,
+		},
+		{
+			name:    "invalid CPE",
+			modify:  func(c *ClassifierConfig) { c.CPEs = []string{"cpe:2.3:a:acme:{{.version}}"} },
+			wantErr: require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := valid()
+			test.modify(&c)
+			test.wantErr(t, CatalogerConfig{Classifiers: []ClassifierConfig{c}}.Validate())
+		})
+	}
+
+	t.Run("duplicate class", func(t *testing.T) {
+		require.Error(t, CatalogerConfig{Classifiers: []ClassifierConfig{valid(), valid()}}.Validate())
+	})
+}
+
+func Test_ListClassifiers(t *testing.T) {
+	classifiers, err := ListClassifiers(CatalogerConfig{
+		Classifiers: []ClassifierConfig{
+			{
+				Class:          "acme-agent-binary",
+				FileGlob:       "**/acme-agent",
+				VersionPattern: `acme-agent/(?P<version>[0-9.]+)`,
+				Package:        "acme-agent",
+			},
+			{
+				Class:          "python-binary",
+				FileGlob:       "**/python*",
+				VersionPattern: `python(?P<version>[0-9.]+)`,
+				Package:        "python",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// default classifiers replaced by user classifiers stay in place, new user classifiers run after the defaults
+	// (but before the library classifiers)
+	require.Len(t, classifiers, len(defaultClassifiers)+1+len(defaultLibraryClassifiers))
+	assert.Equal(t, ClassifierInfo{Class: "python-binary", FileGlob: "**/python*", Package: "python", Source: ConfigClassifierSource}, classifiers[0])
+	assert.Equal(t, ClassifierInfo{Class: "acme-agent-binary", FileGlob: "**/acme-agent", Package: "acme-agent", Source: ConfigClassifierSource}, classifiers[len(defaultClassifiers)])
+	assert.Equal(t, DefaultClassifierSource, classifiers[1].Source)
+	assert.Equal(t, "", classifiers[len(classifiers)-1].FileGlob)
+}
+
+func Test_Cataloger_ConfigClassifiers(t *testing.T) {
+	c := NewCataloger(CatalogerConfig{
+		Classifiers: []ClassifierConfig{
+			{
+				Class:          "acme-agent-binary",
+				FileGlob:       "**/acme-agent",
+				VersionPattern: `acme-agent/(?P<version>[0-9]+\.[0-9]+\.[0-9]+)\+build\.(?P<build>[0-9]+)`,
+				Package:        "acme-agent",
+				PURL:           "pkg:generic/acme/agent@{{.version}}?build={{.build}}",
+				CPEs: []string{
+					"cpe:2.3:a:acme:agent:{{.version}}:*:*:*:*:*:*:*",
+					"cpe:2.3:a:acme:acme-agent:{{.version}}:*:*:*:*:*:*:*",
+				},
+			},
+			{
+				// replaces the default python classifier
+				Class:          "python-binary",
+				FileGlob:       "**/python*",
+				VersionPattern: `\x00python(?P<version>[0-9]+\.[0-9]+\.[0-9]+)\x00`,
+				Package:        "python",
+				PURL:           "pkg:generic/python@{{.version}}",
+			},
+		},
+	})
+
+	src, err := source.NewFromDirectory("test-fixtures/config-classifiers")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	packages, _, err := c.Catalog(resolver)
+	require.NoError(t, err)
+	require.Len(t, packages, 2)
+
+	acme, python := packages[0], packages[1]
+	if acme.Name != "acme-agent" {
+		acme, python = python, acme
+	}
+
+	assertPackagesAreEqual(t, expectedPackage("acme-agent", "2.3.4", "pkg:generic/acme/agent@2.3.4?build=77", "opt/acme/bin/acme-agent", "acme-agent-binary"), acme)
+	var cpes []string
+	for _, c := range acme.CPEs {
+		cpes = append(cpes, cpe.String(c))
+	}
+	assert.Equal(t, []string{
+		"cpe:2.3:a:acme:agent:2.3.4:*:*:*:*:*:*:*",
+		"cpe:2.3:a:acme:acme-agent:2.3.4:*:*:*:*:*:*:*",
+	}, cpes)
+
+	assertPackagesAreEqual(t, expectedPackage("python", "3.99.1", "pkg:generic/python@3.99.1", "opt/acme/bin/python3.99", "python-binary"), python)
+	assert.Empty(t, python.CPEs)
+}
+
+func expectedPackage(name, version, purl, path, class string) pkg.Package {
+	return pkg.Package{
+		Name:      name,
+		Version:   version,
+		PURL:      purl,
+		Locations: locations(path),
+		Metadata:  metadata(class, path),
+	}
+}
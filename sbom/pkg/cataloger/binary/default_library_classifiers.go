@@ -0,0 +1,100 @@
+package binary
+
+import (
+	"regexp"
+
+	"github.com/nextlinux/sbom/sbom/cpe"
+)
+
+var defaultLibraryClassifiers = []libraryClassifier{
+	{
+		classifier: classifier{
+			Class:   "openssl-library",
+			Package: "openssl",
+			PURL:    mustPURL("pkg:generic/openssl@version"),
+			CPEs:    singleCPE("cpe:2.3:a:openssl:openssl:*:*:*:*:*:*:*:*"),
+		},
+		SharedLibraryPattern: regexp.MustCompile(`(?i)^(lib(ssl|crypto)[-.]|(ssl|lib)eay32\.dll$)`),
+		// OpenSSL 3.0.8 7 Feb 2023
+		// OpenSSL 1.1.1t  7 Feb 2023
+		VersionPattern: regexp.MustCompile(`OpenSSL (?P<version>[0-9]+\.[0-9]+\.[0-9]+[a-z]*) +[0-9]{1,2} [A-Z][a-z]{2} [0-9]{4}`),
+	},
+	{
+		classifier: classifier{
+			Class:   "zlib-library",
+			Package: "zlib",
+			PURL:    mustPURL("pkg:generic/zlib@version"),
+			CPEs:    singleCPE("cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*"),
+		},
+		SharedLibraryPattern: regexp.MustCompile(`(?i)^(libz[-.]|zlib1?\.dll$)`),
+		// deflate 1.2.13 Copyright 1995-2022 Jean-loup Gailly and Mark Adler
+		// inflate 1.2.13 Copyright 1995-2022 Mark Adler
+		VersionPattern: regexp.MustCompile(`(?:de|in)flate (?P<version>[0-9]+\.[0-9]+(?:\.[0-9]+)*) Copyright [0-9]{4}-[0-9]{4} `),
+	},
+	{
+		classifier: classifier{
+			Class:   "sqlite-library",
+			Package: "sqlite",
+			PURL:    mustPURL("pkg:generic/sqlite@version"),
+			CPEs:    singleCPE("cpe:2.3:a:sqlite:sqlite:*:*:*:*:*:*:*:*"),
+		},
+		SharedLibraryPattern: regexp.MustCompile(`(?i)^(lib)?sqlite3[-.]`),
+		// the database file header
+		Markers: []string{"SQLite format 3"},
+		// [NUL]3.41.2[NUL]
+		VersionPattern: regexp.MustCompile(`\x00(?P<version>3\.[0-9]+\.[0-9]+)\x00`),
+	},
+	{
+		classifier: classifier{
+			Class:   "curl-library",
+			Package: "curl",
+			PURL:    mustPURL("pkg:generic/curl@version"),
+			CPEs: []cpe.CPE{
+				cpe.Must("cpe:2.3:a:haxx:libcurl:*:*:*:*:*:*:*:*"),
+				cpe.Must("cpe:2.3:a:haxx:curl:*:*:*:*:*:*:*:*"),
+			},
+		},
+		SharedLibraryPattern: regexp.MustCompile(`(?i)^libcurl[-.]`),
+		// libcurl/8.1.2 (the prefix of curl_version())
+		VersionPattern: regexp.MustCompile(`libcurl/(?P<version>[0-9]+\.[0-9]+\.[0-9]+)`),
+	},
+	{
+		classifier: classifier{
+			Class:   "libxml2-library",
+			Package: "libxml2",
+			PURL:    mustPURL("pkg:generic/libxml2@version"),
+			CPEs:    singleCPE("cpe:2.3:a:xmlsoft:libxml2:*:*:*:*:*:*:*:*"),
+		},
+		SharedLibraryPattern: regexp.MustCompile(`(?i)^libxml2[-.]`),
+		// a parser error message
+		Markers: []string{"invalid xmlChar value"},
+		// [NUL]21004[NUL] (xmlParserVersion, which may have a suffix for builds from git: [NUL]21004-GITv2.10.4[NUL])
+		VersionPattern: regexp.MustCompile(`\x00(?P<major>2)(?P<minor>[0-9]{2})(?P<patch>[0-9]{2})(?:-GIT[^\x00]*)?\x00`),
+	},
+	{
+		classifier: classifier{
+			Class:   "pcre-library",
+			Package: "pcre",
+			PURL:    mustPURL("pkg:generic/pcre@version"),
+			CPEs:    singleCPE("cpe:2.3:a:pcre:pcre:*:*:*:*:*:*:*:*"),
+		},
+		SharedLibraryPattern: regexp.MustCompile(`(?i)^libpcre(cpp|posix|16|32)?[-.]`),
+		// a pattern compilation error message
+		Markers: []string{`\ at end of pattern`},
+		// [NUL]8.45 2021-06-15[NUL]
+		VersionPattern: regexp.MustCompile(`\x00(?P<version>[0-9]\.[0-9]{2}) [0-9]{4}-[0-9]{2}-[0-9]{2}\x00`),
+	},
+	{
+		classifier: classifier{
+			Class:   "pcre2-library",
+			Package: "pcre2",
+			PURL:    mustPURL("pkg:generic/pcre2@version"),
+			CPEs:    singleCPE("cpe:2.3:a:pcre:pcre2:*:*:*:*:*:*:*:*"),
+		},
+		SharedLibraryPattern: regexp.MustCompile(`(?i)^libpcre2-`),
+		// a pattern compilation error message
+		Markers: []string{`\ at end of pattern`},
+		// [NUL]10.42 2022-12-11[NUL]
+		VersionPattern: regexp.MustCompile(`\x00(?P<version>1[0-9]\.[0-9]{2}) [0-9]{4}-[0-9]{2}-[0-9]{2}\x00`),
+	},
+}
@@ -0,0 +1,272 @@
+package binary
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/unionreader"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// libraryClassifier is a classifier for a library that has been statically linked into (or vendored within) another
+// binary. Unlike classifiers, which find a specific program by name, library classifiers are matched against the
+// contents of every binary (ELF, PE, and Mach-O).
+type libraryClassifier struct {
+	// classifier describes the package to create when the library is found (FileGlob and EvidenceMatcher are unused)
+	classifier
+
+	// SharedLibraryPattern matches the file names of the shared library itself. The shared library, and binaries that
+	// dynamically link against it, embed the same version strings but do not contain a copy of the library.
+	SharedLibraryPattern *regexp.Regexp
+
+	// Markers are strings found in every build of the library, at least one of which must be present. These are used
+	// when the version string alone is too generic to identify the library.
+	Markers []string
+
+	// VersionPattern is matched against the contents of the binary, capturing the version either as a "version" group
+	// or as "major", "minor", and "patch" groups (for libraries that only embed a numeric version, e.g. 21004).
+	VersionPattern *regexp.Regexp
+}
+
+// match returns the package for the library if it is contained within the given binary.
+func (c libraryClassifier) match(location source.Location, b binaryData) *pkg.Package {
+	if c.SharedLibraryPattern.MatchString(path.Base(location.RealPath)) {
+		return nil
+	}
+	for _, lib := range b.imported {
+		if c.SharedLibraryPattern.MatchString(path.Base(lib)) {
+			return nil
+		}
+	}
+
+	if len(c.Markers) > 0 && !b.containsAny(c.Markers) {
+		return nil
+	}
+
+	matchMetadata := b.matchNamedCaptureGroups(c.VersionPattern)
+	if _, ok := matchMetadata["version"]; !ok && matchMetadata["major"] != "" {
+		matchMetadata["version"] = numericVersion(matchMetadata["major"], matchMetadata["minor"], matchMetadata["patch"])
+	}
+
+	return newPackage(c.classifier, location, matchMetadata)
+}
+
+// catalogLibraries returns the libraries that are contained within any binary in the resolver.
+func catalogLibraries(resolver source.FileResolver, classifiers []libraryClassifier) ([]pkg.Package, error) {
+	locations, err := resolver.FilesByMIMEType(internal.ExecutableMIMETypeSet.List()...)
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []pkg.Package
+	for _, location := range locations {
+		binaries, err := readBinaryData(resolver, location)
+		if err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Trace("unable to read binary")
+			continue
+		}
+
+		for _, b := range binaries {
+			for _, cls := range classifiers {
+				if p := cls.match(location, b); p != nil {
+					packages = append(packages, *p)
+				}
+			}
+		}
+	}
+	return packages, nil
+}
+
+// binaryData is the part of a binary that library classifiers are matched against.
+type binaryData struct {
+	// sections are the contents of the read-only data sections, where libraries keep their version strings (and other
+	// string constants), which avoids scanning the code and debug information of the binary.
+	sections [][]byte
+
+	// imported are the shared libraries the binary is dynamically linked against
+	imported []string
+}
+
+// readBinaryData reads the read-only data sections and imported libraries of the given binary (one for each
+// architecture of a universal Mach-O binary).
+func readBinaryData(resolver source.FileResolver, location source.Location) ([]binaryData, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	unionReader, err := unionreader.GetUnionReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get union reader for file: %w", err)
+	}
+
+	readers, err := unionreader.GetReaders(unionReader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get readers for file: %w", err)
+	}
+
+	var binaries []binaryData
+	for _, r := range readers {
+		if b, ok := readELFData(r); ok {
+			binaries = append(binaries, b)
+		} else if b, ok := readMachOData(r); ok {
+			binaries = append(binaries, b)
+		} else if b, ok := readPEData(r); ok {
+			binaries = append(binaries, b)
+		}
+	}
+	return binaries, nil
+}
+
+func readELFData(r io.ReaderAt) (binaryData, bool) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return binaryData{}, false
+	}
+
+	var b binaryData
+	b.imported, _ = f.ImportedLibraries()
+	for _, s := range f.Sections {
+		if s.Type != elf.SHT_PROGBITS || s.Flags&elf.SHF_ALLOC == 0 || s.Flags&(elf.SHF_WRITE|elf.SHF_EXECINSTR) != 0 {
+			continue
+		}
+		b.addSection(s.Data())
+	}
+	return b, true
+}
+
+func readMachOData(r io.ReaderAt) (binaryData, bool) {
+	f, err := macho.NewFile(r)
+	if err != nil {
+		return binaryData{}, false
+	}
+
+	var b binaryData
+	b.imported, _ = f.ImportedLibraries()
+	for _, s := range f.Sections {
+		if s.Seg != "__TEXT" || (s.Name != "__cstring" && s.Name != "__const") {
+			continue
+		}
+		b.addSection(s.Data())
+	}
+	return b, true
+}
+
+func readPEData(r io.ReaderAt) (binaryData, bool) {
+	f, err := pe.NewFile(r)
+	if err != nil {
+		return binaryData{}, false
+	}
+
+	var b binaryData
+	b.imported, _ = f.ImportedLibraries()
+	for _, s := range f.Sections {
+		if s.Characteristics&pe.IMAGE_SCN_CNT_INITIALIZED_DATA == 0 || s.Characteristics&(pe.IMAGE_SCN_MEM_WRITE|pe.IMAGE_SCN_MEM_EXECUTE) != 0 {
+			continue
+		}
+		b.addSection(s.Data())
+	}
+	return b, true
+}
+
+func (b *binaryData) addSection(data []byte, err error) {
+	if err != nil || len(data) == 0 {
+		return
+	}
+	b.sections = append(b.sections, data)
+}
+
+func (b binaryData) containsAny(values []string) bool {
+	for _, s := range b.sections {
+		for _, v := range values {
+			if bytes.Contains(s, []byte(v)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchNamedCaptureGroups returns the named capture groups of the first non-empty match of the pattern within any
+// section (see internal.MatchNamedCaptureGroups).
+func (b binaryData) matchNamedCaptureGroups(pattern *regexp.Regexp) map[string]string {
+	names := pattern.SubexpNames()
+	var results map[string]string
+	for _, s := range b.sections {
+		for _, match := range pattern.FindAllSubmatch(s, -1) {
+			for i, name := range names {
+				if name == "" {
+					continue
+				}
+				if results == nil {
+					results = make(map[string]string)
+				}
+				results[name] = string(match[i])
+			}
+			for _, value := range results {
+				if value != "" {
+					return results
+				}
+			}
+		}
+	}
+	return results
+}
+
+// containedInRelationships relates each library package to the binaries that it was found within.
+func containedInRelationships(packages []pkg.Package, classifiers []libraryClassifier) []artifact.Relationship {
+	classes := internal.NewStringSet()
+	for _, cls := range classifiers {
+		classes.Add(cls.Class)
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range packages {
+		meta, ok := p.Metadata.(pkg.BinaryMetadata)
+		if !ok {
+			continue
+		}
+		for _, m := range meta.Matches {
+			if !classes.Contains(m.Classifier) {
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: p,
+				To:   m.Location.Coordinates,
+				Type: artifact.ContainedInRelationship,
+			})
+		}
+	}
+	return relationships
+}
+
+// numericVersion returns the dotted version from numeric version components, dropping any zero-padding
+// (e.g. 2, 09, 14 becomes 2.9.14).
+func numericVersion(components ...string) string {
+	var parts []string
+	for _, c := range components {
+		if c == "" {
+			continue
+		}
+		n, err := strconv.Atoi(c)
+		if err != nil {
+			parts = append(parts, c)
+			continue
+		}
+		parts = append(parts, strconv.Itoa(n))
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,100 @@
+package binary
+
+import (
+	"os/exec"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func Test_Cataloger_LibraryClassifiers(t *testing.T) {
+	cmd := exec.Command("make")
+	cmd.Dir = "test-fixtures/libraries"
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	src, err := source.NewFromDirectory("test-fixtures/libraries")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	packages, relationships, err := NewCataloger(DefaultCatalogerConfig()).Catalog(resolver)
+	require.NoError(t, err)
+
+	type found struct {
+		purl      string
+		locations []string
+		cpes      int
+	}
+
+	var got []found
+	for _, p := range packages {
+		var locations []string
+		for _, l := range p.Locations.ToSlice() {
+			locations = append(locations, l.RealPath)
+			assert.Equal(t, pkg.PrimaryEvidenceAnnotation, l.Annotations[pkg.EvidenceAnnotationKey])
+		}
+		sort.Strings(locations)
+		got = append(got, found{purl: p.PURL, locations: locations, cpes: len(p.CPEs)})
+	}
+
+	// note: the openssl shared library and the binary that dynamically links against it are not considered to
+	// contain openssl, even though both embed the version string
+	expected := []found{
+		{purl: "pkg:generic/curl@8.1.2", locations: []string{"bin/fetch"}, cpes: 2},
+		{purl: "pkg:generic/pcre@8.45", locations: []string{"bin/grep"}, cpes: 1},
+		{purl: "pkg:generic/pcre2@10.42", locations: []string{"bin/grep"}, cpes: 1},
+		{purl: "pkg:generic/sqlite@3.41.2", locations: []string{"bin/sqlite3"}, cpes: 1},
+		{purl: "pkg:generic/openssl@3.0.8", locations: []string{"bin/vendored", "bin/vendored-copy"}, cpes: 1},
+		{purl: "pkg:generic/zlib@1.2.13", locations: []string{"bin/vendored", "bin/vendored-copy"}, cpes: 1},
+		{purl: "pkg:generic/libxml2@2.10.4", locations: []string{"bin/xml-lint"}, cpes: 1},
+	}
+	assert.ElementsMatch(t, expected, got)
+
+	var gotRelationships []string
+	for _, r := range relationships {
+		assert.Equal(t, artifact.ContainedInRelationship, r.Type)
+		gotRelationships = append(gotRelationships, r.From.(pkg.Package).Name+" -> "+r.To.(source.Coordinates).RealPath)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"curl -> bin/fetch",
+		"pcre -> bin/grep",
+		"pcre2 -> bin/grep",
+		"sqlite -> bin/sqlite3",
+		"openssl -> bin/vendored",
+		"openssl -> bin/vendored-copy",
+		"zlib -> bin/vendored",
+		"zlib -> bin/vendored-copy",
+		"libxml2 -> bin/xml-lint",
+	}, gotRelationships)
+
+	for _, p := range packages {
+		meta, ok := p.Metadata.(pkg.BinaryMetadata)
+		require.True(t, ok)
+		assert.Len(t, meta.Matches, len(p.Locations.ToSlice()), "every location should be evidence of the library")
+	}
+}
+
+func Test_numericVersion(t *testing.T) {
+	tests := []struct {
+		components []string
+		want       string
+	}{
+		{components: []string{"2", "10", "04"}, want: "2.10.4"},
+		{components: []string{"2", "09", "14"}, want: "2.9.14"},
+		{components: []string{"2", "11", ""}, want: "2.11"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			assert.Equal(t, test.want, numericVersion(test.components...))
+		})
+	}
+}
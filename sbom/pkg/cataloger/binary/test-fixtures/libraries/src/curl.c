@@ -0,0 +1,4 @@
+// libcurl, statically linked
+const char *curl_version = "libcurl/8.1.2";
+
+void _start(void) {}
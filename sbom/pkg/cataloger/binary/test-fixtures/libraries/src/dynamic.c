@@ -0,0 +1,6 @@
+// dynamically linked to openssl (referencing the version string of the shared library)
+const char *openssl_version = "OpenSSL 3.0.8 7 Feb 2023";
+
+int OPENSSL_init_crypto(void);
+
+void _start(void) { OPENSSL_init_crypto(); }
@@ -0,0 +1,4 @@
+// libxml2, statically linked
+const char *libxml2_strings[] = {"xmlParseCharRef: invalid xmlChar value %d\n", "21004"};
+
+void _start(void) {}
@@ -0,0 +1,4 @@
+// pcre and pcre2, statically linked
+const char *pcre_strings[] = {"\\ at end of pattern", "8.45 2021-06-15", "10.42 2022-12-11"};
+
+void _start(void) {}
@@ -0,0 +1,4 @@
+// sqlite, statically linked
+const char *sqlite_strings[] = {"SQLite format 3", "3.41.2", "2023-03-22 11:56:21 0d1fc92f94cb6b76bffe3ec34d69cffde2924203304e8ffc4155597af0c191da"};
+
+void _start(void) {}
@@ -0,0 +1,5 @@
+// openssl and zlib, statically linked
+const char *openssl_version = "OpenSSL 3.0.8 7 Feb 2023";
+const char *deflate_copyright = " deflate 1.2.13 Copyright 1995-2022 Jean-loup Gailly and Mark Adler ";
+
+void _start(void) {}
@@ -19,20 +19,27 @@ import (
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/dotnet"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/elixir"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/erlang"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/githubactions"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/golang"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/haskell"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/java"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/javascript"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/julia"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/kernel"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/lua"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/nix"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/ocaml"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/perl"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/php"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/portage"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/python"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/r"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/rpm"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/ruby"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/rust"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/sbom"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/swift"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/terraform"
 )
 
 const AllCatalogersPattern = "all"
@@ -44,18 +51,28 @@ func ImageCatalogers(cfg Config) []pkg.Cataloger {
 		ruby.NewGemSpecCataloger(),
 		python.NewPythonPackageCataloger(),
 		php.NewComposerInstalledCataloger(),
+		php.NewCMSCataloger(),
 		javascript.NewPackageCataloger(),
+		r.NewPackageCataloger(),
+		julia.NewProjectCataloger(),
+		perl.NewInstalledCataloger(),
+		lua.NewInstalledCataloger(),
+		ocaml.NewOpamSwitchCataloger(),
 		deb.NewDpkgdbCataloger(),
 		rpm.NewRpmDBCataloger(),
 		java.NewJavaCataloger(cfg.Java()),
 		java.NewNativeImageCataloger(),
+		java.NewAndroidArchiveCataloger(),
 		apkdb.NewApkdbCataloger(),
 		golang.NewGoModuleBinaryCataloger(cfg.Go()),
 		dotnet.NewDotnetDepsCataloger(),
+		dotnet.NewDotnetPortableExecutableCataloger(),
+		cpp.NewVcpkgInstalledCataloger(),
+		terraform.NewProviderBinaryCataloger(),
 		portage.NewPortageCataloger(),
 		nix.NewStoreCataloger(),
 		sbom.NewSBOMCataloger(),
-		binary.NewCataloger(),
+		binary.NewCataloger(cfg.Binary()),
 		kernel.NewLinuxKernelCataloger(cfg.Kernel()),
 	}, cfg.Catalogers)
 }
@@ -68,26 +85,48 @@ func DirectoryCatalogers(cfg Config) []pkg.Cataloger {
 		python.NewPythonIndexCataloger(),
 		python.NewPythonPackageCataloger(),
 		php.NewComposerLockCataloger(),
+		php.NewCMSCataloger(),
 		javascript.NewLockCataloger(),
+		r.NewRenvLockCataloger(),
+		r.NewPackageCataloger(),
+		julia.NewManifestCataloger(),
+		julia.NewProjectCataloger(),
+		perl.NewCpanfileSnapshotCataloger(),
+		perl.NewInstalledCataloger(),
+		lua.NewRockspecCataloger(),
+		lua.NewInstalledCataloger(),
+		ocaml.NewOpamLockCataloger(),
+		ocaml.NewOpamSwitchCataloger(),
 		deb.NewDpkgdbCataloger(),
 		rpm.NewRpmDBCataloger(),
 		rpm.NewFileCataloger(),
 		java.NewJavaCataloger(cfg.Java()),
-		java.NewJavaPomCataloger(),
+		java.NewJavaPomCataloger(cfg.Java()),
 		java.NewNativeImageCataloger(),
+		java.NewAndroidArchiveCataloger(),
 		java.NewJavaGradleLockfileCataloger(),
+		java.NewJavaGradleBuildCataloger(),
 		apkdb.NewApkdbCataloger(),
 		golang.NewGoModuleBinaryCataloger(cfg.Go()),
 		golang.NewGoModFileCataloger(cfg.Go()),
 		rust.NewCargoLockCataloger(),
 		dart.NewPubspecLockCataloger(),
 		dotnet.NewDotnetDepsCataloger(),
+		dotnet.NewDotnetPackagesLockCataloger(),
+		dotnet.NewDotnetPackageReferenceCataloger(),
+		dotnet.NewDotnetPortableExecutableCataloger(),
 		swift.NewCocoapodsCataloger(),
+		swift.NewSwiftPackageManagerCataloger(),
 		cpp.NewConanCataloger(),
+		cpp.NewVcpkgManifestCataloger(),
+		cpp.NewVcpkgInstalledCataloger(),
+		terraform.NewLockCataloger(),
+		terraform.NewProviderBinaryCataloger(),
+		githubactions.NewActionUsageCataloger(),
 		portage.NewPortageCataloger(),
 		haskell.NewHackageCataloger(),
 		sbom.NewSBOMCataloger(),
-		binary.NewCataloger(),
+		binary.NewCataloger(cfg.Binary()),
 		elixir.NewMixLockCataloger(),
 		erlang.NewRebarLockCataloger(),
 		kernel.NewLinuxKernelCataloger(cfg.Kernel()),
@@ -109,9 +148,11 @@ func AllCatalogers(cfg Config) []pkg.Cataloger {
 		rpm.NewRpmDBCataloger(),
 		rpm.NewFileCataloger(),
 		java.NewJavaCataloger(cfg.Java()),
-		java.NewJavaPomCataloger(),
+		java.NewJavaPomCataloger(cfg.Java()),
 		java.NewNativeImageCataloger(),
+		java.NewAndroidArchiveCataloger(),
 		java.NewJavaGradleLockfileCataloger(),
+		java.NewJavaGradleBuildCataloger(),
 		apkdb.NewApkdbCataloger(),
 		golang.NewGoModuleBinaryCataloger(cfg.Go()),
 		golang.NewGoModFileCataloger(cfg.Go()),
@@ -119,14 +160,34 @@ func AllCatalogers(cfg Config) []pkg.Cataloger {
 		rust.NewAuditBinaryCataloger(),
 		dart.NewPubspecLockCataloger(),
 		dotnet.NewDotnetDepsCataloger(),
+		dotnet.NewDotnetPackagesLockCataloger(),
+		dotnet.NewDotnetPackageReferenceCataloger(),
+		dotnet.NewDotnetPortableExecutableCataloger(),
 		php.NewComposerInstalledCataloger(),
 		php.NewComposerLockCataloger(),
+		php.NewCMSCataloger(),
+		r.NewRenvLockCataloger(),
+		r.NewPackageCataloger(),
+		julia.NewManifestCataloger(),
+		julia.NewProjectCataloger(),
+		perl.NewCpanfileSnapshotCataloger(),
+		perl.NewInstalledCataloger(),
+		lua.NewRockspecCataloger(),
+		lua.NewInstalledCataloger(),
+		ocaml.NewOpamLockCataloger(),
+		ocaml.NewOpamSwitchCataloger(),
 		swift.NewCocoapodsCataloger(),
+		swift.NewSwiftPackageManagerCataloger(),
 		cpp.NewConanCataloger(),
+		cpp.NewVcpkgManifestCataloger(),
+		cpp.NewVcpkgInstalledCataloger(),
+		terraform.NewLockCataloger(),
+		terraform.NewProviderBinaryCataloger(),
+		githubactions.NewActionUsageCataloger(),
 		portage.NewPortageCataloger(),
 		haskell.NewHackageCataloger(),
 		sbom.NewSBOMCataloger(),
-		binary.NewCataloger(),
+		binary.NewCataloger(cfg.Binary()),
 		elixir.NewMixLockCataloger(),
 		erlang.NewRebarLockCataloger(),
 		kernel.NewLinuxKernelCataloger(cfg.Kernel()),
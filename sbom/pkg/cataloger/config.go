@@ -1,26 +1,33 @@
 package cataloger
 
 import (
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/binary"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/golang"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/java"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/kernel"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/linkage"
 )
 
 // TODO: these field naming vs helper function naming schemes are inconsistent.
 
 type Config struct {
-	Search      SearchConfig
-	Golang      golang.GoCatalogerOpts
-	LinuxKernel kernel.LinuxCatalogerConfig
-	Catalogers  []string
-	Parallelism int
+	Search          SearchConfig
+	Golang          golang.GoCatalogerOpts
+	LinuxKernel     kernel.LinuxCatalogerConfig
+	BinaryCataloger binary.CatalogerConfig
+	Linkage         linkage.Config
+	Maven           java.MavenConfig
+	Catalogers      []string
+	Parallelism     int
 }
 
 func DefaultConfig() Config {
 	return Config{
-		Search:      DefaultSearchConfig(),
-		Parallelism: 1,
-		LinuxKernel: kernel.DefaultLinuxCatalogerConfig(),
+		Search:          DefaultSearchConfig(),
+		Parallelism:     1,
+		LinuxKernel:     kernel.DefaultLinuxCatalogerConfig(),
+		BinaryCataloger: binary.DefaultCatalogerConfig(),
+		Linkage:         linkage.DefaultConfig(),
 	}
 }
 
@@ -28,6 +35,7 @@ func (c Config) Java() java.Config {
 	return java.Config{
 		SearchUnindexedArchives: c.Search.IncludeUnindexedArchives,
 		SearchIndexedArchives:   c.Search.IncludeIndexedArchives,
+		Maven:                   c.Maven,
 	}
 }
 
@@ -38,3 +46,7 @@ func (c Config) Go() golang.GoCatalogerOpts {
 func (c Config) Kernel() kernel.LinuxCatalogerConfig {
 	return c.LinuxKernel
 }
+
+func (c Config) Binary() binary.CatalogerConfig {
+	return c.BinaryCataloger
+}
@@ -12,3 +12,16 @@ func NewConanCataloger() *generic.Cataloger {
 		WithParserByGlobs(parseConanfile, "**/conanfile.txt").
 		WithParserByGlobs(parseConanlock, "**/conan.lock")
 }
+
+// NewVcpkgManifestCataloger returns a new C/C++ vcpkg.json manifest cataloger object.
+func NewVcpkgManifestCataloger() *generic.Cataloger {
+	return generic.NewCataloger("vcpkg-manifest-cataloger").
+		WithParserByGlobs(parseVcpkgManifest, "**/vcpkg.json")
+}
+
+// NewVcpkgInstalledCataloger returns a new C/C++ cataloger object for the ports installed by vcpkg (as recorded
+// within the vcpkg/status database).
+func NewVcpkgInstalledCataloger() *generic.Cataloger {
+	return generic.NewCataloger("vcpkg-installed-cataloger").
+		WithParserByGlobs(parseVcpkgStatus, "**/vcpkg/status")
+}
@@ -3,23 +3,42 @@ package cpp
 import (
 	"testing"
 
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
 )
 
 func TestCataloger_Globs(t *testing.T) {
 	tests := []struct {
-		name     string
-		fixture  string
-		expected []string
+		name      string
+		fixture   string
+		cataloger *generic.Cataloger
+		expected  []string
 	}{
 		{
-			name:    "obtain conan files",
-			fixture: "test-fixtures/glob-paths",
+			name:      "obtain conan files",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewConanCataloger(),
 			expected: []string{
 				"somewhere/src/conanfile.txt",
 				"somewhere/src/conan.lock",
 			},
 		},
+		{
+			name:      "obtain vcpkg manifest files",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewVcpkgManifestCataloger(),
+			expected: []string{
+				"somewhere/src/vcpkg.json",
+			},
+		},
+		{
+			name:      "obtain vcpkg status files",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewVcpkgInstalledCataloger(),
+			expected: []string{
+				"somewhere/installed/vcpkg/status",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -27,7 +46,7 @@ func TestCataloger_Globs(t *testing.T) {
 			pkgtest.NewCatalogTester().
 				FromDirectory(t, test.fixture).
 				ExpectsResolverContentQueries(test.expected).
-				TestCataloger(t, NewConanCataloger())
+				TestCataloger(t, test.cataloger)
 		})
 	}
 }
@@ -1,6 +1,7 @@
 package cpp
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/nextlinux/packageurl-go"
@@ -37,12 +38,7 @@ func newConanfilePackage(m pkg.ConanMetadata, locations ...source.Location) *pkg
 }
 
 func newConanlockPackage(m pkg.ConanLockMetadata, locations ...source.Location) *pkg.Package {
-	fields := strings.Split(strings.Split(m.Ref, "@")[0], "/")
-	if len(fields) < 2 {
-		return nil
-	}
-
-	pkgName, pkgVersion := fields[0], fields[1]
+	pkgName, pkgVersion := m.NameAndVersion()
 
 	if pkgName == "" || pkgVersion == "" {
 		return nil
@@ -74,3 +70,57 @@ func packageURL(name, version string) string {
 		"",
 	).ToString()
 }
+
+func newVcpkgManifestPackage(name, version string, portVersion int, m pkg.VcpkgManifestMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         vcpkgPackageURL(name, version, portVersion, ""),
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgManifestMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func newVcpkgInstalledPackage(name, version string, m pkg.VcpkgInstalledMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         vcpkgPackageURL(name, version, m.PortVersion, m.Triplet),
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgInstalledMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// vcpkgPackageURL returns the PURL for a vcpkg port, e.g. pkg:vcpkg/zlib@1.3?port_version=1&triplet=x64-linux.
+func vcpkgPackageURL(name, version string, portVersion int, triplet string) string {
+	var qualifiers packageurl.Qualifiers
+	if portVersion > 0 {
+		qualifiers = append(qualifiers, packageurl.Qualifier{Key: "port_version", Value: strconv.Itoa(portVersion)})
+	}
+	if triplet != "" {
+		qualifiers = append(qualifiers, packageurl.Qualifier{Key: "triplet", Value: triplet})
+	}
+
+	return packageurl.NewPackageURL(
+		pkg.VcpkgPkg.PackageURLType(),
+		"",
+		name,
+		version,
+		qualifiers,
+		"",
+	).ToString()
+}
@@ -27,9 +27,14 @@ type conanLock struct {
 	} `json:"graph_lock"`
 	Version     string `json:"version"`
 	ProfileHost string `json:"profile_host"`
+	// conan 2 lockfiles list the references of the host context (requires) and the build context (build_requires)
+	// instead of a graph of nodes
+	Requires      []string `json:"requires"`
+	BuildRequires []string `json:"build_requires"`
 }
 
-// parseConanlock is a parser function for conan.lock contents, returning all packages discovered.
+// parseConanlock is a parser function for conan.lock contents (either conan 1 or conan 2 lockfiles), returning all
+// packages discovered.
 func parseConanlock(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	var pkgs []pkg.Package
 	var cl conanLock
@@ -54,6 +59,30 @@ func parseConanlock(_ source.FileResolver, _ *generic.Environment, reader source
 		}
 	}
 
+	// note: python_requires are not considered, since they are only used to evaluate recipes and are not part of
+	// the built packages
+	for _, requires := range []struct {
+		context string
+		refs    []string
+	}{
+		{context: "host", refs: cl.Requires},
+		{context: "build", refs: cl.BuildRequires},
+	} {
+		for _, ref := range requires.refs {
+			p := newConanlockPackage(
+				pkg.ConanLockMetadata{
+					Ref:     ref,
+					Context: requires.context,
+				},
+				reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+			)
+
+			if p != nil {
+				pkgs = append(pkgs, *p)
+			}
+		}
+	}
+
 	return pkgs, nil, nil
 }
 
@@ -37,3 +37,66 @@ func TestParseConanlock(t *testing.T) {
 
 	pkgtest.TestFileParser(t, fixture, parseConanlock, expected, expectedRelationships)
 }
+
+func TestParseConanlock_Conan2(t *testing.T) {
+	fixture := "test-fixtures/conan2/conan.lock"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	expected := []pkg.Package{
+		{
+			Name:         "zlib",
+			Version:      "1.2.13",
+			PURL:         "pkg:conan/zlib@1.2.13",
+			Locations:    locations,
+			Language:     pkg.CPP,
+			Type:         pkg.ConanPkg,
+			MetadataType: pkg.ConanLockMetadataType,
+			Metadata: pkg.ConanLockMetadata{
+				Ref:     "zlib/1.2.13#97d5730b529b4224045fe7090592d4c1%1692672717.68",
+				Context: "host",
+			},
+		},
+		{
+			Name:         "openssl",
+			Version:      "3.1.2",
+			PURL:         "pkg:conan/openssl@3.1.2",
+			Locations:    locations,
+			Language:     pkg.CPP,
+			Type:         pkg.ConanPkg,
+			MetadataType: pkg.ConanLockMetadataType,
+			Metadata: pkg.ConanLockMetadata{
+				Ref:     "openssl/3.1.2#3e4ef8a0ee6cbba4b5d15b6c6b3d4f27%1692269806.262",
+				Context: "host",
+			},
+		},
+		{
+			Name:         "cmake",
+			Version:      "3.27.4",
+			PURL:         "pkg:conan/cmake@3.27.4",
+			Locations:    locations,
+			Language:     pkg.CPP,
+			Type:         pkg.ConanPkg,
+			MetadataType: pkg.ConanLockMetadataType,
+			Metadata: pkg.ConanLockMetadata{
+				Ref:     "cmake/3.27.4#6a3bf0a6e8e2a2a1d5b5c4d1e4a3e2a1%1693403431.131",
+				Context: "build",
+			},
+		},
+		{
+			Name:         "ninja",
+			Version:      "1.11.1",
+			PURL:         "pkg:conan/ninja@1.11.1",
+			Locations:    locations,
+			Language:     pkg.CPP,
+			Type:         pkg.ConanPkg,
+			MetadataType: pkg.ConanLockMetadataType,
+			Metadata: pkg.ConanLockMetadata{
+				Ref:     "ninja/1.11.1@tools/stable#77587f8c8318662ac8e5a7867eb4be21%1684431244.21",
+				Context: "build",
+			},
+		},
+	}
+
+	var expectedRelationships []artifact.Relationship
+
+	pkgtest.TestFileParser(t, fixture, parseConanlock, expected, expectedRelationships)
+}
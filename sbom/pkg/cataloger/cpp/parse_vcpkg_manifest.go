@@ -0,0 +1,251 @@
+package cpp
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseVcpkgManifest
+
+// vcpkgBuiltinRegistry is the repository of the registry used when no other registry is configured for a port
+const vcpkgBuiltinRegistry = "https://github.com/microsoft/vcpkg"
+
+type vcpkgManifest struct {
+	Name string `json:"name"`
+	vcpkgVersion
+	Dependencies    []vcpkgDependency   `json:"dependencies"`
+	Overrides       []vcpkgOverride     `json:"overrides"`
+	BuiltinBaseline string              `json:"builtin-baseline"`
+	Configuration   *vcpkgConfiguration `json:"vcpkg-configuration"`
+}
+
+// vcpkgVersion holds the version fields of a manifest or override, of which only one is expected to be set.
+type vcpkgVersion struct {
+	Version       string `json:"version"`
+	VersionSemver string `json:"version-semver"`
+	VersionDate   string `json:"version-date"`
+	VersionString string `json:"version-string"`
+	PortVersion   int    `json:"port-version"`
+}
+
+func (v vcpkgVersion) version() string {
+	for _, version := range []string{v.Version, v.VersionSemver, v.VersionDate, v.VersionString} {
+		if version != "" {
+			return version
+		}
+	}
+	return ""
+}
+
+type vcpkgOverride struct {
+	Name string `json:"name"`
+	vcpkgVersion
+}
+
+// vcpkgDependency is a dependency of a manifest, which is either the name of a port or an object.
+type vcpkgDependency struct {
+	Name              string
+	VersionConstraint string
+	Features          []string
+	Platform          string
+	Host              bool
+}
+
+func (d *vcpkgDependency) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		d.Name = name
+		return nil
+	}
+
+	var dependency struct {
+		Name              string            `json:"name"`
+		VersionConstraint string            `json:"version>="`
+		Features          []json.RawMessage `json:"features"`
+		Platform          string            `json:"platform"`
+		Host              bool              `json:"host"`
+	}
+	if err := json.Unmarshal(data, &dependency); err != nil {
+		return err
+	}
+
+	d.Name = dependency.Name
+	d.VersionConstraint = dependency.VersionConstraint
+	d.Platform = dependency.Platform
+	d.Host = dependency.Host
+
+	// features are either names or objects with a name (and the platform the feature applies to)
+	for _, raw := range dependency.Features {
+		var feature struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(raw, &feature.Name); err != nil {
+			if err := json.Unmarshal(raw, &feature); err != nil {
+				return err
+			}
+		}
+		if feature.Name != "" {
+			d.Features = append(d.Features, feature.Name)
+		}
+	}
+
+	return nil
+}
+
+// vcpkgConfiguration holds the registries that ports are resolved from (see
+// https://learn.microsoft.com/en-us/vcpkg/reference/vcpkg-configuration-json).
+type vcpkgConfiguration struct {
+	DefaultRegistry *vcpkgRegistry  `json:"default-registry"`
+	Registries      []vcpkgRegistry `json:"registries"`
+}
+
+type vcpkgRegistry struct {
+	Kind       string   `json:"kind"`
+	Repository string   `json:"repository"`
+	Path       string   `json:"path"`
+	Baseline   string   `json:"baseline"`
+	Packages   []string `json:"packages"`
+}
+
+func (r vcpkgRegistry) location() string {
+	switch {
+	case r.Kind == "builtin":
+		return vcpkgBuiltinRegistry
+	case r.Repository != "":
+		return r.Repository
+	default:
+		return r.Path
+	}
+}
+
+// registryFor returns the location and baseline of the registry that the given port is resolved from.
+func (m vcpkgManifest) registryFor(name string) (string, string) {
+	if m.Configuration != nil {
+		for _, registry := range m.Configuration.Registries {
+			for _, pattern := range registry.Packages {
+				if matched, _ := path.Match(pattern, name); matched {
+					return registry.location(), registry.Baseline
+				}
+			}
+		}
+		if registry := m.Configuration.DefaultRegistry; registry != nil {
+			return registry.location(), registry.Baseline
+		}
+	}
+	return vcpkgBuiltinRegistry, m.BuiltinBaseline
+}
+
+// parseVcpkgManifest is a parser function for vcpkg.json contents, returning the project (when named) and each of the
+// dependencies declared within the manifest. Versions are only known for dependencies that are overridden, since all
+// others are resolved against the registry baseline at install time.
+func parseVcpkgManifest(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	// the ports of a vcpkg checkout (ports/<name>/vcpkg.json) describe how to build each port, and are not a project
+	if path.Base(path.Dir(path.Dir(reader.Location.RealPath))) == "ports" {
+		return nil, nil, nil
+	}
+
+	var manifest vcpkgManifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse vcpkg.json file: %w", err)
+	}
+
+	locations := []source.Location{reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)}
+	if manifest.Configuration == nil {
+		configuration, location := readVcpkgConfiguration(resolver, reader.Location)
+		if configuration != nil {
+			manifest.Configuration = configuration
+			locations = append(locations, location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+		}
+	}
+
+	overrides := make(map[string]vcpkgVersion)
+	for _, override := range manifest.Overrides {
+		overrides[override.Name] = override.vcpkgVersion
+	}
+
+	var pkgs []pkg.Package
+	for _, dependency := range manifest.Dependencies {
+		if dependency.Name == "" {
+			continue
+		}
+		registry, baseline := manifest.registryFor(dependency.Name)
+		override := overrides[dependency.Name]
+		pkgs = append(pkgs, newVcpkgManifestPackage(
+			dependency.Name,
+			override.version(),
+			override.PortVersion,
+			pkg.VcpkgManifestMetadata{
+				VersionConstraint: dependency.VersionConstraint,
+				Features:          dependency.Features,
+				Platform:          dependency.Platform,
+				Host:              dependency.Host,
+				Registry:          registry,
+				Baseline:          baseline,
+			},
+			locations...,
+		))
+	}
+
+	if manifest.Name == "" {
+		return pkgs, nil, nil
+	}
+
+	root := newVcpkgManifestPackage(
+		manifest.Name,
+		manifest.version(),
+		manifest.PortVersion,
+		pkg.VcpkgManifestMetadata{},
+		reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+	)
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	for _, p := range pkgs {
+		if seen.Contains(string(p.ID())) {
+			continue
+		}
+		seen.Add(string(p.ID()))
+		relationships = append(relationships, artifact.Relationship{
+			From: p,
+			To:   root,
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+
+	return append([]pkg.Package{root}, pkgs...), relationships, nil
+}
+
+// readVcpkgConfiguration reads the vcpkg-configuration.json next to the given vcpkg.json, if there is one.
+func readVcpkgConfiguration(resolver source.FileResolver, manifestLocation source.Location) (*vcpkgConfiguration, source.Location) {
+	if resolver == nil {
+		return nil, source.Location{}
+	}
+
+	location := resolver.RelativeFileByPath(manifestLocation, path.Join(path.Dir(manifestLocation.RealPath), "vcpkg-configuration.json"))
+	if location == nil {
+		return nil, source.Location{}
+	}
+
+	reader, err := resolver.FileContentsByLocation(*location)
+	if err != nil {
+		log.WithFields("path", location.RealPath, "error", err).Debug("unable to read vcpkg-configuration.json")
+		return nil, source.Location{}
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	var configuration vcpkgConfiguration
+	if err := json.NewDecoder(reader).Decode(&configuration); err != nil {
+		log.WithFields("path", location.RealPath, "error", err).Debug("unable to parse vcpkg-configuration.json")
+		return nil, source.Location{}
+	}
+
+	return &configuration, *location
+}
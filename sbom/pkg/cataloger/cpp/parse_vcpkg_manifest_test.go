@@ -0,0 +1,105 @@
+package cpp
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseVcpkgManifest(t *testing.T) {
+	manifestLocation := source.NewLocation("vcpkg.json")
+	locations := source.NewLocationSet(manifestLocation, source.NewLocation("vcpkg-configuration.json"))
+	builtinBaseline := "3426db05b996481ca31e95fff3734cf23e0f51bc"
+
+	root := pkg.Package{
+		Name:         "my-application",
+		Version:      "0.1.0",
+		FoundBy:      "vcpkg-manifest-cataloger",
+		PURL:         "pkg:vcpkg/my-application@0.1.0",
+		Locations:    source.NewLocationSet(manifestLocation),
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgManifestMetadataType,
+		Metadata:     pkg.VcpkgManifestMetadata{},
+	}
+	fmtPkg := pkg.Package{
+		Name:         "fmt",
+		FoundBy:      "vcpkg-manifest-cataloger",
+		PURL:         "pkg:vcpkg/fmt",
+		Locations:    locations,
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgManifestMetadataType,
+		Metadata: pkg.VcpkgManifestMetadata{
+			Registry: "https://github.com/example/vcpkg-registry",
+			Baseline: "c9a3ccd6ed26b0c0a3f1c2fcb67a1bd3b5e9e0f6",
+		},
+	}
+	curl := pkg.Package{
+		Name:         "curl",
+		FoundBy:      "vcpkg-manifest-cataloger",
+		PURL:         "pkg:vcpkg/curl",
+		Locations:    locations,
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgManifestMetadataType,
+		Metadata: pkg.VcpkgManifestMetadata{
+			VersionConstraint: "8.4.0",
+			Features:          []string{"ssl", "http2"},
+			Registry:          vcpkgBuiltinRegistry,
+			Baseline:          builtinBaseline,
+		},
+	}
+	vcpkgCmake := pkg.Package{
+		Name:         "vcpkg-cmake",
+		FoundBy:      "vcpkg-manifest-cataloger",
+		PURL:         "pkg:vcpkg/vcpkg-cmake",
+		Locations:    locations,
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgManifestMetadataType,
+		Metadata: pkg.VcpkgManifestMetadata{
+			Host:     true,
+			Registry: vcpkgBuiltinRegistry,
+			Baseline: builtinBaseline,
+		},
+	}
+	zlib := pkg.Package{
+		Name:         "zlib",
+		Version:      "1.3",
+		FoundBy:      "vcpkg-manifest-cataloger",
+		PURL:         "pkg:vcpkg/zlib@1.3?port_version=1",
+		Locations:    locations,
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgManifestMetadataType,
+		Metadata: pkg.VcpkgManifestMetadata{
+			Platform: "linux",
+			Registry: vcpkgBuiltinRegistry,
+			Baseline: builtinBaseline,
+		},
+	}
+
+	var expectedRelationships []artifact.Relationship
+	for _, dep := range []pkg.Package{fmtPkg, curl, vcpkgCmake, zlib} {
+		expectedRelationships = append(expectedRelationships, artifact.Relationship{
+			From: dep,
+			To:   root,
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+
+	expectedPkgs := []pkg.Package{root, fmtPkg, curl, vcpkgCmake, zlib}
+
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/vcpkg-manifest").
+		Expects(expectedPkgs, expectedRelationships).
+		TestCataloger(t, NewVcpkgManifestCataloger())
+}
+
+func TestParseVcpkgManifest_SkipsPorts(t *testing.T) {
+	pkgtest.TestFileParser(t, "test-fixtures/ports/zlib/vcpkg.json", parseVcpkgManifest, nil, nil)
+}
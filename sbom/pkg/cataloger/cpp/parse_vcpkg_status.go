@@ -0,0 +1,291 @@
+package cpp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseVcpkgStatus
+
+// vcpkgParagraph is a single entry of the vcpkg status database, describing either a port (the "core" paragraph) or
+// one of the features installed for a port.
+type vcpkgParagraph map[string]string
+
+func (p vcpkgParagraph) key() string {
+	return p["Package"] + ":" + p["Architecture"] + "[" + p["Feature"] + "]"
+}
+
+func (p vcpkgParagraph) installed() bool {
+	// e.g. "install ok installed" or "purge ok not-installed"
+	fields := strings.Fields(p["Status"])
+	return len(fields) == 3 && fields[2] == "installed"
+}
+
+// dependencies returns the ports listed within the "Depends" field, which are separated by commas (outside of any
+// feature list, e.g. "vcpkg-cmake:x64-linux, curl[core,ssl]:x64-linux").
+func (p vcpkgParagraph) dependencies() []string {
+	var dependencies []string
+	var current strings.Builder
+	depth := 0
+	add := func() {
+		if dependency := strings.TrimSpace(current.String()); dependency != "" {
+			dependencies = append(dependencies, dependency)
+		}
+		current.Reset()
+	}
+	for _, r := range p["Depends"] {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case r == ',' && depth == 0:
+			add()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	add()
+	return dependencies
+}
+
+// parseVcpkgStatus is a parser function for the vcpkg status database (installed/vcpkg/status), returning all
+// installed ports. The database is made up of the status file along with any incremental updates that have not yet
+// been merged into it (installed/vcpkg/updates/*), and the files of each port are listed within
+// installed/vcpkg/info/<port>_<version>_<triplet>.list.
+func parseVcpkgStatus(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	paragraphs, err := readVcpkgParagraphs(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse vcpkg status file: %w", err)
+	}
+
+	vcpkgDir := path.Dir(reader.Location.RealPath)
+	database := make(map[string]vcpkgParagraph)
+	var order []string
+	apply := func(paragraphs []vcpkgParagraph) {
+		for _, p := range paragraphs {
+			if _, ok := database[p.key()]; !ok {
+				order = append(order, p.key())
+			}
+			database[p.key()] = p
+		}
+	}
+	apply(paragraphs)
+	for _, update := range readVcpkgUpdates(resolver, vcpkgDir) {
+		apply(update)
+	}
+
+	// features are installed as separate paragraphs that refer to their port
+	features := make(map[string][]vcpkgParagraph)
+	for _, key := range order {
+		p := database[key]
+		if p["Feature"] != "" && p.installed() {
+			port := p["Package"] + ":" + p["Architecture"]
+			features[port] = append(features[port], p)
+		}
+	}
+
+	location := reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)
+
+	var pkgs []pkg.Package
+	for _, key := range order {
+		p := database[key]
+		if p["Feature"] != "" || !p.installed() || p["Package"] == "" {
+			continue
+		}
+
+		m := pkg.VcpkgInstalledMetadata{
+			Triplet: p["Architecture"],
+			Abi:     p["Abi"],
+		}
+		if portVersion, err := strconv.Atoi(p["Port-Version"]); err == nil {
+			m.PortVersion = portVersion
+		}
+
+		dependencies := internal.NewStringSet(p.dependencies()...)
+		for _, feature := range features[p["Package"]+":"+p["Architecture"]] {
+			m.Features = append(m.Features, feature["Feature"])
+			for _, dependency := range feature.dependencies() {
+				dependencies.Add(dependency)
+			}
+		}
+		sort.Strings(m.Features)
+		if len(dependencies) > 0 {
+			m.Dependencies = dependencies.ToSlice()
+		}
+
+		locations := []source.Location{location}
+		if listLocation, files := readVcpkgListFile(resolver, vcpkgDir, p["Package"], m.Triplet); listLocation != nil {
+			m.Files = files
+			locations = append(locations, listLocation.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+		}
+
+		pkgs = append(pkgs, newVcpkgInstalledPackage(p["Package"], p["Version"], m, locations...))
+	}
+
+	return pkgs, vcpkgInstalledRelationships(pkgs), nil
+}
+
+func readVcpkgParagraphs(reader io.Reader) ([]vcpkgParagraph, error) {
+	var paragraphs []vcpkgParagraph
+	current := make(vcpkgParagraph)
+	var lastKey string
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.TrimSpace(line) == "":
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, current)
+				current = make(vcpkgParagraph)
+			}
+			lastKey = ""
+		case strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t"):
+			// continuation of a multi-line field (e.g. the description)
+			if lastKey != "" {
+				current[lastKey] += "\n" + strings.TrimSpace(line)
+			}
+		default:
+			key, value, ok := strings.Cut(line, ":")
+			if !ok {
+				continue
+			}
+			lastKey = strings.TrimSpace(key)
+			current[lastKey] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, current)
+	}
+	return paragraphs, nil
+}
+
+// readVcpkgUpdates reads the incremental updates to the status database, in the order they were written.
+func readVcpkgUpdates(resolver source.FileResolver, vcpkgDir string) [][]vcpkgParagraph {
+	if resolver == nil {
+		return nil
+	}
+
+	locations, err := resolver.FilesByGlob(path.Join("/", vcpkgDir, "updates", "*"))
+	if err != nil {
+		log.WithFields("path", vcpkgDir, "error", err).Debug("unable to find vcpkg status updates")
+		return nil
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].RealPath < locations[j].RealPath
+	})
+
+	var updates [][]vcpkgParagraph
+	for _, location := range locations {
+		err := readVcpkgFile(resolver, location, func(reader io.Reader) error {
+			paragraphs, err := readVcpkgParagraphs(reader)
+			if err != nil {
+				return err
+			}
+			updates = append(updates, paragraphs)
+			return nil
+		})
+		if err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Debug("unable to read vcpkg status update")
+		}
+	}
+	return updates
+}
+
+// readVcpkgListFile returns the files installed for a port, which are listed relative to the installed directory
+// (e.g. "x64-linux/include/zlib.h"), along with the location of the list.
+func readVcpkgListFile(resolver source.FileResolver, vcpkgDir, name, triplet string) (*source.Location, []string) {
+	if resolver == nil {
+		return nil, nil
+	}
+
+	// the version within the name of the list may carry the port version (e.g. zlib_1.3#1_x64-linux.list)
+	locations, err := resolver.FilesByGlob(path.Join("/", vcpkgDir, "info", name+"_*_"+triplet+".list"))
+	if err != nil || len(locations) == 0 {
+		return nil, nil
+	}
+	location := locations[0]
+
+	installedDir := path.Dir(vcpkgDir)
+	var files []string
+	err = readVcpkgFile(resolver, location, func(reader io.Reader) error {
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			// directories are listed with a trailing slash
+			if line == "" || strings.HasSuffix(line, "/") {
+				continue
+			}
+			files = append(files, path.Join(installedDir, line))
+		}
+		return scanner.Err()
+	})
+	if err != nil {
+		log.WithFields("path", location.RealPath, "error", err).Debug("unable to read vcpkg file list")
+		return nil, nil
+	}
+
+	return &location, files
+}
+
+func readVcpkgFile(resolver source.FileResolver, location source.Location, fn func(io.Reader) error) error {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+	return fn(reader)
+}
+
+// vcpkgInstalledRelationships relates each installed port to the ports it depends on, which are built for the same
+// triplet unless otherwise stated (e.g. "vcpkg-cmake:x64-linux" for host dependencies).
+func vcpkgInstalledRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	byPort := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		if m, ok := p.Metadata.(pkg.VcpkgInstalledMetadata); ok {
+			byPort[p.Name+":"+m.Triplet] = p
+		}
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.VcpkgInstalledMetadata)
+		if !ok {
+			continue
+		}
+		for _, dependency := range m.Dependencies {
+			// e.g. "curl[core,ssl]:x64-linux"
+			name, triplet, ok := strings.Cut(dependency, ":")
+			if !ok {
+				triplet = m.Triplet
+			}
+			name = strings.Split(name, "[")[0]
+			dep, ok := byPort[name+":"+triplet]
+			if !ok || dep.ID() == p.ID() {
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
+}
@@ -0,0 +1,104 @@
+package cpp
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseVcpkgStatus(t *testing.T) {
+	statusLocation := source.NewLocation("installed/vcpkg/status")
+
+	vcpkgCmake := pkg.Package{
+		Name:         "vcpkg-cmake",
+		Version:      "2023-05-04",
+		FoundBy:      "vcpkg-installed-cataloger",
+		PURL:         "pkg:vcpkg/vcpkg-cmake@2023-05-04?triplet=x64-linux",
+		Locations:    source.NewLocationSet(statusLocation),
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgInstalledMetadataType,
+		Metadata: pkg.VcpkgInstalledMetadata{
+			Triplet: "x64-linux",
+			Abi:     "1f0b7ad5e0e5a4e5f3d0e1c4c6d0f1b0f9a2c3d4e5f60718293a4b5c6d7e8f90",
+		},
+	}
+	zlib := pkg.Package{
+		Name:         "zlib",
+		Version:      "1.3",
+		FoundBy:      "vcpkg-installed-cataloger",
+		PURL:         "pkg:vcpkg/zlib@1.3?port_version=1&triplet=x64-linux",
+		Locations:    source.NewLocationSet(statusLocation, source.NewLocation("installed/vcpkg/info/zlib_1.3#1_x64-linux.list")),
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgInstalledMetadataType,
+		Metadata: pkg.VcpkgInstalledMetadata{
+			PortVersion:  1,
+			Triplet:      "x64-linux",
+			Abi:          "8c4b2b0c4e6d0f3a9a1bd2f0f0a1c0d3b6e4f5a6b7c8d9e0f1a2b3c4d5e6f708",
+			Dependencies: []string{"vcpkg-cmake:x64-linux"},
+			Files: []string{
+				"installed/x64-linux/include/zconf.h",
+				"installed/x64-linux/include/zlib.h",
+				"installed/x64-linux/lib/libz.a",
+				"installed/x64-linux/share/zlib/copyright",
+			},
+		},
+	}
+	curl := pkg.Package{
+		Name:         "curl",
+		Version:      "8.4.0",
+		FoundBy:      "vcpkg-installed-cataloger",
+		PURL:         "pkg:vcpkg/curl@8.4.0?triplet=x64-linux",
+		Locations:    source.NewLocationSet(statusLocation),
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgInstalledMetadataType,
+		Metadata: pkg.VcpkgInstalledMetadata{
+			Triplet:  "x64-linux",
+			Abi:      "0d1e2f3a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6",
+			Features: []string{"openssl", "ssl"},
+			Dependencies: []string{
+				"curl[core,openssl]:x64-linux",
+				"openssl",
+				"vcpkg-cmake:x64-linux",
+				"zlib",
+			},
+		},
+	}
+	// installed by a status update which has not yet been merged into the status file
+	openssl := pkg.Package{
+		Name:         "openssl",
+		Version:      "3.1.4",
+		FoundBy:      "vcpkg-installed-cataloger",
+		PURL:         "pkg:vcpkg/openssl@3.1.4?triplet=x64-linux",
+		Locations:    source.NewLocationSet(statusLocation),
+		Language:     pkg.CPP,
+		Type:         pkg.VcpkgPkg,
+		MetadataType: pkg.VcpkgInstalledMetadataType,
+		Metadata: pkg.VcpkgInstalledMetadata{
+			Triplet:      "x64-linux",
+			Abi:          "9e0f1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7",
+			Dependencies: []string{"vcpkg-cmake:x64-linux"},
+		},
+	}
+
+	expectedRelationships := []artifact.Relationship{
+		{From: vcpkgCmake, To: zlib, Type: artifact.DependencyOfRelationship},
+		{From: openssl, To: curl, Type: artifact.DependencyOfRelationship},
+		{From: vcpkgCmake, To: curl, Type: artifact.DependencyOfRelationship},
+		{From: zlib, To: curl, Type: artifact.DependencyOfRelationship},
+		{From: vcpkgCmake, To: openssl, Type: artifact.DependencyOfRelationship},
+	}
+
+	// fmt has been removed by the last status update
+	expectedPkgs := []pkg.Package{vcpkgCmake, zlib, curl, openssl}
+
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/vcpkg-installed").
+		Expects(expectedPkgs, expectedRelationships).
+		TestCataloger(t, NewVcpkgInstalledCataloger())
+}
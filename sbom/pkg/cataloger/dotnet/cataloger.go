@@ -11,3 +11,16 @@ func NewDotnetDepsCataloger() *generic.Cataloger {
 	return generic.NewCataloger(catalogerName).
 		WithParserByGlobs(parseDotnetDeps, "**/*.deps.json")
 }
+
+// NewDotnetPackagesLockCataloger returns a new Dotnet cataloger object based on NuGet packages.lock.json files.
+func NewDotnetPackagesLockCataloger() *generic.Cataloger {
+	return generic.NewCataloger("dotnet-packages-lock-cataloger").
+		WithParserByGlobs(parseDotnetPackagesLock, dotnetPackagesLockGlob)
+}
+
+// NewDotnetPortableExecutableCataloger returns a new Dotnet cataloger object based on the version resource of .NET
+// assemblies.
+func NewDotnetPortableExecutableCataloger() *generic.Cataloger {
+	return generic.NewCataloger("dotnet-portable-executable-cataloger").
+		WithParserByGlobs(parseDotnetPortableExecutable, "**/*.dll", "**/*.exe")
+}
@@ -3,22 +3,51 @@ package dotnet
 import (
 	"testing"
 
+	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
 )
 
 func TestCataloger_Globs(t *testing.T) {
 	tests := []struct {
-		name     string
-		fixture  string
-		expected []string
+		name      string
+		fixture   string
+		cataloger pkg.Cataloger
+		expected  []string
 	}{
 		{
-			name:    "obtain deps.json files",
-			fixture: "test-fixtures/glob-paths",
+			name:      "obtain deps.json files",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewDotnetDepsCataloger(),
 			expected: []string{
 				"src/something.deps.json",
 			},
 		},
+		{
+			name:      "obtain packages.lock.json files",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewDotnetPackagesLockCataloger(),
+			expected: []string{
+				"src/packages.lock.json",
+			},
+		},
+		{
+			name:      "obtain project files",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewDotnetPackageReferenceCataloger(),
+			expected: []string{
+				"src/packages.lock.json",
+				"src/something.csproj",
+			},
+		},
+		{
+			name:      "obtain portable executable files",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewDotnetPortableExecutableCataloger(),
+			expected: []string{
+				"src/something.dll",
+				"src/something.exe",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -26,7 +55,7 @@ func TestCataloger_Globs(t *testing.T) {
 			pkgtest.NewCatalogTester().
 				FromDirectory(t, test.fixture).
 				ExpectsResolverContentQueries(test.expected).
-				TestCataloger(t, NewDotnetDepsCataloger())
+				TestCataloger(t, test.cataloger)
 		})
 	}
 }
@@ -2,13 +2,14 @@ package dotnet
 
 import (
 	"strings"
+	"unicode"
 
 	"github.com/nextlinux/packageurl-go"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/source"
 )
 
-func newDotnetDepsPackage(nameVersion string, lib dotnetDepsLibrary, locations ...source.Location) *pkg.Package {
+func newDotnetDepsPackage(nameVersion string, lib dotnetDepsLibrary, files []string, locations ...source.Location) *pkg.Package {
 	if lib.Type != "package" {
 		return nil
 	}
@@ -23,13 +24,14 @@ func newDotnetDepsPackage(nameVersion string, lib dotnetDepsLibrary, locations .
 		Path:     lib.Path,
 		Sha512:   lib.Sha512,
 		HashPath: lib.HashPath,
+		Files:    files,
 	}
 
 	p := &pkg.Package{
 		Name:         name,
 		Version:      version,
 		Locations:    source.NewLocationSet(locations...),
-		PURL:         packageURL(m),
+		PURL:         packageURL(m.Name, m.Version),
 		Language:     pkg.Dotnet,
 		Type:         pkg.DotnetPkg,
 		MetadataType: pkg.DotnetDepsMetadataType,
@@ -41,7 +43,78 @@ func newDotnetDepsPackage(nameVersion string, lib dotnetDepsLibrary, locations .
 	return p
 }
 
-func packageURL(m pkg.DotnetDepsMetadata) string {
+func newDotnetPackagesLockPackage(name string, dep dotnetPackagesLockDependency, locations ...source.Location) *pkg.Package {
+	m := pkg.DotnetDepsMetadata{
+		Name:    name,
+		Version: dep.Resolved,
+	}
+	if dep.ContentHash != "" {
+		// the content hash is the same SHA-512 of the nupkg file that deps.json files refer to
+		m.Sha512 = "sha512-" + dep.ContentHash
+	}
+
+	return newDotnetPackage(m, locations...)
+}
+
+func newDotnetPackageReferencePackage(name, version string, locations ...source.Location) *pkg.Package {
+	return newDotnetPackage(pkg.DotnetDepsMetadata{
+		Name:    name,
+		Version: version,
+	}, locations...)
+}
+
+func newDotnetPackage(m pkg.DotnetDepsMetadata, locations ...source.Location) *pkg.Package {
+	p := &pkg.Package{
+		Name:         m.Name,
+		Version:      m.Version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(m.Name, m.Version),
+		Language:     pkg.Dotnet,
+		Type:         pkg.DotnetPkg,
+		MetadataType: pkg.DotnetDepsMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func newDotnetPortableExecutablePackage(m pkg.DotnetPortableExecutableMetadata, locations ...source.Location) *pkg.Package {
+	version := portableExecutableVersion(m)
+	if m.AssemblyName == "" || version == "" {
+		return nil
+	}
+
+	p := &pkg.Package{
+		Name:         m.AssemblyName,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(m.AssemblyName, version),
+		Language:     pkg.Dotnet,
+		Type:         pkg.DotnetPkg,
+		MetadataType: pkg.DotnetPortableExecutableMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// portableExecutableVersion returns the version of the assembly, preferring the product version (which is the
+// informational version, and usually matches the NuGet package version) over the file version.
+func portableExecutableVersion(m pkg.DotnetPortableExecutableMetadata) string {
+	// the informational version may contain build metadata, e.g. "13.0.3+0a2e291c0d9c0c7675d445703e51750363a549ef"
+	version, _, _ := strings.Cut(m.ProductVersion, "+")
+	version = strings.TrimSpace(version)
+	if version != "" && unicode.IsDigit(rune(version[0])) {
+		return version
+	}
+	return strings.TrimSpace(m.FileVersion)
+}
+
+func packageURL(name, version string) string {
 	var qualifiers packageurl.Qualifiers
 
 	return packageurl.NewPackageURL(
@@ -57,8 +130,8 @@ func packageURL(m pkg.DotnetDepsMetadata) string {
 		// official PURL type available.
 		packageurl.TypeNuget,
 		"",
-		m.Name,
-		m.Version,
+		name,
+		version,
 		qualifiers,
 		"",
 	).ToString()
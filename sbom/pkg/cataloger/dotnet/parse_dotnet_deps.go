@@ -3,6 +3,7 @@ package dotnet
 import (
 	"encoding/json"
 	"fmt"
+	"path"
 	"sort"
 
 	"github.com/nextlinux/sbom/sbom/artifact"
@@ -14,7 +15,17 @@ import (
 var _ generic.Parser = parseDotnetDeps
 
 type dotnetDeps struct {
-	Libraries map[string]dotnetDepsLibrary `json:"libraries"`
+	RuntimeTarget dotnetRuntimeTarget                    `json:"runtimeTarget"`
+	Targets       map[string]map[string]dotnetDepsTarget `json:"targets"`
+	Libraries     map[string]dotnetDepsLibrary           `json:"libraries"`
+}
+
+type dotnetRuntimeTarget struct {
+	Name string `json:"name"`
+}
+
+type dotnetDepsTarget struct {
+	Runtime map[string]interface{} `json:"runtime"`
 }
 
 type dotnetDepsLibrary struct {
@@ -43,11 +54,14 @@ func parseDotnetDeps(_ source.FileResolver, _ *generic.Environment, reader sourc
 	// sort the names so that the order of the packages is deterministic
 	sort.Strings(names)
 
+	assemblies := p.runtimeAssemblies(path.Dir(reader.Location.RealPath))
+
 	for _, nameVersion := range names {
 		lib := p.Libraries[nameVersion]
 		dotnetPkg := newDotnetDepsPackage(
 			nameVersion,
 			lib,
+			assemblies[nameVersion],
 			reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
 		)
 
@@ -58,3 +72,27 @@ func parseDotnetDeps(_ source.FileResolver, _ *generic.Environment, reader sourc
 
 	return pkgs, nil, nil
 }
+
+// runtimeAssemblies returns the paths of the runtime assemblies for each library, as deployed by "dotnet publish"
+// (which places all assemblies alongside the deps.json file within the given directory).
+func (d dotnetDeps) runtimeAssemblies(dir string) map[string][]string {
+	targets := d.Targets
+	if target, ok := d.Targets[d.RuntimeTarget.Name]; ok {
+		targets = map[string]map[string]dotnetDepsTarget{d.RuntimeTarget.Name: target}
+	}
+
+	assemblies := make(map[string][]string)
+	for _, libraries := range targets {
+		for nameVersion, target := range libraries {
+			for assembly := range target.Runtime {
+				assemblies[nameVersion] = append(assemblies[nameVersion], path.Join(dir, path.Base(assembly)))
+			}
+		}
+	}
+
+	for nameVersion, paths := range assemblies {
+		sort.Strings(paths)
+		assemblies[nameVersion] = paths
+	}
+	return assemblies
+}
@@ -27,6 +27,7 @@ func TestParseDotnetDeps(t *testing.T) {
 				Sha512:   "sha512-kHBB+QmosVaG6DpngXQ8OlLVVNMzltNITfsRr68Z90qO7dSqJ2EHNd8dtBU1u3AQQLqqFHOY0lfmbpexeH6Pew==",
 				Path:     "awssdk.core/3.7.10.6",
 				HashPath: "awssdk.core.3.7.10.6.nupkg.sha512",
+				Files:    []string{"test-fixtures/AWSSDK.Core.dll"},
 			},
 		},
 		{
@@ -43,6 +44,7 @@ func TestParseDotnetDeps(t *testing.T) {
 				Sha512:   "sha512-xlzi2IYREJH3/m6+lUrQlujzX8wDitm4QGnUu6kUXTQAWPuZY8i+ticFJbzfqaetLA6KR/rO6Ew/HuYD+bxifg==",
 				Path:     "microsoft.extensions.dependencyinjection.abstractions/6.0.0",
 				HashPath: "microsoft.extensions.dependencyinjection.abstractions.6.0.0.nupkg.sha512",
+				Files:    []string{"test-fixtures/Microsoft.Extensions.DependencyInjection.Abstractions.dll"},
 			},
 		},
 		{
@@ -59,6 +61,7 @@ func TestParseDotnetDeps(t *testing.T) {
 				Sha512:   "sha512-k6PWQMuoBDGGHOQTtyois2u4AwyVcIwL2LaSLlTZQm2CYcJ1pxbt6jfAnpWmzENA/wfrYRI/X9DTLoUkE4AsLw==",
 				Path:     "microsoft.extensions.dependencyinjection/6.0.0",
 				HashPath: "microsoft.extensions.dependencyinjection.6.0.0.nupkg.sha512",
+				Files:    []string{"test-fixtures/Microsoft.Extensions.DependencyInjection.dll"},
 			},
 		},
 		{
@@ -75,6 +78,7 @@ func TestParseDotnetDeps(t *testing.T) {
 				Sha512:   "sha512-/HggWBbTwy8TgebGSX5DBZ24ndhzi93sHUBDvP1IxbZD7FDokYzdAr6+vbWGjw2XAfR2EJ1sfKUotpjHnFWPxA==",
 				Path:     "microsoft.extensions.logging.abstractions/6.0.0",
 				HashPath: "microsoft.extensions.logging.abstractions.6.0.0.nupkg.sha512",
+				Files:    []string{"test-fixtures/Microsoft.Extensions.Logging.Abstractions.dll"},
 			},
 		},
 		{
@@ -91,6 +95,7 @@ func TestParseDotnetDeps(t *testing.T) {
 				Sha512:   "sha512-eIbyj40QDg1NDz0HBW0S5f3wrLVnKWnDJ/JtZ+yJDFnDj90VoPuoPmFkeaXrtu+0cKm5GRAwoDf+dBWXK0TUdg==",
 				Path:     "microsoft.extensions.logging/6.0.0",
 				HashPath: "microsoft.extensions.logging.6.0.0.nupkg.sha512",
+				Files:    []string{"test-fixtures/Microsoft.Extensions.Logging.dll"},
 			},
 		},
 
@@ -108,6 +113,7 @@ func TestParseDotnetDeps(t *testing.T) {
 				Sha512:   "sha512-dzXN0+V1AyjOe2xcJ86Qbo233KHuLEY0njf/P2Kw8SfJU+d45HNS2ctJdnEnrWbM9Ye2eFgaC5Mj9otRMU6IsQ==",
 				Path:     "microsoft.extensions.options/6.0.0",
 				HashPath: "microsoft.extensions.options.6.0.0.nupkg.sha512",
+				Files:    []string{"test-fixtures/Microsoft.Extensions.Options.dll"},
 			},
 		},
 		{
@@ -124,6 +130,7 @@ func TestParseDotnetDeps(t *testing.T) {
 				Sha512:   "sha512-9+PnzmQFfEFNR9J2aDTfJGGupShHjOuGw4VUv+JB044biSHrnmCIMD+mJHmb2H7YryrfBEXDurxQ47gJZdCKNQ==",
 				Path:     "microsoft.extensions.primitives/6.0.0",
 				HashPath: "microsoft.extensions.primitives.6.0.0.nupkg.sha512",
+				Files:    []string{"test-fixtures/Microsoft.Extensions.Primitives.dll"},
 			},
 		},
 		{
@@ -140,6 +147,7 @@ func TestParseDotnetDeps(t *testing.T) {
 				Sha512:   "sha512-ppPFpBcvxdsfUonNcvITKqLl3bqxWbDCZIzDWHzjpdAHRFfZe0Dw9HmA0+za13IdyrgJwpkDTDA9fHaxOrt20A==",
 				Path:     "newtonsoft.json/13.0.1",
 				HashPath: "newtonsoft.json.13.0.1.nupkg.sha512",
+				Files:    []string{"test-fixtures/Newtonsoft.Json.dll"},
 			},
 		},
 		{
@@ -156,6 +164,7 @@ func TestParseDotnetDeps(t *testing.T) {
 				Sha512:   "sha512-apLOvSJQLlIbKlbx+Y2UDHSP05kJsV7mou+fvJoRGs/iR+jC22r8cuFVMjjfVxz/AD4B2UCltFhE1naRLXwKNw==",
 				Path:     "serilog.sinks.console/4.0.1",
 				HashPath: "serilog.sinks.console.4.0.1.nupkg.sha512",
+				Files:    []string{"test-fixtures/Serilog.Sinks.Console.dll"},
 			},
 		},
 		{
@@ -172,6 +181,7 @@ func TestParseDotnetDeps(t *testing.T) {
 				Sha512:   "sha512-+QX0hmf37a0/OZLxM3wL7V6/ADvC1XihXN4Kq/p6d8lCPfgkRdiuhbWlMaFjR9Av0dy5F0+MBeDmDdRZN/YwQA==",
 				Path:     "serilog/2.10.0",
 				HashPath: "serilog.2.10.0.nupkg.sha512",
+				Files:    []string{"test-fixtures/Serilog.dll"},
 			},
 		},
 		{
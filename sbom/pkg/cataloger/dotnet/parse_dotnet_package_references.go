@@ -0,0 +1,293 @@
+package dotnet
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var dotnetProjectGlobs = []string{
+	"**/*.csproj",
+	"**/*.fsproj",
+	"**/*.vbproj",
+}
+
+const (
+	dotnetCentralPackagesGlob = "**/Directory.Packages.props"
+	dotnetPackagesLockGlob    = "**/packages.lock.json"
+)
+
+var msbuildPropertyPattern = regexp.MustCompile(`\$\(([A-Za-z_][\w.-]*)\)`)
+
+// msbuildProject holds the fields of interest of an MSBuild project file (e.g. a csproj or Directory.Packages.props).
+type msbuildProject struct {
+	PropertyGroups []msbuildPropertyGroup `xml:"PropertyGroup"`
+	ItemGroups     []msbuildItemGroup     `xml:"ItemGroup"`
+}
+
+type msbuildPropertyGroup struct {
+	Properties []msbuildProperty `xml:",any"`
+}
+
+type msbuildProperty struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type msbuildItemGroup struct {
+	PackageReferences       []msbuildPackageItem `xml:"PackageReference"`
+	PackageVersions         []msbuildPackageItem `xml:"PackageVersion"`
+	GlobalPackageReferences []msbuildPackageItem `xml:"GlobalPackageReference"`
+}
+
+type msbuildPackageItem struct {
+	Include         string `xml:"Include,attr"`
+	Version         string `xml:"Version,attr"`
+	VersionOverride string `xml:"VersionOverride,attr"`
+	VersionElement  string `xml:"Version"`
+}
+
+func (i msbuildPackageItem) names() []string {
+	var names []string
+	for _, name := range strings.Split(i.Include, ";") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (i msbuildPackageItem) version() string {
+	for _, v := range []string{i.VersionOverride, i.Version, i.VersionElement} {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func (p msbuildProject) properties() map[string]string {
+	properties := make(map[string]string)
+	for _, group := range p.PropertyGroups {
+		for _, property := range group.Properties {
+			properties[property.XMLName.Local] = strings.TrimSpace(property.Value)
+		}
+	}
+	return properties
+}
+
+// NewDotnetPackageReferenceCataloger returns a cataloger for packages referenced by MSBuild project files (csproj,
+// fsproj, and vbproj), including versions managed centrally within Directory.Packages.props files. References that
+// are pinned by a packages.lock.json file are left to the packages lock cataloger.
+func NewDotnetPackageReferenceCataloger() pkg.Cataloger {
+	return &packageReferenceCataloger{}
+}
+
+type packageReferenceCataloger struct{}
+
+func (c *packageReferenceCataloger) Name() string {
+	return "dotnet-package-reference-cataloger"
+}
+
+func (c *packageReferenceCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	index := newDotnetProjectIndex(resolver)
+	return generic.NewCataloger(c.Name()).
+		WithParserByGlobs(index.parseProject, dotnetProjectGlobs...).
+		WithParserByGlobs(index.parseCentralPackages, dotnetCentralPackagesGlob).
+		Catalog(resolver)
+}
+
+// dotnetCentralPackages is a Directory.Packages.props file, which applies to all projects within the directory tree.
+type dotnetCentralPackages struct {
+	location   source.Location
+	versions   map[string]string
+	properties map[string]string
+}
+
+type dotnetProjectIndex struct {
+	central map[string]*dotnetCentralPackages
+	locked  map[string]internal.StringSet
+}
+
+func newDotnetProjectIndex(resolver source.FileResolver) *dotnetProjectIndex {
+	index := &dotnetProjectIndex{
+		central: make(map[string]*dotnetCentralPackages),
+		locked:  make(map[string]internal.StringSet),
+	}
+
+	forEachFile(resolver, dotnetCentralPackagesGlob, func(location source.Location, reader io.Reader) error {
+		project, err := readMSBuildProject(reader)
+		if err != nil {
+			return err
+		}
+		central := &dotnetCentralPackages{
+			location:   location,
+			versions:   make(map[string]string),
+			properties: project.properties(),
+		}
+		for _, group := range project.ItemGroups {
+			for _, item := range group.PackageVersions {
+				for _, name := range item.names() {
+					central.versions[strings.ToLower(name)] = item.version()
+				}
+			}
+		}
+		index.central[path.Dir(location.RealPath)] = central
+		return nil
+	})
+
+	forEachFile(resolver, dotnetPackagesLockGlob, func(location source.Location, reader io.Reader) error {
+		var lock dotnetPackagesLock
+		if err := json.NewDecoder(reader).Decode(&lock); err != nil {
+			return err
+		}
+		names := internal.NewStringSet()
+		for _, dependencies := range lock.Dependencies {
+			for name := range dependencies {
+				names.Add(strings.ToLower(name))
+			}
+		}
+		index.locked[path.Dir(location.RealPath)] = names
+		return nil
+	})
+
+	return index
+}
+
+func forEachFile(resolver source.FileResolver, glob string, fn func(source.Location, io.Reader) error) {
+	locations, err := resolver.FilesByGlob(glob)
+	if err != nil {
+		log.WithFields("glob", glob, "error", err).Debug("unable to find dotnet files")
+		return
+	}
+	for _, location := range locations {
+		reader, err := resolver.FileContentsByLocation(location)
+		if err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Debug("unable to read dotnet file")
+			continue
+		}
+		if err := fn(location, reader); err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Debug("unable to parse dotnet file")
+		}
+		internal.CloseAndLogError(reader, location.RealPath)
+	}
+}
+
+// centralPackagesFor returns the nearest Directory.Packages.props file for a project within the given directory.
+func (x *dotnetProjectIndex) centralPackagesFor(dir string) *dotnetCentralPackages {
+	for d := dir; ; d = path.Dir(d) {
+		if central, ok := x.central[d]; ok {
+			return central
+		}
+		if d == "/" || d == "." {
+			return nil
+		}
+	}
+}
+
+// parseProject is a parser function for the PackageReference items of an MSBuild project file.
+func (x *dotnetProjectIndex) parseProject(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	project, err := readMSBuildProject(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dir := path.Dir(reader.Location.RealPath)
+	central := x.centralPackagesFor(dir)
+	locked := x.locked[dir]
+
+	properties := make(map[string]string)
+	if central != nil {
+		for k, v := range central.properties {
+			properties[k] = v
+		}
+	}
+	for k, v := range project.properties() {
+		properties[k] = v
+	}
+
+	var pkgs []pkg.Package
+	seen := internal.NewStringSet()
+	for _, group := range project.ItemGroups {
+		for _, item := range group.PackageReferences {
+			for _, name := range item.names() {
+				key := strings.ToLower(name)
+				if seen.Contains(key) || (locked != nil && locked.Contains(key)) {
+					continue
+				}
+				seen.Add(key)
+
+				locations := []source.Location{reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)}
+				version := item.version()
+				if version == "" && central != nil {
+					if v, ok := central.versions[key]; ok {
+						version = v
+						locations = append(locations, central.location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+					}
+				}
+
+				pkgs = append(pkgs, *newDotnetPackageReferencePackage(name, expandMSBuildProperties(version, properties), locations...))
+			}
+		}
+	}
+
+	return pkgs, nil, nil
+}
+
+// parseCentralPackages is a parser function for the GlobalPackageReference items of a Directory.Packages.props file,
+// which are referenced by every project within the directory tree (the PackageVersion items only declare versions).
+func (x *dotnetProjectIndex) parseCentralPackages(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	project, err := readMSBuildProject(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	properties := project.properties()
+
+	var pkgs []pkg.Package
+	for _, group := range project.ItemGroups {
+		for _, item := range group.GlobalPackageReferences {
+			for _, name := range item.names() {
+				version := expandMSBuildProperties(item.version(), properties)
+				pkgs = append(pkgs, *newDotnetPackageReferencePackage(name, version, reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)))
+			}
+		}
+	}
+
+	return pkgs, nil, nil
+}
+
+func readMSBuildProject(reader io.Reader) (*msbuildProject, error) {
+	var project msbuildProject
+	if err := xml.NewDecoder(reader).Decode(&project); err != nil {
+		return nil, fmt.Errorf("unable to parse MSBuild project file: %w", err)
+	}
+	return &project, nil
+}
+
+// expandMSBuildProperties replaces property references (e.g. "$(SerilogVersion)") within a version. Versions with
+// properties that cannot be expanded (e.g. set from the command line) are not reported.
+func expandMSBuildProperties(version string, properties map[string]string) string {
+	expanded := msbuildPropertyPattern.ReplaceAllStringFunc(version, func(match string) string {
+		name := msbuildPropertyPattern.FindStringSubmatch(match)[1]
+		if v, ok := properties[name]; ok {
+			return v
+		}
+		return match
+	})
+	if strings.Contains(expanded, "$(") {
+		return ""
+	}
+	return expanded
+}
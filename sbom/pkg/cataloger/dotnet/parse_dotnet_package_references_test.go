@@ -0,0 +1,72 @@
+package dotnet
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestPackageReferenceCataloger(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/package-references")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := NewDotnetPackageReferenceCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	assert.Empty(t, relationships)
+
+	var actual []string
+	for _, p := range pkgs {
+		var paths []string
+		for _, l := range p.Locations.ToSlice() {
+			paths = append(paths, l.RealPath)
+		}
+		sort.Strings(paths)
+		actual = append(actual, fmt.Sprintf("%v %s@%s", paths, p.Name, p.Version))
+	}
+
+	assert.ElementsMatch(t, []string{
+		"[/Directory.Packages.props] Nerdbank.GitVersioning@3.6.133",
+		// versions are managed centrally
+		"[/Directory.Packages.props /src/App/App.csproj] Newtonsoft.Json@13.0.3",
+		"[/Directory.Packages.props /src/App/App.csproj] Serilog@2.10.0",
+		"[/src/App/App.csproj] AWSSDK.Core@3.7.100.14",
+		"[/src/App/App.csproj] Microsoft.Extensions.Logging@6.0.0",
+		"[/src/Lib/Lib.fsproj] FSharp.Core@6.0.7",
+		"[/Directory.Packages.props /src/Lib/Lib.fsproj] Newtonsoft.Json@13.0.3",
+		"[/src/Lib/Lib.fsproj] Serilog.Sinks.Console@[4.0.1,5.0.0)",
+		// the version property is not defined within the project
+		"[/src/Lib/Lib.fsproj] Microsoft.SourceLink.GitHub@",
+		// Newtonsoft.Json is pinned by the packages.lock.json file and reported by the packages lock cataloger instead
+		"[/src/Locked/Locked.csproj] Polly@7.2.4",
+	}, actual)
+}
+
+func Test_expandMSBuildProperties(t *testing.T) {
+	properties := map[string]string{
+		"SerilogVersion": "2.10.0",
+		"Major":          "6",
+	}
+
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{version: "1.2.3", expected: "1.2.3"},
+		{version: "$(SerilogVersion)", expected: "2.10.0"},
+		{version: "$(Major).0.0", expected: "6.0.0"},
+		{version: "[$(Major).0.0, )", expected: "[6.0.0, )"},
+		{version: "$(Missing)", expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			assert.Equal(t, test.expected, expandMSBuildProperties(test.version, properties))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package dotnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseDotnetPackagesLock
+
+// dotnetPackagesLock represents a NuGet packages.lock.json file, which holds the resolved dependencies for each
+// target framework of a project.
+type dotnetPackagesLock struct {
+	Version      int                                                `json:"version"`
+	Dependencies map[string]map[string]dotnetPackagesLockDependency `json:"dependencies"`
+}
+
+type dotnetPackagesLockDependency struct {
+	Type         string            `json:"type"`
+	Requested    string            `json:"requested"`
+	Resolved     string            `json:"resolved"`
+	ContentHash  string            `json:"contentHash"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+func parseDotnetPackagesLock(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var lock dotnetPackagesLock
+	if err := json.NewDecoder(reader).Decode(&lock); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse packages.lock.json file: %w", err)
+	}
+
+	var pkgs []pkg.Package
+	var relationships []artifact.Relationship
+	pkgsByNameVersion := make(map[string]*pkg.Package)
+	relationshipKeys := make(map[string]struct{})
+
+	var frameworks []string
+	for framework := range lock.Dependencies {
+		frameworks = append(frameworks, framework)
+	}
+
+	// sort the frameworks and names so that the order of the packages is deterministic
+	sort.Strings(frameworks)
+
+	for _, framework := range frameworks {
+		dependencies := lock.Dependencies[framework]
+
+		var names []string
+		for name := range dependencies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		// package names are case-insensitive
+		resolved := make(map[string]*pkg.Package)
+		for _, name := range names {
+			dep := dependencies[name]
+			// project references are not packages
+			if dep.Type == "Project" || dep.Resolved == "" {
+				continue
+			}
+
+			nameVersion := name + "/" + dep.Resolved
+			p, ok := pkgsByNameVersion[nameVersion]
+			if !ok {
+				p = newDotnetPackagesLockPackage(name, dep, reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation))
+				pkgsByNameVersion[nameVersion] = p
+				pkgs = append(pkgs, *p)
+			}
+			resolved[strings.ToLower(name)] = p
+		}
+
+		for _, name := range names {
+			dependent, ok := resolved[strings.ToLower(name)]
+			if !ok {
+				continue
+			}
+
+			var depNames []string
+			for depName := range dependencies[name].Dependencies {
+				depNames = append(depNames, depName)
+			}
+			sort.Strings(depNames)
+
+			for _, depName := range depNames {
+				dep, ok := resolved[strings.ToLower(depName)]
+				if !ok {
+					continue
+				}
+				key := string(dep.ID()) + ":" + string(dependent.ID())
+				if _, exists := relationshipKeys[key]; exists {
+					continue
+				}
+				relationshipKeys[key] = struct{}{}
+				relationships = append(relationships, artifact.Relationship{
+					From: *dep,
+					To:   *dependent,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+
+	return pkgs, relationships, nil
+}
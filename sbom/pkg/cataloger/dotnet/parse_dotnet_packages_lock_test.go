@@ -0,0 +1,84 @@
+package dotnet
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseDotnetPackagesLock(t *testing.T) {
+	fixture := "test-fixtures/packages.lock.json"
+	fixtureLocationSet := source.NewLocationSet(source.NewLocation(fixture))
+
+	newtonsoft := pkg.Package{
+		Name:         "Newtonsoft.Json",
+		Version:      "13.0.1",
+		PURL:         "pkg:nuget/Newtonsoft.Json@13.0.1",
+		Locations:    fixtureLocationSet,
+		Language:     pkg.Dotnet,
+		Type:         pkg.DotnetPkg,
+		MetadataType: pkg.DotnetDepsMetadataType,
+		Metadata: pkg.DotnetDepsMetadata{
+			Name:    "Newtonsoft.Json",
+			Version: "13.0.1",
+			Sha512:  "sha512-ppPFpBcvxdsfUonNcvITKqLl3bqxWbDCZIzDWHzjpdAHRFfZe0Dw9HmA0+za13IdyrgJwpkDTDA9fHaxOrt20A==",
+		},
+	}
+	serilog := pkg.Package{
+		Name:         "Serilog",
+		Version:      "2.10.0",
+		PURL:         "pkg:nuget/Serilog@2.10.0",
+		Locations:    fixtureLocationSet,
+		Language:     pkg.Dotnet,
+		Type:         pkg.DotnetPkg,
+		MetadataType: pkg.DotnetDepsMetadataType,
+		Metadata: pkg.DotnetDepsMetadata{
+			Name:    "Serilog",
+			Version: "2.10.0",
+			Sha512:  "sha512-+QX0hmf37a0/OZLxM3wL7V6/ADvC1XihXN4Kq/p6d8lCPfgkRdiuhbWlMaFjR9Av0dy5F0+MBeDmDdRZN/YwQA==",
+		},
+	}
+	serilogSinksConsole := pkg.Package{
+		Name:         "Serilog.Sinks.Console",
+		Version:      "4.0.1",
+		PURL:         "pkg:nuget/Serilog.Sinks.Console@4.0.1",
+		Locations:    fixtureLocationSet,
+		Language:     pkg.Dotnet,
+		Type:         pkg.DotnetPkg,
+		MetadataType: pkg.DotnetDepsMetadataType,
+		Metadata: pkg.DotnetDepsMetadata{
+			Name:    "Serilog.Sinks.Console",
+			Version: "4.0.1",
+			Sha512:  "sha512-apLOvSJQLlIbKlbx+Y2UDHSP05kJsV7mou+fvJoRGs/iR+jC22r8cuFVMjjfVxz/AD4B2UCltFhE1naRLXwKNw==",
+		},
+	}
+	// each target framework may resolve different versions
+	newtonsoftNet7 := pkg.Package{
+		Name:         "Newtonsoft.Json",
+		Version:      "13.0.3",
+		PURL:         "pkg:nuget/Newtonsoft.Json@13.0.3",
+		Locations:    fixtureLocationSet,
+		Language:     pkg.Dotnet,
+		Type:         pkg.DotnetPkg,
+		MetadataType: pkg.DotnetDepsMetadataType,
+		Metadata: pkg.DotnetDepsMetadata{
+			Name:    "Newtonsoft.Json",
+			Version: "13.0.3",
+			Sha512:  "sha512-HrC5BXdl00IP9zeV+0Z848QWPAoCr9P3bDEZguI+gkLcBKAOxix/tLEAAHC+UvDNPv4a2d18lOReHMOagPa+zQ==",
+		},
+	}
+
+	expectedPkgs := []pkg.Package{newtonsoft, serilog, serilogSinksConsole, newtonsoftNet7}
+	expectedRelationships := []artifact.Relationship{
+		{
+			From: serilog,
+			To:   serilogSinksConsole,
+			Type: artifact.DependencyOfRelationship,
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseDotnetPackagesLock, expectedPkgs, expectedRelationships)
+}
@@ -0,0 +1,334 @@
+package dotnet
+
+import (
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"path"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/unionreader"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseDotnetPortableExecutable
+
+const (
+	// see https://learn.microsoft.com/en-us/windows/win32/debug/pe-format#optional-header-data-directories-image-only
+	imageDirectoryEntryResource      = 2
+	imageDirectoryEntryCOMDescriptor = 14
+
+	// see https://learn.microsoft.com/en-us/windows/win32/menurc/resource-types
+	resourceTypeVersion = 16
+
+	vsFixedFileInfoSignature = 0xFEEF04BD
+
+	// resource trees are three levels deep (type, name, and language), anything deeper is malformed
+	maxResourceDepth = 3
+)
+
+// parseDotnetPortableExecutable is a parser function for .NET assemblies, returning a package described by the
+// version resource of the assembly.
+func parseDotnetPortableExecutable(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	unionReader, err := unionreader.GetUnionReader(reader.ReadCloser)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read portable executable: %w", err)
+	}
+
+	f, err := pe.NewFile(unionReader)
+	if err != nil {
+		// not every file with a .dll or .exe extension is a portable executable
+		return nil, nil, nil
+	}
+	defer f.Close()
+
+	// only .NET assemblies have a CLR header, all other portable executables are native code
+	if dataDirectory(f, imageDirectoryEntryCOMDescriptor).VirtualAddress == 0 {
+		return nil, nil, nil
+	}
+
+	info, err := readVersionResource(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read version resource: %w", err)
+	}
+	if info == nil {
+		return nil, nil, nil
+	}
+
+	m := newDotnetPortableExecutableMetadata(path.Base(reader.Location.RealPath), info)
+	p := newDotnetPortableExecutablePackage(m, reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation))
+	if p == nil {
+		return nil, nil, nil
+	}
+
+	return []pkg.Package{*p}, nil, nil
+}
+
+func newDotnetPortableExecutableMetadata(filename string, info map[string]string) pkg.DotnetPortableExecutableMetadata {
+	m := pkg.DotnetPortableExecutableMetadata{
+		FileVersion:      info["FileVersion"],
+		ProductVersion:   info["ProductVersion"],
+		ProductName:      info["ProductName"],
+		CompanyName:      info["CompanyName"],
+		FileDescription:  info["FileDescription"],
+		InternalName:     info["InternalName"],
+		OriginalFilename: info["OriginalFilename"],
+		LegalCopyright:   info["LegalCopyright"],
+		Comments:         info["Comments"],
+	}
+
+	// the assembly name is the file name without extension, which usually matches the NuGet package name
+	for _, name := range []string{m.OriginalFilename, m.InternalName, filename} {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		ext := path.Ext(name)
+		if strings.EqualFold(ext, ".dll") || strings.EqualFold(ext, ".exe") {
+			name = strings.TrimSuffix(name, ext)
+		}
+		m.AssemblyName = name
+		break
+	}
+
+	return m
+}
+
+func dataDirectory(f *pe.File, index int) pe.DataDirectory {
+	switch h := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		if index < int(h.NumberOfRvaAndSizes) {
+			return h.DataDirectory[index]
+		}
+	case *pe.OptionalHeader64:
+		if index < int(h.NumberOfRvaAndSizes) {
+			return h.DataDirectory[index]
+		}
+	}
+	return pe.DataDirectory{}
+}
+
+// readVersionResource returns the string values of the version resource (VS_VERSIONINFO) of the portable executable,
+// or nil if there is no version resource.
+func readVersionResource(f *pe.File) (map[string]string, error) {
+	directory := dataDirectory(f, imageDirectoryEntryResource)
+	if directory.VirtualAddress == 0 {
+		return nil, nil
+	}
+
+	section := sectionForRVA(f, directory.VirtualAddress)
+	if section == nil {
+		return nil, fmt.Errorf("no section for resource directory at RVA=0x%x", directory.VirtualAddress)
+	}
+	data, err := section.Data()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read resource section: %w", err)
+	}
+
+	r := resourceReader{
+		data:    data,
+		base:    directory.VirtualAddress - section.VirtualAddress,
+		address: section.VirtualAddress,
+	}
+	entry, ok := r.find(0, 0, resourceTypeVersion)
+	if !ok {
+		return nil, nil
+	}
+
+	versionInfo, ok := r.dataEntry(entry)
+	if !ok {
+		return nil, fmt.Errorf("invalid version resource data entry")
+	}
+
+	return parseVersionInfo(versionInfo), nil
+}
+
+func sectionForRVA(f *pe.File, rva uint32) *pe.Section {
+	for _, s := range f.Sections {
+		if rva >= s.VirtualAddress && rva < s.VirtualAddress+s.VirtualSize {
+			return s
+		}
+	}
+	return nil
+}
+
+// resourceReader reads the resource directory tree of a portable executable (see
+// https://learn.microsoft.com/en-us/windows/win32/debug/pe-format#the-rsrc-section).
+type resourceReader struct {
+	data []byte
+	// base is the offset of the resource directory within the section data
+	base uint32
+	// address is the RVA of the section
+	address uint32
+}
+
+// find returns the offset of the first data entry under the resource directory at the given offset, only descending
+// into the entry with the given ID at the top (type) level.
+func (r resourceReader) find(offset uint32, depth int, id uint32) (uint32, bool) {
+	if depth >= maxResourceDepth {
+		return 0, false
+	}
+
+	start := uint64(r.base) + uint64(offset)
+	if start+16 > uint64(len(r.data)) {
+		return 0, false
+	}
+	count := uint64(binary.LittleEndian.Uint16(r.data[start+12:])) + uint64(binary.LittleEndian.Uint16(r.data[start+14:]))
+
+	for i := uint64(0); i < count; i++ {
+		entry := start + 16 + i*8
+		if entry+8 > uint64(len(r.data)) {
+			return 0, false
+		}
+		name := binary.LittleEndian.Uint32(r.data[entry:])
+		target := binary.LittleEndian.Uint32(r.data[entry+4:])
+
+		if depth == 0 && name != id {
+			continue
+		}
+
+		// the high bit indicates that the entry is another directory, otherwise it is a data entry
+		if target&0x80000000 == 0 {
+			return target, true
+		}
+		if found, ok := r.find(target&0x7FFFFFFF, depth+1, id); ok {
+			return found, true
+		}
+	}
+	return 0, false
+}
+
+// dataEntry returns the contents of the resource data entry at the given offset.
+func (r resourceReader) dataEntry(offset uint32) ([]byte, bool) {
+	entry := uint64(r.base) + uint64(offset)
+	if entry+16 > uint64(len(r.data)) {
+		return nil, false
+	}
+	rva := binary.LittleEndian.Uint32(r.data[entry:])
+	size := binary.LittleEndian.Uint32(r.data[entry+4:])
+
+	if rva < r.address {
+		return nil, false
+	}
+	start := uint64(rva - r.address)
+	end := start + uint64(size)
+	if end > uint64(len(r.data)) {
+		return nil, false
+	}
+	return r.data[start:end], true
+}
+
+// versionBlock is a single node within a VS_VERSIONINFO structure, for instance StringFileInfo, a StringTable, or a
+// String (see https://learn.microsoft.com/en-us/windows/win32/menurc/vs-versioninfo).
+type versionBlock struct {
+	length   int
+	key      string
+	value    []byte
+	children []versionBlock
+}
+
+func (b versionBlock) textValue() string {
+	return strings.TrimRight(decodeUTF16(b.value), "\x00")
+}
+
+func parseVersionInfo(data []byte) map[string]string {
+	root, ok := readVersionBlock(data)
+	if !ok || root.key != "VS_VERSION_INFO" {
+		return nil
+	}
+
+	info := make(map[string]string)
+	for _, child := range root.children {
+		if child.key != "StringFileInfo" {
+			continue
+		}
+		for _, table := range child.children {
+			for _, s := range table.children {
+				// the first string table (language) wins
+				if _, exists := info[s.key]; !exists {
+					info[s.key] = strings.TrimSpace(s.textValue())
+				}
+			}
+		}
+	}
+
+	// fall back to the binary file version when there is no string for it
+	if info["FileVersion"] == "" && len(root.value) >= 16 && binary.LittleEndian.Uint32(root.value) == vsFixedFileInfoSignature {
+		ms := binary.LittleEndian.Uint32(root.value[8:])
+		ls := binary.LittleEndian.Uint32(root.value[12:])
+		info["FileVersion"] = fmt.Sprintf("%d.%d.%d.%d", ms>>16, ms&0xFFFF, ls>>16, ls&0xFFFF)
+	}
+
+	return info
+}
+
+func readVersionBlock(data []byte) (versionBlock, bool) {
+	if len(data) < 6 {
+		return versionBlock{}, false
+	}
+	length := int(binary.LittleEndian.Uint16(data))
+	valueLength := int(binary.LittleEndian.Uint16(data[2:]))
+	text := binary.LittleEndian.Uint16(data[4:]) == 1
+	if length < 6 || length > len(data) {
+		return versionBlock{}, false
+	}
+	data = data[:length]
+
+	// the key is a null-terminated UTF-16 string
+	pos := 6
+	var key []uint16
+	for ; pos+1 < len(data); pos += 2 {
+		c := binary.LittleEndian.Uint16(data[pos:])
+		if c == 0 {
+			pos += 2
+			break
+		}
+		key = append(key, c)
+	}
+	pos = align4(pos)
+
+	// the value length of text values is in characters (words) rather than bytes
+	valueSize := valueLength
+	if text {
+		valueSize *= 2
+	}
+	if pos+valueSize > len(data) {
+		valueSize = len(data) - pos
+	}
+	if valueSize < 0 {
+		return versionBlock{}, false
+	}
+
+	block := versionBlock{
+		length: length,
+		key:    string(utf16.Decode(key)),
+		value:  data[pos : pos+valueSize],
+	}
+
+	for pos = align4(pos + valueSize); pos < len(data); {
+		child, ok := readVersionBlock(data[pos:])
+		if !ok {
+			break
+		}
+		block.children = append(block.children, child)
+		pos = align4(pos + child.length)
+	}
+
+	return block, true
+}
+
+func align4(n int) int {
+	return (n + 3) &^ 3
+}
+
+func decodeUTF16(data []byte) string {
+	chars := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		chars = append(chars, binary.LittleEndian.Uint16(data[i:]))
+	}
+	return string(utf16.Decode(chars))
+}
@@ -0,0 +1,221 @@
+package dotnet
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseDotnetPortableExecutable(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPortableExecutable(t, filepath.Join(dir, "Newtonsoft.Json.dll"), true, map[string]string{
+		"CompanyName":      "Newtonsoft",
+		"FileDescription":  "Json.NET",
+		"FileVersion":      "13.0.3.27908",
+		"InternalName":     "Newtonsoft.Json.dll",
+		"LegalCopyright":   "Copyright © James Newton-King 2008",
+		"OriginalFilename": "Newtonsoft.Json.dll",
+		"ProductName":      "Json.NET",
+		"ProductVersion":   "13.0.3+0a2e291c0d9c0c7675d445703e51750363a549ef",
+	})
+	// native code is not a .NET assembly, even with a version resource
+	writeTestPortableExecutable(t, filepath.Join(dir, "native.dll"), false, map[string]string{
+		"FileVersion": "1.2.3",
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "not-a-pe.dll"), []byte("MZ but not really"), 0600))
+
+	location := source.NewLocation("/Newtonsoft.Json.dll")
+	expected := []pkg.Package{
+		{
+			Name:         "Newtonsoft.Json",
+			Version:      "13.0.3",
+			PURL:         "pkg:nuget/Newtonsoft.Json@13.0.3",
+			Locations:    source.NewLocationSet(location),
+			Language:     pkg.Dotnet,
+			Type:         pkg.DotnetPkg,
+			MetadataType: pkg.DotnetPortableExecutableMetadataType,
+			Metadata: pkg.DotnetPortableExecutableMetadata{
+				AssemblyName:     "Newtonsoft.Json",
+				FileVersion:      "13.0.3.27908",
+				ProductVersion:   "13.0.3+0a2e291c0d9c0c7675d445703e51750363a549ef",
+				ProductName:      "Json.NET",
+				CompanyName:      "Newtonsoft",
+				FileDescription:  "Json.NET",
+				InternalName:     "Newtonsoft.Json.dll",
+				OriginalFilename: "Newtonsoft.Json.dll",
+				LegalCopyright:   "Copyright © James Newton-King 2008",
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, dir).
+		Expects(expected, nil).
+		TestCataloger(t, NewDotnetPortableExecutableCataloger())
+}
+
+func Test_portableExecutableVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata pkg.DotnetPortableExecutableMetadata
+		expected string
+	}{
+		{
+			name:     "product version with build metadata",
+			metadata: pkg.DotnetPortableExecutableMetadata{ProductVersion: "6.0.0+4822e3c3aa77eb82b2fb33c9321f923cf11ddde6", FileVersion: "6.0.21.52210"},
+			expected: "6.0.0",
+		},
+		{
+			name:     "non-numeric product version",
+			metadata: pkg.DotnetPortableExecutableMetadata{ProductVersion: "Release build", FileVersion: "1.2.3.4"},
+			expected: "1.2.3.4",
+		},
+		{
+			name:     "file version only",
+			metadata: pkg.DotnetPortableExecutableMetadata{FileVersion: "3.7.10.6"},
+			expected: "3.7.10.6",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, portableExecutableVersion(test.metadata))
+		})
+	}
+}
+
+// writeTestPortableExecutable writes a minimal PE32 image with a version resource (and optionally a CLR header).
+func writeTestPortableExecutable(t *testing.T, path string, clr bool, info map[string]string) {
+	t.Helper()
+
+	const (
+		sectionOffset  = 0x200
+		sectionAddress = 0x1000
+		versionOffset  = 0x58
+	)
+
+	versionInfo := testVersionInfo(info)
+
+	// the resource directory tree: type (RT_VERSION) -> name (1) -> language (0x409) -> data entry
+	rsrc := new(bytes.Buffer)
+	for _, level := range []struct{ id, target uint32 }{
+		{id: resourceTypeVersion, target: 0x80000000 | 0x18},
+		{id: 1, target: 0x80000000 | 0x30},
+		{id: 0x409, target: 0x48},
+	} {
+		mustWrite(t, rsrc, [3]uint32{})     // characteristics, timestamp, and version
+		mustWrite(t, rsrc, [2]uint16{0, 1}) // number of named and ID entries
+		mustWrite(t, rsrc, [2]uint32{level.id, level.target})
+	}
+	mustWrite(t, rsrc, [4]uint32{sectionAddress + versionOffset, uint32(len(versionInfo)), 0, 0})
+	rsrc.Write(make([]byte, versionOffset-rsrc.Len()))
+	rsrc.Write(versionInfo)
+	size := uint32(align4(rsrc.Len()))
+	rsrc.Write(make([]byte, int(size)-rsrc.Len()))
+
+	optionalHeader := pe.OptionalHeader32{
+		Magic:               0x10b,
+		SizeOfImage:         sectionAddress + size,
+		SizeOfHeaders:       sectionOffset,
+		SectionAlignment:    0x1000,
+		FileAlignment:       0x200,
+		NumberOfRvaAndSizes: 16,
+	}
+	optionalHeader.DataDirectory[imageDirectoryEntryResource] = pe.DataDirectory{VirtualAddress: sectionAddress, Size: size}
+	if clr {
+		optionalHeader.DataDirectory[imageDirectoryEntryCOMDescriptor] = pe.DataDirectory{VirtualAddress: 0x2000, Size: 72}
+	}
+
+	var name [8]uint8
+	copy(name[:], ".rsrc")
+
+	image := new(bytes.Buffer)
+	image.WriteString("MZ")
+	image.Write(make([]byte, 0x3c-image.Len()))
+	mustWrite(t, image, uint32(0x40))
+	image.WriteString("PE\x00\x00")
+	mustWrite(t, image, pe.FileHeader{
+		Machine:              pe.IMAGE_FILE_MACHINE_I386,
+		NumberOfSections:     1,
+		SizeOfOptionalHeader: uint16(binary.Size(optionalHeader)),
+		Characteristics:      pe.IMAGE_FILE_EXECUTABLE_IMAGE | pe.IMAGE_FILE_32BIT_MACHINE | pe.IMAGE_FILE_DLL,
+	})
+	mustWrite(t, image, optionalHeader)
+	mustWrite(t, image, pe.SectionHeader32{
+		Name:             name,
+		VirtualSize:      size,
+		VirtualAddress:   sectionAddress,
+		SizeOfRawData:    size,
+		PointerToRawData: sectionOffset,
+		Characteristics:  0x40000040, // initialized data, readable
+	})
+	image.Write(make([]byte, sectionOffset-image.Len()))
+	image.Write(rsrc.Bytes())
+
+	require.NoError(t, os.WriteFile(path, image.Bytes(), 0600))
+}
+
+// testVersionInfo encodes a VS_VERSIONINFO structure with a single string table.
+func testVersionInfo(info map[string]string) []byte {
+	var keys []string
+	for k := range info {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var stringBlocks [][]byte
+	for _, k := range keys {
+		value := utf16Bytes(info[k] + "\x00")
+		stringBlocks = append(stringBlocks, testVersionBlock(k, value, uint16(len(value)/2), true))
+	}
+	table := testVersionBlock("040904b0", nil, 0, true, stringBlocks...)
+	stringFileInfo := testVersionBlock("StringFileInfo", nil, 0, true, table)
+
+	fixed := new(bytes.Buffer)
+	_ = binary.Write(fixed, binary.LittleEndian, [13]uint32{vsFixedFileInfoSignature, 0x00010000})
+
+	return testVersionBlock("VS_VERSION_INFO", fixed.Bytes(), uint16(fixed.Len()), false, stringFileInfo)
+}
+
+func testVersionBlock(key string, value []byte, valueLength uint16, text bool, children ...[]byte) []byte {
+	body := new(bytes.Buffer)
+	body.Write(utf16Bytes(key + "\x00"))
+	body.Write(make([]byte, align4(6+body.Len())-6-body.Len()))
+	body.Write(value)
+	for _, child := range children {
+		body.Write(make([]byte, align4(6+body.Len())-6-body.Len()))
+		body.Write(child)
+	}
+
+	var typ uint16
+	if text {
+		typ = 1
+	}
+
+	block := new(bytes.Buffer)
+	_ = binary.Write(block, binary.LittleEndian, [3]uint16{uint16(6 + body.Len()), valueLength, typ})
+	block.Write(body.Bytes())
+	return block.Bytes()
+}
+
+func utf16Bytes(s string) []byte {
+	b := new(bytes.Buffer)
+	_ = binary.Write(b, binary.LittleEndian, utf16.Encode([]rune(s)))
+	return b.Bytes()
+}
+
+func mustWrite(t *testing.T, b *bytes.Buffer, data interface{}) {
+	t.Helper()
+	require.NoError(t, binary.Write(b, binary.LittleEndian, data))
+}
@@ -0,0 +1,19 @@
+/*
+Package githubactions provides a concrete Cataloger implementation for the actions, reusable workflows and container
+images referenced by GitHub Actions workflows and action definitions.
+*/
+package githubactions
+
+import (
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+)
+
+const catalogerName = "github-actions-usage-cataloger"
+
+// NewActionUsageCataloger returns a new cataloger object for the dependencies of GitHub Actions workflows
+// (.github/workflows/*.yml) and action definitions (action.yml).
+func NewActionUsageCataloger() *generic.Cataloger {
+	return generic.NewCataloger(catalogerName).
+		WithParserByGlobs(parseWorkflow, "**/.github/workflows/*.yml", "**/.github/workflows/*.yaml").
+		WithParserByGlobs(parseAction, "**/action.yml", "**/action.yaml")
+}
@@ -0,0 +1,35 @@
+package githubactions
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+)
+
+func TestCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain workflow and action files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/.github/workflows/build.yml",
+				"src/.github/workflows/release.yaml",
+				"src/actions/setup/action.yml",
+				"src/action.yaml",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewActionUsageCataloger())
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package githubactions
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// match example:    b4ffde65f46336ab88eb53be808477a3936bae11
+var commitSHAPattern = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// newPackageFromUses returns the package referenced by a "uses" value, which is one of:
+//
+//	{owner}/{repo}[/{path}]@{ref}   an action (or a reusable workflow, when the path is to a workflow file)
+//	docker://{image}                a container image
+//
+// Local references (e.g. "./.github/actions/setup") and references made from expressions are not packages.
+func newPackageFromUses(uses, kind string, locations ...source.Location) *pkg.Package {
+	uses = strings.TrimSpace(uses)
+	switch {
+	case uses == "", strings.HasPrefix(uses, "./"), strings.Contains(uses, "${{"):
+		return nil
+	case strings.HasPrefix(uses, "docker://"):
+		return newDockerPackage(strings.TrimPrefix(uses, "docker://"), locations...)
+	}
+
+	name, ref, ok := strings.Cut(uses, "@")
+	if !ok || ref == "" {
+		return nil
+	}
+	fields := strings.SplitN(name, "/", 3)
+	if len(fields) < 2 || fields[0] == "" || fields[1] == "" {
+		return nil
+	}
+	var subpath string
+	if len(fields) == 3 {
+		subpath = fields[2]
+	}
+
+	p := pkg.Package{
+		Name:         name,
+		Version:      ref,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(fields[0], fields[1], ref, subpath),
+		Type:         pkg.GithubActionPkg,
+		MetadataType: pkg.GithubActionsUseMetadataType,
+		Metadata: pkg.GithubActionsUseMetadata{
+			Kind:   kind,
+			Ref:    ref,
+			Pinned: commitSHAPattern.MatchString(ref),
+		},
+	}
+
+	p.SetID()
+
+	return &p
+}
+
+// newDockerPackage returns the package for a container image (e.g. "alpine:3.18" or
+// "ghcr.io/octo-org/tool@sha256:..."), which is versioned by its digest when given, otherwise by its tag.
+func newDockerPackage(image string, locations ...source.Location) *pkg.Package {
+	name, ref := image, ""
+	pinned := false
+	if i := strings.Index(image, "@"); i >= 0 {
+		name, ref = image[:i], image[i+1:]
+		pinned = true
+	} else if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		// a colon after the last slash separates the tag, otherwise it is the port of the registry
+		name, ref = image[:i], image[i+1:]
+	}
+	if name == "" {
+		return nil
+	}
+
+	p := pkg.Package{
+		Name:         name,
+		Version:      ref,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         dockerPackageURL(name, ref),
+		Type:         pkg.GithubActionPkg,
+		MetadataType: pkg.GithubActionsUseMetadataType,
+		Metadata: pkg.GithubActionsUseMetadata{
+			Kind:   pkg.GithubDockerUse,
+			Ref:    ref,
+			Pinned: pinned,
+		},
+	}
+
+	p.SetID()
+
+	return &p
+}
+
+// packageURL returns the PURL for an action or reusable workflow, where the path within the repository is the subpath,
+// e.g. pkg:githubactions/github/codeql-action@v3#init.
+func packageURL(owner, repo, ref, subpath string) string {
+	var qualifiers packageurl.Qualifiers
+
+	return packageurl.NewPackageURL(
+		pkg.GithubActionPkg.PackageURLType(),
+		owner,
+		repo,
+		ref,
+		qualifiers,
+		subpath,
+	).ToString()
+}
+
+// dockerPackageURL returns the PURL for a container image, where the registry (when given) is the repository_url,
+// e.g. pkg:docker/octo-org/tool@1.0?repository_url=ghcr.io.
+func dockerPackageURL(image, ref string) string {
+	var qualifiers packageurl.Qualifiers
+
+	fields := strings.Split(image, "/")
+	if len(fields) > 1 && (strings.ContainsAny(fields[0], ".:") || fields[0] == "localhost") {
+		qualifiers = append(qualifiers, packageurl.Qualifier{Key: "repository_url", Value: fields[0]})
+		fields = fields[1:]
+	}
+
+	return packageurl.NewPackageURL(
+		packageurl.TypeDocker,
+		strings.Join(fields[:len(fields)-1], "/"),
+		fields[len(fields)-1],
+		ref,
+		qualifiers,
+		"",
+	).ToString()
+}
@@ -0,0 +1,44 @@
+package githubactions
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseAction
+
+type actionDef struct {
+	Runs struct {
+		Using string    `yaml:"using"`
+		Image string    `yaml:"image"`
+		Steps []stepDef `yaml:"steps"`
+	} `yaml:"runs"`
+}
+
+// parseAction is a parser function for GitHub Actions action definitions (action.yml), returning the actions used by
+// the steps of composite actions and the container image of docker actions (when it is not built from a Dockerfile).
+func parseAction(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var action actionDef
+	if err := yaml.NewDecoder(reader).Decode(&action); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse GitHub Actions action file: %w", err)
+	}
+
+	uses := newUsesCollector(reader.Location)
+	switch action.Runs.Using {
+	case "composite":
+		for _, step := range action.Runs.Steps {
+			uses.add(step.Uses, pkg.GithubActionUse)
+		}
+	case "docker":
+		// e.g. "docker://alpine:3.18" (as opposed to "Dockerfile")
+		uses.add(action.Runs.Image, pkg.GithubActionUse)
+	}
+
+	return uses.pkgs, nil, nil
+}
@@ -0,0 +1,72 @@
+package githubactions
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseAction(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			fixture: "test-fixtures/composite-action/action.yml",
+			expected: []pkg.Package{
+				{
+					Name:         "actions/setup-node",
+					Version:      "60edb5dd545a775178f52524783378180af0d1f8",
+					PURL:         "pkg:githubactions/actions/setup-node@60edb5dd545a775178f52524783378180af0d1f8",
+					Type:         pkg.GithubActionPkg,
+					MetadataType: pkg.GithubActionsUseMetadataType,
+					Metadata: pkg.GithubActionsUseMetadata{
+						Kind:   pkg.GithubActionUse,
+						Ref:    "60edb5dd545a775178f52524783378180af0d1f8",
+						Pinned: true,
+					},
+				},
+				{
+					Name:         "actions/cache",
+					Version:      "v3",
+					PURL:         "pkg:githubactions/actions/cache@v3",
+					Type:         pkg.GithubActionPkg,
+					MetadataType: pkg.GithubActionsUseMetadataType,
+					Metadata: pkg.GithubActionsUseMetadata{
+						Kind: pkg.GithubActionUse,
+						Ref:  "v3",
+					},
+				},
+			},
+		},
+		{
+			fixture: "test-fixtures/docker-action/action.yml",
+			expected: []pkg.Package{
+				{
+					Name:         "localhost:5000/scanner",
+					Version:      "1.2.0",
+					PURL:         "pkg:docker/scanner@1.2.0?repository_url=localhost:5000",
+					Type:         pkg.GithubActionPkg,
+					MetadataType: pkg.GithubActionsUseMetadataType,
+					Metadata: pkg.GithubActionsUseMetadata{
+						Kind: pkg.GithubDockerUse,
+						Ref:  "1.2.0",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			for i := range test.expected {
+				test.expected[i].Locations = source.NewLocationSet(source.NewLocation(test.fixture))
+			}
+			var expectedRelationships []artifact.Relationship
+			pkgtest.TestFileParser(t, test.fixture, parseAction, test.expected, expectedRelationships)
+		})
+	}
+}
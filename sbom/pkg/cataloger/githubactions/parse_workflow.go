@@ -0,0 +1,84 @@
+package githubactions
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseWorkflow
+
+type workflowDef struct {
+	Jobs map[string]workflowJobDef `yaml:"jobs"`
+}
+
+type workflowJobDef struct {
+	// Uses is set for jobs that call a reusable workflow
+	Uses  string    `yaml:"uses"`
+	Steps []stepDef `yaml:"steps"`
+}
+
+type stepDef struct {
+	Uses string `yaml:"uses"`
+}
+
+// parseWorkflow is a parser function for GitHub Actions workflow contents, returning the reusable workflows called by
+// jobs and the actions (and container images) used by steps.
+func parseWorkflow(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var workflow workflowDef
+	if err := yaml.NewDecoder(reader).Decode(&workflow); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse GitHub Actions workflow file: %w", err)
+	}
+
+	// jobs are keyed by their ID, so are visited in a stable order
+	var jobIDs []string
+	for id := range workflow.Jobs {
+		jobIDs = append(jobIDs, id)
+	}
+	sort.Strings(jobIDs)
+
+	uses := newUsesCollector(reader.Location)
+	for _, id := range jobIDs {
+		job := workflow.Jobs[id]
+		uses.add(job.Uses, pkg.GithubWorkflowUse)
+		for _, step := range job.Steps {
+			uses.add(step.Uses, pkg.GithubActionUse)
+		}
+	}
+
+	return uses.pkgs, nil, nil
+}
+
+// usesCollector gathers the packages referenced by "uses" values within a single file, where the same reference may
+// be made several times (e.g. actions/checkout within each job).
+type usesCollector struct {
+	location source.Location
+	seen     internal.StringSet
+	pkgs     []pkg.Package
+}
+
+func newUsesCollector(location source.Location) *usesCollector {
+	return &usesCollector{
+		location: location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+		seen:     internal.NewStringSet(),
+	}
+}
+
+func (c *usesCollector) add(uses, kind string) {
+	if c.seen.Contains(uses) {
+		return
+	}
+	p := newPackageFromUses(uses, kind, c.location)
+	if p == nil {
+		return
+	}
+	c.seen.Add(uses)
+	c.pkgs = append(c.pkgs, *p)
+}
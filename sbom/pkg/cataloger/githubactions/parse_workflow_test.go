@@ -0,0 +1,97 @@
+package githubactions
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseWorkflow(t *testing.T) {
+	fixture := "test-fixtures/workflow/ci.yml"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	// local actions and workflows, and references made from expressions, are not cataloged
+	expected := []pkg.Package{
+		{
+			Name:         "actions/checkout",
+			Version:      "b4ffde65f46336ab88eb53be808477a3936bae11",
+			PURL:         "pkg:githubactions/actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11",
+			Locations:    locations,
+			Type:         pkg.GithubActionPkg,
+			MetadataType: pkg.GithubActionsUseMetadataType,
+			Metadata: pkg.GithubActionsUseMetadata{
+				Kind:   pkg.GithubActionUse,
+				Ref:    "b4ffde65f46336ab88eb53be808477a3936bae11",
+				Pinned: true,
+			},
+		},
+		{
+			Name:         "actions/setup-go",
+			Version:      "v5",
+			PURL:         "pkg:githubactions/actions/setup-go@v5",
+			Locations:    locations,
+			Type:         pkg.GithubActionPkg,
+			MetadataType: pkg.GithubActionsUseMetadataType,
+			Metadata: pkg.GithubActionsUseMetadata{
+				Kind: pkg.GithubActionUse,
+				Ref:  "v5",
+			},
+		},
+		{
+			Name:         "golangci/golangci-lint",
+			Version:      "v1.55.2",
+			PURL:         "pkg:docker/golangci/golangci-lint@v1.55.2",
+			Locations:    locations,
+			Type:         pkg.GithubActionPkg,
+			MetadataType: pkg.GithubActionsUseMetadataType,
+			Metadata: pkg.GithubActionsUseMetadata{
+				Kind: pkg.GithubDockerUse,
+				Ref:  "v1.55.2",
+			},
+		},
+		{
+			Name:         "github/codeql-action/init",
+			Version:      "v3",
+			PURL:         "pkg:githubactions/github/codeql-action@v3#init",
+			Locations:    locations,
+			Type:         pkg.GithubActionPkg,
+			MetadataType: pkg.GithubActionsUseMetadataType,
+			Metadata: pkg.GithubActionsUseMetadata{
+				Kind: pkg.GithubActionUse,
+				Ref:  "v3",
+			},
+		},
+		{
+			Name:         "ghcr.io/octo-org/scanner",
+			Version:      "sha256:2a6c7a5a2ac1b6a1fd2f1f2e9d7e3b8e5c4f1a0b9c8d7e6f5a4b3c2d1e0f9a8b",
+			PURL:         "pkg:docker/octo-org/scanner@sha256:2a6c7a5a2ac1b6a1fd2f1f2e9d7e3b8e5c4f1a0b9c8d7e6f5a4b3c2d1e0f9a8b?repository_url=ghcr.io",
+			Locations:    locations,
+			Type:         pkg.GithubActionPkg,
+			MetadataType: pkg.GithubActionsUseMetadataType,
+			Metadata: pkg.GithubActionsUseMetadata{
+				Kind:   pkg.GithubDockerUse,
+				Ref:    "sha256:2a6c7a5a2ac1b6a1fd2f1f2e9d7e3b8e5c4f1a0b9c8d7e6f5a4b3c2d1e0f9a8b",
+				Pinned: true,
+			},
+		},
+		{
+			Name:         "octo-org/workflows/.github/workflows/release.yml",
+			Version:      "main",
+			PURL:         "pkg:githubactions/octo-org/workflows@main#.github/workflows/release.yml",
+			Locations:    locations,
+			Type:         pkg.GithubActionPkg,
+			MetadataType: pkg.GithubActionsUseMetadataType,
+			Metadata: pkg.GithubActionsUseMetadata{
+				Kind: pkg.GithubWorkflowUse,
+				Ref:  "main",
+			},
+		},
+	}
+
+	var expectedRelationships []artifact.Relationship
+
+	pkgtest.TestFileParser(t, fixture, parseWorkflow, expected, expectedRelationships)
+}
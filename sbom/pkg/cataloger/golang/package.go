@@ -1,18 +1,27 @@
 package golang
 
 import (
+	"fmt"
 	"regexp"
 	"runtime/debug"
 	"strings"
 
 	"github.com/nextlinux/packageurl-go"
 	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/cpe"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/source"
 )
 
 func (c *goBinaryCataloger) newGoBinaryPackage(resolver source.FileResolver, dep *debug.Module, mainModule, goVersion, architecture string, buildSettings map[string]string, locations ...source.Location) pkg.Package {
+	var replacement *pkg.GolangModuleReplacement
 	if dep.Replace != nil {
+		replacement = &pkg.GolangModuleReplacement{
+			OriginalPath:    dep.Path,
+			OriginalVersion: dep.Version,
+			TargetPath:      dep.Replace.Path,
+			TargetVersion:   dep.Replace.Version,
+		}
 		dep = dep.Replace
 	}
 
@@ -36,6 +45,7 @@ func (c *goBinaryCataloger) newGoBinaryPackage(resolver source.FileResolver, dep
 			Architecture:      architecture,
 			BuildSettings:     buildSettings,
 			MainModule:        mainModule,
+			Replacement:       replacement,
 		},
 	}
 
@@ -44,6 +54,50 @@ func (c *goBinaryCataloger) newGoBinaryPackage(resolver source.FileResolver, dep
 	return p
 }
 
+// stdlibVersionPattern matches released go toolchain versions (e.g. "go1.20.4" or "go1.21rc2"), but not devel builds.
+var stdlibVersionPattern = regexp.MustCompile(`^go\d+(\.\d+)*((rc|beta)\d+)?$`)
+
+// newGoStdlibPackage creates a package representing the go standard library that was compiled into the binary, so that
+// the toolchain version can be matched against vulnerabilities like any other module.
+func newGoStdlibPackage(goVersion, mainModule, architecture string, locations ...source.Location) *pkg.Package {
+	// the version may be followed by experiments, e.g. "go1.21.0 X:loopvar"
+	fields := strings.Fields(goVersion)
+	if len(fields) == 0 || !stdlibVersionPattern.MatchString(fields[0]) {
+		return nil
+	}
+	version := fields[0]
+	semver := strings.TrimPrefix(version, "go")
+
+	var cpes []cpe.CPE
+	c, err := cpe.New(fmt.Sprintf("cpe:2.3:a:golang:go:%s:-:*:*:*:*:*:*", semver))
+	if err != nil {
+		log.Tracef("unable to create stdlib CPE for go version=%q: %v", version, err)
+	} else {
+		cpes = append(cpes, c)
+	}
+
+	p := pkg.Package{
+		Name:         "stdlib",
+		Version:      version,
+		Licenses:     []string{"BSD-3-Clause"},
+		PURL:         packageURL("stdlib", semver),
+		CPEs:         cpes,
+		Language:     pkg.Go,
+		Type:         pkg.GoModulePkg,
+		Locations:    source.NewLocationSet(locations...),
+		MetadataType: pkg.GolangBinMetadataType,
+		Metadata: pkg.GolangBinMetadata{
+			GoCompiledVersion: goVersion,
+			Architecture:      architecture,
+			MainModule:        mainModule,
+		},
+	}
+
+	p.SetID()
+
+	return &p
+}
+
 func packageURL(moduleName, moduleVersion string) string {
 	// source: https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst#golang
 	// note: "The version is often empty when a commit is not specified and should be the commit in most cases when available."
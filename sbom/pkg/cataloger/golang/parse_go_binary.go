@@ -45,6 +45,7 @@ type goBinaryCataloger struct {
 // Catalog is given an object to resolve file references and content, this function returns any discovered Packages after analyzing rpm db installation.
 func (c *goBinaryCataloger) parseGoBinary(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	var pkgs []pkg.Package
+	var relationships []artifact.Relationship
 
 	unionReader, err := unionreader.GetUnionReader(reader.ReadCloser)
 	if err != nil {
@@ -55,9 +56,11 @@ func (c *goBinaryCataloger) parseGoBinary(resolver source.FileResolver, _ *gener
 	internal.CloseAndLogError(reader.ReadCloser, reader.RealPath)
 
 	for i, mod := range mods {
-		pkgs = append(pkgs, c.buildGoPkgInfo(resolver, reader.Location, mod, archs[i])...)
+		p, r := c.buildGoPkgInfo(resolver, reader.Location, mod, archs[i])
+		pkgs = append(pkgs, p...)
+		relationships = append(relationships, r...)
 	}
-	return pkgs, nil, nil
+	return pkgs, relationships, nil
 }
 
 func (c *goBinaryCataloger) makeGoMainPackage(resolver source.FileResolver, mod *debug.BuildInfo, arch string, location source.Location) pkg.Package {
@@ -71,6 +74,16 @@ func (c *goBinaryCataloger) makeGoMainPackage(resolver source.FileResolver, mod
 		gbs,
 		location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
 	)
+
+	// expose the build settings that are most relevant to consumers as typed fields
+	if metadata, ok := main.Metadata.(pkg.GolangBinMetadata); ok {
+		metadata.TrimPath = gbs["-trimpath"] == "true"
+		metadata.CGOEnabled = gbs["CGO_ENABLED"] == "1"
+		metadata.LDFlags = gbs["-ldflags"]
+		main.Metadata = metadata
+		main.SetID()
+	}
+
 	if main.Version == devel {
 		if version, ok := gbs["vcs.revision"]; ok {
 			if timestamp, ok := gbs["vcs.time"]; ok {
@@ -197,10 +210,10 @@ func createMainModuleFromPath(path string) (mod debug.Module) {
 	return
 }
 
-func (c *goBinaryCataloger) buildGoPkgInfo(resolver source.FileResolver, location source.Location, mod *debug.BuildInfo, arch string) []pkg.Package {
+func (c *goBinaryCataloger) buildGoPkgInfo(resolver source.FileResolver, location source.Location, mod *debug.BuildInfo, arch string) ([]pkg.Package, []artifact.Relationship) {
 	var pkgs []pkg.Package
 	if mod == nil {
-		return pkgs
+		return pkgs, nil
 	}
 
 	var empty debug.Module
@@ -226,12 +239,33 @@ func (c *goBinaryCataloger) buildGoPkgInfo(resolver source.FileResolver, locatio
 		}
 	}
 
+	stdlib := newGoStdlibPackage(
+		mod.GoVersion,
+		mod.Main.Path,
+		arch,
+		location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+	)
+	if stdlib != nil {
+		pkgs = append(pkgs, *stdlib)
+	}
+
 	if mod.Main == empty {
-		return pkgs
+		return pkgs, nil
 	}
 
 	main := c.makeGoMainPackage(resolver, mod, arch, location)
+
+	// every module (and the stdlib) compiled into the binary is a dependency of the main module
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		relationships = append(relationships, artifact.Relationship{
+			From: p,
+			To:   main,
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+
 	pkgs = append(pkgs, main)
 
-	return pkgs
+	return pkgs, relationships
 }
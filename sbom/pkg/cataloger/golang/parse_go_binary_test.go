@@ -14,6 +14,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/cpe"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/source"
 )
@@ -486,6 +488,12 @@ func TestBuildGoPkgInfo(t *testing.T) {
 						Architecture:      archDetails,
 						H1Digest:          "h1:Ihq/mm/suC88gF8WFcVwk+OV6Tq+wyA1O0E5UEvDglI=",
 						MainModule:        "github.com/nextlinux/sbom",
+						Replacement: &pkg.GolangModuleReplacement{
+							OriginalPath:    "golang.org/x/term",
+							OriginalVersion: "v0.0.0-20210927222741-03fcf44c2211",
+							TargetPath:      "golang.org/x/term",
+							TargetVersion:   "v0.0.0-20210916214954-140adaaadfaf",
+						},
 					},
 				},
 				unmodifiedMain,
@@ -510,8 +518,84 @@ func TestBuildGoPkgInfo(t *testing.T) {
 			)
 
 			c := goBinaryCataloger{}
-			pkgs := c.buildGoPkgInfo(source.EmptyResolver{}, location, test.mod, test.arch)
+			pkgs, _ := c.buildGoPkgInfo(source.EmptyResolver{}, location, test.mod, test.arch)
 			assert.Equal(t, test.expected, pkgs)
 		})
 	}
 }
+
+func TestBuildGoPkgInfo_StdlibAndRelationships(t *testing.T) {
+	location := source.NewLocationFromCoordinates(
+		source.Coordinates{
+			RealPath:     "/a-path",
+			FileSystemID: "layer-id",
+		},
+	)
+
+	mod := &debug.BuildInfo{
+		GoVersion: "go1.20.4 X:boringcrypto",
+		Main:      debug.Module{Path: "github.com/nextlinux/sbom", Version: "v0.1.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "-trimpath", Value: "true"},
+			{Key: "-ldflags", Value: "-s -w -X main.version=v0.1.0"},
+			{Key: "CGO_ENABLED", Value: "0"},
+			{Key: "GOARCH", Value: "amd64"},
+		},
+		Deps: []*debug.Module{
+			{
+				Path:    "golang.org/x/sys",
+				Version: "v0.0.0-20211006194710-c8a6f5223071",
+			},
+		},
+	}
+
+	c := goBinaryCataloger{}
+	pkgs, relationships := c.buildGoPkgInfo(source.EmptyResolver{}, location, mod, "amd64")
+	require.Len(t, pkgs, 3)
+
+	dep, stdlib, main := pkgs[0], pkgs[1], pkgs[2]
+	assert.Equal(t, "golang.org/x/sys", dep.Name)
+
+	assert.Equal(t, "stdlib", stdlib.Name)
+	assert.Equal(t, "go1.20.4", stdlib.Version)
+	assert.Equal(t, "pkg:golang/stdlib@1.20.4", stdlib.PURL)
+	require.Len(t, stdlib.CPEs, 1)
+	assert.Equal(t, "cpe:2.3:a:golang:go:1.20.4:-:*:*:*:*:*:*", cpe.String(stdlib.CPEs[0]))
+
+	assert.Equal(t, "github.com/nextlinux/sbom", main.Name)
+	metadata, ok := main.Metadata.(pkg.GolangBinMetadata)
+	require.True(t, ok)
+	assert.True(t, metadata.TrimPath)
+	assert.False(t, metadata.CGOEnabled)
+	assert.Equal(t, "-s -w -X main.version=v0.1.0", metadata.LDFlags)
+
+	assert.Equal(t, []artifact.Relationship{
+		{From: dep, To: main, Type: artifact.DependencyOfRelationship},
+		{From: stdlib, To: main, Type: artifact.DependencyOfRelationship},
+	}, relationships)
+}
+
+func TestNewGoStdlibPackage(t *testing.T) {
+	tests := []struct {
+		goVersion string
+		expected  string
+	}{
+		{goVersion: "go1.20.4", expected: "go1.20.4"},
+		{goVersion: "go1.21rc2", expected: "go1.21rc2"},
+		{goVersion: "go1.21.0 X:loopvar", expected: "go1.21.0"},
+		{goVersion: "devel go1.22-4f39a3a Thu Aug 10 18:44:22 2023 +0000"},
+		{goVersion: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.goVersion, func(t *testing.T) {
+			p := newGoStdlibPackage(test.goVersion, "", "amd64")
+			if test.expected == "" {
+				assert.Nil(t, p)
+				return
+			}
+			require.NotNil(t, p)
+			assert.Equal(t, test.expected, p.Version)
+		})
+	}
+}
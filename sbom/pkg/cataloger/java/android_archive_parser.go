@@ -0,0 +1,226 @@
+package java
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/internal/file"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseAndroidArchive
+
+var androidArchiveGlobs = []string{
+	"**/*.apk",
+	"**/*.aab",
+	"**/*.aar",
+}
+
+var (
+	// apks and aars have the manifest at the root, while app bundles keep the manifest of the base module separately
+	androidManifestGlobs = []string{"/AndroidManifest.xml", "/base/manifest/AndroidManifest.xml"}
+
+	// the android gradle plugin records the version of each Jetpack (AndroidX) library as META-INF/<group>_<artifact>.version
+	androidLibraryVersionGlobs = []string{"/META-INF/*.version", "/base/root/META-INF/*.version"}
+
+	// native libraries are stored per ABI: lib/<abi>/ for apks, base/lib/<abi>/ for app bundles and jni/<abi>/ for aars
+	androidNativeLibraryGlobs = []string{"/lib/*.so", "/base/lib/*.so", "/jni/*.so"}
+)
+
+// parseAndroidArchive is a parser function for Android application (apk, aab) and library (aar) archives, returning
+// the application or library itself along with the Jetpack and native libraries bundled within it.
+func parseAndroidArchive(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	_, archivePath, cleanupFn, err := saveArchiveToTmp(reader.AccessPath(), reader)
+	// note: even on error, we should always run cleanup functions
+	defer cleanupFn()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileManifest, err := file.NewZipFileManifest(archivePath)
+	if err != nil {
+		// note: alpine packages also use the .apk extension, which are not zip archives
+		log.WithFields("location", reader.AccessPath(), "error", err).Trace("unable to read files from android archive")
+		return nil, nil, nil
+	}
+
+	manifestMatches := fileManifest.GlobMatch(androidManifestGlobs...)
+	if len(manifestMatches) == 0 {
+		return nil, nil, nil
+	}
+
+	contents, err := file.ContentsFromZip(archivePath, manifestMatches[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to extract android manifest (%s): %w", reader.AccessPath(), err)
+	}
+
+	manifest, err := parseAndroidManifest([]byte(contents[manifestMatches[0]]))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse android manifest (%s): %w", reader.AccessPath(), err)
+	}
+
+	app := newAndroidAppPackage(*manifest, reader.Location)
+
+	libraries, err := androidLibraryPackages(archivePath, reader.Location, fileManifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	libraries = append(libraries, androidNativeLibraryPackages(reader.Location, fileManifest)...)
+
+	pkgs := []pkg.Package{app}
+	var relationships []artifact.Relationship
+	for _, p := range libraries {
+		pkgs = append(pkgs, p)
+		relationships = append(relationships, artifact.Relationship{
+			From: app,
+			To:   p,
+			Type: artifact.ContainsRelationship,
+		})
+	}
+
+	return pkgs, relationships, nil
+}
+
+func newAndroidAppPackage(manifest androidManifest, location source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:    manifest.pkg,
+		Version: manifest.versionName,
+		Locations: source.NewLocationSet(
+			location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+		),
+		Type:         pkg.AndroidPkg,
+		MetadataType: pkg.AndroidAppMetadataType,
+		Metadata: pkg.AndroidAppMetadata{
+			Package:          manifest.pkg,
+			ArchiveType:      strings.TrimPrefix(path.Ext(location.RealPath), "."),
+			VersionCode:      manifest.versionCode,
+			MinSdkVersion:    manifest.minSdkVersion,
+			TargetSdkVersion: manifest.targetSdkVersion,
+		},
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// androidLibraryPackages returns the Jetpack (AndroidX) libraries recorded by version files within the archive.
+func androidLibraryPackages(archivePath string, location source.Location, fileManifest file.ZipFileManifest) ([]pkg.Package, error) {
+	matches := fileManifest.GlobMatch(androidLibraryVersionGlobs...)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	contents, err := file.ContentsFromZip(archivePath, matches...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract android library versions (%s): %w", location.AccessPath(), err)
+	}
+
+	var pkgs []pkg.Package
+	for _, match := range matches {
+		version := strings.TrimSpace(contents[match])
+		if version == "" {
+			continue
+		}
+
+		groupID, artifactID := androidLibraryCoordinates(path.Base(match))
+		if artifactID == "" {
+			continue
+		}
+
+		properties := &pkg.PomProperties{
+			Path:       match,
+			GroupID:    groupID,
+			ArtifactID: artifactID,
+			Version:    version,
+		}
+
+		var purl string
+		if groupID != "" {
+			purl = packageurl.NewPackageURL(packageurl.TypeMaven, groupID, artifactID, version, nil, "").ToString()
+		}
+
+		p := pkg.Package{
+			Name:     artifactID,
+			Version:  version,
+			PURL:     purl,
+			Language: pkg.Java,
+			Locations: source.NewLocationSet(
+				location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+			),
+			Type:         pkg.JavaPkg,
+			MetadataType: pkg.JavaMetadataType,
+			Metadata: pkg.JavaMetadata{
+				VirtualPath:   fmt.Sprintf("%s:%s", location.AccessPath(), match),
+				PomProperties: properties,
+			},
+		}
+		p.SetID()
+		pkgs = append(pkgs, p)
+	}
+
+	return pkgs, nil
+}
+
+// androidLibraryCoordinates returns the group and artifact IDs from the name of a library version file (e.g.
+// "androidx.core_core-ktx.version"). Version files that do not follow this convention (e.g.
+// "kotlinx_coroutines_core.version") are named after the whole file name, without a group.
+func androidLibraryCoordinates(name string) (string, string) {
+	name = strings.TrimSuffix(name, ".version")
+	fields := strings.SplitN(name, "_", 2)
+	if len(fields) != 2 || !strings.Contains(fields[0], ".") || fields[1] == "" {
+		return "", name
+	}
+	return fields[0], fields[1]
+}
+
+// androidNativeLibraryPackages returns the native shared libraries bundled for each ABI within the archive.
+func androidNativeLibraryPackages(location source.Location, fileManifest file.ZipFileManifest) []pkg.Package {
+	matches := fileManifest.GlobMatch(androidNativeLibraryGlobs...)
+	sort.Strings(matches)
+
+	var pkgs []pkg.Package
+	for _, match := range matches {
+		abi := androidNativeLibraryABI(match)
+		if abi == "" {
+			continue
+		}
+
+		p := pkg.Package{
+			Name: path.Base(match),
+			Locations: source.NewLocationSet(
+				location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+			),
+			Type:         pkg.BinaryPkg,
+			MetadataType: pkg.AndroidNativeLibraryMetadataType,
+			Metadata: pkg.AndroidNativeLibraryMetadata{
+				Path: match,
+				ABI:  abi,
+			},
+		}
+		p.SetID()
+		pkgs = append(pkgs, p)
+	}
+
+	return pkgs
+}
+
+// androidNativeLibraryABI returns the ABI directory that a native library is stored within (e.g. "arm64-v8a" for
+// "lib/arm64-v8a/libexample.so"), or an empty string if the library is not directly within an ABI directory.
+func androidNativeLibraryABI(p string) string {
+	fields := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	if len(fields) < 3 {
+		return ""
+	}
+	if dir := fields[len(fields)-3]; dir != "lib" && dir != "jni" {
+		return ""
+	}
+	return fields[len(fields)-2]
+}
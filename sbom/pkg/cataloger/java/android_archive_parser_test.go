@@ -0,0 +1,266 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseAndroidArchive(t *testing.T) {
+	apkManifest, err := os.ReadFile("test-fixtures/android/apk/AndroidManifest.xml")
+	require.NoError(t, err)
+	aabManifest, err := os.ReadFile("test-fixtures/android/aab/AndroidManifest.xml")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		archive      string
+		entries      map[string][]byte
+		app          *pkg.Package
+		libraries    []pkg.Package
+		expectNoPkgs bool
+	}{
+		{
+			name:    "apk",
+			archive: "example.apk",
+			entries: map[string][]byte{
+				"AndroidManifest.xml":                                       apkManifest,
+				"classes.dex":                                               []byte("dex\n035"),
+				"META-INF/androidx.core_core.version":                       []byte("1.9.0\n"),
+				"META-INF/kotlinx_coroutines_core.version":                  []byte("1.6.4\n"),
+				"META-INF/androidx.empty_empty.version":                     []byte(""),
+				"lib/arm64-v8a/libexample.so":                               []byte("\x7fELF"),
+				"lib/x86_64/libexample.so":                                  []byte("\x7fELF"),
+				"assets/lib/not-an-abi/nested/libextra.so":                  []byte("\x7fELF"),
+				"res/drawable/icon.png":                                     []byte("png"),
+				"META-INF/com/android/build/gradle/app-metadata.properties": []byte("appMetadataVersion=1.1\n"),
+			},
+			app: &pkg.Package{
+				Name:         "com.example.app",
+				Version:      "1.2.3",
+				Type:         pkg.AndroidPkg,
+				MetadataType: pkg.AndroidAppMetadataType,
+				Metadata: pkg.AndroidAppMetadata{
+					Package:          "com.example.app",
+					ArchiveType:      "apk",
+					VersionCode:      "42",
+					MinSdkVersion:    "21",
+					TargetSdkVersion: "34",
+				},
+			},
+			libraries: []pkg.Package{
+				{
+					Name:         "core",
+					Version:      "1.9.0",
+					PURL:         "pkg:maven/androidx.core/core@1.9.0",
+					Language:     pkg.Java,
+					Type:         pkg.JavaPkg,
+					MetadataType: pkg.JavaMetadataType,
+					Metadata: pkg.JavaMetadata{
+						VirtualPath: "{archive}:META-INF/androidx.core_core.version",
+						PomProperties: &pkg.PomProperties{
+							Path:       "META-INF/androidx.core_core.version",
+							GroupID:    "androidx.core",
+							ArtifactID: "core",
+							Version:    "1.9.0",
+						},
+					},
+				},
+				{
+					Name:         "kotlinx_coroutines_core",
+					Version:      "1.6.4",
+					Language:     pkg.Java,
+					Type:         pkg.JavaPkg,
+					MetadataType: pkg.JavaMetadataType,
+					Metadata: pkg.JavaMetadata{
+						VirtualPath: "{archive}:META-INF/kotlinx_coroutines_core.version",
+						PomProperties: &pkg.PomProperties{
+							Path:       "META-INF/kotlinx_coroutines_core.version",
+							ArtifactID: "kotlinx_coroutines_core",
+							Version:    "1.6.4",
+						},
+					},
+				},
+				{
+					Name:         "libexample.so",
+					Type:         pkg.BinaryPkg,
+					MetadataType: pkg.AndroidNativeLibraryMetadataType,
+					Metadata: pkg.AndroidNativeLibraryMetadata{
+						Path: "lib/arm64-v8a/libexample.so",
+						ABI:  "arm64-v8a",
+					},
+				},
+				{
+					Name:         "libexample.so",
+					Type:         pkg.BinaryPkg,
+					MetadataType: pkg.AndroidNativeLibraryMetadataType,
+					Metadata: pkg.AndroidNativeLibraryMetadata{
+						Path: "lib/x86_64/libexample.so",
+						ABI:  "x86_64",
+					},
+				},
+			},
+		},
+		{
+			name:    "aab",
+			archive: "example.aab",
+			entries: map[string][]byte{
+				"base/manifest/AndroidManifest.xml":                     aabManifest,
+				"base/root/META-INF/androidx.activity_activity.version": []byte("1.7.2\n"),
+				"base/lib/armeabi-v7a/libbundle.so":                     []byte("\x7fELF"),
+				"BundleConfig.pb":                                       []byte("\x0a\x00"),
+			},
+			app: &pkg.Package{
+				Name:         "com.example.bundle",
+				Version:      "2.0.0",
+				Type:         pkg.AndroidPkg,
+				MetadataType: pkg.AndroidAppMetadataType,
+				Metadata: pkg.AndroidAppMetadata{
+					Package:          "com.example.bundle",
+					ArchiveType:      "aab",
+					VersionCode:      "7",
+					MinSdkVersion:    "24",
+					TargetSdkVersion: "33",
+				},
+			},
+			libraries: []pkg.Package{
+				{
+					Name:         "activity",
+					Version:      "1.7.2",
+					PURL:         "pkg:maven/androidx.activity/activity@1.7.2",
+					Language:     pkg.Java,
+					Type:         pkg.JavaPkg,
+					MetadataType: pkg.JavaMetadataType,
+					Metadata: pkg.JavaMetadata{
+						VirtualPath: "{archive}:base/root/META-INF/androidx.activity_activity.version",
+						PomProperties: &pkg.PomProperties{
+							Path:       "base/root/META-INF/androidx.activity_activity.version",
+							GroupID:    "androidx.activity",
+							ArtifactID: "activity",
+							Version:    "1.7.2",
+						},
+					},
+				},
+				{
+					Name:         "libbundle.so",
+					Type:         pkg.BinaryPkg,
+					MetadataType: pkg.AndroidNativeLibraryMetadataType,
+					Metadata: pkg.AndroidNativeLibraryMetadata{
+						Path: "base/lib/armeabi-v7a/libbundle.so",
+						ABI:  "armeabi-v7a",
+					},
+				},
+			},
+		},
+		{
+			name:    "aar",
+			archive: "example-library.aar",
+			entries: map[string][]byte{
+				"AndroidManifest.xml": []byte(`<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android" package="com.example.library">
+    <uses-sdk android:minSdkVersion="19" />
+</manifest>`),
+				"classes.jar":                jarWithManifest(t, "example-library", "1.0.0"),
+				"jni/arm64-v8a/libnative.so": []byte("\x7fELF"),
+			},
+			app: &pkg.Package{
+				Name:         "com.example.library",
+				Type:         pkg.AndroidPkg,
+				MetadataType: pkg.AndroidAppMetadataType,
+				Metadata: pkg.AndroidAppMetadata{
+					Package:       "com.example.library",
+					ArchiveType:   "aar",
+					MinSdkVersion: "19",
+				},
+			},
+			libraries: []pkg.Package{
+				{
+					Name:         "libnative.so",
+					Type:         pkg.BinaryPkg,
+					MetadataType: pkg.AndroidNativeLibraryMetadataType,
+					Metadata: pkg.AndroidNativeLibraryMetadata{
+						Path: "jni/arm64-v8a/libnative.so",
+						ABI:  "arm64-v8a",
+					},
+				},
+			},
+		},
+		{
+			name:    "zip without an android manifest",
+			archive: "not-android.apk",
+			entries: map[string][]byte{
+				"README.md": []byte("hello"),
+			},
+			expectNoPkgs: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := filepath.Join(t.TempDir(), tt.archive)
+			require.NoError(t, os.WriteFile(fixture, zipOf(t, tt.entries), 0600))
+
+			if tt.expectNoPkgs {
+				pkgtest.TestFileParser(t, fixture, parseAndroidArchive, nil, nil)
+				return
+			}
+
+			locations := source.NewLocationSet(source.NewLocation(fixture))
+
+			app := *tt.app
+			app.Locations = locations
+
+			expected := []pkg.Package{app}
+			var relationships []artifact.Relationship
+			for _, l := range tt.libraries {
+				l.Locations = locations
+				if m, ok := l.Metadata.(pkg.JavaMetadata); ok {
+					m.VirtualPath = fixture + m.VirtualPath[len("{archive}"):]
+					l.Metadata = m
+				}
+				expected = append(expected, l)
+				relationships = append(relationships, artifact.Relationship{
+					From: app,
+					To:   l,
+					Type: artifact.ContainsRelationship,
+				})
+			}
+
+			pkgtest.TestFileParser(t, fixture, parseAndroidArchive, expected, relationships)
+		})
+	}
+}
+
+func TestParseAndroidArchive_NotAZip(t *testing.T) {
+	// alpine packages share the .apk extension, but are gzipped tar archives
+	fixture := filepath.Join(t.TempDir(), "musl-1.2.3-r4.apk")
+	require.NoError(t, os.WriteFile(fixture, []byte("\x1f\x8b\x08\x00not a zip"), 0600))
+
+	pkgtest.TestFileParser(t, fixture, parseAndroidArchive, nil, nil)
+}
+
+func Test_androidLibraryCoordinates(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantGroup    string
+		wantArtifact string
+	}{
+		{name: "androidx.core_core-ktx.version", wantGroup: "androidx.core", wantArtifact: "core-ktx"},
+		{name: "com.google.android.material_material.version", wantGroup: "com.google.android.material", wantArtifact: "material"},
+		{name: "kotlinx_coroutines_android.version", wantArtifact: "kotlinx_coroutines_android"},
+		{name: "androidx.core.version", wantArtifact: "androidx.core"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group, artifact := androidLibraryCoordinates(tt.name)
+			require.Equal(t, tt.wantGroup, group)
+			require.Equal(t, tt.wantArtifact, artifact)
+		})
+	}
+}
@@ -49,16 +49,18 @@ type archiveParser struct {
 	contentPath  string
 	fileInfo     archiveFilename
 	detectNested bool
+	quarkusLayer string
 }
 
 // parseJavaArchive is a parser function for java archive contents, returning all Java libraries and nested archives.
-func parseJavaArchive(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+func parseJavaArchive(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	parser, cleanupFn, err := newJavaArchiveParser(reader, true)
 	// note: even on error, we should always run cleanup functions
 	defer cleanupFn()
 	if err != nil {
 		return nil, nil, err
 	}
+	parser.quarkusLayer = quarkusFastJarLayer(resolver, reader.Location)
 	return parser.parse()
 }
 
@@ -122,6 +124,8 @@ func (j *archiveParser) parse() ([]pkg.Package, []artifact.Relationship, error)
 		if err != nil {
 			return nil, nil, err
 		}
+		// attribute nested jars to the layers of a Spring Boot archive (dependencies, snapshot-dependencies, application...)
+		j.attributeSpringBootLayers(j.discoverSpringBootLayers(), nestedPkgs)
 		pkgs = append(pkgs, nestedPkgs...)
 		relationships = append(relationships, nestedRelationships...)
 	}
@@ -131,6 +135,9 @@ func (j *archiveParser) parse() ([]pkg.Package, []artifact.Relationship, error)
 		pkgs = append([]pkg.Package{*parentPkg}, pkgs...)
 	}
 
+	// attribute all packages of a jar within a Quarkus fast-jar application to its layer (boot, main, application)
+	attributeQuarkusLayer(j.quarkusLayer, pkgs)
+
 	// add pURLs to all packages found
 	// note: since package information may change after initial creation when parsing multiple locations within the
 	// jar, we wait until the conclusion of the parsing process before synthesizing pURLs.
@@ -1,10 +1,19 @@
 /*
-Package java provides a concrete Cataloger implementation for Java archives (jar, war, ear, par, sar, jpi, hpi, and native-image formats).
+Package java provides a concrete Cataloger implementation for Java archives (jar, war, ear, par, sar, jpi, hpi, and native-image formats)
+and Android archives (apk, aab and aar).
 */
 package java
 
 import (
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
 )
 
 // NewJavaCataloger returns a new Java archive cataloger object.
@@ -27,15 +36,56 @@ func NewJavaCataloger(cfg Config) *generic.Cataloger {
 // NewJavaPomCataloger returns a cataloger capable of parsing
 // dependencies from a pom.xml file.
 // Pom files list dependencies that maybe not be locally installed yet.
-func NewJavaPomCataloger() *generic.Cataloger {
-	return generic.NewCataloger("java-pom-cataloger").
-		WithParserByGlobs(parserPomXML, "**/pom.xml")
+// Properties, parent POMs, and dependency management are resolved across all
+// POMs found in the scanned tree (and optionally the local maven repository).
+// Dependencies shared by several POMs (e.g. modules of one project) are reported once.
+func NewJavaPomCataloger(cfg Config) pkg.Cataloger {
+	mavenCfg := cfg.Maven
+	if mavenCfg.UseLocalRepository && mavenCfg.LocalRepositoryDir == "" {
+		homeDir, err := homedir.Dir()
+		if err != nil {
+			log.Debugf("unable to determine user home dir: %v", err)
+		} else {
+			mavenCfg.LocalRepositoryDir = filepath.Join(homeDir, ".m2", "repository")
+		}
+	}
+	return &pomCataloger{
+		cfg: mavenCfg,
+	}
+}
+
+type pomCataloger struct {
+	cfg MavenConfig
+}
+
+func (c *pomCataloger) Name() string {
+	return "java-pom-cataloger"
+}
+
+func (c *pomCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	// a new maven resolver is used for every catalog request, since POMs are cached and indexed by the resolver
+	r := newMavenResolver(resolver, c.cfg)
+	pkgs, relationships, err := generic.NewCataloger(c.Name()).
+		WithParserByGlobs(r.parsePomXML, pomXMLDirGlob).
+		Catalog(resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkgs, relationships = mergeMavenPackages(pkgs, relationships)
+	return pkgs, relationships, nil
 }
 
 // NewJavaGradleLockfileCataloger returns a cataloger capable of parsing
-// dependencies from a gradle.lockfile file.
-// older versions of lockfiles aren't supported yet
+// dependencies from a gradle.lockfile file, or from the per-configuration
+// lockfiles in gradle/dependency-locks written by gradle versions before 6.0.
 func NewJavaGradleLockfileCataloger() *generic.Cataloger {
 	return generic.NewCataloger("java-gradle-lockfile-cataloger").
-		WithParserByGlobs(parseGradleLockfile, gradleLockfileGlob)
+		WithParserByGlobs(parseGradleLockfile, gradleLockfileGlob, gradleLegacyLockfilesGlob)
+}
+
+// NewAndroidArchiveCataloger returns a cataloger capable of parsing Android applications (apk, aab) and libraries
+// (aar), along with the Jetpack and native libraries bundled within them.
+func NewAndroidArchiveCataloger() *generic.Cataloger {
+	return generic.NewCataloger("android-archive-cataloger").
+		WithParserByGlobs(parseAndroidArchive, androidArchiveGlobs...)
 }
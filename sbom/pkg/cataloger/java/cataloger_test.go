@@ -82,7 +82,33 @@ func Test_POMCataloger_Globs(t *testing.T) {
 			pkgtest.NewCatalogTester().
 				FromDirectory(t, test.fixture).
 				ExpectsResolverContentQueries(test.expected).
-				TestCataloger(t, NewJavaPomCataloger())
+				TestCataloger(t, NewJavaPomCataloger(Config{}))
+		})
+	}
+}
+
+func Test_GradleLockfileCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain gradle lockfiles",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/gradle.lockfile",
+				"src/gradle/dependency-locks/compileClasspath.lockfile",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewJavaGradleLockfileCataloger())
 		})
 	}
 }
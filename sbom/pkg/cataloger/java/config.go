@@ -3,4 +3,14 @@ package java
 type Config struct {
 	SearchUnindexedArchives bool
 	SearchIndexedArchives   bool
+	Maven                   MavenConfig
+}
+
+// MavenConfig describes how dependencies declared in pom.xml files are resolved.
+type MavenConfig struct {
+	// UseLocalRepository enables looking up parent POMs, imported BOMs, and the POMs of transitive dependencies within
+	// the local maven repository when they are not found in the scanned tree.
+	UseLocalRepository bool
+	// LocalRepositoryDir is the location of the local maven repository (defaults to ~/.m2/repository).
+	LocalRepositoryDir string
 }
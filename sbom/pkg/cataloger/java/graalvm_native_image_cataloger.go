@@ -573,6 +573,7 @@ func fetchPkgs(reader unionreader.UnionReader, filename string) []pkg.Package {
 // Catalog attempts to find any native image executables reachable from a resolver.
 func (c *NativeImageCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
 	var pkgs []pkg.Package
+	var relationships []artifact.Relationship
 	fileMatches, err := resolver.FilesByMIMEType(internal.ExecutableMIMETypeSet.List()...)
 	if err != nil {
 		return pkgs, nil, fmt.Errorf("failed to find binaries by mime types: %w", err)
@@ -590,9 +591,21 @@ func (c *NativeImageCataloger) Catalog(resolver source.FileResolver) ([]pkg.Pack
 			return nil, nil, err
 		}
 		newPkgs := fetchPkgs(reader, location.RealPath)
-		pkgs = append(pkgs, newPkgs...)
 		internal.CloseAndLogError(readerCloser, location.RealPath)
+
+		// components are compiled into the native image, so the executable is the evidence for each of them:
+		// [executable] --(contains)--> [component]
+		for _, p := range newPkgs {
+			p.Locations = source.NewLocationSet(location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation))
+			p.SetID()
+			pkgs = append(pkgs, p)
+			relationships = append(relationships, artifact.Relationship{
+				From: location.Coordinates,
+				To:   p,
+				Type: artifact.ContainsRelationship,
+			})
+		}
 	}
 
-	return pkgs, nil, nil
+	return pkgs, relationships, nil
 }
@@ -0,0 +1,402 @@
+package java
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/vifraa/gopom"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+const (
+	// limits the depth of parent, BOM import, and property chains (which may be cyclic in malformed POMs)
+	maxMavenResolutionDepth = 20
+	// limits how far transitive dependencies are followed from the POM being cataloged
+	maxMavenTransitiveDepth = 10
+)
+
+// mavenID identifies a single maven artifact by its coordinates.
+type mavenID struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+func (id mavenID) String() string {
+	return fmt.Sprintf("%s:%s:%s", id.GroupID, id.ArtifactID, id.Version)
+}
+
+// mavenProject is a POM along with the chain of parent POMs that it inherits from (as far as they could be found).
+type mavenProject struct {
+	pom      gopom.Project
+	parent   *mavenProject
+	location *source.Location // the location within the scanned tree, nil if the POM was read from the local repository
+	managed  map[string]gopom.Dependency
+}
+
+// mavenResolver resolves the effective dependencies of POM files: properties, parent POMs, dependency management and
+// BOM imports are looked up in the other POMs found in the scanned tree, and optionally in the local maven repository.
+type mavenResolver struct {
+	cfg      MavenConfig
+	resolver source.FileResolver
+	// all POMs found in the scanned tree by their coordinates (lazily populated)
+	index map[mavenID]source.Location
+	// all decoded projects by location or local repository path
+	projects map[string]*mavenProject
+}
+
+func newMavenResolver(resolver source.FileResolver, cfg MavenConfig) *mavenResolver {
+	return &mavenResolver{
+		cfg:      cfg,
+		resolver: resolver,
+		projects: make(map[string]*mavenProject),
+	}
+}
+
+// project returns the given POM with its parents resolved.
+func (r *mavenResolver) project(pom gopom.Project, location *source.Location) *mavenProject {
+	return r.newProject(pom, location, 0)
+}
+
+func (r *mavenResolver) newProject(pom gopom.Project, location *source.Location, depth int) *mavenProject {
+	p := &mavenProject{
+		pom:      pom,
+		location: location,
+	}
+	if pom.Parent.ArtifactID != "" && depth < maxMavenResolutionDepth {
+		p.parent = r.findParent(p, depth+1)
+	}
+	return p
+}
+
+// findParent looks for the parent POM by its relative path first (as maven does), then by its coordinates.
+func (r *mavenResolver) findParent(p *mavenProject, depth int) *mavenProject {
+	parent := p.pom.Parent
+	if p.location != nil && r.resolver != nil {
+		relativePath := parent.RelativePath
+		if relativePath == "" {
+			relativePath = "../pom.xml"
+		}
+		if !strings.HasSuffix(relativePath, ".xml") {
+			relativePath = path.Join(relativePath, "pom.xml")
+		}
+		parentPath := path.Join(path.Dir(p.location.RealPath), relativePath)
+
+		locations, err := r.resolver.FilesByPath(parentPath)
+		if err != nil {
+			log.WithFields("path", parentPath, "error", err).Trace("unable to find parent pom by relative path")
+		}
+		for _, location := range locations {
+			candidate := r.projectAtLocation(location, depth)
+			if candidate != nil && candidate.pom.ArtifactID == parent.ArtifactID {
+				return candidate
+			}
+		}
+	}
+
+	return r.findProject(mavenID{
+		GroupID:    p.resolve(parent.GroupID),
+		ArtifactID: p.resolve(parent.ArtifactID),
+		Version:    p.resolve(parent.Version),
+	}, depth)
+}
+
+// findProject returns the POM for the given coordinates from the scanned tree or the local maven repository.
+func (r *mavenResolver) findProject(id mavenID, depth int) *mavenProject {
+	if id.GroupID == "" || id.ArtifactID == "" || id.Version == "" || propertyMatcher.MatchString(id.Version) {
+		return nil
+	}
+
+	if location, ok := r.treeIndex()[id]; ok {
+		return r.projectAtLocation(location, depth)
+	}
+
+	if !r.cfg.UseLocalRepository || r.cfg.LocalRepositoryDir == "" {
+		return nil
+	}
+
+	pomPath := filepath.Join(
+		r.cfg.LocalRepositoryDir,
+		filepath.Join(strings.Split(id.GroupID, ".")...),
+		id.ArtifactID,
+		id.Version,
+		fmt.Sprintf("%s-%s.pom", id.ArtifactID, id.Version),
+	)
+	if p, ok := r.projects[pomPath]; ok {
+		return p
+	}
+	// note: the entry is reserved before reading to prevent cycles through malformed parent declarations
+	r.projects[pomPath] = nil
+
+	f, err := os.Open(pomPath)
+	if err != nil {
+		log.WithFields("artifact", id, "error", err).Trace("unable to find pom in local maven repository")
+		return nil
+	}
+	defer internal.CloseAndLogError(f, pomPath)
+
+	pom, err := decodePomXML(f)
+	if err != nil {
+		log.WithFields("path", pomPath, "error", err).Debug("unable to read pom from local maven repository")
+		return nil
+	}
+
+	p := r.newProject(pom, nil, depth)
+	r.projects[pomPath] = p
+	return p
+}
+
+func (r *mavenResolver) projectAtLocation(location source.Location, depth int) *mavenProject {
+	key := location.RealPath
+	if p, ok := r.projects[key]; ok {
+		return p
+	}
+	r.projects[key] = nil
+
+	pom, err := r.readPom(location)
+	if err != nil {
+		log.WithFields("path", location.RealPath, "error", err).Debug("unable to read pom")
+		return nil
+	}
+
+	p := r.newProject(pom, &location, depth)
+	r.projects[key] = p
+	return p
+}
+
+func (r *mavenResolver) readPom(location source.Location) (gopom.Project, error) {
+	reader, err := r.resolver.FileContentsByLocation(location)
+	if err != nil {
+		return gopom.Project{}, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+	return decodePomXML(reader)
+}
+
+// treeIndex returns the coordinates of all POMs found within the scanned tree.
+func (r *mavenResolver) treeIndex() map[mavenID]source.Location {
+	if r.index != nil {
+		return r.index
+	}
+	r.index = make(map[mavenID]source.Location)
+	if r.resolver == nil {
+		return r.index
+	}
+
+	locations, err := r.resolver.FilesByGlob(pomXMLDirGlob)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to find pom files")
+		return r.index
+	}
+
+	for _, location := range locations {
+		// note: parents may be found by relative path while the index is being built (the coordinates may depend on
+		// properties declared by the parent, e.g. a CI-friendly ${revision})
+		p := r.projectAtLocation(location, 0)
+		if p == nil {
+			continue
+		}
+		id := p.id()
+		if _, ok := r.index[id]; !ok {
+			r.index[id] = location
+		}
+	}
+	return r.index
+}
+
+// managedDependencies returns the dependencyManagement entries that apply to the given project (declared by the
+// project, its parents, or imported BOMs) keyed by dependencyKey.
+func (r *mavenResolver) managedDependencies(p *mavenProject, depth int) map[string]gopom.Dependency {
+	if p.managed != nil {
+		return p.managed
+	}
+	p.managed = make(map[string]gopom.Dependency)
+	if depth > maxMavenResolutionDepth {
+		return p.managed
+	}
+
+	// the nearest declaration wins, and explicit declarations take precedence over imported BOMs
+	var imports []gopom.Dependency
+	for current := p; current != nil; current = current.parent {
+		for _, dep := range current.pom.DependencyManagement.Dependencies {
+			dep = p.resolveDependency(dep)
+			if dep.Scope == "import" && dep.Type == "pom" {
+				imports = append(imports, dep)
+				continue
+			}
+			if _, ok := p.managed[dependencyKey(dep)]; !ok {
+				p.managed[dependencyKey(dep)] = dep
+			}
+		}
+	}
+
+	for _, dep := range imports {
+		id := mavenID{GroupID: dep.GroupID, ArtifactID: dep.ArtifactID, Version: dep.Version}
+		bom := r.findProject(id, depth+1)
+		if bom == nil {
+			log.WithFields("bom", id).Trace("unable to find imported maven BOM")
+			continue
+		}
+		for key, managed := range r.managedDependencies(bom, depth+1) {
+			if _, ok := p.managed[key]; !ok {
+				p.managed[key] = managed
+			}
+		}
+	}
+
+	return p.managed
+}
+
+// dependencies returns the effective dependencies of the project: dependencies declared by the project and its parents
+// with properties resolved and versions, scopes, and exclusions filled in from dependency management.
+func (r *mavenResolver) dependencies(p *mavenProject) []gopom.Dependency {
+	managed := r.managedDependencies(p, 0)
+
+	var deps []gopom.Dependency
+	seen := internal.NewStringSet()
+	for current := p; current != nil; current = current.parent {
+		for _, dep := range current.pom.Dependencies {
+			dep = p.resolveDependency(dep)
+			key := dependencyKey(dep)
+			if seen.Contains(key) {
+				continue
+			}
+			seen.Add(key)
+
+			if m, ok := managed[key]; ok {
+				if dep.Version == "" {
+					dep.Version = m.Version
+				}
+				if dep.Scope == "" {
+					dep.Scope = m.Scope
+				}
+				if dep.Optional == "" {
+					dep.Optional = m.Optional
+				}
+				if len(dep.Exclusions) == 0 {
+					dep.Exclusions = m.Exclusions
+				}
+			}
+			if dep.Scope == "" {
+				dep.Scope = "compile"
+			}
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// id returns the coordinates of the project, inheriting the group and version from the parent declaration if needed.
+func (p *mavenProject) id() mavenID {
+	raw := p.rawID()
+	return mavenID{
+		GroupID:    p.resolve(raw.GroupID),
+		ArtifactID: p.resolve(raw.ArtifactID),
+		Version:    p.resolve(raw.Version),
+	}
+}
+
+// rawID returns the coordinates of the project without resolving any property references.
+func (p *mavenProject) rawID() mavenID {
+	groupID := p.pom.GroupID
+	if groupID == "" {
+		groupID = p.pom.Parent.GroupID
+	}
+	version := p.pom.Version
+	if version == "" {
+		version = p.pom.Parent.Version
+	}
+	return mavenID{
+		GroupID:    groupID,
+		ArtifactID: p.pom.ArtifactID,
+		Version:    version,
+	}
+}
+
+// resolve replaces all property references in the given value, looking in the project and all of its parents.
+// Unresolvable references are left as-is.
+func (p *mavenProject) resolve(value string) string {
+	for i := 0; i < maxMavenResolutionDepth && strings.Contains(value, "${"); i++ {
+		resolved := propertyMatcher.ReplaceAllStringFunc(value, func(match string) string {
+			if v, ok := p.property(strings.TrimSpace(match[2 : len(match)-1])); ok {
+				return v
+			}
+			return match
+		})
+		if resolved == value {
+			break
+		}
+		value = resolved
+	}
+	return value
+}
+
+func (p *mavenProject) property(name string) (string, bool) {
+	// "pom." is a deprecated alias for "project."
+	expression := strings.TrimPrefix(strings.TrimPrefix(name, "project."), "pom.")
+	switch expression {
+	case "groupId":
+		// note: the value may contain further references, which are resolved by the caller
+		groupID := p.rawID().GroupID
+		return groupID, groupID != ""
+	case "version":
+		version := p.rawID().Version
+		return version, version != ""
+	}
+
+	for current := p; current != nil; current = current.parent {
+		if value, ok := current.pom.Properties.Entries[name]; ok {
+			return value, true
+		}
+	}
+
+	if strings.HasPrefix(name, "project.") {
+		match := "${" + name + "}"
+		if value := resolveProperty(p.pom, match); value != match {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func (p *mavenProject) resolveDependency(dep gopom.Dependency) gopom.Dependency {
+	dep.GroupID = p.resolve(dep.GroupID)
+	dep.ArtifactID = p.resolve(dep.ArtifactID)
+	dep.Version = p.resolve(dep.Version)
+	dep.Type = p.resolve(dep.Type)
+	dep.Classifier = p.resolve(dep.Classifier)
+	dep.Scope = p.resolve(dep.Scope)
+	dep.Optional = p.resolve(dep.Optional)
+	return dep
+}
+
+// dependencyKey returns the key maven uses to match dependencies with dependency management entries.
+func dependencyKey(dep gopom.Dependency) string {
+	depType := dep.Type
+	if depType == "" {
+		depType = "jar"
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", dep.GroupID, dep.ArtifactID, depType, dep.Classifier)
+}
+
+// transitiveScope returns the scope of a transitive dependency given the scope of the dependency that brought it in,
+// or an empty string if the transitive dependency is not part of the dependency graph. See
+// https://maven.apache.org/guides/introduction/introduction-to-dependency-mechanism.html#dependency-scope
+func transitiveScope(parent, child string) string {
+	if child != "compile" && child != "runtime" {
+		return ""
+	}
+	switch parent {
+	case "compile":
+		return child
+	case "runtime", "provided", "test":
+		return parent
+	}
+	return ""
+}
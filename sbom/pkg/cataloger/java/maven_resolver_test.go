@@ -0,0 +1,185 @@
+package java
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vifraa/gopom"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func Test_mavenProject_resolve(t *testing.T) {
+	parent := &mavenProject{
+		pom: gopom.Project{
+			GroupID:    "org.example",
+			ArtifactID: "parent",
+			Version:    "${revision}",
+			Properties: gopom.Properties{
+				Entries: map[string]string{
+					"revision":        "1.2.0",
+					"jackson.version": "2.15.2",
+					"lib.version":     "${project.version}",
+				},
+			},
+		},
+	}
+	child := &mavenProject{
+		pom: gopom.Project{
+			Parent: gopom.Parent{
+				GroupID:    "org.example",
+				ArtifactID: "parent",
+				Version:    "${revision}",
+			},
+			ArtifactID: "child",
+			Properties: gopom.Properties{
+				Entries: map[string]string{
+					"jackson.version": "2.14.0",
+					"cycle":           "${cycle}",
+				},
+			},
+		},
+		parent: parent,
+	}
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "${project.groupId}", expected: "org.example"},
+		{value: "${project.version}", expected: "1.2.0"},
+		{value: "${pom.version}", expected: "1.2.0"},
+		{value: "${project.parent.version}", expected: "1.2.0"},
+		{value: "${project.artifactId}", expected: "child"},
+		// the nearest declaration of a property wins
+		{value: "${jackson.version}", expected: "2.14.0"},
+		// inherited properties are resolved within the context of the child
+		{value: "${lib.version}-SNAPSHOT", expected: "1.2.0-SNAPSHOT"},
+		{value: "${missing}", expected: "${missing}"},
+		{value: "${cycle}", expected: "${cycle}"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			assert.Equal(t, test.expected, child.resolve(test.value))
+		})
+	}
+
+	assert.Equal(t, mavenID{GroupID: "org.example", ArtifactID: "child", Version: "1.2.0"}, child.id())
+}
+
+func Test_transitiveScope(t *testing.T) {
+	tests := []struct {
+		parent   string
+		child    string
+		expected string
+	}{
+		{parent: "compile", child: "compile", expected: "compile"},
+		{parent: "compile", child: "runtime", expected: "runtime"},
+		{parent: "runtime", child: "compile", expected: "runtime"},
+		{parent: "provided", child: "compile", expected: "provided"},
+		{parent: "test", child: "runtime", expected: "test"},
+		{parent: "compile", child: "test", expected: ""},
+		{parent: "compile", child: "provided", expected: ""},
+		{parent: "system", child: "compile", expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s+%s", test.parent, test.child), func(t *testing.T) {
+			assert.Equal(t, test.expected, transitiveScope(test.parent, test.child))
+		})
+	}
+}
+
+func Test_pomCataloger_multiModule(t *testing.T) {
+	tests := []struct {
+		name                  string
+		cfg                   MavenConfig
+		expectedPackages      []string
+		expectedRelationships []string
+	}{
+		{
+			name: "scanned tree only",
+			expectedPackages: []string{
+				"/app/pom.xml example-lib@1.2.0 (compile)",
+				"/app/pom.xml jackson-databind@2.15.2 (compile)",
+				// the version comes from a BOM which can only be found in the local repository
+				"/app/pom.xml commons-lang3@ (runtime)",
+				// dependencies shared by modules are reported once
+				"/app/pom.xml,/lib/pom.xml junit@4.13.2 (test)",
+				"/app/pom.xml,/lib/pom.xml slf4j-api@2.0.7 (compile)",
+			},
+			expectedRelationships: []string{
+				"slf4j-api -> example-lib",
+			},
+		},
+		{
+			name: "with local repository",
+			cfg: MavenConfig{
+				UseLocalRepository: true,
+				LocalRepositoryDir: "test-fixtures/pom/m2-repository",
+			},
+			expectedPackages: []string{
+				"/app/pom.xml example-lib@1.2.0 (compile)",
+				"/app/pom.xml jackson-databind@2.15.2 (compile)",
+				"/app/pom.xml commons-lang3@3.12.0 (runtime)",
+				"/app/pom.xml,/lib/pom.xml junit@4.13.2 (test)",
+				"/app/pom.xml,/lib/pom.xml slf4j-api@2.0.7 (compile)",
+				// jackson-core is excluded, while test and optional dependencies are not transitive
+				"/app/pom.xml jackson-annotations@2.15.2 (compile)",
+			},
+			expectedRelationships: []string{
+				"slf4j-api -> example-lib",
+				"jackson-annotations -> jackson-databind",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, err := source.NewFromDirectory("test-fixtures/pom/multi-module")
+			require.NoError(t, err)
+			resolver, err := src.FileResolver(source.SquashedScope)
+			require.NoError(t, err)
+
+			pkgs, relationships, err := NewJavaPomCataloger(Config{Maven: test.cfg}).Catalog(resolver)
+			require.NoError(t, err)
+
+			var actualPackages []string
+			for _, p := range pkgs {
+				actualPackages = append(actualPackages, describeMavenPackage(t, p))
+			}
+			assert.ElementsMatch(t, test.expectedPackages, actualPackages)
+
+			var actualRelationships []string
+			for _, r := range relationships {
+				assert.Equal(t, artifact.DependencyOfRelationship, r.Type)
+				actualRelationships = append(actualRelationships, fmt.Sprintf("%s -> %s", r.From.(pkg.Package).Name, r.To.(pkg.Package).Name))
+			}
+			assert.ElementsMatch(t, test.expectedRelationships, actualRelationships)
+		})
+	}
+}
+
+func describeMavenPackage(t *testing.T, p pkg.Package) string {
+	t.Helper()
+	var paths []string
+	for _, l := range p.Locations.ToSlice() {
+		paths = append(paths, l.RealPath)
+	}
+	sort.Strings(paths)
+
+	description := fmt.Sprintf("%s %s@%s", strings.Join(paths, ","), p.Name, p.Version)
+	metadata, ok := p.Metadata.(pkg.JavaMetadata)
+	require.True(t, ok)
+	if metadata.PomProperties != nil && metadata.PomProperties.Scope != "" {
+		description += fmt.Sprintf(" (%s)", metadata.PomProperties.Scope)
+	}
+	return description
+}
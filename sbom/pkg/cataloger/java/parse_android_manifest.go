@@ -0,0 +1,455 @@
+package java
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf16"
+)
+
+// androidManifest is the information of interest from an AndroidManifest.xml file.
+type androidManifest struct {
+	pkg              string
+	versionName      string
+	versionCode      string
+	minSdkVersion    string
+	targetSdkVersion string
+}
+
+// androidManifestElement is a start element within an AndroidManifest.xml file, with attributes keyed by local name.
+type androidManifestElement struct {
+	name       string
+	attributes map[string]string
+}
+
+// parseAndroidManifest parses an AndroidManifest.xml file in any of the encodings found within Android archives:
+// binary XML (apk), protobuf (aab) or plain text XML (aar).
+func parseAndroidManifest(contents []byte) (*androidManifest, error) {
+	var elements []androidManifestElement
+	var err error
+	trimmed := bytes.TrimLeft(contents, "\ufeff \t\r\n")
+	switch {
+	case len(contents) >= 2 && binary.LittleEndian.Uint16(contents) == resXMLType:
+		elements, err = parseBinaryXML(contents)
+	case len(trimmed) > 0 && trimmed[0] == '<':
+		elements, err = parseTextXML(trimmed)
+	default:
+		elements, err = parseProtoXML(contents)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest androidManifest
+	for _, e := range elements {
+		switch e.name {
+		case "manifest":
+			manifest.pkg = e.attributes["package"]
+			manifest.versionName = e.attributes["versionName"]
+			manifest.versionCode = e.attributes["versionCode"]
+		case "uses-sdk":
+			manifest.minSdkVersion = e.attributes["minSdkVersion"]
+			manifest.targetSdkVersion = e.attributes["targetSdkVersion"]
+		}
+	}
+
+	if manifest.pkg == "" {
+		return nil, errors.New("no package name found in android manifest")
+	}
+	return &manifest, nil
+}
+
+func parseTextXML(contents []byte) ([]androidManifestElement, error) {
+	var elements []androidManifestElement
+	decoder := xml.NewDecoder(bytes.NewReader(contents))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return elements, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse android manifest: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		e := androidManifestElement{name: start.Name.Local, attributes: make(map[string]string)}
+		for _, attr := range start.Attr {
+			e.attributes[attr.Name.Local] = attr.Value
+		}
+		elements = append(elements, e)
+	}
+}
+
+// chunk types and value types of the Android binary XML format (see ResourceTypes.h within the Android framework)
+const (
+	resStringPoolType      = 0x0001
+	resXMLType             = 0x0003
+	resXMLStartElementType = 0x0102
+	resXMLResourceMapType  = 0x0180
+
+	stringPoolUTF8Flag = 1 << 8
+
+	resValueTypeString     = 0x03
+	resValueTypeIntDec     = 0x10
+	resValueTypeIntHex     = 0x11
+	resValueTypeIntBoolean = 0x12
+
+	noEntry = 0xffffffff
+)
+
+// androidAttributeNames are the framework attributes of interest by resource ID, since attribute names may be
+// stripped from the string pool of compiled manifests.
+var androidAttributeNames = map[uint32]string{
+	0x0101020c: "minSdkVersion",
+	0x0101021b: "versionCode",
+	0x0101021c: "versionName",
+	0x01010270: "targetSdkVersion",
+}
+
+// parseBinaryXML returns the start elements of an Android binary XML document, which is a sequence of chunks (each
+// with a type, header size and total size) containing a string pool, a resource map and the XML tree nodes.
+func parseBinaryXML(contents []byte) ([]androidManifestElement, error) {
+	if len(contents) < 8 {
+		return nil, errors.New("android binary xml is truncated")
+	}
+
+	var elements []androidManifestElement
+	var pool []string
+	var resourceIDs []uint32
+	for offset := int(binary.LittleEndian.Uint16(contents[2:])); offset+8 <= len(contents); {
+		chunkType := binary.LittleEndian.Uint16(contents[offset:])
+		headerSize := int(binary.LittleEndian.Uint16(contents[offset+2:]))
+		size := int(binary.LittleEndian.Uint32(contents[offset+4:]))
+		if size < 8 || headerSize > size || offset+size > len(contents) {
+			return nil, fmt.Errorf("malformed android binary xml chunk at offset %d", offset)
+		}
+		chunk := contents[offset : offset+size]
+
+		switch chunkType {
+		case resStringPoolType:
+			var err error
+			if pool, err = parseStringPool(chunk); err != nil {
+				return nil, err
+			}
+		case resXMLResourceMapType:
+			for i := headerSize; i+4 <= len(chunk); i += 4 {
+				resourceIDs = append(resourceIDs, binary.LittleEndian.Uint32(chunk[i:]))
+			}
+		case resXMLStartElementType:
+			e, err := parseStartElement(chunk[headerSize:], pool, resourceIDs)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, e)
+		}
+
+		offset += size
+	}
+
+	return elements, nil
+}
+
+func parseStringPool(chunk []byte) ([]string, error) {
+	if len(chunk) < 28 {
+		return nil, errors.New("android binary xml string pool is truncated")
+	}
+	headerSize := int(binary.LittleEndian.Uint16(chunk[2:]))
+	count := int(binary.LittleEndian.Uint32(chunk[8:]))
+	flags := binary.LittleEndian.Uint32(chunk[16:])
+	stringsStart := int(binary.LittleEndian.Uint32(chunk[20:]))
+	if headerSize+count*4 > len(chunk) || stringsStart > len(chunk) {
+		return nil, errors.New("android binary xml string pool is truncated")
+	}
+
+	pool := make([]string, count)
+	for i := range pool {
+		offset := stringsStart + int(binary.LittleEndian.Uint32(chunk[headerSize+i*4:]))
+		if offset >= len(chunk) {
+			return nil, fmt.Errorf("android binary xml string %d is out of bounds", i)
+		}
+		var err error
+		if flags&stringPoolUTF8Flag != 0 {
+			pool[i], err = decodeUTF8PoolString(chunk[offset:])
+		} else {
+			pool[i], err = decodeUTF16PoolString(chunk[offset:])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pool, nil
+}
+
+// decodeUTF8PoolString decodes a string prefixed by its UTF-16 length and UTF-8 length (each 1 or 2 bytes).
+func decodeUTF8PoolString(b []byte) (string, error) {
+	length := func(b []byte) (int, int) {
+		if len(b) > 1 && b[0]&0x80 != 0 {
+			return int(b[0]&0x7f)<<8 | int(b[1]), 2
+		}
+		if len(b) > 0 {
+			return int(b[0]), 1
+		}
+		return 0, 0
+	}
+
+	_, n := length(b)
+	size, m := length(b[n:])
+	start := n + m
+	if start+size > len(b) {
+		return "", errors.New("android binary xml string is truncated")
+	}
+	return string(b[start : start+size]), nil
+}
+
+// decodeUTF16PoolString decodes a string prefixed by its length in UTF-16 code units (2 or 4 bytes).
+func decodeUTF16PoolString(b []byte) (string, error) {
+	if len(b) < 2 {
+		return "", errors.New("android binary xml string is truncated")
+	}
+	size := int(binary.LittleEndian.Uint16(b))
+	start := 2
+	if size&0x8000 != 0 {
+		if len(b) < 4 {
+			return "", errors.New("android binary xml string is truncated")
+		}
+		size = (size&0x7fff)<<16 | int(binary.LittleEndian.Uint16(b[2:]))
+		start = 4
+	}
+	if start+size*2 > len(b) {
+		return "", errors.New("android binary xml string is truncated")
+	}
+
+	units := make([]uint16, size)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(b[start+i*2:])
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// parseStartElement parses the body of a start element node: the element namespace and name followed by the
+// attributes (each with a namespace, name, raw string value and typed value).
+func parseStartElement(body []byte, pool []string, resourceIDs []uint32) (androidManifestElement, error) {
+	if len(body) < 20 {
+		return androidManifestElement{}, errors.New("android binary xml element is truncated")
+	}
+	attributeStart := int(binary.LittleEndian.Uint16(body[8:]))
+	attributeSize := int(binary.LittleEndian.Uint16(body[10:]))
+	attributeCount := int(binary.LittleEndian.Uint16(body[12:]))
+	if attributeSize < 20 || attributeStart+attributeCount*attributeSize > len(body) {
+		return androidManifestElement{}, errors.New("android binary xml attributes are truncated")
+	}
+
+	e := androidManifestElement{
+		name:       poolString(pool, binary.LittleEndian.Uint32(body[4:])),
+		attributes: make(map[string]string),
+	}
+	for i := 0; i < attributeCount; i++ {
+		attr := body[attributeStart+i*attributeSize:]
+		nameIdx := binary.LittleEndian.Uint32(attr[4:])
+		rawValue := binary.LittleEndian.Uint32(attr[8:])
+		dataType := attr[15]
+		data := binary.LittleEndian.Uint32(attr[16:])
+
+		name := poolString(pool, nameIdx)
+		if int(nameIdx) < len(resourceIDs) {
+			if n, ok := androidAttributeNames[resourceIDs[nameIdx]]; ok {
+				name = n
+			}
+		}
+
+		var value string
+		switch {
+		case rawValue != noEntry:
+			value = poolString(pool, rawValue)
+		case dataType == resValueTypeString:
+			value = poolString(pool, data)
+		case dataType == resValueTypeIntDec, dataType == resValueTypeIntHex:
+			value = strconv.FormatInt(int64(int32(data)), 10)
+		case dataType == resValueTypeIntBoolean:
+			value = strconv.FormatBool(data != 0)
+		default:
+			// references to resources (e.g. "@string/version") cannot be resolved without the resource table
+			continue
+		}
+		e.attributes[name] = value
+	}
+	return e, nil
+}
+
+func poolString(pool []string, idx uint32) string {
+	if int(idx) < len(pool) {
+		return pool[idx]
+	}
+	return ""
+}
+
+// field numbers of the aapt2 protobuf XML format (see Resources.proto within the Android framework)
+const (
+	protoXMLNodeElement       = 1
+	protoXMLElementName       = 3
+	protoXMLElementAttribute  = 4
+	protoXMLElementChild      = 5
+	protoXMLAttributeName     = 2
+	protoXMLAttributeValue    = 3
+	protoXMLAttributeResID    = 5
+	protoXMLAttributeCompiled = 6
+	protoItemPrimitive        = 7
+	protoPrimitiveIntDecimal  = 6
+	protoPrimitiveIntHex      = 7
+)
+
+// parseProtoXML returns the start elements of an XmlNode message, as found in Android App Bundles.
+func parseProtoXML(contents []byte) ([]androidManifestElement, error) {
+	fields, err := protoFields(contents)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse android manifest: %w", err)
+	}
+
+	var elements []androidManifestElement
+	for _, f := range fields {
+		if f.number != protoXMLNodeElement {
+			continue
+		}
+		nested, err := parseProtoXMLElement(f.bytes)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, nested...)
+	}
+	return elements, nil
+}
+
+func parseProtoXMLElement(contents []byte) ([]androidManifestElement, error) {
+	fields, err := protoFields(contents)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse android manifest element: %w", err)
+	}
+
+	e := androidManifestElement{attributes: make(map[string]string)}
+	var children []androidManifestElement
+	for _, f := range fields {
+		switch f.number {
+		case protoXMLElementName:
+			e.name = string(f.bytes)
+		case protoXMLElementAttribute:
+			name, value, err := parseProtoXMLAttribute(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			e.attributes[name] = value
+		case protoXMLElementChild:
+			nested, err := parseProtoXML(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, nested...)
+		}
+	}
+	return append([]androidManifestElement{e}, children...), nil
+}
+
+func parseProtoXMLAttribute(contents []byte) (string, string, error) {
+	fields, err := protoFields(contents)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to parse android manifest attribute: %w", err)
+	}
+
+	var name, value, compiled string
+	for _, f := range fields {
+		switch f.number {
+		case protoXMLAttributeName:
+			name = string(f.bytes)
+		case protoXMLAttributeValue:
+			value = string(f.bytes)
+		case protoXMLAttributeResID:
+			if n, ok := androidAttributeNames[uint32(f.varint)]; ok {
+				name = n
+			}
+		case protoXMLAttributeCompiled:
+			compiled = protoPrimitiveInt(f.bytes)
+		}
+	}
+	if value == "" {
+		value = compiled
+	}
+	return name, value, nil
+}
+
+// protoPrimitiveInt returns the integer value of an Item message holding a primitive integer (or an empty string).
+func protoPrimitiveInt(item []byte) string {
+	fields, err := protoFields(item)
+	if err != nil {
+		return ""
+	}
+	for _, f := range fields {
+		if f.number != protoItemPrimitive {
+			continue
+		}
+		primitive, err := protoFields(f.bytes)
+		if err != nil {
+			return ""
+		}
+		for _, p := range primitive {
+			if p.number == protoPrimitiveIntDecimal || p.number == protoPrimitiveIntHex {
+				return strconv.FormatInt(int64(int32(p.varint)), 10)
+			}
+		}
+	}
+	return ""
+}
+
+type protoField struct {
+	number int
+	varint uint64
+	bytes  []byte
+}
+
+// protoFields decodes the fields of a protobuf message (without a schema, so only varint and length-delimited values
+// are retained).
+func protoFields(b []byte) ([]protoField, error) {
+	var fields []protoField
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errors.New("invalid protobuf field key")
+		}
+		b = b[n:]
+
+		f := protoField{number: int(key >> 3)}
+		switch key & 0x7 {
+		case 0: // varint
+			f.varint, n = binary.Uvarint(b)
+			if n <= 0 {
+				return nil, errors.New("invalid protobuf varint")
+			}
+			b = b[n:]
+		case 1: // 64-bit
+			if len(b) < 8 {
+				return nil, errors.New("truncated protobuf field")
+			}
+			b = b[8:]
+		case 2: // length-delimited
+			size, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < size {
+				return nil, errors.New("truncated protobuf field")
+			}
+			f.bytes = b[n : n+int(size)]
+			b = b[n+int(size):]
+		case 5: // 32-bit
+			if len(b) < 4 {
+				return nil, errors.New("truncated protobuf field")
+			}
+			b = b[4:]
+		default:
+			return nil, fmt.Errorf("unsupported protobuf wire type %d", key&0x7)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
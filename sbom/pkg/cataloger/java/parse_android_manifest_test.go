@@ -0,0 +1,81 @@
+package java
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseAndroidManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		input   string
+		want    *androidManifest
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "binary xml (apk)",
+			fixture: "test-fixtures/android/apk/AndroidManifest.xml",
+			want: &androidManifest{
+				pkg:              "com.example.app",
+				versionName:      "1.2.3",
+				versionCode:      "42",
+				minSdkVersion:    "21",
+				targetSdkVersion: "34",
+			},
+		},
+		{
+			name:    "protobuf xml (aab)",
+			fixture: "test-fixtures/android/aab/AndroidManifest.xml",
+			want: &androidManifest{
+				pkg:              "com.example.bundle",
+				versionName:      "2.0.0",
+				versionCode:      "7",
+				minSdkVersion:    "24",
+				targetSdkVersion: "33",
+			},
+		},
+		{
+			name: "text xml (aar)",
+			input: `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android" package="com.example.library">
+    <uses-sdk android:minSdkVersion="19" />
+</manifest>`,
+			want: &androidManifest{
+				pkg:           "com.example.library",
+				minSdkVersion: "19",
+			},
+		},
+		{
+			name:    "no package",
+			input:   `<manifest xmlns:android="http://schemas.android.com/apk/res/android" android:versionName="1.0"/>`,
+			wantErr: require.Error,
+		},
+		{
+			name:    "truncated binary xml",
+			input:   "\x03\x00\x08\x00\xff\x00\x00\x00\x01\x00\x1c\x00\xff\xff\x00\x00",
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+
+			contents := []byte(tt.input)
+			if tt.fixture != "" {
+				var err error
+				contents, err = os.ReadFile(tt.fixture)
+				require.NoError(t, err)
+			}
+
+			got, err := parseAndroidManifest(contents)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
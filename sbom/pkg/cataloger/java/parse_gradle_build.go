@@ -0,0 +1,394 @@
+package java
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var gradleBuildScriptGlobs = []string{
+	"**/build.gradle",
+	"**/build.gradle.kts",
+}
+
+const gradlePropertiesGlob = "**/gradle.properties"
+
+// gradleConfigurationScopes maps the standard gradle dependency configurations to the equivalent maven scopes.
+// Configurations of other source sets are prefixed with the source set name (e.g. testImplementation).
+var gradleConfigurationScopes = map[string]string{
+	"api":                 "compile",
+	"implementation":      "compile",
+	"compile":             "compile",
+	"compileOnly":         "provided",
+	"compileOnlyApi":      "provided",
+	"annotationProcessor": "provided",
+	"kapt":                "provided",
+	"ksp":                 "provided",
+	"runtimeOnly":         "runtime",
+	"runtime":             "runtime",
+}
+
+var (
+	gradleBlockCommentPattern = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	gradleLineCommentPattern  = regexp.MustCompile(`(?m)(^|\s)//.*$`)
+	// e.g. `implementation "group:name:version"`, `testImplementation(libs.junit)`, or `api(platform("group:name:version"))`
+	gradleDeclarationPattern = regexp.MustCompile(`(?m)^[ \t]*([A-Za-z][A-Za-z0-9]*)(?:[ \t]+|[ \t]*\([ \t]*)(.+?)[ \t]*$`)
+	gradlePlatformPattern    = regexp.MustCompile(`^(?:enforcedPlatform|platform)\s*\(\s*`)
+	gradleStringPattern      = regexp.MustCompile(`^(["'])([^"']+)["']`)
+	gradleMapEntryPattern    = regexp.MustCompile(`\b(group|name|version)\s*[:=]\s*(["'])([^"']*)["']`)
+	gradleAccessorPattern    = regexp.MustCompile(`^([A-Za-z]\w*)\.([A-Za-z][\w.]*)`)
+	// e.g. `def jodaVersion = '2.2'`, `val jodaVersion = "2.2"`, or `jodaVersion = '2.2'` within an ext block
+	gradleVariablePattern      = regexp.MustCompile(`(?m)^\s*(?:(?:def|val|var)\s+|(?:project\.)?ext\.)?([A-Za-z_]\w*)\s*=\s*["']([^"'$]*)["']\s*$`)
+	gradleInterpolationPattern = regexp.MustCompile(`\$\{([^}]+)\}|\$([A-Za-z_]\w*)`)
+)
+
+// NewJavaGradleBuildCataloger returns a cataloger capable of parsing dependencies declared in gradle build scripts
+// (build.gradle and build.gradle.kts), including references to gradle version catalogs. Dependencies that are
+// pinned by a gradle lockfile are left to the gradle lockfile cataloger.
+func NewJavaGradleBuildCataloger() pkg.Cataloger {
+	return &gradleBuildCataloger{}
+}
+
+type gradleBuildCataloger struct{}
+
+func (c *gradleBuildCataloger) Name() string {
+	return "java-gradle-build-cataloger"
+}
+
+func (c *gradleBuildCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	project := newGradleProject(resolver)
+	return generic.NewCataloger(c.Name()).
+		WithParserByGlobs(project.parseGradleBuildScript, gradleBuildScriptGlobs...).
+		Catalog(resolver)
+}
+
+type gradleCatalogRef struct {
+	catalog  *gradleVersionCatalog
+	location source.Location
+}
+
+// gradleProject holds the files shared between the build scripts of a gradle build: version catalogs, properties,
+// and lockfiles, each indexed by the directory they apply to.
+type gradleProject struct {
+	catalogs   map[string]map[string]gradleCatalogRef
+	properties map[string]map[string]string
+	locked     map[string]internal.StringSet
+}
+
+func newGradleProject(resolver source.FileResolver) *gradleProject {
+	p := &gradleProject{
+		catalogs:   make(map[string]map[string]gradleCatalogRef),
+		properties: make(map[string]map[string]string),
+		locked:     make(map[string]internal.StringSet),
+	}
+
+	// catalogs are found in the gradle directory of the root project, e.g. gradle/libs.versions.toml is accessed as "libs"
+	forEachGradleFile(resolver, func(location source.Location, reader io.Reader) error {
+		catalog, err := parseGradleVersionCatalog(reader)
+		if err != nil {
+			return err
+		}
+		root := path.Dir(path.Dir(location.RealPath))
+		if p.catalogs[root] == nil {
+			p.catalogs[root] = make(map[string]gradleCatalogRef)
+		}
+		name := strings.TrimSuffix(path.Base(location.RealPath), ".versions.toml")
+		p.catalogs[root][name] = gradleCatalogRef{catalog: catalog, location: location}
+		return nil
+	}, gradleVersionCatalogGlob)
+
+	forEachGradleFile(resolver, func(location source.Location, reader io.Reader) error {
+		p.properties[path.Dir(location.RealPath)] = readGradleProperties(reader)
+		return nil
+	}, gradlePropertiesGlob)
+
+	forEachGradleFile(resolver, func(location source.Location, reader io.Reader) error {
+		dir := path.Dir(location.RealPath)
+		if strings.HasSuffix(dir, "/gradle/dependency-locks") {
+			dir = path.Dir(path.Dir(dir))
+		}
+		if p.locked[dir] == nil {
+			p.locked[dir] = internal.NewStringSet()
+		}
+		for _, dep := range readGradleLockfile(reader) {
+			p.locked[dir].Add(dep.Group + ":" + dep.Name)
+		}
+		return nil
+	}, gradleLockfileGlob, gradleLegacyLockfilesGlob)
+
+	return p
+}
+
+func forEachGradleFile(resolver source.FileResolver, fn func(source.Location, io.Reader) error, globs ...string) {
+	locations, err := resolver.FilesByGlob(globs...)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to find gradle files")
+		return
+	}
+	for _, location := range locations {
+		reader, err := resolver.FileContentsByLocation(location)
+		if err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Debug("unable to read gradle file")
+			continue
+		}
+		if err := fn(location, reader); err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Debug("unable to parse gradle file")
+		}
+		internal.CloseAndLogError(reader, location.RealPath)
+	}
+}
+
+// parseGradleBuildScript is a parser function for gradle build scripts (in either the Groovy or Kotlin DSL), returning
+// all statically declared dependencies that are not pinned by a lockfile.
+func (p *gradleProject) parseGradleBuildScript(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read gradle build script: %w", err)
+	}
+	script := gradleBlockCommentPattern.ReplaceAllString(string(contents), "")
+	script = gradleLineCommentPattern.ReplaceAllString(script, "$1")
+
+	dir := path.Dir(reader.Location.RealPath)
+	variables := p.variables(dir, script)
+	catalogs := p.catalogsFor(dir)
+	locked := p.locked[dir]
+
+	// the same module may be declared for several configurations (e.g. compileOnly and annotationProcessor), in
+	// which case the first declaration wins
+	seen := internal.NewStringSet()
+	var pkgs []pkg.Package
+	for _, match := range gradleDeclarationPattern.FindAllStringSubmatch(script, -1) {
+		scope, ok := gradleScope(match[1])
+		if !ok {
+			continue
+		}
+
+		locations := []source.Location{reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)}
+		deps, catalogLocation := parseGradleDeclaration(match[2], variables, catalogs)
+		if catalogLocation != nil {
+			locations = append(locations, catalogLocation.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+		}
+
+		for _, dep := range deps {
+			key := dep.Group + ":" + dep.Name
+			if seen.Contains(key) || (locked != nil && locked.Contains(key)) {
+				continue
+			}
+			seen.Add(key)
+			pkgs = append(pkgs, newGradlePackage(dep, scope, locations...))
+		}
+	}
+
+	return pkgs, nil, nil
+}
+
+// parseGradleDeclaration returns the dependencies referenced by the arguments of a dependency declaration, along with
+// the location of the version catalog they were found in (if any).
+func parseGradleDeclaration(args string, variables map[string]string, catalogs map[string]gradleCatalogRef) ([]gradleDependency, *source.Location) {
+	// platforms (BOMs) are reported like any other dependency
+	args = gradlePlatformPattern.ReplaceAllString(args, "")
+
+	// e.g. "group:name:version"
+	if match := gradleStringPattern.FindStringSubmatch(args); match != nil {
+		coordinates := match[2]
+		if match[1] == `"` {
+			coordinates = interpolateGradleString(coordinates, variables)
+		}
+		if dep, ok := parseGradleCoordinates(coordinates); ok {
+			return []gradleDependency{dep}, nil
+		}
+		return nil, nil
+	}
+
+	// e.g. libs.guava or libs.bundles.groovy
+	if match := gradleAccessorPattern.FindStringSubmatch(args); match != nil {
+		ref, ok := catalogs[match[1]]
+		if !ok {
+			return nil, nil
+		}
+		accessor := match[2]
+		switch {
+		case strings.HasPrefix(accessor, "bundles."):
+			return ref.catalog.bundle(strings.TrimPrefix(accessor, "bundles.")), &ref.location
+		case strings.HasPrefix(accessor, "plugins."), strings.HasPrefix(accessor, "versions."):
+			return nil, nil
+		}
+		if dep, ok := ref.catalog.library(accessor); ok {
+			return []gradleDependency{dep}, &ref.location
+		}
+		return nil, nil
+	}
+
+	// e.g. group: 'com.google.guava', name: 'guava', version: '31.1-jre'
+	var dep gradleDependency
+	for _, match := range gradleMapEntryPattern.FindAllStringSubmatch(args, -1) {
+		value := match[3]
+		if match[2] == `"` {
+			value = interpolateGradleString(value, variables)
+		}
+		switch match[1] {
+		case "group":
+			dep.Group = value
+		case "name":
+			dep.Name = value
+		case "version":
+			dep.Version = value
+		}
+	}
+	if dep.Group != "" && dep.Name != "" {
+		return []gradleDependency{dep}, nil
+	}
+	return nil, nil
+}
+
+// parseGradleCoordinates parses dependency notation such as "group:name:version:classifier@extension" (the version
+// is optional, e.g. when it is provided by a platform).
+func parseGradleCoordinates(coordinates string) (gradleDependency, bool) {
+	coordinates, _, _ = strings.Cut(coordinates, "@")
+	parts := strings.Split(coordinates, ":")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return gradleDependency{}, false
+	}
+	dep := gradleDependency{
+		Group: parts[0],
+		Name:  parts[1],
+	}
+	if len(parts) > 2 {
+		dep.Version = parts[2]
+	}
+	return dep, true
+}
+
+// gradleScope returns the scope for a dependency configuration, or false if the name is not a known configuration.
+func gradleScope(configuration string) (string, bool) {
+	var base string
+	for name := range gradleConfigurationScopes {
+		if len(name) <= len(base) || !strings.HasSuffix(strings.ToLower(configuration), strings.ToLower(name)) {
+			continue
+		}
+		prefix := configuration[:len(configuration)-len(name)]
+		// the configuration name must be prefixed by a source set name in camel case (e.g. not "xapi")
+		if prefix != "" && !unicode.IsUpper(rune(configuration[len(prefix)])) {
+			continue
+		}
+		base = name
+	}
+	if base == "" {
+		return "", false
+	}
+
+	prefix := strings.ToLower(configuration[:len(configuration)-len(base)])
+	if strings.Contains(prefix, "test") {
+		return "test", true
+	}
+	return gradleConfigurationScopes[base], true
+}
+
+// variables returns all statically assigned string variables within the build script and the gradle.properties files
+// that apply to it (with the nearest declaration winning).
+func (p *gradleProject) variables(dir, script string) map[string]string {
+	variables := make(map[string]string)
+
+	var dirs []string
+	for d := dir; ; d = path.Dir(d) {
+		dirs = append(dirs, d)
+		if d == "/" || d == "." {
+			break
+		}
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		for k, v := range p.properties[dirs[i]] {
+			variables[k] = v
+		}
+	}
+
+	for _, match := range gradleVariablePattern.FindAllStringSubmatch(script, -1) {
+		variables[match[1]] = match[2]
+	}
+	return variables
+}
+
+// catalogsFor returns the version catalogs available to a build script in the given directory (from the nearest root
+// project).
+func (p *gradleProject) catalogsFor(dir string) map[string]gradleCatalogRef {
+	for d := dir; ; d = path.Dir(d) {
+		if catalogs, ok := p.catalogs[d]; ok {
+			return catalogs
+		}
+		if d == "/" || d == "." {
+			return nil
+		}
+	}
+}
+
+func interpolateGradleString(value string, variables map[string]string) string {
+	return gradleInterpolationPattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := strings.Trim(strings.TrimPrefix(match, "$"), "{}")
+		if v, ok := variables[name]; ok {
+			return v
+		}
+		// e.g. ${project.jodaVersion} or ${rootProject.ext.jodaVersion}
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			if v, ok := variables[name[i+1:]]; ok {
+				return v
+			}
+		}
+		return match
+	})
+}
+
+func readGradleProperties(reader io.Reader) map[string]string {
+	properties := make(map[string]string)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			key, value, found = strings.Cut(line, ":")
+		}
+		if found {
+			properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return properties
+}
+
+func newGradlePackage(dep gradleDependency, scope string, locations ...source.Location) pkg.Package {
+	m := pkg.JavaMetadata{
+		PomProperties: &pkg.PomProperties{
+			GroupID: dep.Group,
+			Scope:   scope,
+		},
+		// the version is as declared (which may be a range or missing) since there is no lockfile pinning it
+		Unresolved: true,
+	}
+
+	p := pkg.Package{
+		Name:         dep.Name,
+		Version:      dep.Version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(dep.Name, dep.Version, m),
+		Language:     pkg.Java,
+		Type:         pkg.JavaPkg,
+		MetadataType: pkg.JavaMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
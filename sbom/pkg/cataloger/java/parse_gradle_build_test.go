@@ -0,0 +1,120 @@
+package java
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func Test_gradleBuildCataloger(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/gradle-build")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := NewJavaGradleBuildCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	assert.Empty(t, relationships)
+
+	var actual []string
+	for _, p := range pkgs {
+		metadata, ok := p.Metadata.(pkg.JavaMetadata)
+		require.True(t, ok)
+		assert.True(t, metadata.Unresolved)
+
+		var paths []string
+		for _, l := range p.Locations.ToSlice() {
+			paths = append(paths, l.RealPath)
+		}
+		sort.Strings(paths)
+		actual = append(actual, fmt.Sprintf("%v %s:%s@%s (%s)", paths, metadata.PomProperties.GroupID, p.Name, p.Version, metadata.PomProperties.Scope))
+	}
+
+	assert.ElementsMatch(t, []string{
+		"[/app/build.gradle.kts /gradle/libs.versions.toml] com.google.guava:guava@32.1.1-jre (compile)",
+		"[/app/build.gradle.kts /gradle/libs.versions.toml] com.fasterxml.jackson.core:jackson-databind@2.15.2 (compile)",
+		"[/app/build.gradle.kts /gradle/libs.versions.toml] com.fasterxml.jackson.core:jackson-annotations@2.15.2 (compile)",
+		"[/app/build.gradle.kts] org.springframework.boot:spring-boot-dependencies@3.1.2 (compile)",
+		// the version is managed by the platform
+		"[/app/build.gradle.kts] org.springframework.boot:spring-boot-starter-web@ (compile)",
+		"[/app/build.gradle.kts] org.slf4j:slf4j-simple@2.0.7 (runtime)",
+		"[/app/build.gradle.kts /gradle/libs.versions.toml] org.junit.jupiter:junit-jupiter@5.9.3 (test)",
+		"[/lib/build.gradle] org.apache.commons:commons-lang3@3.12.0 (compile)",
+		// the version is from the root gradle.properties
+		"[/lib/build.gradle] joda-time:joda-time@2.12.5 (compile)",
+		"[/lib/build.gradle] org.projectlombok:lombok@1.18.28 (provided)",
+		"[/lib/build.gradle] org.junit.platform:junit-platform-launcher@1.9.3 (test)",
+		// gson is pinned by the lockfile and reported by the gradle lockfile cataloger instead
+		"[/locked/build.gradle] org.apache.httpcomponents:httpclient@4.+ (compile)",
+		// gson is pinned by a legacy (pre gradle 6.0) lockfile, which the gradle lockfile cataloger reports
+		"[/legacy-locked/build.gradle] org.apache.httpcomponents:httpclient@4.+ (compile)",
+	}, actual)
+}
+
+func Test_gradleScope(t *testing.T) {
+	tests := []struct {
+		configuration string
+		scope         string
+		ok            bool
+	}{
+		{configuration: "implementation", scope: "compile", ok: true},
+		{configuration: "api", scope: "compile", ok: true},
+		{configuration: "compileOnly", scope: "provided", ok: true},
+		{configuration: "kapt", scope: "provided", ok: true},
+		{configuration: "runtimeOnly", scope: "runtime", ok: true},
+		{configuration: "testImplementation", scope: "test", ok: true},
+		{configuration: "testCompileOnly", scope: "test", ok: true},
+		{configuration: "integrationTestRuntimeOnly", scope: "test", ok: true},
+		{configuration: "debugImplementation", scope: "compile", ok: true},
+		{configuration: "classpath", ok: false},
+		{configuration: "id", ok: false},
+		{configuration: "xapi", ok: false},
+	}
+	for _, test := range tests {
+		t.Run(test.configuration, func(t *testing.T) {
+			scope, ok := gradleScope(test.configuration)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.scope, scope)
+		})
+	}
+}
+
+func Test_parseGradleCoordinates(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected gradleDependency
+		ok       bool
+	}{
+		{
+			input:    "com.google.guava:guava:32.1.1-jre",
+			expected: gradleDependency{Group: "com.google.guava", Name: "guava", Version: "32.1.1-jre"},
+			ok:       true,
+		},
+		{
+			input:    "org.lwjgl:lwjgl:3.3.2:natives-linux@jar",
+			expected: gradleDependency{Group: "org.lwjgl", Name: "lwjgl", Version: "3.3.2"},
+			ok:       true,
+		},
+		{
+			input:    "org.springframework.boot:spring-boot-starter-web",
+			expected: gradleDependency{Group: "org.springframework.boot", Name: "spring-boot-starter-web"},
+			ok:       true,
+		},
+		{
+			input: "libs/vendored.jar",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			dep, ok := parseGradleCoordinates(test.input)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, dep)
+		})
+	}
+}
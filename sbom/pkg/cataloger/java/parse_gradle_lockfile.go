@@ -2,6 +2,7 @@ package java
 
 import (
 	"bufio"
+	"io"
 	"strings"
 
 	"github.com/nextlinux/sbom/sbom/artifact"
@@ -10,7 +11,11 @@ import (
 	"github.com/nextlinux/sbom/sbom/source"
 )
 
-const gradleLockfileGlob = "**/gradle.lockfile*"
+const (
+	gradleLockfileGlob = "**/gradle.lockfile*"
+	// lockfiles written by gradle versions before 6.0 (one per configuration)
+	gradleLegacyLockfilesGlob = "**/gradle/dependency-locks/*.lockfile"
+)
 
 // Dependency represents a single dependency in the gradle.lockfile file
 type LockfileDependency struct {
@@ -22,6 +27,28 @@ type LockfileDependency struct {
 func parseGradleLockfile(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	var pkgs []pkg.Package
 
+	dependencies := readGradleLockfile(reader)
+
+	// map the dependencies
+	for _, dep := range dependencies {
+		mappedPkg := pkg.Package{
+			Name:    dep.Name,
+			Version: dep.Version,
+			Locations: source.NewLocationSet(
+				reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+			),
+			Language:     pkg.Java,
+			Type:         pkg.JavaPkg,
+			MetadataType: pkg.JavaMetadataType,
+		}
+		pkgs = append(pkgs, mappedPkg)
+	}
+
+	return pkgs, nil, nil
+}
+
+// readGradleLockfile returns all dependencies pinned by a gradle.lockfile file.
+func readGradleLockfile(reader io.Reader) []LockfileDependency {
 	// Create a new scanner to read the file
 	scanner := bufio.NewScanner(reader)
 
@@ -46,20 +73,5 @@ func parseGradleLockfile(_ source.FileResolver, _ *generic.Environment, reader s
 			dependencies = append(dependencies, dep)
 		}
 	}
-	// map the dependencies
-	for _, dep := range dependencies {
-		mappedPkg := pkg.Package{
-			Name:    dep.Name,
-			Version: dep.Version,
-			Locations: source.NewLocationSet(
-				reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
-			),
-			Language:     pkg.Java,
-			Type:         pkg.JavaPkg,
-			MetadataType: pkg.JavaMetadataType,
-		}
-		pkgs = append(pkgs, mappedPkg)
-	}
-
-	return pkgs, nil, nil
+	return dependencies
 }
@@ -39,6 +39,25 @@ func Test_parserGradleLockfile(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "test-fixtures/gradle/dependency-locks/compileClasspath.lockfile",
+			expected: []pkg.Package{
+				{
+					Name:         "joda-time",
+					Version:      "2.2",
+					Language:     pkg.Java,
+					Type:         pkg.JavaPkg,
+					MetadataType: pkg.JavaMetadataType,
+				},
+				{
+					Name:         "junit",
+					Version:      "4.12",
+					Language:     pkg.Java,
+					Type:         pkg.JavaPkg,
+					MetadataType: pkg.JavaMetadataType,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
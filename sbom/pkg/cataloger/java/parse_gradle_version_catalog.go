@@ -0,0 +1,193 @@
+package java
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+)
+
+const gradleVersionCatalogGlob = "**/gradle/*.versions.toml"
+
+// gradleDependency is a single module declared within a gradle build script or version catalog.
+type gradleDependency struct {
+	Group   string
+	Name    string
+	Version string
+}
+
+// gradleVersionCatalog holds the libraries and bundles declared in a gradle version catalog (e.g. gradle/libs.versions.toml).
+// See https://docs.gradle.org/current/userguide/platforms.html#sub::toml-dependencies-format for more details.
+type gradleVersionCatalog struct {
+	libraries map[string]gradleDependency
+	bundles   map[string][]string
+}
+
+// library returns the library for the given accessor path (e.g. "androidx.core.ktx" for libs.androidx.core.ktx).
+func (c gradleVersionCatalog) library(accessor string) (gradleDependency, bool) {
+	dep, ok := c.libraries[normalizeGradleAlias(accessor)]
+	return dep, ok
+}
+
+// bundle returns all libraries within the bundle for the given accessor path (e.g. "groovy" for libs.bundles.groovy).
+func (c gradleVersionCatalog) bundle(accessor string) []gradleDependency {
+	var deps []gradleDependency
+	for _, alias := range c.bundles[normalizeGradleAlias(accessor)] {
+		if dep, ok := c.libraries[alias]; ok {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+func parseGradleVersionCatalog(reader io.Reader) (*gradleVersionCatalog, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load gradle version catalog for parsing: %w", err)
+	}
+	doc := tree.ToMap()
+
+	versions := make(map[string]interface{})
+	flattenGradleCatalogTable(versions, "", asMap(doc["versions"]), isRichVersion)
+
+	libraries := make(map[string]interface{})
+	flattenGradleCatalogTable(libraries, "", asMap(doc["libraries"]), isLibraryDeclaration)
+
+	catalog := &gradleVersionCatalog{
+		libraries: make(map[string]gradleDependency),
+		bundles:   make(map[string][]string),
+	}
+
+	for alias, value := range libraries {
+		dep, ok := newGradleCatalogLibrary(value, versions)
+		if !ok {
+			continue
+		}
+		catalog.libraries[alias] = dep
+	}
+
+	bundles := make(map[string]interface{})
+	flattenGradleCatalogTable(bundles, "", asMap(doc["bundles"]), nil)
+	for alias, value := range bundles {
+		members, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, m := range members {
+			if s, ok := m.(string); ok {
+				catalog.bundles[alias] = append(catalog.bundles[alias], normalizeGradleAlias(s))
+			}
+		}
+	}
+
+	return catalog, nil
+}
+
+// newGradleCatalogLibrary creates a dependency from a library declaration, which is either "group:name:version" or a
+// table with module (or group and name) and version (either a literal, a reference, or a rich version).
+func newGradleCatalogLibrary(value interface{}, versions map[string]interface{}) (gradleDependency, bool) {
+	if s, ok := value.(string); ok {
+		return parseGradleCoordinates(s)
+	}
+
+	fields := asMap(value)
+	if fields == nil {
+		return gradleDependency{}, false
+	}
+
+	var dep gradleDependency
+	if module, ok := fields["module"].(string); ok {
+		group, name, found := strings.Cut(module, ":")
+		if !found {
+			return gradleDependency{}, false
+		}
+		dep.Group, dep.Name = group, name
+	} else {
+		dep.Group, _ = fields["group"].(string)
+		dep.Name, _ = fields["name"].(string)
+	}
+	if dep.Group == "" || dep.Name == "" {
+		return gradleDependency{}, false
+	}
+
+	switch v := fields["version"].(type) {
+	case string:
+		dep.Version = v
+	case map[string]interface{}:
+		if ref, ok := v["ref"].(string); ok {
+			dep.Version = gradleCatalogVersion(versions[normalizeGradleAlias(ref)])
+		} else {
+			dep.Version = gradleCatalogVersion(v)
+		}
+	}
+	return dep, true
+}
+
+// gradleCatalogVersion returns the version to report for a version declaration, which may be a rich version (see
+// https://docs.gradle.org/current/userguide/rich_versions.html).
+func gradleCatalogVersion(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		for _, key := range []string{"strictly", "require", "prefer"} {
+			if s, ok := v[key].(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// flattenGradleCatalogTable collects all entries of a catalog table by their normalized alias. Aliases containing dots
+// are nested tables in TOML, so nested tables are descended into unless they are a declaration themselves.
+func flattenGradleCatalogTable(dest map[string]interface{}, prefix string, table map[string]interface{}, isDeclaration func(map[string]interface{}) bool) {
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		alias := normalizeGradleAlias(key)
+		if prefix != "" {
+			alias = prefix + "." + alias
+		}
+		value := table[key]
+		if nested := asMap(value); nested != nil && (isDeclaration == nil || !isDeclaration(nested)) {
+			flattenGradleCatalogTable(dest, alias, nested, isDeclaration)
+			continue
+		}
+		dest[alias] = value
+	}
+}
+
+func isRichVersion(table map[string]interface{}) bool {
+	for _, key := range []string{"strictly", "require", "prefer", "reject", "rejectAll"} {
+		if _, ok := table[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isLibraryDeclaration(table map[string]interface{}) bool {
+	for _, key := range []string{"module", "group", "name"} {
+		if _, ok := table[key].(string); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func asMap(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+// normalizeGradleAlias returns the alias as it is accessed from a build script: "-", "_" and "." are all separators.
+func normalizeGradleAlias(alias string) string {
+	return strings.NewReplacer("-", ".", "_", ".").Replace(alias)
+}
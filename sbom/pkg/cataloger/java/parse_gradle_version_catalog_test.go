@@ -0,0 +1,55 @@
+package java
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseGradleVersionCatalog(t *testing.T) {
+	f, err := os.Open("test-fixtures/gradle-build/gradle/libs.versions.toml")
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+
+	catalog, err := parseGradleVersionCatalog(f)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]gradleDependency{
+		"guava": {
+			Group:   "com.google.guava",
+			Name:    "guava",
+			Version: "32.1.1-jre",
+		},
+		"jackson.databind": {
+			Group:   "com.fasterxml.jackson.core",
+			Name:    "jackson-databind",
+			Version: "2.15.2",
+		},
+		"jackson.annotations": {
+			Group:   "com.fasterxml.jackson.core",
+			Name:    "jackson-annotations",
+			Version: "2.15.2",
+		},
+		// rich versions are reported by their strictest constraint
+		"junit.jupiter": {
+			Group:   "org.junit.jupiter",
+			Name:    "junit-jupiter",
+			Version: "5.9.3",
+		},
+	}, catalog.libraries)
+
+	dep, ok := catalog.library("junit.jupiter")
+	assert.True(t, ok)
+	assert.Equal(t, "junit-jupiter", dep.Name)
+
+	_, ok = catalog.library("spring.boot")
+	assert.False(t, ok)
+
+	var names []string
+	for _, d := range catalog.bundle("jackson") {
+		names = append(names, d.Name)
+	}
+	assert.Equal(t, []string{"jackson-databind", "jackson-annotations"}, names)
+}
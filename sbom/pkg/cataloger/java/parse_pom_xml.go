@@ -11,37 +11,192 @@ import (
 	"github.com/vifraa/gopom"
 	"golang.org/x/net/html/charset"
 
+	"github.com/nextlinux/sbom/internal"
 	"github.com/nextlinux/sbom/sbom/artifact"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
 	"github.com/nextlinux/sbom/sbom/source"
 )
 
-const pomXMLGlob = "*pom.xml"
+const (
+	pomXMLGlob    = "*pom.xml"
+	pomXMLDirGlob = "**/pom.xml"
+)
 
 var propertyMatcher = regexp.MustCompile("[$][{][^}]+[}]")
 
-func parserPomXML(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+// parsePomXML catalogs the dependencies declared in a pom.xml file. Dependencies of dependencies are followed when
+// their POMs can be found, in which case each transitive dependency is related to the dependency that pulled it in.
+// The project described by the pom.xml is not reported as a package, so dependencies declared directly by the POM have
+// no dependency-of relationship.
+func (r *mavenResolver) parsePomXML(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	pom, err := decodePomXML(reader)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	project := r.project(pom, &reader.Location)
+	primary := reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)
+	supporting := reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation)
+
 	var pkgs []pkg.Package
-	for _, dep := range pom.Dependencies {
-		p := newPackageFromPom(
-			pom,
-			dep,
-			reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
-		)
-		if p.Name == "" {
+	var relationships []artifact.Relationship
+
+	type node struct {
+		pkg        *pkg.Package
+		project    *mavenProject
+		scope      string
+		exclusions []gopom.Exclusion
+		depth      int
+	}
+
+	// walk the graph breadth-first so the nearest declaration of an artifact wins (as with maven version mediation)
+	seen := make(map[string]pkg.Package)
+	queue := []node{{project: project}}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		for _, dep := range r.dependencies(n.project) {
+			scope := dep.Scope
+			if n.depth > 0 {
+				if dep.Optional == "true" || isExcluded(dep, n.exclusions) {
+					continue
+				}
+				if scope = transitiveScope(n.scope, dep.Scope); scope == "" {
+					continue
+				}
+			}
+
+			key := dep.GroupID + ":" + dep.ArtifactID
+			p, ok := seen[key]
+			if !ok {
+				// dependencies declared in the POM are primary evidence, while transitive dependencies are supporting
+				location := supporting
+				if n.depth == 0 {
+					location = primary
+				}
+				p = newPackageFromDependency(dep, scope, location)
+				if p.Name == "" {
+					continue
+				}
+				seen[key] = p
+				pkgs = append(pkgs, p)
+
+				if n.depth < maxMavenTransitiveDepth {
+					id := mavenID{GroupID: dep.GroupID, ArtifactID: dep.ArtifactID, Version: dep.Version}
+					if child := r.findProject(id, 0); child != nil {
+						queue = append(queue, node{
+							pkg:        &p,
+							project:    child,
+							scope:      scope,
+							exclusions: append(append([]gopom.Exclusion{}, n.exclusions...), dep.Exclusions...),
+							depth:      n.depth + 1,
+						})
+					}
+				}
+			}
+
+			if n.pkg != nil {
+				relationships = append(relationships, artifact.Relationship{
+					From: p,
+					To:   *n.pkg,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+
+	return pkgs, relationships, nil
+}
+
+// mergeMavenPackages merges packages with the same coordinates (groupId:artifactId:version) found by separate POMs
+// (e.g. the modules of a multi-module project that share dependencies) into a single package with all locations.
+// Relationships are updated to refer to the merged packages.
+func mergeMavenPackages(pkgs []pkg.Package, relationships []artifact.Relationship) ([]pkg.Package, []artifact.Relationship) {
+	var merged []*pkg.Package
+	byKey := make(map[string]*pkg.Package)
+	byID := make(map[artifact.ID]*pkg.Package)
+	declared := internal.NewStringSet()
+	for i := range pkgs {
+		p := pkgs[i]
+		key := mavenPackageKey(p)
+		existing, ok := byKey[key]
+		if !ok {
+			existing = &p
+			byKey[key] = existing
+			merged = append(merged, existing)
+			if isDeclaredMavenPackage(p) {
+				declared.Add(key)
+			}
+		} else {
+			existing.Locations.Add(p.Locations.ToSlice()...)
+			// a dependency declared directly by a POM takes precedence over the same dependency pulled in transitively
+			if !declared.Contains(key) && isDeclaredMavenPackage(p) {
+				existing.Metadata = p.Metadata
+				declared.Add(key)
+			}
+		}
+		byID[p.ID()] = existing
+	}
+
+	results := make([]pkg.Package, 0, len(merged))
+	for _, p := range merged {
+		p.SetID()
+		results = append(results, *p)
+	}
+
+	seen := internal.NewStringSet()
+	var mergedRelationships []artifact.Relationship
+	for _, r := range relationships {
+		from, fromOK := byID[r.From.ID()]
+		to, toOK := byID[r.To.ID()]
+		if !fromOK || !toOK || from == to {
+			continue
+		}
+		key := fmt.Sprintf("%s:%s:%s", from.ID(), to.ID(), r.Type)
+		if seen.Contains(key) {
 			continue
 		}
+		seen.Add(key)
+		mergedRelationships = append(mergedRelationships, artifact.Relationship{
+			From: *from,
+			To:   *to,
+			Type: r.Type,
+			Data: r.Data,
+		})
+	}
+
+	return results, mergedRelationships
+}
+
+func mavenPackageKey(p pkg.Package) string {
+	var groupID string
+	if m, ok := p.Metadata.(pkg.JavaMetadata); ok && m.PomProperties != nil {
+		groupID = m.PomProperties.GroupID
+	}
+	return fmt.Sprintf("%s:%s:%s", groupID, p.Name, p.Version)
+}
 
-		pkgs = append(pkgs, p)
+// isDeclaredMavenPackage indicates if the package was declared directly by a POM (as opposed to being a transitive
+// dependency), which is recorded as primary evidence on its location.
+func isDeclaredMavenPackage(p pkg.Package) bool {
+	for _, l := range p.Locations.ToSlice() {
+		if l.Annotations[pkg.EvidenceAnnotationKey] == pkg.PrimaryEvidenceAnnotation {
+			return true
+		}
 	}
+	return false
+}
 
-	return pkgs, nil, nil
+// isExcluded indicates if the dependency matches any of the given exclusions (which may use "*" wildcards).
+func isExcluded(dep gopom.Dependency, exclusions []gopom.Exclusion) bool {
+	for _, e := range exclusions {
+		if (e.GroupID == "*" || e.GroupID == dep.GroupID) && (e.ArtifactID == "*" || e.ArtifactID == dep.ArtifactID) {
+			return true
+		}
+	}
+	return false
 }
 
 func parsePomXMLProject(path string, reader io.Reader) (*pkg.PomProject, error) {
@@ -65,15 +220,17 @@ func newPomProject(path string, p gopom.Project) *pkg.PomProject {
 	}
 }
 
-func newPackageFromPom(pom gopom.Project, dep gopom.Dependency, locations ...source.Location) pkg.Package {
+// newPackageFromDependency creates a package for a dependency declared in a POM (with properties already resolved).
+func newPackageFromDependency(dep gopom.Dependency, scope string, locations ...source.Location) pkg.Package {
 	m := pkg.JavaMetadata{
 		PomProperties: &pkg.PomProperties{
-			GroupID: resolveProperty(pom, dep.GroupID),
+			GroupID: dep.GroupID,
+			Scope:   scope,
 		},
 	}
 
 	name := dep.ArtifactID
-	version := resolveProperty(pom, dep.Version)
+	version := dep.Version
 
 	p := pkg.Package{
 		Name:         name,
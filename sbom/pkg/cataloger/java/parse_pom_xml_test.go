@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vifraa/gopom"
 
 	"github.com/nextlinux/sbom/sbom/pkg"
@@ -28,7 +29,7 @@ func Test_parserPomXML(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "com.joda"},
+						PomProperties: &pkg.PomProperties{GroupID: "com.joda", Scope: "compile"},
 					},
 				},
 				{
@@ -39,7 +40,7 @@ func Test_parserPomXML(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "junit"},
+						PomProperties: &pkg.PomProperties{GroupID: "junit", Scope: "test"},
 					},
 				},
 			},
@@ -51,7 +52,7 @@ func Test_parserPomXML(t *testing.T) {
 			for i := range test.expected {
 				test.expected[i].Locations.Add(source.NewLocation(test.input))
 			}
-			pkgtest.TestFileParser(t, test.input, parserPomXML, test.expected, nil)
+			pkgtest.TestFileParser(t, test.input, newMavenResolver(nil, MavenConfig{}).parsePomXML, test.expected, nil)
 		})
 	}
 }
@@ -72,7 +73,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "org.apache.commons"},
+						PomProperties: &pkg.PomProperties{GroupID: "org.apache.commons", Scope: "compile"},
 					},
 				},
 				{
@@ -83,7 +84,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "org.junit.jupiter"},
+						PomProperties: &pkg.PomProperties{GroupID: "org.junit.jupiter", Scope: "test"},
 					},
 				},
 				{
@@ -94,7 +95,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "org.assertj"},
+						PomProperties: &pkg.PomProperties{GroupID: "org.assertj", Scope: "test"},
 					},
 				},
 				{
@@ -105,7 +106,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "commons-io"},
+						PomProperties: &pkg.PomProperties{GroupID: "commons-io", Scope: "test"},
 					},
 				},
 				{
@@ -116,7 +117,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "org.mockito"},
+						PomProperties: &pkg.PomProperties{GroupID: "org.mockito", Scope: "test"},
 					},
 				},
 				{
@@ -127,7 +128,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "org.graalvm.js"},
+						PomProperties: &pkg.PomProperties{GroupID: "org.graalvm.js", Scope: "test"},
 					},
 				},
 				{
@@ -138,7 +139,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "org.graalvm.js"},
+						PomProperties: &pkg.PomProperties{GroupID: "org.graalvm.js", Scope: "test"},
 					},
 				},
 				{
@@ -149,7 +150,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "org.apache.commons"},
+						PomProperties: &pkg.PomProperties{GroupID: "org.apache.commons", Scope: "test"},
 					},
 				},
 				{
@@ -160,7 +161,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "org.openjdk.jmh"},
+						PomProperties: &pkg.PomProperties{GroupID: "org.openjdk.jmh", Scope: "test"},
 					},
 				},
 				{
@@ -171,7 +172,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 					Type:         pkg.JavaPkg,
 					MetadataType: pkg.JavaMetadataType,
 					Metadata: pkg.JavaMetadata{
-						PomProperties: &pkg.PomProperties{GroupID: "org.openjdk.jmh"},
+						PomProperties: &pkg.PomProperties{GroupID: "org.openjdk.jmh", Scope: "test"},
 					},
 				},
 			},
@@ -183,7 +184,7 @@ func Test_parseCommonsTextPomXMLProject(t *testing.T) {
 			for i := range test.expected {
 				test.expected[i].Locations.Add(source.NewLocation(test.input))
 			}
-			pkgtest.TestFileParser(t, test.input, parserPomXML, test.expected, nil)
+			pkgtest.TestFileParser(t, test.input, newMavenResolver(nil, MavenConfig{}).parsePomXML, test.expected, nil)
 		})
 	}
 }
@@ -346,3 +347,18 @@ func Test_resolveProperty(t *testing.T) {
 		})
 	}
 }
+
+func Test_mergeMavenPackages(t *testing.T) {
+	dep := gopom.Dependency{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.7"}
+	transitive := newPackageFromDependency(dep, "runtime", source.NewLocation("/app/pom.xml").WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+	declared := newPackageFromDependency(dep, "compile", source.NewLocation("/lib/pom.xml").WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation))
+	declaredTest := newPackageFromDependency(dep, "test", source.NewLocation("/test/pom.xml").WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation))
+
+	// the first declaration wins over transitive dependencies and later declarations
+	merged, _ := mergeMavenPackages([]pkg.Package{transitive, declared, declaredTest}, nil)
+	require.Len(t, merged, 1)
+	assert.Len(t, merged[0].Locations.ToSlice(), 3)
+	metadata, ok := merged[0].Metadata.(pkg.JavaMetadata)
+	require.True(t, ok)
+	assert.Equal(t, "compile", metadata.PomProperties.Scope)
+}
@@ -0,0 +1,169 @@
+package java
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal/file"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/pkg"
+)
+
+// Spring Boot writes the indexes to BOOT-INF for executable jars and WEB-INF for executable wars
+var (
+	springBootLayersIndexGlobs    = []string{"/BOOT-INF/layers.idx", "/WEB-INF/layers.idx"}
+	springBootClasspathIndexGlobs = []string{"/BOOT-INF/classpath.idx", "/WEB-INF/classpath.idx"}
+)
+
+const (
+	// these are the layers that Spring Boot uses when no custom layer configuration is provided
+	springBootDependenciesLayer         = "dependencies"
+	springBootSnapshotDependenciesLayer = "snapshot-dependencies"
+)
+
+// springBootLayerEntry is a single path (or directory prefix, when ending in "/") assigned to a layer in layers.idx.
+type springBootLayerEntry struct {
+	layer string
+	path  string
+}
+
+// springBootLayers describes which layer each path within a Spring Boot archive belongs to.
+type springBootLayers []springBootLayerEntry
+
+// layerOf returns the layer that the given path within the archive belongs to (or an empty string if it is not known).
+func (l springBootLayers) layerOf(pathWithinArchive string) string {
+	pathWithinArchive = strings.TrimPrefix(pathWithinArchive, "/")
+
+	var layer string
+	var longest int
+	for _, e := range l {
+		matches := e.path == pathWithinArchive || (strings.HasSuffix(e.path, "/") && strings.HasPrefix(pathWithinArchive, e.path))
+		if matches && len(e.path) > longest {
+			layer = e.layer
+			longest = len(e.path)
+		}
+	}
+	return layer
+}
+
+// discoverSpringBootLayers reads the layer index of a Spring Boot archive. When the archive was not built with layers
+// enabled the classpath index is used instead, with the nested jars assigned to the default dependency layers.
+func (j *archiveParser) discoverSpringBootLayers() springBootLayers {
+	if matches := j.fileManifest.GlobMatch(springBootLayersIndexGlobs...); len(matches) > 0 {
+		contents, err := file.ContentsFromZip(j.archivePath, matches[0])
+		if err != nil {
+			log.WithFields("location", j.location.AccessPath(), "error", err).Debug("unable to extract spring boot layers index")
+			return nil
+		}
+		layers, err := parseSpringBootLayersIndex(strings.NewReader(contents[matches[0]]))
+		if err != nil {
+			log.WithFields("location", j.location.AccessPath(), "error", err).Debug("unable to parse spring boot layers index")
+		}
+		return layers
+	}
+
+	if matches := j.fileManifest.GlobMatch(springBootClasspathIndexGlobs...); len(matches) > 0 {
+		contents, err := file.ContentsFromZip(j.archivePath, matches[0])
+		if err != nil {
+			log.WithFields("location", j.location.AccessPath(), "error", err).Debug("unable to extract spring boot classpath index")
+			return nil
+		}
+		layers, err := parseSpringBootClasspathIndex(strings.NewReader(contents[matches[0]]))
+		if err != nil {
+			log.WithFields("location", j.location.AccessPath(), "error", err).Debug("unable to parse spring boot classpath index")
+		}
+		return layers
+	}
+
+	return nil
+}
+
+// parseSpringBootLayersIndex parses a Spring Boot layers.idx file, which lists each layer as a top level entry (e.g.
+// `- "dependencies":`) followed by the indented paths that belong to it (e.g. `  - "BOOT-INF/lib/"`). Paths ending in
+// "/" include everything within that directory.
+func parseSpringBootLayersIndex(reader io.Reader) (springBootLayers, error) {
+	var layers springBootLayers
+	var layer string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+		value := springBootIndexValue(trimmed)
+
+		if !strings.HasPrefix(line, " ") {
+			// a top level entry starts a new layer
+			layer = value
+			continue
+		}
+
+		if layer == "" || value == "" {
+			continue
+		}
+		layers = append(layers, springBootLayerEntry{layer: layer, path: value})
+	}
+	return layers, scanner.Err()
+}
+
+// parseSpringBootClasspathIndex parses a Spring Boot classpath.idx file, which lists each nested jar in classpath
+// order (e.g. `- "BOOT-INF/lib/spring-core-5.3.23.jar"`). Each jar is assigned to the layer that Spring Boot would
+// place it in by default.
+func parseSpringBootClasspathIndex(reader io.Reader) (springBootLayers, error) {
+	var layers springBootLayers
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		trimmed := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(trimmed, "- ") {
+			continue
+		}
+		value := springBootIndexValue(trimmed)
+		if value == "" {
+			continue
+		}
+
+		layer := springBootDependenciesLayer
+		if strings.Contains(value, "SNAPSHOT") {
+			layer = springBootSnapshotDependenciesLayer
+		}
+		layers = append(layers, springBootLayerEntry{layer: layer, path: value})
+	}
+	return layers, scanner.Err()
+}
+
+// springBootIndexValue returns the (unquoted) value of a list entry within a Spring Boot index file.
+func springBootIndexValue(entry string) string {
+	value := strings.TrimSpace(strings.TrimPrefix(entry, "- "))
+	// layer names are followed by a colon
+	value = strings.TrimSuffix(value, ":")
+	return strings.Trim(value, `"`)
+}
+
+// attributeSpringBootLayers records the layer that each nested package was found in, based on the path of the jar
+// that the package was discovered in within this archive.
+func (j *archiveParser) attributeSpringBootLayers(layers springBootLayers, pkgs []pkg.Package) {
+	if len(layers) == 0 {
+		return
+	}
+
+	prefix := j.location.AccessPath() + ":"
+	for i := range pkgs {
+		p := &pkgs[i]
+		metadata, ok := p.Metadata.(pkg.JavaMetadata)
+		if !ok || metadata.Layer != "" || !strings.HasPrefix(metadata.VirtualPath, prefix) {
+			continue
+		}
+
+		// the virtual path of nested packages is <this archive>:<path within this archive>[:<further nesting>...]
+		pathWithinArchive := strings.SplitN(strings.TrimPrefix(metadata.VirtualPath, prefix), ":", 2)[0]
+		layer := layers.layerOf(pathWithinArchive)
+		if layer == "" {
+			continue
+		}
+		metadata.Layer = layer
+		metadata.LayerScheme = pkg.SpringBootLayerScheme
+		p.Metadata = metadata
+	}
+}
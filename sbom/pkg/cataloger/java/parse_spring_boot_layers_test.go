@@ -0,0 +1,185 @@
+package java
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func Test_parseSpringBootLayersIndex(t *testing.T) {
+	input := `- "dependencies":
+  - "BOOT-INF/lib/"
+- "spring-boot-loader":
+  - "org/"
+- "snapshot-dependencies":
+  - "BOOT-INF/lib/example-lib-1.0.0-SNAPSHOT.jar"
+- "application":
+  - "BOOT-INF/classes/"
+  - "BOOT-INF/classpath.idx"
+  - "BOOT-INF/layers.idx"
+  - "META-INF/"
+`
+	layers, err := parseSpringBootLayersIndex(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, springBootLayers{
+		{layer: "dependencies", path: "BOOT-INF/lib/"},
+		{layer: "spring-boot-loader", path: "org/"},
+		{layer: "snapshot-dependencies", path: "BOOT-INF/lib/example-lib-1.0.0-SNAPSHOT.jar"},
+		{layer: "application", path: "BOOT-INF/classes/"},
+		{layer: "application", path: "BOOT-INF/classpath.idx"},
+		{layer: "application", path: "BOOT-INF/layers.idx"},
+		{layer: "application", path: "META-INF/"},
+	}, layers)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "BOOT-INF/lib/spring-core-5.3.23.jar", want: "dependencies"},
+		// the most specific entry wins
+		{path: "BOOT-INF/lib/example-lib-1.0.0-SNAPSHOT.jar", want: "snapshot-dependencies"},
+		{path: "/BOOT-INF/classes/application.properties", want: "application"},
+		{path: "WEB-INF/lib/spring-core-5.3.23.jar", want: ""},
+		// directory entries must match whole path segments
+		{path: "BOOT-INF/library.jar", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, layers.layerOf(tt.path))
+		})
+	}
+}
+
+func Test_parseSpringBootClasspathIndex(t *testing.T) {
+	input := `- "BOOT-INF/lib/spring-core-5.3.23.jar"
+- "BOOT-INF/lib/example-lib-1.0.0-SNAPSHOT.jar"
+`
+	layers, err := parseSpringBootClasspathIndex(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, springBootLayers{
+		{layer: "dependencies", path: "BOOT-INF/lib/spring-core-5.3.23.jar"},
+		{layer: "snapshot-dependencies", path: "BOOT-INF/lib/example-lib-1.0.0-SNAPSHOT.jar"},
+	}, layers)
+}
+
+func TestParseSpringBootJar_Layers(t *testing.T) {
+	tests := []struct {
+		name  string
+		index map[string]string
+		want  map[string]string
+	}{
+		{
+			name: "layers index",
+			index: map[string]string{
+				"BOOT-INF/layers.idx": `- "dependencies":
+  - "BOOT-INF/lib/spring-core-5.3.23.jar"
+- "snapshot-dependencies":
+  - "BOOT-INF/lib/example-lib-1.0.0-SNAPSHOT.jar"
+- "application":
+  - "BOOT-INF/classes/"
+  - "BOOT-INF/lib/example-app-module-1.0.0.jar"
+  - "META-INF/"
+`,
+			},
+			want: map[string]string{
+				"example-app":        "",
+				"spring-core":        "dependencies",
+				"example-lib":        "snapshot-dependencies",
+				"example-app-module": "application",
+			},
+		},
+		{
+			name: "classpath index",
+			index: map[string]string{
+				"BOOT-INF/classpath.idx": `- "BOOT-INF/lib/spring-core-5.3.23.jar"
+- "BOOT-INF/lib/example-lib-1.0.0-SNAPSHOT.jar"
+- "BOOT-INF/lib/example-app-module-1.0.0.jar"
+`,
+			},
+			want: map[string]string{
+				"example-app":        "",
+				"spring-core":        "dependencies",
+				"example-lib":        "snapshot-dependencies",
+				"example-app-module": "dependencies",
+			},
+		},
+		{
+			name: "not a spring boot archive",
+			want: map[string]string{
+				"example-app":        "",
+				"spring-core":        "",
+				"example-lib":        "",
+				"example-app-module": "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := map[string][]byte{
+				"META-INF/MANIFEST.MF":                        manifestFor("example-app", "1.0.0"),
+				"BOOT-INF/lib/spring-core-5.3.23.jar":         jarWithManifest(t, "spring-core", "5.3.23"),
+				"BOOT-INF/lib/example-lib-1.0.0-SNAPSHOT.jar": jarWithManifest(t, "example-lib", "1.0.0-SNAPSHOT"),
+				"BOOT-INF/lib/example-app-module-1.0.0.jar":   jarWithManifest(t, "example-app-module", "1.0.0"),
+			}
+			for name, contents := range tt.index {
+				entries[name] = []byte(contents)
+			}
+
+			fixturePath := filepath.Join(t.TempDir(), "example-app-1.0.0.jar")
+			require.NoError(t, os.WriteFile(fixturePath, zipOf(t, entries), 0600))
+
+			fixture, err := os.Open(fixturePath)
+			require.NoError(t, err)
+
+			actual, _, err := parseJavaArchive(nil, nil, source.LocationReadCloser{
+				Location:   source.NewLocation(fixture.Name()),
+				ReadCloser: fixture,
+			})
+			require.NoError(t, err)
+
+			got := make(map[string]string)
+			for _, p := range actual {
+				metadata := p.Metadata.(pkg.JavaMetadata)
+				got[p.Name] = metadata.Layer
+				if metadata.Layer != "" {
+					assert.Equal(t, pkg.SpringBootLayerScheme, metadata.LayerScheme)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func manifestFor(name, version string) []byte {
+	return []byte("Manifest-Version: 1.0\nImplementation-Title: " + name + "\nImplementation-Version: " + version + "\n")
+}
+
+func jarWithManifest(t *testing.T, name, version string) []byte {
+	return zipOf(t, map[string][]byte{
+		"META-INF/MANIFEST.MF": manifestFor(name, version),
+	})
+}
+
+func zipOf(t *testing.T, entries map[string][]byte) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, contents := range entries {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write(contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,63 @@
+package java
+
+import (
+	"path"
+	"strings"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// quarkusRunnerJar is the jar found at the root of a Quarkus fast-jar application (quarkus-app/, which the container
+// images built by Quarkus copy to /deployments/)
+const quarkusRunnerJar = "quarkus-run.jar"
+
+// quarkusFastJarLayers are the directories of a Quarkus fast-jar application that jars are placed in, along with the
+// layer each represents: the bootstrap classpath, the runtime dependencies, and the application itself.
+var quarkusFastJarLayers = []struct {
+	dir   string
+	layer string
+}{
+	{dir: "lib/boot", layer: "boot"},
+	{dir: "lib/main", layer: "main"},
+	{dir: "app", layer: "application"},
+}
+
+// quarkusFastJarLayer returns the layer of the given jar when it is part of a Quarkus fast-jar application (or an
+// empty string otherwise), which is identified by the quarkus-run.jar at the root of the application.
+func quarkusFastJarLayer(resolver source.FileResolver, location source.Location) string {
+	if resolver == nil {
+		return ""
+	}
+
+	dir := path.Dir(location.RealPath)
+	for _, l := range quarkusFastJarLayers {
+		if !strings.HasSuffix(dir, "/"+l.dir) {
+			continue
+		}
+		runner := path.Join(strings.TrimSuffix(dir, l.dir), quarkusRunnerJar)
+		if locations, err := resolver.FilesByPath(runner); err == nil && len(locations) > 0 {
+			return l.layer
+		}
+	}
+	return ""
+}
+
+// attributeQuarkusLayer records the Quarkus fast-jar layer on all packages found within the archive (unless already
+// attributed to a layer of a nested archive).
+func attributeQuarkusLayer(layer string, pkgs []pkg.Package) {
+	if layer == "" {
+		return
+	}
+
+	for i := range pkgs {
+		p := &pkgs[i]
+		metadata, ok := p.Metadata.(pkg.JavaMetadata)
+		if !ok || metadata.Layer != "" {
+			continue
+		}
+		metadata.Layer = layer
+		metadata.LayerScheme = pkg.QuarkusFastJarLayerScheme
+		p.Metadata = metadata
+	}
+}
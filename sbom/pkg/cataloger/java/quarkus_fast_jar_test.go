@@ -0,0 +1,56 @@
+package java
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestJavaCataloger_QuarkusFastJarLayers(t *testing.T) {
+	root := t.TempDir()
+	jars := map[string][]byte{
+		"quarkus-app/quarkus-run.jar":                            jarWithManifest(t, "quarkus-run", "1.0.0"),
+		"quarkus-app/app/example-app-1.0.0.jar":                  jarWithManifest(t, "example-app", "1.0.0"),
+		"quarkus-app/lib/boot/quarkus-bootstrap-runner.jar":      jarWithManifest(t, "quarkus-bootstrap-runner", "3.2.0"),
+		"quarkus-app/lib/main/io.quarkus.quarkus-core-3.2.0.jar": jarWithManifest(t, "quarkus-core", "3.2.0"),
+		// there is no quarkus-run.jar at the root of this directory, so it is not a Quarkus application
+		"other/lib/main/commons-lang3-3.12.0.jar": jarWithManifest(t, "commons-lang3", "3.12.0"),
+	}
+	for name, contents := range jars {
+		p := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, contents, 0600))
+	}
+
+	src, err := source.NewFromDirectory(root)
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, _, err := NewJavaCataloger(Config{}).Catalog(resolver)
+	require.NoError(t, err)
+
+	got := make(map[string]string)
+	for _, p := range pkgs {
+		metadata := p.Metadata.(pkg.JavaMetadata)
+		got[p.Name] = metadata.Layer
+		if metadata.Layer != "" {
+			assert.Equal(t, pkg.QuarkusFastJarLayerScheme, metadata.LayerScheme)
+		}
+	}
+
+	assert.Equal(t, map[string]string{
+		"quarkus-run":              "",
+		"example-app":              "application",
+		"quarkus-bootstrap-runner": "boot",
+		"io.quarkus.quarkus-core":  "main",
+		"commons-lang3":            "",
+	}, got)
+}
@@ -0,0 +1,17 @@
+plugins {
+    java
+    alias(libs.plugins.spring.boot)
+}
+
+val slf4jVersion = "2.0.7"
+
+dependencies {
+    implementation(project(":lib"))
+    implementation(libs.guava)
+    implementation(libs.bundles.jackson)
+    implementation(platform("org.springframework.boot:spring-boot-dependencies:3.1.2"))
+    implementation("org.springframework.boot:spring-boot-starter-web")
+    runtimeOnly("org.slf4j:slf4j-simple:$slf4jVersion")
+    // implementation("commons-io:commons-io:2.13.0")
+    testImplementation(libs.junit.jupiter)
+}
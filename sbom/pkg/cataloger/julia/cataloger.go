@@ -0,0 +1,21 @@
+/*
+Package julia provides a concrete Cataloger implementation for Julia Manifest.toml and Project.toml files.
+*/
+package julia
+
+import (
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+)
+
+// NewManifestCataloger returns a new cataloger for Julia packages resolved within Manifest.toml files.
+func NewManifestCataloger() *generic.Cataloger {
+	return generic.NewCataloger("julia-manifest-cataloger").
+		WithParserByGlobs(parseManifest, "**/Manifest.toml", "**/JuliaManifest.toml", "**/Manifest-v*.toml")
+}
+
+// NewProjectCataloger returns a new cataloger for Julia packages described by their own Project.toml file (e.g.
+// packages installed within a depot).
+func NewProjectCataloger() *generic.Cataloger {
+	return generic.NewCataloger("julia-project-cataloger").
+		WithParserByGlobs(parseProject, "**/Project.toml", "**/JuliaProject.toml")
+}
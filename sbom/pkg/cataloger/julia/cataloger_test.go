@@ -0,0 +1,60 @@
+package julia
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+)
+
+func Test_ManifestCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain manifest files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/Manifest.toml",
+				"src/JuliaManifest.toml",
+				"src/Manifest-v1.10.toml",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewManifestCataloger())
+		})
+	}
+}
+
+func Test_ProjectCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain project files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/Project.toml",
+				"src/pkg/JuliaProject.toml",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewProjectCataloger())
+		})
+	}
+}
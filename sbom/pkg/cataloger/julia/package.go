@@ -0,0 +1,34 @@
+package julia
+
+import (
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func newPackage(name, version string, m pkg.JuliaPackageMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(name, version, m.UUID),
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// packageURL returns the package URL for a Julia package, where the UUID qualifier identifies the package (the name
+// alone is not unique across registries).
+func packageURL(name, version, uuid string) string {
+	var qualifiers packageurl.Qualifiers
+	if uuid != "" {
+		qualifiers = packageurl.Qualifiers{{Key: "uuid", Value: uuid}}
+	}
+	return packageurl.NewPackageURL(pkg.JuliaPkg.PackageURLType(), "", name, version, qualifiers, "").ToString()
+}
@@ -0,0 +1,162 @@
+package julia
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseManifest
+
+// manifestEntry is a single resolved package within a manifest, along with the dependencies that it was resolved with.
+type manifestEntry struct {
+	name string
+	uuid string
+	// deps maps the name of each dependency to its UUID (which is only recorded when the name alone is ambiguous)
+	deps map[string]string
+	pkg  *pkg.Package
+}
+
+// parseManifest is a parser function for Manifest.toml contents, returning all resolved Julia packages along with the
+// relationships between them. Standard library packages (which are bundled with Julia itself) are not reported.
+func parseManifest(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load Manifest.toml for parsing: %w", err)
+	}
+
+	// manifests written by Julia 1.7 and later nest the packages within "deps", while older manifests have each
+	// package at the top level
+	packages := tree
+	if tree.Has("manifest_format") {
+		deps, ok := tree.Get("deps").(*toml.Tree)
+		if !ok {
+			return nil, nil, nil
+		}
+		packages = deps
+	}
+
+	names := packages.Keys()
+	sort.Strings(names)
+
+	var entries []manifestEntry
+	for _, name := range names {
+		tables, ok := packages.GetPath([]string{name}).([]*toml.Tree)
+		if !ok {
+			continue
+		}
+		for _, table := range tables {
+			entries = append(entries, newManifestEntry(name, table, reader.Location))
+		}
+	}
+
+	var pkgs []pkg.Package
+	for _, e := range entries {
+		if e.pkg != nil {
+			pkgs = append(pkgs, *e.pkg)
+		}
+	}
+
+	return pkgs, manifestRelationships(entries), nil
+}
+
+func newManifestEntry(name string, table *toml.Tree, location source.Location) manifestEntry {
+	e := manifestEntry{
+		name: name,
+		uuid: tomlString(table, "uuid"),
+		deps: make(map[string]string),
+	}
+
+	switch deps := table.Get("deps").(type) {
+	case []interface{}:
+		for _, d := range deps {
+			if depName, ok := d.(string); ok {
+				e.deps[depName] = ""
+			}
+		}
+	case *toml.Tree:
+		for _, depName := range deps.Keys() {
+			uuid, _ := deps.GetPath([]string{depName}).(string)
+			e.deps[depName] = uuid
+		}
+	}
+
+	m := pkg.JuliaPackageMetadata{
+		UUID:        e.uuid,
+		GitTreeSHA1: tomlString(table, "git-tree-sha1"),
+		RepoURL:     tomlString(table, "repo-url"),
+		RepoRev:     tomlString(table, "repo-rev"),
+		Path:        tomlString(table, "path"),
+		Deps:        sortedKeys(e.deps),
+	}
+
+	// standard library packages are not obtained from anywhere other than the Julia installation
+	if m.GitTreeSHA1 == "" && m.RepoURL == "" && m.Path == "" {
+		return e
+	}
+
+	p := newPackage(name, tomlString(table, "version"), m,
+		location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+	)
+	e.pkg = &p
+	return e
+}
+
+// manifestRelationships relates each package within a manifest to the packages that it depends on. Dependencies are
+// identified by name, unless the manifest records the UUID of the dependency.
+func manifestRelationships(entries []manifestEntry) []artifact.Relationship {
+	byName := make(map[string][]manifestEntry)
+	for _, e := range entries {
+		if e.pkg != nil {
+			byName[e.name] = append(byName[e.name], e)
+		}
+	}
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	for _, e := range entries {
+		if e.pkg == nil {
+			continue
+		}
+		for _, depName := range sortedKeys(e.deps) {
+			uuid := e.deps[depName]
+			for _, dep := range byName[depName] {
+				key := string(dep.pkg.ID()) + ":" + string(e.pkg.ID())
+				if (uuid != "" && dep.uuid != uuid) || seen.Contains(key) {
+					continue
+				}
+				seen.Add(key)
+				relationships = append(relationships, artifact.Relationship{
+					From: *dep.pkg,
+					To:   *e.pkg,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+	return relationships
+}
+
+func tomlString(tree *toml.Tree, key string) string {
+	value, _ := tree.GetPath([]string{key}).(string)
+	return value
+}
+
+func sortedKeys(m map[string]string) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,182 @@
+package julia
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseManifest(t *testing.T) {
+	fixture := "test-fixtures/Manifest.toml"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	example := pkg.Package{
+		Name:         "Example",
+		Version:      "0.5.3",
+		PURL:         "pkg:julia/Example@0.5.3?uuid=7876af07-990d-54b4-ab0e-23690620f79a",
+		Locations:    locations,
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata: pkg.JuliaPackageMetadata{
+			UUID:        "7876af07-990d-54b4-ab0e-23690620f79a",
+			GitTreeSHA1: "46e44e869b4d90b96bd8ed1fdcf32244fddfb6cc",
+		},
+	}
+	json := pkg.Package{
+		Name:         "JSON",
+		Version:      "0.21.4",
+		PURL:         "pkg:julia/JSON@0.21.4?uuid=682c06a0-de6a-54ab-a142-c8b1cf79cde6",
+		Locations:    locations,
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata: pkg.JuliaPackageMetadata{
+			UUID:        "682c06a0-de6a-54ab-a142-c8b1cf79cde6",
+			GitTreeSHA1: "31e996f0a15c7b280ba9f76636b3ff9e2ae58c9a",
+			Deps:        []string{"Dates", "Mmap", "Parsers", "Unicode"},
+		},
+	}
+	myLocalPkg := pkg.Package{
+		Name:         "MyLocalPkg",
+		Version:      "0.1.0",
+		PURL:         "pkg:julia/MyLocalPkg@0.1.0?uuid=0b9f1d4e-2d49-4b8f-9c6a-3f1f0f7b1b2a",
+		Locations:    locations,
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata: pkg.JuliaPackageMetadata{
+			UUID: "0b9f1d4e-2d49-4b8f-9c6a-3f1f0f7b1b2a",
+			Path: "dev/MyLocalPkg",
+			Deps: []string{"Example"},
+		},
+	}
+	parsers := pkg.Package{
+		Name:         "Parsers",
+		Version:      "2.7.2",
+		PURL:         "pkg:julia/Parsers@2.7.2?uuid=69de0a69-1ddd-5017-9359-2bf0b02dc9f0",
+		Locations:    locations,
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata: pkg.JuliaPackageMetadata{
+			UUID:        "69de0a69-1ddd-5017-9359-2bf0b02dc9f0",
+			GitTreeSHA1: "716e24b21538abc91f6205fd1d8363f39b442851",
+			Deps:        []string{"Dates", "PrecompileTools", "UUIDs"},
+		},
+	}
+	precompileTools := pkg.Package{
+		Name:         "PrecompileTools",
+		Version:      "1.2.0",
+		PURL:         "pkg:julia/PrecompileTools@1.2.0?uuid=aea7be01-6a6a-4083-8856-8a6e6704d82a",
+		Locations:    locations,
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata: pkg.JuliaPackageMetadata{
+			UUID:        "aea7be01-6a6a-4083-8856-8a6e6704d82a",
+			GitTreeSHA1: "03b4c25b43cb84cee5c90aa9b5ea0a78fd848d2f",
+			RepoURL:     "https://github.com/JuliaLang/PrecompileTools.jl.git",
+			RepoRev:     "main",
+			Deps:        []string{"Preferences"},
+		},
+	}
+	preferences := pkg.Package{
+		Name:         "Preferences",
+		Version:      "1.4.1",
+		PURL:         "pkg:julia/Preferences@1.4.1?uuid=21216c6a-2e73-6563-6e65-726566657250",
+		Locations:    locations,
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata: pkg.JuliaPackageMetadata{
+			UUID:        "21216c6a-2e73-6563-6e65-726566657250",
+			GitTreeSHA1: "00805cd429dcb4870060ff49ef443486c262e38e",
+			Deps:        []string{"TOML"},
+		},
+	}
+
+	// note: standard library packages (Dates, Printf and Unicode) are not expected
+	expectedPkgs := []pkg.Package{example, json, myLocalPkg, parsers, precompileTools, preferences}
+	expectedRelationships := []artifact.Relationship{
+		{From: parsers, To: json, Type: artifact.DependencyOfRelationship},
+		{From: example, To: myLocalPkg, Type: artifact.DependencyOfRelationship},
+		{From: precompileTools, To: parsers, Type: artifact.DependencyOfRelationship},
+		{From: preferences, To: precompileTools, Type: artifact.DependencyOfRelationship},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseManifest, expectedPkgs, expectedRelationships)
+}
+
+func TestParseManifest_V1Format(t *testing.T) {
+	fixture := "test-fixtures/manifest-v1/Manifest.toml"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	consumer := pkg.Package{
+		Name:         "Consumer",
+		Version:      "0.1.0",
+		PURL:         "pkg:julia/Consumer@0.1.0?uuid=e8a1b2c3-d4e5-4f60-8a7b-9c0d1e2f3a4b",
+		Locations:    locations,
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata: pkg.JuliaPackageMetadata{
+			UUID:        "e8a1b2c3-d4e5-4f60-8a7b-9c0d1e2f3a4b",
+			GitTreeSHA1: "5c8e7ab3b2f7d8a0e4c6b9a1d3f5e7c9b1a3d5f7",
+			Deps:        []string{"Example", "Tables"},
+		},
+	}
+	example := pkg.Package{
+		Name:         "Example",
+		Version:      "0.5.3",
+		PURL:         "pkg:julia/Example@0.5.3?uuid=7876af07-990d-54b4-ab0e-23690620f79a",
+		Locations:    locations,
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata: pkg.JuliaPackageMetadata{
+			UUID:        "7876af07-990d-54b4-ab0e-23690620f79a",
+			GitTreeSHA1: "46e44e869b4d90b96bd8ed1fdcf32244fddfb6cc",
+		},
+	}
+	// a different package with the same name (from another registry)
+	otherExample := pkg.Package{
+		Name:         "Example",
+		Version:      "1.0.0",
+		PURL:         "pkg:julia/Example@1.0.0?uuid=4d1b2e3f-0a9b-4c8d-8e7f-6a5b4c3d2e1f",
+		Locations:    locations,
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata: pkg.JuliaPackageMetadata{
+			UUID:        "4d1b2e3f-0a9b-4c8d-8e7f-6a5b4c3d2e1f",
+			GitTreeSHA1: "d2b1ff1d5e8b3d96e4f0b2a8c9e5f4a3b2c1d0e9",
+		},
+	}
+	tables := pkg.Package{
+		Name:         "Tables",
+		Version:      "1.11.0",
+		PURL:         "pkg:julia/Tables@1.11.0?uuid=bd369af6-aec1-5ad0-b16a-f7cc5008161c",
+		Locations:    locations,
+		Language:     pkg.Julia,
+		Type:         pkg.JuliaPkg,
+		MetadataType: pkg.JuliaPackageMetadataType,
+		Metadata: pkg.JuliaPackageMetadata{
+			UUID:        "bd369af6-aec1-5ad0-b16a-f7cc5008161c",
+			GitTreeSHA1: "a1f34829d5ac0ef499f6d84428bd6b4c71f02ead",
+			Deps:        []string{"Markdown"},
+		},
+	}
+
+	expectedPkgs := []pkg.Package{consumer, example, otherExample, tables}
+	expectedRelationships := []artifact.Relationship{
+		// the UUID of the dependency selects between the packages named "Example"
+		{From: otherExample, To: consumer, Type: artifact.DependencyOfRelationship},
+		{From: tables, To: consumer, Type: artifact.DependencyOfRelationship},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseManifest, expectedPkgs, expectedRelationships)
+}
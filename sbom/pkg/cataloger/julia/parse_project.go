@@ -0,0 +1,53 @@
+package julia
+
+import (
+	"fmt"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseProject
+
+type project struct {
+	Name    string            `toml:"name"`
+	UUID    string            `toml:"uuid"`
+	Version string            `toml:"version"`
+	Authors []string          `toml:"authors"`
+	Deps    map[string]string `toml:"deps"`
+}
+
+// parseProject is a parser function for Project.toml contents, returning the package that the project describes.
+// Projects without a name and UUID are environments (e.g. ~/.julia/environments/v1.9) rather than packages, and are
+// not reported.
+func parseProject(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load Project.toml for parsing: %w", err)
+	}
+
+	var proj project
+	if err := tree.Unmarshal(&proj); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse Project.toml: %w", err)
+	}
+
+	if proj.Name == "" || proj.UUID == "" {
+		return nil, nil, nil
+	}
+
+	m := pkg.JuliaPackageMetadata{
+		UUID:    proj.UUID,
+		Authors: proj.Authors,
+		Deps:    sortedKeys(proj.Deps),
+	}
+
+	p := newPackage(proj.Name, proj.Version, m,
+		reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+	)
+
+	return []pkg.Package{p}, nil, nil
+}
@@ -0,0 +1,35 @@
+package julia
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseProject(t *testing.T) {
+	fixture := "test-fixtures/depot/packages/JSON/9NBv4/Project.toml"
+	expectedPkgs := []pkg.Package{
+		{
+			Name:         "JSON",
+			Version:      "0.21.4",
+			PURL:         "pkg:julia/JSON@0.21.4?uuid=682c06a0-de6a-54ab-a142-c8b1cf79cde6",
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Language:     pkg.Julia,
+			Type:         pkg.JuliaPkg,
+			MetadataType: pkg.JuliaPackageMetadataType,
+			Metadata: pkg.JuliaPackageMetadata{
+				UUID: "682c06a0-de6a-54ab-a142-c8b1cf79cde6",
+				Deps: []string{"Dates", "Mmap", "Parsers", "Unicode"},
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseProject, expectedPkgs, nil)
+}
+
+func TestParseProject_Environment(t *testing.T) {
+	// environments only declare dependencies, thus are not packages themselves
+	pkgtest.TestFileParser(t, "test-fixtures/depot/environments/v1.9/Project.toml", parseProject, nil, nil)
+}
@@ -63,6 +63,8 @@ func (l LinuxKernelCataloger) Catalog(resolver source.FileResolver) ([]pkg.Packa
 		errs = multierror.Append(errs, err)
 	}
 
+	kernelPackages = addKernelConfigs(resolver, kernelPackages)
+
 	allRelationships = append(allRelationships, kernelRelationships...)
 	allPackages = append(allPackages, kernelPackages...)
 
@@ -77,6 +79,15 @@ func (l LinuxKernelCataloger) Catalog(resolver source.FileResolver) ([]pkg.Packa
 		moduleToKernelRelationships := createKernelToModuleRelationships(kernelPackages, modulePackages)
 		allRelationships = append(allRelationships, moduleRelationships...)
 		allRelationships = append(allRelationships, moduleToKernelRelationships...)
+		allRelationships = append(allRelationships, createModuleDependencyRelationships(resolver, modulePackages)...)
+
+		builtinPackages, err := catalogBuiltinModules(resolver, l.Name())
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+
+		allPackages = append(allPackages, builtinPackages...)
+		allRelationships = append(allRelationships, createKernelToBuiltinModuleRelationships(kernelPackages, builtinPackages)...)
 	}
 
 	return allPackages, allRelationships, errs
@@ -1,8 +1,12 @@
 package kernel
 
 import (
+	"os/exec"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/nextlinux/sbom/sbom/artifact"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
@@ -90,3 +94,87 @@ func Test_KernelCataloger(t *testing.T) {
 			),
 		)
 }
+
+func Test_KernelCataloger_ModulesDirectory(t *testing.T) {
+	cmd := exec.Command("make")
+	cmd.Dir = "test-fixtures/modules-directory"
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	src, err := source.NewFromDirectory("test-fixtures/modules-directory")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := NewLinuxKernelCataloger(DefaultLinuxCatalogerConfig()).Catalog(resolver)
+	require.NoError(t, err)
+
+	modules := make(map[string]pkg.LinuxKernelModuleMetadata)
+	purls := make(map[string]string)
+	for _, p := range pkgs {
+		assert.Equal(t, pkg.LinuxKernelModulePkg, p.Type)
+		assert.Equal(t, "linux-kernel-cataloger", p.FoundBy)
+		m, ok := p.Metadata.(pkg.LinuxKernelModuleMetadata)
+		require.True(t, ok)
+		modules[p.Name] = m
+		purls[p.Name] = p.PURL
+	}
+	require.Len(t, modules, 5)
+
+	// built-in modules are versioned by the kernel they are compiled into
+	assert.Equal(t, "pkg:generic/ext4@6.1.0-test", purls["ext4"])
+	assert.Equal(t, "pkg:generic/hid_generic@6.1.0-test", purls["hid_generic"])
+
+	for _, name := range []string{"sunrpc", "nfs", "nfsv4"} {
+		assert.False(t, modules[name].BuiltIn, name)
+		assert.Equal(t, "6.1.0-test", modules[name].KernelVersion, name)
+	}
+
+	assert.Equal(t, pkg.LinuxKernelModuleMetadata{
+		Name:          "ext4",
+		Description:   "Fourth Extended Filesystem",
+		Author:        "Remy Card, Stephen Tweedie, Andrew Morton, Andreas Dilger, Theodore Ts'o and others",
+		License:       "GPL",
+		KernelVersion: "6.1.0-test",
+		Parameters:    map[string]pkg.LinuxKernelModuleParameter{},
+		BuiltIn:       true,
+	}, modules["ext4"])
+
+	assert.Equal(t, pkg.LinuxKernelModuleMetadata{
+		Name:          "hid_generic",
+		License:       "GPL",
+		KernelVersion: "6.1.0-test",
+		Parameters: map[string]pkg.LinuxKernelModuleParameter{
+			"quirks": {Type: "charp", Description: "Add quirks"},
+		},
+		BuiltIn: true,
+	}, modules["hid_generic"])
+
+	var got []string
+	for _, r := range relationships {
+		assert.Equal(t, artifact.DependencyOfRelationship, r.Type)
+		got = append(got, r.From.(pkg.Package).Name+" -> "+r.To.(pkg.Package).Name)
+	}
+
+	// note: there is no kernel to relate the modules (or the built-in modules) to
+	assert.ElementsMatch(t, []string{
+		"sunrpc -> nfs",
+		"nfs -> nfsv4",
+		"sunrpc -> nfsv4",
+	}, got)
+}
+
+func Test_createKernelToBuiltinModuleRelationships(t *testing.T) {
+	kernel := newLinuxKernelPackage(pkg.LinuxKernelMetadata{Version: "6.1.0-test"})
+	builtin := newLinuxKernelModulePackage(pkg.LinuxKernelModuleMetadata{Name: "ext4", KernelVersion: "6.1.0-test", BuiltIn: true})
+	other := newLinuxKernelModulePackage(pkg.LinuxKernelModuleMetadata{Name: "ext4", KernelVersion: "5.10.0-other", BuiltIn: true})
+
+	assert.Equal(t, []artifact.Relationship{
+		{
+			From: kernel,
+			To:   builtin,
+			Type: artifact.ContainsRelationship,
+		},
+	}, createKernelToBuiltinModuleRelationships([]pkg.Package{kernel}, []pkg.Package{builtin, other}))
+}
@@ -34,12 +34,19 @@ func newLinuxKernelModulePackage(metadata pkg.LinuxKernelModuleMetadata, locatio
 		licenses = []string{}
 	}
 
+	// built-in modules are built from the kernel source tree, so are versioned by the kernel unless the module declares
+	// a version of its own
+	version := metadata.Version
+	if version == "" && metadata.BuiltIn {
+		version = metadata.KernelVersion
+	}
+
 	p := pkg.Package{
 		Name:         metadata.Name,
-		Version:      metadata.Version,
+		Version:      version,
 		Locations:    source.NewLocationSet(locations...),
 		Licenses:     licenses,
-		PURL:         packageURL(metadata.Name, metadata.Version),
+		PURL:         packageURL(metadata.Name, version),
 		Type:         pkg.LinuxKernelModulePkg,
 		MetadataType: pkg.LinuxKernelModuleMetadataType,
 		Metadata:     metadata,
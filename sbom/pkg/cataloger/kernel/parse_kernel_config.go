@@ -0,0 +1,166 @@
+package kernel
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var kernelConfigGlobs = []string{
+	"**/boot/config-*",
+	"**/lib/modules/*/config",
+}
+
+// securityConfigOptions are the kernel build options that are recorded on the kernel package (the full config has
+// thousands of options, most of which are not of interest when assessing the kernel).
+var securityConfigOptions = internal.NewStringSet(
+	// module loading
+	"CONFIG_MODULES",
+	"CONFIG_MODULE_SIG",
+	"CONFIG_MODULE_SIG_FORCE",
+	"CONFIG_MODULE_SIG_ALL",
+	"CONFIG_MODULE_SIG_HASH",
+	"CONFIG_STRICT_MODULE_RWX",
+	// security modules
+	"CONFIG_SECURITY",
+	"CONFIG_SECURITY_SELINUX",
+	"CONFIG_SECURITY_APPARMOR",
+	"CONFIG_SECURITY_YAMA",
+	"CONFIG_SECURITY_LOCKDOWN_LSM",
+	"CONFIG_LOCK_DOWN_KERNEL_FORCE_INTEGRITY",
+	"CONFIG_LOCK_DOWN_KERNEL_FORCE_CONFIDENTIALITY",
+	"CONFIG_LSM",
+	"CONFIG_SECCOMP",
+	"CONFIG_SECCOMP_FILTER",
+	// memory protections
+	"CONFIG_STACKPROTECTOR",
+	"CONFIG_STACKPROTECTOR_STRONG",
+	"CONFIG_FORTIFY_SOURCE",
+	"CONFIG_STRICT_KERNEL_RWX",
+	"CONFIG_RANDOMIZE_BASE",
+	"CONFIG_RANDOMIZE_MEMORY",
+	"CONFIG_HARDENED_USERCOPY",
+	"CONFIG_SLAB_FREELIST_RANDOM",
+	"CONFIG_SLAB_FREELIST_HARDENED",
+	"CONFIG_INIT_ON_ALLOC_DEFAULT_ON",
+	"CONFIG_INIT_ON_FREE_DEFAULT_ON",
+	"CONFIG_VMAP_STACK",
+	// speculative execution mitigations
+	"CONFIG_PAGE_TABLE_ISOLATION",
+	"CONFIG_RETPOLINE",
+	// attack surface
+	"CONFIG_DEVMEM",
+	"CONFIG_STRICT_DEVMEM",
+	"CONFIG_IO_STRICT_DEVMEM",
+	"CONFIG_KEXEC",
+	"CONFIG_HIBERNATION",
+	"CONFIG_LEGACY_VSYSCALL_NONE",
+	"CONFIG_USER_NS",
+	"CONFIG_BPF_JIT_ALWAYS_ON",
+	"CONFIG_BPF_UNPRIV_DEFAULT_OFF",
+	"CONFIG_KPROBES",
+)
+
+// addKernelConfigs records the security-relevant options of the kernel config on each kernel package of the same
+// version.
+func addKernelConfigs(resolver source.FileResolver, kernelPackages []pkg.Package) []pkg.Package {
+	if len(kernelPackages) == 0 {
+		return kernelPackages
+	}
+
+	locations, err := resolver.FilesByGlob(kernelConfigGlobs...)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to search for kernel configs")
+		return kernelPackages
+	}
+
+	for _, location := range locations {
+		version := kernelConfigVersion(location.RealPath)
+		if version == "" {
+			continue
+		}
+
+		var config map[string]string
+		for i := range kernelPackages {
+			p := &kernelPackages[i]
+			metadata, ok := p.Metadata.(pkg.LinuxKernelMetadata)
+			if !ok || p.Version != version || metadata.SecurityConfig != nil {
+				continue
+			}
+
+			if config == nil {
+				if config, err = readKernelConfig(resolver, location); err != nil {
+					log.WithFields("path", location.RealPath, "error", err).Debug("unable to read kernel config")
+					break
+				}
+			}
+
+			metadata.SecurityConfig = config
+			p.Metadata = metadata
+			p.Locations.Add(location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+			p.SetID()
+		}
+	}
+
+	return kernelPackages
+}
+
+// kernelConfigVersion returns the kernel version that the config is for, from either the file name
+// (/boot/config-<version>) or the modules directory (/lib/modules/<version>/config).
+func kernelConfigVersion(p string) string {
+	base := path.Base(p)
+	if strings.HasPrefix(base, "config-") {
+		return strings.TrimPrefix(base, "config-")
+	}
+	return path.Base(path.Dir(p))
+}
+
+func readKernelConfig(resolver source.FileResolver, location source.Location) (map[string]string, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	return parseKernelConfig(reader)
+}
+
+// parseKernelConfig returns the security-relevant options from a kernel config, for example:
+//
+//	CONFIG_MODULE_SIG=y
+//	CONFIG_MODULE_SIG_HASH="sha512"
+//	# CONFIG_MODULE_SIG_FORCE is not set
+func parseKernelConfig(reader io.Reader) (map[string]string, error) {
+	config := make(map[string]string)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		var option, value string
+		switch {
+		case strings.HasPrefix(line, "# CONFIG_") && strings.HasSuffix(line, " is not set"):
+			option = strings.TrimSuffix(strings.TrimPrefix(line, "# "), " is not set")
+			value = "n"
+		case strings.HasPrefix(line, "CONFIG_"):
+			fields := strings.SplitN(line, "=", 2)
+			if len(fields) != 2 {
+				continue
+			}
+			option, value = fields[0], strings.Trim(fields[1], `"`)
+		default:
+			continue
+		}
+
+		if securityConfigOptions.Contains(option) {
+			config[option] = value
+		}
+	}
+
+	return config, scanner.Err()
+}
@@ -0,0 +1,67 @@
+package kernel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func Test_addKernelConfigs(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/modules-directory")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	kernels := []pkg.Package{
+		newLinuxKernelPackage(pkg.LinuxKernelMetadata{Version: "6.1.0-test"}, source.NewLocation("boot/vmlinuz-6.1.0-test")),
+		newLinuxKernelPackage(pkg.LinuxKernelMetadata{Version: "5.10.0-other"}, source.NewLocation("boot/vmlinuz-5.10.0-other")),
+	}
+	originalID := kernels[0].ID()
+
+	kernels = addKernelConfigs(resolver, kernels)
+
+	metadata, ok := kernels[0].Metadata.(pkg.LinuxKernelMetadata)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"CONFIG_MODULES":          "y",
+		"CONFIG_MODULE_SIG":       "y",
+		"CONFIG_MODULE_SIG_FORCE": "n",
+		"CONFIG_MODULE_SIG_HASH":  "sha512",
+		"CONFIG_SECURITY_SELINUX": "y",
+		"CONFIG_LSM":              "landlock,lockdown,yama,integrity,selinux,bpf",
+		"CONFIG_RANDOMIZE_BASE":   "y",
+		"CONFIG_DEVMEM":           "n",
+	}, metadata.SecurityConfig)
+	assert.NotEqual(t, originalID, kernels[0].ID(), "the package ID should reflect the config")
+
+	var paths []string
+	for _, l := range kernels[0].Locations.ToSlice() {
+		paths = append(paths, l.RealPath)
+	}
+	assert.ElementsMatch(t, []string{"boot/vmlinuz-6.1.0-test", "boot/config-6.1.0-test"}, paths)
+
+	// there is no config for this kernel version
+	metadata, ok = kernels[1].Metadata.(pkg.LinuxKernelMetadata)
+	require.True(t, ok)
+	assert.Nil(t, metadata.SecurityConfig)
+}
+
+func Test_kernelConfigVersion(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/boot/config-6.0.7-301.fc37.x86_64", want: "6.0.7-301.fc37.x86_64"},
+		{path: "/lib/modules/6.0.7-301.fc37.x86_64/config", want: "6.0.7-301.fc37.x86_64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, kernelConfigVersion(tt.path))
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package kernel
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+const (
+	modulesBuiltinGlob        = "**/lib/modules/*/modules.builtin"
+	modulesBuiltinModinfoGlob = "**/lib/modules/*/modules.builtin.modinfo"
+)
+
+// catalogBuiltinModules returns the modules that are compiled into the kernel, as listed in modules.builtin (with
+// the details of each module from modules.builtin.modinfo, when present).
+func catalogBuiltinModules(resolver source.FileResolver, catalogerName string) ([]pkg.Package, error) {
+	builtinLocations, err := resolver.FilesByGlob(modulesBuiltinGlob)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search for modules.builtin: %w", err)
+	}
+	modinfoLocations, err := resolver.FilesByGlob(modulesBuiltinModinfoGlob)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search for modules.builtin.modinfo: %w", err)
+	}
+
+	// both files are found within the modules directory for the kernel version (/lib/modules/<version>)
+	builtinByDir := make(map[string]source.Location)
+	for _, location := range builtinLocations {
+		builtinByDir[path.Dir(location.RealPath)] = location
+	}
+	modinfoByDir := make(map[string]source.Location)
+	for _, location := range modinfoLocations {
+		modinfoByDir[path.Dir(location.RealPath)] = location
+	}
+
+	var dirs []string
+	for dir := range builtinByDir {
+		dirs = append(dirs, dir)
+	}
+	for dir := range modinfoByDir {
+		if _, ok := builtinByDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+
+	var packages []pkg.Package
+	for _, dir := range dirs {
+		var locations []source.Location
+		modules := make(map[string]*pkg.LinuxKernelModuleMetadata)
+		var names []string
+		add := func(name string) *pkg.LinuxKernelModuleMetadata {
+			if m, ok := modules[name]; ok {
+				return m
+			}
+			m := &pkg.LinuxKernelModuleMetadata{
+				Name:          name,
+				KernelVersion: path.Base(dir),
+				Parameters:    make(map[string]pkg.LinuxKernelModuleParameter),
+				BuiltIn:       true,
+			}
+			modules[name] = m
+			names = append(names, name)
+			return m
+		}
+
+		if location, ok := builtinByDir[dir]; ok {
+			builtin, err := readModulesBuiltin(resolver, location)
+			if err != nil {
+				log.WithFields("path", location.RealPath, "error", err).Debug("unable to read modules.builtin")
+			}
+			for _, name := range builtin {
+				add(name)
+			}
+			locations = append(locations, location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation))
+		}
+
+		if location, ok := modinfoByDir[dir]; ok {
+			entries, err := readModulesBuiltinModinfo(resolver, location)
+			if err != nil {
+				log.WithFields("path", location.RealPath, "error", err).Debug("unable to read modules.builtin.modinfo")
+			}
+			for _, e := range entries {
+				if err := addLinuxKernelModuleEntry(add(e.module), []byte(e.entry)); err != nil {
+					log.WithFields("path", location.RealPath, "error", err).Trace("unable to parse built-in module entry")
+				}
+			}
+			annotation := pkg.SupportingEvidenceAnnotation
+			if len(locations) == 0 {
+				annotation = pkg.PrimaryEvidenceAnnotation
+			}
+			locations = append(locations, location.WithAnnotation(pkg.EvidenceAnnotationKey, annotation))
+		}
+
+		for _, name := range names {
+			p := newLinuxKernelModulePackage(*modules[name], locations...)
+			p.FoundBy = catalogerName
+			p.SetID()
+			packages = append(packages, p)
+		}
+	}
+
+	return packages, nil
+}
+
+func readModulesBuiltin(resolver source.FileResolver, location source.Location) ([]string, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	return parseModulesBuiltin(reader)
+}
+
+func readModulesBuiltinModinfo(resolver source.FileResolver, location source.Location) ([]builtinModinfoEntry, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	return parseModulesBuiltinModinfo(reader)
+}
+
+// parseModulesBuiltin returns the names of the modules listed in modules.builtin, for example:
+//
+//	kernel/fs/ext4/ext4.ko
+//	kernel/drivers/tty/serial/8250/8250.ko
+func parseModulesBuiltin(reader io.Reader) ([]string, error) {
+	var names []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		names = append(names, moduleName(line))
+	}
+	return names, scanner.Err()
+}
+
+type builtinModinfoEntry struct {
+	module string
+	entry  string
+}
+
+// parseModulesBuiltinModinfo returns the modinfo entries of modules.builtin.modinfo, which is a NUL separated list of
+// the same entries found in the .modinfo section of a loadable module, each prefixed with the module name (e.g.
+// "ext4.license=GPL").
+func parseModulesBuiltinModinfo(reader io.Reader) ([]builtinModinfoEntry, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []builtinModinfoEntry
+	for _, raw := range bytes.Split(contents, []byte{0}) {
+		entry := string(raw)
+		key := strings.SplitN(entry, "=", 2)[0]
+		idx := strings.Index(key, ".")
+		if idx <= 0 {
+			continue
+		}
+		entries = append(entries, builtinModinfoEntry{
+			module: entry[:idx],
+			entry:  entry[idx+1:],
+		})
+	}
+	return entries, nil
+}
+
+// moduleName returns the name of the module for a module file path (e.g. kernel/drivers/hid/hid-generic.ko becomes
+// hid_generic), which is how the kernel refers to modules.
+func moduleName(p string) string {
+	name := path.Base(p)
+	if idx := strings.Index(name, ".ko"); idx != -1 {
+		name = name[:idx]
+	}
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// createKernelToBuiltinModuleRelationships relates each kernel to the modules that are compiled into it:
+// [kernel] --(contains)--> [built-in module]
+func createKernelToBuiltinModuleRelationships(kernelPackages, builtinPackages []pkg.Package) []artifact.Relationship {
+	var relationships []artifact.Relationship
+	for _, kp := range kernelPackages {
+		for _, mp := range builtinPackages {
+			m, ok := mp.Metadata.(pkg.LinuxKernelModuleMetadata)
+			if !ok || m.KernelVersion != kp.Version {
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: kp,
+				To:   mp,
+				Type: artifact.ContainsRelationship,
+			})
+		}
+	}
+	return relationships
+}
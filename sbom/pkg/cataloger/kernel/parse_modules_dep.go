@@ -0,0 +1,130 @@
+package kernel
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+const modulesDepGlob = "**/lib/modules/*/modules.dep"
+
+// createModuleDependencyRelationships relates modules to the modules they depend on, as listed in modules.dep:
+// [dependency module] --(dependency of)--> [module]
+func createModuleDependencyRelationships(resolver source.FileResolver, modulePackages []pkg.Package) []artifact.Relationship {
+	if len(modulePackages) == 0 {
+		return nil
+	}
+
+	modulesByPath := make(map[string]pkg.Package)
+	for _, p := range modulePackages {
+		m, ok := p.Metadata.(pkg.LinuxKernelModuleMetadata)
+		if !ok || m.Path == "" {
+			continue
+		}
+		modulesByPath[modulePathKey(m.Path)] = p
+	}
+
+	locations, err := resolver.FilesByGlob(modulesDepGlob)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to search for modules.dep")
+		return nil
+	}
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	for _, location := range locations {
+		deps, err := readModulesDep(resolver, location)
+		if err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Debug("unable to read modules.dep")
+			continue
+		}
+
+		// paths are relative to the modules directory (/lib/modules/<version>), however, older tooling wrote absolute paths
+		dir := path.Dir(location.RealPath)
+		resolve := func(p string) (pkg.Package, bool) {
+			if !path.IsAbs(p) {
+				p = path.Join(dir, p)
+			}
+			mp, ok := modulesByPath[modulePathKey(p)]
+			return mp, ok
+		}
+
+		for _, d := range deps {
+			module, ok := resolve(d.module)
+			if !ok {
+				continue
+			}
+			for _, dependency := range d.dependencies {
+				dp, ok := resolve(dependency)
+				if !ok {
+					continue
+				}
+
+				key := string(dp.ID()) + ":" + string(module.ID())
+				if seen.Contains(key) {
+					continue
+				}
+				seen.Add(key)
+
+				relationships = append(relationships, artifact.Relationship{
+					From: dp,
+					To:   module,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+
+	return relationships
+}
+
+// modulePathKey normalizes module paths from modules.dep and the resolver, which may or may not be rooted.
+func modulePathKey(p string) string {
+	return strings.TrimPrefix(path.Clean(p), "/")
+}
+
+type moduleDependencies struct {
+	module       string
+	dependencies []string
+}
+
+func readModulesDep(resolver source.FileResolver, location source.Location) ([]moduleDependencies, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	return parseModulesDep(reader)
+}
+
+// parseModulesDep returns the dependencies of each module listed in modules.dep, for example:
+//
+//	kernel/fs/nfs/nfsv4.ko: kernel/fs/nfs/nfs.ko kernel/net/sunrpc/sunrpc.ko
+//	kernel/net/sunrpc/sunrpc.ko:
+func parseModulesDep(reader io.Reader) ([]moduleDependencies, error) {
+	var result []moduleDependencies
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		module := strings.TrimSpace(fields[0])
+		if module == "" {
+			continue
+		}
+		result = append(result, moduleDependencies{
+			module:       module,
+			dependencies: strings.Fields(fields[1]),
+		})
+	}
+	return result, scanner.Err()
+}
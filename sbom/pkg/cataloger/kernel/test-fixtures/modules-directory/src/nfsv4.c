@@ -0,0 +1,7 @@
+// the entries of the .modinfo section, as written by the MODULE_* macros
+static const char modinfo[] __attribute__((section(".modinfo"), used)) =
+	"license=GPL\0"
+	"description=nfsv4 test module\0"
+	"depends=nfs,sunrpc\0"
+	"name=nfsv4\0"
+	"vermagic=6.1.0-test SMP preempt mod_unload \0";
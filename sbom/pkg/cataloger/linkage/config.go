@@ -0,0 +1,15 @@
+package linkage
+
+// Config controls the analysis of which ELF binaries load which shared libraries.
+type Config struct {
+	// Enabled adds a relationship from each ELF binary to each shared library it loads (and from the package owning
+	// the binary to the package owning the library). This reads the dynamic section of every ELF binary found, so it
+	// is disabled by default.
+	Enabled bool
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Enabled: false,
+	}
+}
@@ -0,0 +1,130 @@
+package linkage
+
+import (
+	"debug/elf"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/unionreader"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// elfBinary is the dynamic linking information of a single ELF executable or shared library.
+type elfBinary struct {
+	location source.Location
+	class    elf.Class
+	machine  elf.Machine
+	soname   string
+	needed   []string
+	rpath    []string
+	runpath  []string
+}
+
+// readELFBinaries returns the dynamic linking information for every ELF executable and shared library in the resolver.
+func readELFBinaries(resolver source.FileResolver) ([]*elfBinary, error) {
+	locations, err := resolver.FilesByMIMEType(internal.ExecutableMIMETypeSet.List()...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find binaries by mime types: %w", err)
+	}
+
+	var binaries []*elfBinary
+	for _, location := range locations {
+		binary, err := readELFBinary(resolver, location)
+		if err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Trace("unable to read ELF dynamic section")
+			continue
+		}
+		if binary != nil {
+			binaries = append(binaries, binary)
+		}
+	}
+	return binaries, nil
+}
+
+func readELFBinary(resolver source.FileResolver, location source.Location) (*elfBinary, error) {
+	readerCloser, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(readerCloser, location.RealPath)
+
+	reader, err := unionreader.GetUnionReader(readerCloser)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := elf.NewFile(reader)
+	if err != nil {
+		// not an ELF binary (e.g. a Mach-O or PE binary)
+		return nil, nil
+	}
+	if f.Type != elf.ET_EXEC && f.Type != elf.ET_DYN {
+		return nil, nil
+	}
+
+	binary := &elfBinary{
+		location: location,
+		class:    f.Class,
+		machine:  f.Machine,
+	}
+	if binary.needed, err = f.DynString(elf.DT_NEEDED); err != nil {
+		return nil, err
+	}
+	if sonames, err := f.DynString(elf.DT_SONAME); err == nil && len(sonames) > 0 {
+		binary.soname = sonames[0]
+	}
+	if rpaths, err := f.DynString(elf.DT_RPATH); err == nil {
+		binary.rpath = binary.expandSearchPaths(rpaths)
+	}
+	if runpaths, err := f.DynString(elf.DT_RUNPATH); err == nil {
+		binary.runpath = binary.expandSearchPaths(runpaths)
+	}
+
+	return binary, nil
+}
+
+// expandSearchPaths splits RPATH or RUNPATH entries into directories, expanding the dynamic string tokens that are
+// understood by the dynamic linker ($ORIGIN and $LIB).
+func (b *elfBinary) expandSearchPaths(entries []string) []string {
+	origin := path.Dir(path.Join("/", b.location.RealPath))
+	lib := "lib"
+	if b.class == elf.ELFCLASS64 {
+		lib = "lib64"
+	}
+	replacer := strings.NewReplacer("${ORIGIN}", origin, "$ORIGIN", origin, "${LIB}", lib, "$LIB", lib)
+
+	var dirs []string
+	for _, entry := range entries {
+		for _, dir := range strings.Split(entry, ":") {
+			if dir == "" || strings.Contains(dir, "PLATFORM") {
+				// the platform cannot be determined statically
+				continue
+			}
+			dirs = append(dirs, path.Clean(replacer.Replace(dir)))
+		}
+	}
+	return dirs
+}
+
+// searchPaths returns the directories that the dynamic linker searches for the libraries needed by the binary, in
+// order: the RPATH (only when there is no RUNPATH), the RUNPATH, the configured library paths, then the default paths.
+func (b *elfBinary) searchPaths(configured []string) []string {
+	var dirs []string
+	if len(b.runpath) == 0 {
+		dirs = append(dirs, b.rpath...)
+	}
+	dirs = append(dirs, b.runpath...)
+	dirs = append(dirs, configured...)
+	if b.class == elf.ELFCLASS64 {
+		dirs = append(dirs, "/lib64", "/usr/lib64")
+	}
+	return append(dirs, "/lib", "/usr/lib")
+}
+
+// compatible returns true when the given library can be loaded by the binary.
+func (b *elfBinary) compatible(library *elfBinary) bool {
+	return b.class == library.class && b.machine == library.machine
+}
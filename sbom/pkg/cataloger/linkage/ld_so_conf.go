@@ -0,0 +1,130 @@
+package linkage
+
+import (
+	"bufio"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+const ldSoConfPath = "/etc/ld.so.conf"
+
+// musl reads the library path from /etc/ld-musl-<arch>.path instead of ld.so.conf
+const muslPathGlob = "/etc/ld-musl-*.path"
+
+// readLibraryPaths returns the library directories configured for the dynamic linker (which glibc caches within
+// ld.so.cache), in the order they are searched.
+func readLibraryPaths(resolver source.FileResolver) []string {
+	var dirs []string
+	seen := internal.NewStringSet()
+	for _, dir := range append(readLdSoConf(resolver, ldSoConfPath, internal.NewStringSet()), readMuslPath(resolver)...) {
+		if seen.Contains(dir) {
+			continue
+		}
+		seen.Add(dir)
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// readLdSoConf reads the directories listed within an ld.so.conf file, following any "include" directives.
+func readLdSoConf(resolver source.FileResolver, confPath string, visited internal.StringSet) []string {
+	var dirs []string
+	for _, location := range filesByPath(resolver, confPath) {
+		if visited.Contains(location.RealPath) {
+			continue
+		}
+		visited.Add(location.RealPath)
+
+		for _, line := range readLines(resolver, location) {
+			fields := strings.FieldsFunc(line, func(r rune) bool {
+				return r == ' ' || r == '\t' || r == ':' || r == ','
+			})
+			if len(fields) == 0 {
+				continue
+			}
+			switch fields[0] {
+			case "include":
+				for _, pattern := range fields[1:] {
+					if !path.IsAbs(pattern) {
+						pattern = path.Join(path.Dir(confPath), pattern)
+					}
+					for _, include := range filesByGlob(resolver, pattern) {
+						dirs = append(dirs, readLdSoConf(resolver, include, visited)...)
+					}
+				}
+			case "hwcap":
+				continue
+			default:
+				for _, dir := range fields {
+					dirs = append(dirs, path.Clean(dir))
+				}
+			}
+		}
+	}
+	return dirs
+}
+
+func readMuslPath(resolver source.FileResolver) []string {
+	var dirs []string
+	for _, p := range filesByGlob(resolver, muslPathGlob) {
+		for _, location := range filesByPath(resolver, p) {
+			for _, line := range readLines(resolver, location) {
+				for _, dir := range strings.Split(line, ":") {
+					if dir = strings.TrimSpace(dir); dir != "" {
+						dirs = append(dirs, path.Clean(dir))
+					}
+				}
+			}
+		}
+	}
+	return dirs
+}
+
+// readLines returns the lines of the given file, without comments or blank lines.
+func readLines(resolver source.FileResolver, location source.Location) []string {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		log.WithFields("path", location.RealPath, "error", err).Debug("unable to read dynamic linker configuration")
+		return nil
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	var lines []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func filesByPath(resolver source.FileResolver, p string) []source.Location {
+	locations, err := resolver.FilesByPath(p)
+	if err != nil {
+		log.WithFields("path", p, "error", err).Trace("unable to find file")
+		return nil
+	}
+	return locations
+}
+
+// filesByGlob returns the (access) paths of the files matching the glob, sorted as the dynamic linker would.
+func filesByGlob(resolver source.FileResolver, pattern string) []string {
+	locations, err := resolver.FilesByGlob(pattern)
+	if err != nil {
+		log.WithFields("glob", pattern, "error", err).Trace("unable to find files")
+		return nil
+	}
+	var paths []string
+	for _, location := range locations {
+		paths = append(paths, path.Join("/", location.AccessPath()))
+	}
+	sort.Strings(paths)
+	return paths
+}
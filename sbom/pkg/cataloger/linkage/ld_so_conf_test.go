@@ -0,0 +1,44 @@
+package linkage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestReadLibraryPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    []string
+	}{
+		{
+			name:    "glibc includes",
+			fixture: "test-fixtures/ld-so-conf/glibc",
+			want:    []string{"/usr/local/lib", "/usr/lib/x86_64-linux-gnu", "/opt/app/lib", "/opt/other/lib"},
+		},
+		{
+			name:    "musl path",
+			fixture: "test-fixtures/ld-so-conf/musl",
+			want:    []string{"/lib", "/usr/local/lib", "/usr/lib"},
+		},
+		{
+			name:    "no configuration",
+			fixture: "test-fixtures/ld-so-conf/empty",
+			want:    nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, err := source.NewFromDirectory(test.fixture)
+			require.NoError(t, err)
+			resolver, err := src.FileResolver(source.SquashedScope)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.want, readLibraryPaths(resolver))
+		})
+	}
+}
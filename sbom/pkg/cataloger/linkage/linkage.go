@@ -0,0 +1,208 @@
+/*
+Package linkage relates ELF binaries to the shared libraries they load, and the packages that own the binaries to the
+packages that own the libraries.
+*/
+package linkage
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// linkageMetadata is the data of a linkage relationship, listing the needed (DT_NEEDED) entries that were resolved.
+type linkageMetadata struct {
+	Needed []string `json:"needed"`
+}
+
+// Relationships resolves the DT_NEEDED entries of every ELF binary in the resolver to a library, in the same way the
+// dynamic linker would (see ld.so(8)), returning a dependency-of relationship from each library to the binary that
+// loads it. Where both files are owned by packages (as given by the package-to-file relationships, or the locations of
+// the packages), a dependency-of relationship is also returned from the package owning the library to the package
+// owning the binary.
+func Relationships(resolver source.FileResolver, catalog *pkg.Collection, relationships []artifact.Relationship) []artifact.Relationship {
+	binaries, err := readELFBinaries(resolver)
+	if err != nil {
+		log.WithFields("error", err).Debug("unable to analyze ELF binary linkage")
+		return nil
+	}
+	if len(binaries) == 0 {
+		return nil
+	}
+
+	l := newLinker(resolver, binaries, readLibraryPaths(resolver))
+	owners := fileOwners(catalog, relationships)
+
+	var fileEdges []artifact.Relationship
+	packageEdges := make(map[string]*packageEdge)
+	for _, binary := range binaries {
+		for _, needed := range binary.needed {
+			library := l.resolve(binary, needed)
+			if library == nil {
+				log.WithFields("path", binary.location.RealPath, "needed", needed).Trace("unable to resolve needed library")
+				continue
+			}
+
+			fileEdges = append(fileEdges, artifact.Relationship{
+				From: library.location.Coordinates,
+				To:   binary.location.Coordinates,
+				Type: artifact.DependencyOfRelationship,
+				Data: linkageMetadata{Needed: []string{needed}},
+			})
+
+			for _, libraryOwner := range owners[library.location.Coordinates.ID()] {
+				for _, binaryOwner := range owners[binary.location.Coordinates.ID()] {
+					if libraryOwner.ID() == binaryOwner.ID() {
+						continue
+					}
+					key := string(libraryOwner.ID()) + string(binaryOwner.ID())
+					edge, ok := packageEdges[key]
+					if !ok {
+						edge = &packageEdge{from: libraryOwner, to: binaryOwner, needed: internal.NewStringSet()}
+						packageEdges[key] = edge
+					}
+					edge.needed.Add(needed)
+				}
+			}
+		}
+	}
+
+	var keys []string
+	for key := range packageEdges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	edges := fileEdges
+	for _, key := range keys {
+		edge := packageEdges[key]
+		edges = append(edges, artifact.Relationship{
+			From: edge.from,
+			To:   edge.to,
+			Type: artifact.DependencyOfRelationship,
+			Data: linkageMetadata{Needed: edge.needed.ToSlice()},
+		})
+	}
+	return edges
+}
+
+type packageEdge struct {
+	from   pkg.Package
+	to     pkg.Package
+	needed internal.StringSet
+}
+
+// fileOwners returns the packages that own each file, which are the packages that contain the file or that the file
+// is a location of (e.g. a binary classified by its contents).
+func fileOwners(catalog *pkg.Collection, relationships []artifact.Relationship) map[artifact.ID][]pkg.Package {
+	owners := make(map[artifact.ID][]pkg.Package)
+	seen := internal.NewStringSet()
+	add := func(coordinates source.Coordinates, p pkg.Package) {
+		key := string(coordinates.ID()) + string(p.ID())
+		if seen.Contains(key) {
+			return
+		}
+		seen.Add(key)
+		owners[coordinates.ID()] = append(owners[coordinates.ID()], p)
+	}
+
+	for _, r := range relationships {
+		if r.Type != artifact.ContainsRelationship {
+			continue
+		}
+		p, ok := r.From.(pkg.Package)
+		if !ok {
+			continue
+		}
+		if coordinates, ok := r.To.(source.Coordinates); ok {
+			add(coordinates, p)
+		}
+	}
+
+	for _, p := range catalog.Sorted() {
+		for _, location := range p.Locations.ToSlice() {
+			add(location.Coordinates, p)
+		}
+	}
+
+	return owners
+}
+
+// linker resolves needed libraries to the ELF binaries within the resolver.
+type linker struct {
+	resolver   source.FileResolver
+	configured []string
+	byID       map[artifact.ID]*elfBinary
+	// bySoname indexes libraries by the directory they are within and their SONAME, since the dynamic linker cache
+	// refers to libraries by SONAME (and so finds libraries where the file name does not match the SONAME)
+	bySoname map[string][]*elfBinary
+	cache    map[string]*elfBinary
+}
+
+func newLinker(resolver source.FileResolver, binaries []*elfBinary, configured []string) *linker {
+	l := &linker{
+		resolver:   resolver,
+		configured: configured,
+		byID:       make(map[artifact.ID]*elfBinary),
+		bySoname:   make(map[string][]*elfBinary),
+		cache:      make(map[string]*elfBinary),
+	}
+	for _, b := range binaries {
+		l.byID[b.location.Coordinates.ID()] = b
+		if b.soname != "" {
+			for _, p := range []string{b.location.RealPath, b.location.AccessPath()} {
+				key := path.Join(path.Dir(path.Join("/", p)), b.soname)
+				l.bySoname[key] = append(l.bySoname[key], b)
+			}
+		}
+	}
+	return l
+}
+
+// resolve returns the library that the dynamic linker would load for the given needed entry of the binary.
+func (l *linker) resolve(binary *elfBinary, needed string) *elfBinary {
+	if strings.Contains(needed, "/") {
+		// needed entries with a slash are loaded as-is, rather than searched for
+		return l.find(binary, path.Join("/", needed))
+	}
+	for _, dir := range binary.searchPaths(l.configured) {
+		if library := l.find(binary, path.Join(dir, needed)); library != nil {
+			return library
+		}
+	}
+	return nil
+}
+
+// find returns the compatible library at the given path (or with the given SONAME within the directory).
+func (l *linker) find(binary *elfBinary, p string) *elfBinary {
+	// the result is cached per architecture, since binaries of different architectures may resolve differently
+	key := p + "@" + binary.class.String() + "/" + binary.machine.String()
+	if library, ok := l.cache[key]; ok {
+		return library
+	}
+
+	var found *elfBinary
+	for _, location := range filesByPath(l.resolver, p) {
+		if library, ok := l.byID[location.Coordinates.ID()]; ok && binary.compatible(library) {
+			found = library
+			break
+		}
+	}
+	if found == nil {
+		for _, library := range l.bySoname[p] {
+			if binary.compatible(library) {
+				found = library
+				break
+			}
+		}
+	}
+
+	l.cache[key] = found
+	return found
+}
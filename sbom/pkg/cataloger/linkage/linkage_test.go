@@ -0,0 +1,165 @@
+package linkage
+
+import (
+	"bufio"
+	"debug/elf"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// make will run the default make target for the given test fixture path
+func runMakeTarget(t *testing.T, fixtureName string) {
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	fixtureDir := filepath.Join(cwd, "test-fixtures/", fixtureName)
+
+	t.Logf("Generating Fixture in %q", fixtureDir)
+
+	cmd := exec.Command("make")
+	cmd.Dir = fixtureDir
+
+	stderr, err := cmd.StderrPipe()
+	require.NoError(t, err)
+
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+
+	err = cmd.Start()
+	require.NoError(t, err)
+
+	show := func(label string, reader io.ReadCloser) {
+		scanner := bufio.NewScanner(reader)
+		scanner.Split(bufio.ScanLines)
+		for scanner.Scan() {
+			t.Logf("%s: %s", label, scanner.Text())
+		}
+	}
+	go show("out", stdout)
+	go show("err", stderr)
+
+	if err := cmd.Wait(); err != nil {
+		if exiterr, ok := err.(*exec.ExitError); ok {
+			// The program has exited with an exit code != 0
+			// This works on both Unix and Windows. Although package
+			// syscall is generally platform dependent, WaitStatus is
+			// defined for both Unix and Windows and in both cases has
+			// an ExitStatus() method with the same signature.
+			if status, ok := exiterr.Sys().(interface{ ExitStatus() int }); ok {
+				if status.ExitStatus() != 0 {
+					t.Fatalf("failed to generate fixture: rc=%d", status.ExitStatus())
+				}
+			}
+		} else {
+			t.Fatalf("unable to get generate fixture result: %+v", err)
+		}
+	}
+}
+
+func TestRelationships(t *testing.T) {
+	runMakeTarget(t, "linkage")
+
+	src, err := source.NewFromDirectory("test-fixtures/linkage/root")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	newPackage := func(name string, paths ...string) pkg.Package {
+		p := pkg.Package{Name: name, Version: "1.0.0"}
+		var locations []source.Location
+		for _, path := range paths {
+			found, err := resolver.FilesByPath(path)
+			require.NoError(t, err)
+			require.Len(t, found, 1, path)
+			locations = append(locations, found[0])
+		}
+		p.Locations = source.NewLocationSet(locations...)
+		p.SetID()
+		return p
+	}
+
+	base := newPackage("base", "/usr/lib/libbase.so.1")
+	custom := newPackage("custom", "/opt/custom/lib/libcustom.so.2.0.1")
+	// the application is not given any locations, it owns its files by the contains relationships instead
+	app := newPackage("app")
+	var contains []artifact.Relationship
+	for _, path := range []string{"/app/bin/app", "/app/lib/libplugin.so.3"} {
+		found, err := resolver.FilesByPath(path)
+		require.NoError(t, err)
+		require.Len(t, found, 1, path)
+		contains = append(contains, artifact.Relationship{
+			From: app,
+			To:   found[0].Coordinates,
+			Type: artifact.ContainsRelationship,
+		})
+	}
+
+	relationships := Relationships(resolver, pkg.NewCollection(base, custom, app), contains)
+
+	describe := func(node artifact.Identifiable) string {
+		switch n := node.(type) {
+		case pkg.Package:
+			return n.Name
+		case source.Coordinates:
+			return n.RealPath
+		}
+		return fmt.Sprintf("%+v", node)
+	}
+
+	var got []string
+	for _, r := range relationships {
+		require.Equal(t, artifact.DependencyOfRelationship, r.Type)
+		got = append(got, fmt.Sprintf("%s -> %s %v", describe(r.From), describe(r.To), r.Data.(linkageMetadata).Needed))
+	}
+
+	expected := []string{
+		"opt/custom/lib/libcustom.so.2.0.1 -> app/bin/app [libcustom.so.2]",
+		"app/lib/libplugin.so.3 -> app/bin/app [libplugin.so.3]",
+		"usr/lib/libbase.so.1 -> opt/custom/lib/libcustom.so.2.0.1 [libbase.so.1]",
+		"custom -> app [libcustom.so.2]",
+		"base -> custom [libbase.so.1]",
+	}
+	assert.ElementsMatch(t, expected, got)
+}
+
+func TestElfBinary_searchPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		rpath   []string
+		runpath []string
+		want    []string
+	}{
+		{
+			name:  "rpath with origin",
+			rpath: []string{"$ORIGIN/../lib:/opt/lib", "${ORIGIN}"},
+			want:  []string{"/app/lib", "/opt/lib", "/app/bin", "/etc/conf/lib", "/lib64", "/usr/lib64", "/lib", "/usr/lib"},
+		},
+		{
+			name:    "runpath ignores rpath",
+			rpath:   []string{"/opt/rpath"},
+			runpath: []string{"/usr/$LIB/app:/opt/$PLATFORM/lib"},
+			want:    []string{"/usr/lib64/app", "/etc/conf/lib", "/lib64", "/usr/lib64", "/lib", "/usr/lib"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := &elfBinary{
+				location: source.NewLocation("app/bin/app"),
+				class:    elf.ELFCLASS64,
+			}
+			b.rpath = b.expandSearchPaths(test.rpath)
+			b.runpath = b.expandSearchPaths(test.runpath)
+			assert.Equal(t, test.want, b.searchPaths([]string{"/etc/conf/lib"}))
+		})
+	}
+}
@@ -0,0 +1,5 @@
+int custom(void);
+int plugin(void);
+int missing(void);
+
+void _start(void) { custom(); plugin(); missing(); }
@@ -0,0 +1 @@
+int base(void) { return 1; }
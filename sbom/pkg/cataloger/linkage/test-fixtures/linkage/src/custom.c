@@ -0,0 +1,3 @@
+int base(void);
+
+int custom(void) { return base() + 1; }
@@ -0,0 +1 @@
+int missing(void) { return 0; }
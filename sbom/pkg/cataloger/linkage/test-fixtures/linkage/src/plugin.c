@@ -0,0 +1 @@
+int plugin(void) { return 3; }
@@ -0,0 +1,22 @@
+/*
+Package lua provides a concrete Cataloger implementation for Lua rocks, from rockspec files and the rock manifests of
+rocks installed by LuaRocks.
+*/
+package lua
+
+import (
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+)
+
+// NewRockspecCataloger returns a new cataloger for Lua rocks described by rockspec files (outside of an installed
+// rocks tree).
+func NewRockspecCataloger() *generic.Cataloger {
+	return generic.NewCataloger("lua-rockspec-cataloger").
+		WithParserByGlobs(parseRockspec, "**/*.rockspec")
+}
+
+// NewInstalledCataloger returns a new cataloger for Lua rocks installed within a LuaRocks tree.
+func NewInstalledCataloger() *generic.Cataloger {
+	return generic.NewCataloger("lua-rocks-installed-cataloger").
+		WithParserByGlobs(parseRockManifest, "**/rock_manifest")
+}
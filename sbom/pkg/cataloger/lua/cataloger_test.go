@@ -0,0 +1,112 @@
+package lua
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func Test_RockspecCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain rockspec files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/example-1.0-1.rockspec",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewRockspecCataloger())
+		})
+	}
+}
+
+func Test_InstalledCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain rock manifest files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/lib/luarocks/rocks-5.4/example/1.0-1/rock_manifest",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewInstalledCataloger())
+		})
+	}
+}
+
+func TestInstalledCataloger(t *testing.T) {
+	rockDir := "usr/local/lib/luarocks/rocks-5.4/luasocket/3.1.0-1"
+
+	expected := []pkg.Package{
+		{
+			Name:    "luasocket",
+			Version: "3.1.0-1",
+			FoundBy: "lua-rocks-installed-cataloger",
+			PURL:    "pkg:luarocks/luasocket@3.1.0-1",
+			Locations: source.NewLocationSet(
+				source.NewLocation(rockDir+"/rock_manifest"),
+				source.NewLocation(rockDir+"/luasocket-3.1.0-1.rockspec"),
+			),
+			Licenses:     []string{"MIT"},
+			Language:     pkg.Lua,
+			Type:         pkg.LuaRocksPkg,
+			MetadataType: pkg.LuaRocksMetadataType,
+			Metadata: pkg.LuaRocksMetadata{
+				Summary:      "Network support for the Lua language",
+				Homepage:     "https://github.com/lunarmodules/luasocket",
+				License:      "MIT",
+				SourceURL:    "git+https://github.com/lunarmodules/luasocket.git",
+				Dependencies: []string{"lua >= 5.1"},
+				Files: []string{
+					"usr/local/lib/lua/5.4/mime/core.so",
+					"usr/local/lib/lua/5.4/socket/core.so",
+					rockDir + "/doc/README.md",
+					rockDir + "/doc/socket.html",
+					rockDir + "/luasocket-3.1.0-1.rockspec",
+					"usr/local/share/lua/5.4/ltn12.lua",
+					"usr/local/share/lua/5.4/mime.lua",
+					"usr/local/share/lua/5.4/socket.lua",
+					"usr/local/share/lua/5.4/socket/ftp.lua",
+					"usr/local/share/lua/5.4/socket/http.lua",
+				},
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/installed").
+		Expects(expected, nil).
+		TestCataloger(t, NewInstalledCataloger())
+}
+
+func TestRockspecCataloger_IgnoresInstalledRocks(t *testing.T) {
+	// the rockspec of an installed rock is reported by the installed cataloger (along with the rock manifest)
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/installed").
+		Expects(nil, nil).
+		TestCataloger(t, NewRockspecCataloger())
+}
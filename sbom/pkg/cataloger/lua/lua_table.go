@@ -0,0 +1,460 @@
+package lua
+
+import (
+	"fmt"
+	"strings"
+)
+
+// luaTable is a Lua table constructor, split into the fields with a (string) key and the positional items.
+type luaTable struct {
+	fields map[string]interface{}
+	items  []interface{}
+}
+
+// str returns the string value found by following the given keys through nested tables.
+func (t luaTable) str(keys ...string) string {
+	value, _ := t.get(keys...).(string)
+	return value
+}
+
+func (t luaTable) get(keys ...string) interface{} {
+	var value interface{} = t
+	for _, key := range keys {
+		table, ok := value.(luaTable)
+		if !ok {
+			return nil
+		}
+		value = table.fields[key]
+	}
+	return value
+}
+
+// strings returns the string items of the table found by following the given keys through nested tables.
+func (t luaTable) strings(keys ...string) []string {
+	table, ok := t.get(keys...).(luaTable)
+	if !ok {
+		return nil
+	}
+	var values []string
+	for _, item := range table.items {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+type luaTokenKind int
+
+const (
+	luaName luaTokenKind = iota
+	luaString
+	luaNumber
+	luaSymbol
+)
+
+type luaToken struct {
+	kind  luaTokenKind
+	value string
+}
+
+// parseLuaAssignments returns the values of the global (and top-level local) variables assigned within a rockspec or
+// rock manifest. These files are Lua programs, however only the data they describe is of interest: constants (strings,
+// numbers, booleans), table constructors and string concatenations are evaluated, while any other expression (e.g. a
+// function call) evaluates to nil. Statements within function bodies and control structures are ignored.
+func parseLuaAssignments(contents string) (luaTable, error) {
+	tokens, err := tokenizeLua(contents)
+	if err != nil {
+		return luaTable{}, err
+	}
+
+	p := &luaParser{tokens: tokens, vars: luaTable{fields: make(map[string]interface{})}}
+	depth := 0
+	for !p.done() {
+		token := p.peek(0)
+		switch {
+		case depth == 0 && p.isAssignment(0):
+			p.assign(1)
+		case depth == 0 && token.kind == luaName && token.value == "local" && p.isAssignment(1):
+			p.assign(2)
+		case token.kind == luaName && isLuaBlockStart(token.value):
+			depth++
+			p.pos++
+		case token.kind == luaName && isLuaBlockEnd(token.value):
+			depth--
+			p.pos++
+		default:
+			p.pos++
+		}
+	}
+	return p.vars, nil
+}
+
+func isLuaBlockStart(keyword string) bool {
+	switch keyword {
+	case "function", "if", "do", "repeat":
+		return true
+	}
+	return false
+}
+
+func isLuaBlockEnd(keyword string) bool {
+	return keyword == "end" || keyword == "until"
+}
+
+func isLuaBinaryOperator(t luaToken) bool {
+	switch t.value {
+	case "+", "-", "*", "/", "//", "%", "^", "==", "~=", "<", ">", "<=", ">=":
+		return t.kind == luaSymbol
+	case "and", "or":
+		return t.kind == luaName
+	}
+	return false
+}
+
+type luaParser struct {
+	tokens []luaToken
+	pos    int
+	vars   luaTable
+}
+
+func (p *luaParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *luaParser) peek(offset int) luaToken {
+	if p.pos+offset >= len(p.tokens) {
+		return luaToken{kind: luaSymbol}
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *luaParser) next() luaToken {
+	t := p.peek(0)
+	p.pos++
+	return t
+}
+
+func (p *luaParser) accept(symbol string) bool {
+	if t := p.peek(0); t.kind == luaSymbol && t.value == symbol {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// isAssignment returns whether the token at the given offset is a name that a value is assigned to.
+func (p *luaParser) isAssignment(offset int) bool {
+	name, equals := p.peek(offset), p.peek(offset+1)
+	return name.kind == luaName && equals.kind == luaSymbol && equals.value == "="
+}
+
+// assign evaluates the expression following the name (and "=") at the current position.
+func (p *luaParser) assign(length int) {
+	name := p.peek(length - 1).value
+	p.pos += length + 1
+	p.vars.fields[name] = p.expression()
+}
+
+func (p *luaParser) expression() interface{} {
+	value := p.operand()
+	for !p.done() {
+		switch t := p.peek(0); {
+		case t.kind == luaSymbol && t.value == "..":
+			p.pos++
+			right := p.operand()
+			left, leftOK := value.(string)
+			rightValue, rightOK := right.(string)
+			if leftOK && rightOK {
+				value = left + rightValue
+			} else {
+				value = nil
+			}
+		case isLuaBinaryOperator(t):
+			p.pos++
+			p.operand()
+			value = nil
+		default:
+			return value
+		}
+	}
+	return value
+}
+
+func (p *luaParser) operand() interface{} {
+	t := p.next()
+	switch t.kind {
+	case luaString, luaNumber:
+		return t.value
+	case luaName:
+		switch t.value {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "nil":
+			return nil
+		case "not":
+			p.operand()
+			return nil
+		case "function":
+			p.skipBlock()
+			return nil
+		}
+
+		value := p.vars.fields[t.value]
+		if p.skipSuffixes() {
+			// an indexed value or function call
+			return nil
+		}
+		return value
+	case luaSymbol:
+		switch t.value {
+		case "{":
+			return p.table()
+		case "(":
+			value := p.expression()
+			p.accept(")")
+			if p.skipSuffixes() {
+				return nil
+			}
+			return value
+		case "-", "#":
+			p.operand()
+			return nil
+		}
+	}
+	return nil
+}
+
+// skipSuffixes skips any field access, indexing or call that follows a name, returning whether there were any.
+func (p *luaParser) skipSuffixes() bool {
+	skipped := false
+	for !p.done() {
+		t := p.peek(0)
+		switch {
+		case t.kind == luaSymbol && (t.value == "." || t.value == ":"):
+			p.pos += 2
+		case t.kind == luaSymbol && t.value == "[":
+			p.pos++
+			p.expression()
+			p.accept("]")
+		case t.kind == luaSymbol && t.value == "(":
+			p.skipBalanced("(", ")")
+		case t.kind == luaSymbol && t.value == "{":
+			p.pos++
+			p.table()
+		case t.kind == luaString:
+			p.pos++
+		default:
+			return skipped
+		}
+		skipped = true
+	}
+	return skipped
+}
+
+// skipBalanced skips tokens from the given opening symbol up to (and including) the matching closing symbol.
+func (p *luaParser) skipBalanced(open, close string) {
+	depth := 0
+	for !p.done() {
+		t := p.next()
+		if t.kind != luaSymbol {
+			continue
+		}
+		switch t.value {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// skipBlock skips the remainder of a function body, up to (and including) the matching "end".
+func (p *luaParser) skipBlock() {
+	depth := 1
+	for !p.done() && depth > 0 {
+		t := p.next()
+		if t.kind != luaName {
+			continue
+		}
+		switch {
+		case isLuaBlockStart(t.value):
+			depth++
+		case isLuaBlockEnd(t.value):
+			depth--
+		}
+	}
+}
+
+// table parses the fields of a table constructor, following the opening brace.
+func (p *luaParser) table() luaTable {
+	table := luaTable{fields: make(map[string]interface{})}
+	for !p.done() {
+		if p.accept("}") {
+			return table
+		}
+
+		switch {
+		case p.accept("["):
+			key := p.expression()
+			p.accept("]")
+			p.accept("=")
+			value := p.expression()
+			if k, ok := key.(string); ok {
+				table.fields[k] = value
+			}
+		case p.isAssignment(0):
+			key := p.next().value
+			p.pos++
+			table.fields[key] = p.expression()
+		default:
+			start := p.pos
+			table.items = append(table.items, p.expression())
+			if p.pos == start {
+				// not a valid field, skip over it
+				p.pos++
+			}
+		}
+
+		if !p.accept(",") {
+			p.accept(";")
+		}
+	}
+	return table
+}
+
+// tokenizeLua splits Lua source into names (including keywords), strings (unquoted), numbers and symbols, dropping
+// comments.
+func tokenizeLua(contents string) ([]luaToken, error) {
+	var tokens []luaToken
+	for i := 0; i < len(contents); {
+		c := contents[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == '\v':
+			i++
+		case strings.HasPrefix(contents[i:], "--"):
+			i += 2
+			if _, n, ok := luaLongBracket(contents[i:]); ok {
+				i += n
+				continue
+			}
+			for i < len(contents) && contents[i] != '\n' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			value, n, err := luaQuotedString(contents[i:])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, luaToken{kind: luaString, value: value})
+			i += n
+		case c == '[' && (strings.HasPrefix(contents[i:], "[[") || strings.HasPrefix(contents[i:], "[=")):
+			value, n, ok := luaLongBracket(contents[i:])
+			if !ok {
+				tokens = append(tokens, luaToken{kind: luaSymbol, value: "["})
+				i++
+				continue
+			}
+			tokens = append(tokens, luaToken{kind: luaString, value: value})
+			i += n
+		case isLuaNameStart(c):
+			start := i
+			for i < len(contents) && (isLuaNameStart(contents[i]) || isDigit(contents[i])) {
+				i++
+			}
+			tokens = append(tokens, luaToken{kind: luaName, value: contents[start:i]})
+		case isDigit(c) || (c == '.' && i+1 < len(contents) && isDigit(contents[i+1])):
+			start := i
+			for i < len(contents) && (isLuaNameStart(contents[i]) || isDigit(contents[i]) || contents[i] == '.' ||
+				((contents[i] == '-' || contents[i] == '+') && (contents[i-1] == 'e' || contents[i-1] == 'E' || contents[i-1] == 'p' || contents[i-1] == 'P'))) {
+				i++
+			}
+			tokens = append(tokens, luaToken{kind: luaNumber, value: contents[start:i]})
+		default:
+			symbol := contents[i : i+1]
+			for _, s := range []string{"...", "..", "==", "~=", "<=", ">=", "//", "::"} {
+				if strings.HasPrefix(contents[i:], s) {
+					symbol = s
+					break
+				}
+			}
+			tokens = append(tokens, luaToken{kind: luaSymbol, value: symbol})
+			i += len(symbol)
+		}
+	}
+	return tokens, nil
+}
+
+func isLuaNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// luaLongBracket returns the contents of a long bracket (e.g. [[...]] or [==[...]==]) at the start of the given
+// string, along with the length of the long bracket.
+func luaLongBracket(s string) (string, int, bool) {
+	if !strings.HasPrefix(s, "[") {
+		return "", 0, false
+	}
+	level := 0
+	for 1+level < len(s) && s[1+level] == '=' {
+		level++
+	}
+	if 1+level >= len(s) || s[1+level] != '[' {
+		return "", 0, false
+	}
+
+	open := 2 + level
+	closing := "]" + strings.Repeat("=", level) + "]"
+	end := strings.Index(s[open:], closing)
+	if end < 0 {
+		// an unterminated long bracket runs to the end of the file
+		return s[open:], len(s), true
+	}
+
+	value := s[open : open+end]
+	// a newline immediately following the opening bracket is not part of the string
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "\r"), "\n")
+	return value, open + end + len(closing), true
+}
+
+// luaQuotedString returns the (unescaped) contents of a quoted string at the start of the given string, along with the
+// length of the quoted string.
+func luaQuotedString(s string) (string, int, error) {
+	quote := s[0]
+	var value strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case quote:
+			return value.String(), i + 1, nil
+		case '\n':
+			return "", 0, fmt.Errorf("unfinished string")
+		case '\\':
+			i++
+			if i >= len(s) {
+				return "", 0, fmt.Errorf("unfinished string")
+			}
+			switch e := s[i]; e {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			case 'r':
+				value.WriteByte('\r')
+			default:
+				value.WriteByte(e)
+			}
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unfinished string")
+}
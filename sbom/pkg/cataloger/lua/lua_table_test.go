@@ -0,0 +1,51 @@
+package lua
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseLuaAssignments(t *testing.T) {
+	contents := `
+-- comment
+local base = "1.0"
+package = 'example' -- trailing comment
+version = base .. "-1"
+number = 42
+flag = true
+call = require("something").value
+description = {
+   summary = [[a "long" string]],
+   ["quoted key"] = "value",
+   nested = { deep = { "a", 'b', [==[c]==] } },
+   fn = function(x) if x then return { version = "2" } end end,
+   "item";
+}
+function helper()
+   package = "not at the top level"
+end
+unfinished = base .. missing
+`
+	vars, err := parseLuaAssignments(contents)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0", vars.str("base"))
+	assert.Equal(t, "example", vars.str("package"))
+	assert.Equal(t, "1.0-1", vars.str("version"))
+	assert.Equal(t, "42", vars.str("number"))
+	assert.Equal(t, true, vars.get("flag"))
+	assert.Nil(t, vars.get("call"))
+	assert.Equal(t, `a "long" string`, vars.str("description", "summary"))
+	assert.Equal(t, "value", vars.str("description", "quoted key"))
+	assert.Equal(t, []string{"a", "b", "c"}, vars.strings("description", "nested", "deep"))
+	assert.Nil(t, vars.get("description", "fn"))
+	assert.Equal(t, []string{"item"}, vars.strings("description"))
+	assert.Nil(t, vars.get("unfinished"))
+}
+
+func Test_parseLuaAssignments_UnfinishedString(t *testing.T) {
+	_, err := parseLuaAssignments(`package = "example`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+package lua
+
+import (
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func newPackage(name, version string, m pkg.LuaRocksMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(name, version),
+		Language:     pkg.Lua,
+		Type:         pkg.LuaRocksPkg,
+		MetadataType: pkg.LuaRocksMetadataType,
+		Metadata:     m,
+	}
+	if m.License != "" {
+		p.Licenses = []string{m.License}
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func packageURL(name, version string) string {
+	return packageurl.NewPackageURL(pkg.LuaRocksPkg.PackageURLType(), "", name, version, nil, "").ToString()
+}
@@ -0,0 +1,106 @@
+package lua
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseRockManifest
+
+// rocksDirPattern matches the directory of the rocks within a LuaRocks tree, capturing the tree and the Lua version
+// (which LuaRocks 2 did not include within the directory name).
+var rocksDirPattern = regexp.MustCompile(`^(.*/)?lib/luarocks/rocks(?:-(\d+\.\d+))?$`)
+
+// parseRockManifest is a parser function for the rock_manifest of an installed rock, found within
+// <tree>/lib/luarocks/rocks-<lua version>/<name>/<version>/. The rock is described by the rockspec next to the
+// manifest, and the manifest lists the files installed by the rock (by the directory they are installed to).
+func parseRockManifest(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read rock_manifest: %w", err)
+	}
+
+	vars, err := parseLuaAssignments(string(contents))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse rock_manifest: %w", err)
+	}
+
+	manifest, ok := vars.get("rock_manifest").(luaTable)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	rockDir := path.Dir(reader.RealPath)
+	name, version := path.Base(path.Dir(rockDir)), path.Base(rockDir)
+
+	locations := []source.Location{reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)}
+
+	var m pkg.LuaRocksMetadata
+	if resolver != nil {
+		if rockspec := resolver.RelativeFileByPath(reader.Location, path.Join(rockDir, name+"-"+version+".rockspec")); rockspec != nil {
+			spec, err := readRockspecLocation(resolver, *rockspec)
+			if err != nil {
+				log.WithFields("path", rockspec.RealPath, "error", err).Debug("unable to parse rockspec")
+			} else {
+				m = rockspecMetadata(spec)
+				locations = append(locations, rockspec.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+			}
+		}
+	}
+	m.Files = rockFiles(manifest, rockDir)
+
+	return []pkg.Package{newPackage(name, version, m, locations...)}, nil, nil
+}
+
+// rockFiles returns the paths of the files listed within a rock manifest. Lua modules ("lua"), native modules ("lib")
+// and scripts ("bin") are installed within the tree, while everything else (e.g. docs) stays within the rock directory.
+// When the Lua version of the tree is not known, the Lua and native modules cannot be located and are not listed.
+func rockFiles(manifest luaTable, rockDir string) []string {
+	dirs := make(map[string]string)
+	if match := rocksDirPattern.FindStringSubmatch(path.Dir(path.Dir(rockDir))); match != nil {
+		tree, luaVersion := match[1], match[2]
+		dirs["bin"] = path.Join(tree, "bin")
+		if luaVersion != "" {
+			dirs["lua"] = path.Join(tree, "share", "lua", luaVersion)
+			dirs["lib"] = path.Join(tree, "lib", "lua", luaVersion)
+		}
+	}
+
+	var files []string
+	var walk func(string, interface{})
+	walk = func(p string, value interface{}) {
+		switch value := value.(type) {
+		case string:
+			// files are listed with their checksum
+			files = append(files, p)
+		case luaTable:
+			for name, child := range value.fields {
+				walk(path.Join(p, name), child)
+			}
+		}
+	}
+
+	for key, value := range manifest.fields {
+		dir, ok := dirs[key]
+		switch {
+		case ok:
+			walk(dir, value)
+		case key == "lua" || key == "lib" || key == "bin":
+			continue
+		default:
+			walk(path.Join(rockDir, key), value)
+		}
+	}
+
+	sort.Strings(files)
+	return files
+}
@@ -0,0 +1,66 @@
+package lua
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseRockspec
+
+// parseRockspec is a parser function for rockspec contents (see https://github.com/luarocks/luarocks/wiki/Rockspec-format).
+// Rockspecs within an installed rock are reported along with the rock_manifest instead.
+func parseRockspec(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	spec, err := readRockspec(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse rockspec: %w", err)
+	}
+
+	// rock names are case-insensitive, and are installed by their lower case name
+	name, version := strings.ToLower(spec.str("package")), spec.str("version")
+	if name == "" || version == "" {
+		return nil, nil, nil
+	}
+
+	if resolver != nil && resolver.RelativeFileByPath(reader.Location, path.Join(path.Dir(reader.RealPath), "rock_manifest")) != nil {
+		return nil, nil, nil
+	}
+
+	p := newPackage(name, version, rockspecMetadata(spec), reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation))
+	return []pkg.Package{p}, nil, nil
+}
+
+func readRockspec(reader io.Reader) (luaTable, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return luaTable{}, err
+	}
+	return parseLuaAssignments(string(contents))
+}
+
+func readRockspecLocation(resolver source.FileResolver, location source.Location) (luaTable, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return luaTable{}, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	return readRockspec(reader)
+}
+
+func rockspecMetadata(spec luaTable) pkg.LuaRocksMetadata {
+	return pkg.LuaRocksMetadata{
+		Summary:      spec.str("description", "summary"),
+		Homepage:     spec.str("description", "homepage"),
+		License:      spec.str("description", "license"),
+		SourceURL:    spec.str("source", "url"),
+		Dependencies: spec.strings("dependencies"),
+	}
+}
@@ -0,0 +1,41 @@
+package lua
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseRockspec(t *testing.T) {
+	fixture := "test-fixtures/lua-cjson-2.1.0.10-1.rockspec"
+	expected := []pkg.Package{
+		{
+			Name:         "lua-cjson",
+			Version:      "2.1.0.10-1",
+			PURL:         "pkg:luarocks/lua-cjson@2.1.0.10-1",
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Licenses:     []string{"MIT"},
+			Language:     pkg.Lua,
+			Type:         pkg.LuaRocksPkg,
+			MetadataType: pkg.LuaRocksMetadataType,
+			Metadata: pkg.LuaRocksMetadata{
+				Summary:      "A fast JSON encoding/parsing module",
+				Homepage:     "http://www.kyne.com.au/~mark/software/lua-cjson.php",
+				License:      "MIT",
+				SourceURL:    "git+https://github.com/openresty/lua-cjson",
+				Dependencies: []string{"lua >= 5.1", "lua-resty-core ~> 0.1"},
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseRockspec, expected, nil)
+}
+
+func TestParseRockspec_MissingVersion(t *testing.T) {
+	pkgtest.NewCatalogTester().
+		FromString("example.rockspec", `package = "example"`).
+		Expects(nil, nil).
+		TestParser(t, parseRockspec)
+}
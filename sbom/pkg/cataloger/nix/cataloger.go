@@ -2,9 +2,11 @@ package nix
 
 import (
 	"fmt"
+	"path"
 
 	"github.com/bmatcuk/doublestar/v4"
 
+	"github.com/nextlinux/sbom/internal"
 	"github.com/nextlinux/sbom/internal/log"
 	"github.com/nextlinux/sbom/sbom/artifact"
 	"github.com/nextlinux/sbom/sbom/pkg"
@@ -16,7 +18,9 @@ const (
 	nixStoreGlob  = "**/nix/store/*"
 )
 
-// StoreCataloger finds package outputs installed in the Nix store location (/nix/store/*).
+// StoreCataloger finds package outputs installed in the Nix store location (/nix/store/*). When the nix database
+// (/nix/var/nix/db/db.sqlite) or derivations (/nix/store/*.drv) are present, the references between store paths are
+// used to relate packages to one another.
 type StoreCataloger struct{}
 
 func NewStoreCataloger() *StoreCataloger {
@@ -30,6 +34,7 @@ func (c *StoreCataloger) Name() string {
 func (c *StoreCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
 	// we want to search for only directories, which isn't possible via the stereoscope API, so we need to apply the glob manually on all returned paths
 	var pkgs []pkg.Package
+	var basenames []string
 	var filesByPath = make(map[string]*source.LocationSet)
 	for location := range resolver.AllLocations() {
 		matchesStorePath, err := doublestar.Match(nixStoreGlob, location.RealPath)
@@ -58,6 +63,7 @@ func (c *StoreCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, [
 
 		p := newNixStorePackage(*storePath, location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation))
 		pkgs = append(pkgs, p)
+		basenames = append(basenames, storePathBasename(location.RealPath))
 	}
 
 	// add file sets to packages
@@ -77,7 +83,156 @@ func (c *StoreCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, [
 		appendFiles(p, files.ToSlice()...)
 	}
 
-	return pkgs, nil, nil
+	infos := storePathInfos(resolver)
+	for i := range pkgs {
+		if info, ok := infos[basenames[i]]; ok {
+			addStorePathInfo(&pkgs[i], *info)
+		}
+	}
+
+	return pkgs, dependencyRelationships(pkgs, basenames, infos), nil
+}
+
+// storePathInfos returns what is known about store paths from the nix database, falling back to the derivations in
+// the store for any store paths that are not registered in the database (keyed by store path basename).
+func storePathInfos(resolver source.FileResolver) map[string]*storePathInfo {
+	infos := make(map[string]*storePathInfo)
+
+	dbLocations, err := resolver.FilesByGlob(nixDBGlob)
+	if err != nil {
+		log.WithFields("error", err).Warn("unable to search for the nix database")
+	}
+	for _, location := range dbLocations {
+		dbInfos, err := parseNixDB(resolver, location)
+		if err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Warn("unable to read nix database")
+			continue
+		}
+		for basename, info := range dbInfos {
+			infos[basename] = info
+		}
+	}
+
+	derivations := make(map[string]*derivation)
+	drvLocations, err := resolver.FilesByGlob(derivationGlob)
+	if err != nil {
+		log.WithFields("error", err).Warn("unable to search for nix derivations")
+	}
+	for _, location := range drvLocations {
+		reader, err := resolver.FileContentsByLocation(location)
+		if err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Trace("unable to read nix derivation")
+			continue
+		}
+		d, err := parseDerivation(reader)
+		internal.CloseAndLogError(reader, location.RealPath)
+		if err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Trace("unable to parse nix derivation")
+			continue
+		}
+		derivations[storePathBasename(location.RealPath)] = d
+	}
+
+	for drvBasename, d := range derivations {
+		var references []string
+		for _, storePath := range d.outputs {
+			deriver := path.Join(path.Dir(storePath), drvBasename)
+			info, ok := infos[storePathBasename(storePath)]
+			if !ok {
+				// the references recorded in the nix database are those found within the built output, where the
+				// derivation inputs are the (possibly larger) set of store paths needed to build it
+				if references == nil {
+					references = d.references(derivations)
+				}
+				infos[storePathBasename(storePath)] = &storePathInfo{
+					deriver:    deriver,
+					references: references,
+				}
+				continue
+			}
+			if info.deriver == "" {
+				info.deriver = deriver
+			}
+		}
+	}
+
+	return infos
+}
+
+func addStorePathInfo(p *pkg.Package, info storePathInfo) {
+	metadata, ok := p.Metadata.(pkg.NixStoreMetadata)
+	if !ok {
+		log.WithFields("package", p.Name).Warn("nix package metadata missing")
+		return
+	}
+
+	metadata.Deriver = info.deriver
+	metadata.NarHash = info.narHash
+	metadata.Signatures = info.signatures
+
+	p.Metadata = metadata
+	p.SetID()
+}
+
+// dependencyRelationships relates each package to the packages that its store path references.
+func dependencyRelationships(pkgs []pkg.Package, basenames []string, infos map[string]*storePathInfo) []artifact.Relationship {
+	byBasename := make(map[string]pkg.Package)
+	for i, p := range pkgs {
+		byBasename[basenames[i]] = p
+	}
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	for i, p := range pkgs {
+		for _, dep := range referencedPackages(basenames[i], byBasename, infos) {
+			if dep.ID() == p.ID() {
+				continue
+			}
+			key := string(dep.ID()) + ":" + string(p.ID())
+			if seen.Contains(key) {
+				continue
+			}
+			seen.Add(key)
+
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
+}
+
+// referencedPackages returns the packages referenced by the given store path. Store paths that are not packages
+// (e.g. unversioned wrappers or sources) are followed to the packages that they reference.
+func referencedPackages(basename string, byBasename map[string]pkg.Package, infos map[string]*storePathInfo) []pkg.Package {
+	var result []pkg.Package
+	visited := internal.NewStringSet(basename)
+	queue := []string{basename}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		info, ok := infos[current]
+		if !ok {
+			continue
+		}
+		for _, ref := range info.references {
+			refBasename := storePathBasename(ref)
+			if visited.Contains(refBasename) {
+				continue
+			}
+			visited.Add(refBasename)
+
+			if p, ok := byBasename[refBasename]; ok {
+				result = append(result, p)
+				continue
+			}
+			queue = append(queue, refBasename)
+		}
+	}
+	return result
 }
 
 func appendFiles(p *pkg.Package, location ...source.Location) {
@@ -3,6 +3,10 @@ package nix
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
 	"github.com/nextlinux/sbom/sbom/artifact"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
@@ -53,3 +57,90 @@ func TestCataloger_Catalog(t *testing.T) {
 		})
 	}
 }
+
+func TestCataloger_Catalog_StorePathReferences(t *testing.T) {
+	tests := []struct {
+		fixture           string
+		wantMetadata      map[string]pkg.NixStoreMetadata
+		wantRelationships []string
+	}{
+		{
+			// the references are read from the nix database
+			fixture: "test-fixtures/db-closure",
+			wantMetadata: map[string]pkg.NixStoreMetadata{
+				"hello": {
+					OutputHash: "1x0ldbzim2jy8fb3ni3j5x9qck5bv0vw",
+					Deriver:    "/nix/store/4rdmm0x6n8lb1jpbwqwdc7l0n0v0ypgy-hello-2.12.1.drv",
+					NarHash:    "sha256:9c8f4e8a3b7d2d0c1a6f5e4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d",
+					Signatures: []string{
+						"cache.nixos.org-1:5Xa0Jp7HsdEDjWGYzXnYkLNq6nQZRk6JAbUfP4oBcC1T0mUa7OfxGSbp0yEB+Gr0p9KQo6M3qz5nAcc7dDwCBA==",
+						"example.org-1:pK2yT3Lx1XGzkSaZcQmGDdXfWvCbP6q8fEt+eJ7lRhWm4Uo0sNiAB9VwYrTz5gHnMjOqK2cFxDlE1y3iI6aaBg==",
+					},
+				},
+				"glibc": {
+					OutputHash: "9ajmv7r0jsfyp4x1ycg8b9s5q6jd9w4v",
+					Deriver:    "/nix/store/kc2m0rrx7ngqcy5gg5hq4i2y7b2wwvny-glibc-2.37-8.drv",
+					NarHash:    "sha256:0d7a3c1b5e9f8a2c4d6e0f1a3b5c7d9e2f4a6b8c0d1e3f5a7b9c2d4e6f8a0b1c",
+					Signatures: []string{
+						"cache.nixos.org-1:aCSAeT8lv/TU0Bbw1aJgVTIvoN9bZxHXQmVd3nrj5pYWsq2KLvFzPcDmHu7EiOkAwBxNgJ4RtyCfU6hElWaDBg==",
+					},
+				},
+				"libidn2": {
+					OutputHash: "0lk9sj8zq3a3w0ms3pmk0n4l7ybhbn3c",
+					NarHash:    "sha256:5b1e7d3f9a0c2e4b6d8f0a1c3e5b7d9f1a3c5e7b9d0f2a4c6e8b0d1f3a5c7e9b",
+				},
+			},
+			wantRelationships: []string{
+				"glibc -> hello",
+				// hello references libidn2 through the (unversioned) etc store path
+				"libidn2 -> hello",
+				"libidn2 -> glibc",
+			},
+		},
+		{
+			// the references are the inputs of the derivations (when there is no nix database)
+			fixture: "test-fixtures/derivations",
+			wantMetadata: map[string]pkg.NixStoreMetadata{
+				"hello": {
+					OutputHash: "7q3a4j1c0ngs8ffjmgi1xcg2lcw5hb0d",
+					Deriver:    "/nix/store/8sl2xkzm0qb5c4mlwnb8b1gcs3v1yh7x-hello-2.12.1.drv",
+				},
+				"glibc": {
+					OutputHash: "m3wq9s0rvk6yy8c3mbdr7l4d4ic0xz2p",
+					Deriver:    "/nix/store/fwmy7i1q39ybrw6r1zvf6xnd2h7c2vj4-glibc-2.37-8.drv",
+				},
+			},
+			wantRelationships: []string{
+				"glibc -> hello",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			src, err := source.NewFromDirectory(tt.fixture)
+			require.NoError(t, err)
+
+			resolver, err := src.FileResolver(source.SquashedScope)
+			require.NoError(t, err)
+
+			pkgs, relationships, err := NewStoreCataloger().Catalog(resolver)
+			require.NoError(t, err)
+
+			gotMetadata := make(map[string]pkg.NixStoreMetadata)
+			for _, p := range pkgs {
+				metadata, ok := p.Metadata.(pkg.NixStoreMetadata)
+				require.True(t, ok)
+				metadata.Files = nil
+				gotMetadata[p.Name] = metadata
+			}
+			assert.Equal(t, tt.wantMetadata, gotMetadata)
+
+			var gotRelationships []string
+			for _, r := range relationships {
+				assert.Equal(t, artifact.DependencyOfRelationship, r.Type)
+				gotRelationships = append(gotRelationships, r.From.(pkg.Package).Name+" -> "+r.To.(pkg.Package).Name)
+			}
+			assert.ElementsMatch(t, tt.wantRelationships, gotRelationships)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package nix
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const derivationGlob = "**/nix/store/*.drv"
+
+// derivation is the subset of a nix derivation (.drv file) needed to relate store paths to one another. Derivations
+// are serialized in the ATerm format, for example:
+//
+//	Derive([("out","/nix/store/<hash>-hello-2.12.1","","")],[("/nix/store/<hash>-bash-5.2.drv",["out"])],["/nix/store/<hash>-builder.sh"],"x86_64-linux",...)
+type derivation struct {
+	// outputs maps output names (e.g. "out", "bin") to the store paths they are built to
+	outputs map[string]string
+	// inputDerivations maps the derivations that this derivation depends on to the names of the outputs used
+	inputDerivations map[string][]string
+	// inputSources are the store paths (that are not outputs of other derivations) that this derivation depends on
+	inputSources []string
+}
+
+func parseDerivation(reader io.Reader) (*derivation, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read derivation: %w", err)
+	}
+
+	p := atermParser{data: string(contents)}
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	fields, ok := value.([]interface{})
+	if ok && len(fields) > 0 {
+		if _, versioned := fields[0].(string); versioned {
+			// derivations using experimental features are written as DrvWithVersion("<version>", ...)
+			fields = fields[1:]
+		}
+	}
+	if !ok || len(fields) < 3 {
+		return nil, fmt.Errorf("unexpected derivation structure")
+	}
+
+	d := derivation{
+		outputs:          make(map[string]string),
+		inputDerivations: make(map[string][]string),
+	}
+
+	for _, output := range tupleValues(fields[0]) {
+		name, _ := element(output, 0).(string)
+		storePath, _ := element(output, 1).(string)
+		if name != "" && storePath != "" {
+			d.outputs[name] = storePath
+		}
+	}
+
+	for _, input := range tupleValues(fields[1]) {
+		drvPath, _ := element(input, 0).(string)
+		if drvPath == "" {
+			continue
+		}
+		// note: newer derivations may describe the outputs of dynamic derivations here, in which case the outputs are
+		// not a list of names and all outputs of the input derivation are used
+		d.inputDerivations[drvPath] = stringValues(element(input, 1))
+	}
+
+	d.inputSources = stringValues(fields[2])
+
+	return &d, nil
+}
+
+// references returns the store paths that the outputs of the derivation depend on, resolving the outputs of input
+// derivations with the given (already parsed) derivations, keyed by store path basename.
+func (d derivation) references(derivations map[string]*derivation) []string {
+	refs := append([]string{}, d.inputSources...)
+	for drvPath, outputNames := range d.inputDerivations {
+		input, ok := derivations[storePathBasename(drvPath)]
+		if !ok {
+			continue
+		}
+		if len(outputNames) == 0 {
+			for _, storePath := range input.outputs {
+				refs = append(refs, storePath)
+			}
+			continue
+		}
+		for _, name := range outputNames {
+			if storePath, ok := input.outputs[name]; ok {
+				refs = append(refs, storePath)
+			}
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// atermParser parses the subset of the ATerm format used by nix derivations: strings, lists, tuples, and constructors
+// (e.g. Derive(...)). Lists, tuples, and constructor arguments are all returned as []interface{}, strings as string.
+type atermParser struct {
+	data string
+	pos  int
+}
+
+func (p *atermParser) value() (interface{}, error) {
+	if p.pos >= len(p.data) {
+		return nil, fmt.Errorf("unexpected end of derivation at offset=%d", p.pos)
+	}
+
+	switch c := p.data[p.pos]; {
+	case c == '"':
+		return p.string()
+	case c == '[':
+		return p.sequence('[', ']')
+	case c == '(':
+		return p.sequence('(', ')')
+	case isConstructorChar(c):
+		// the constructor name is not needed, only the arguments
+		for p.pos < len(p.data) && isConstructorChar(p.data[p.pos]) {
+			p.pos++
+		}
+		return p.sequence('(', ')')
+	default:
+		return nil, fmt.Errorf("unexpected character=%q in derivation at offset=%d", c, p.pos)
+	}
+}
+
+func (p *atermParser) sequence(open, closing byte) ([]interface{}, error) {
+	if p.pos >= len(p.data) || p.data[p.pos] != open {
+		return nil, fmt.Errorf("expected %q in derivation at offset=%d", open, p.pos)
+	}
+	p.pos++
+
+	values := []interface{}{}
+	for {
+		if p.pos >= len(p.data) {
+			return nil, fmt.Errorf("unexpected end of derivation at offset=%d", p.pos)
+		}
+		if p.data[p.pos] == closing {
+			p.pos++
+			return values, nil
+		}
+		if len(values) > 0 {
+			if p.data[p.pos] != ',' {
+				return nil, fmt.Errorf("expected ',' in derivation at offset=%d", p.pos)
+			}
+			p.pos++
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+}
+
+func (p *atermParser) string() (string, error) {
+	// skip the opening quote
+	p.pos++
+
+	var sb strings.Builder
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			if p.pos >= len(p.data) {
+				return "", fmt.Errorf("unterminated string in derivation")
+			}
+			escaped := p.data[p.pos]
+			p.pos++
+			switch escaped {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(escaped)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated string in derivation")
+}
+
+func isConstructorChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// tupleValues returns the elements of a parsed list that are themselves lists or tuples.
+func tupleValues(value interface{}) [][]interface{} {
+	values, _ := value.([]interface{})
+	var result [][]interface{}
+	for _, v := range values {
+		if t, ok := v.([]interface{}); ok {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// stringValues returns the elements of a parsed list that are strings.
+func stringValues(value interface{}) []string {
+	values, _ := value.([]interface{})
+	var result []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func element(values []interface{}, index int) interface{} {
+	if index >= len(values) {
+		return nil
+	}
+	return values[index]
+}
@@ -0,0 +1,139 @@
+package nix
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseDerivation(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		input   string
+		want    *derivation
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "derivation with inputs and escaped strings",
+			fixture: "test-fixtures/derivations/nix/store/8sl2xkzm0qb5c4mlwnb8b1gcs3v1yh7x-hello-2.12.1.drv",
+			want: &derivation{
+				outputs: map[string]string{
+					"out": "/nix/store/7q3a4j1c0ngs8ffjmgi1xcg2lcw5hb0d-hello-2.12.1",
+				},
+				inputDerivations: map[string][]string{
+					"/nix/store/pw4lm2d3w1ii9bv0zd8c9jbghrqfkg1s-bash-5.2-p15.drv": {"out"},
+					"/nix/store/fwmy7i1q39ybrw6r1zvf6xnd2h7c2vj4-glibc-2.37-8.drv": {"out"},
+				},
+				inputSources: []string{
+					"/nix/store/qb9k1h0zx6m3i7df2s8y0cgnjaw4r5vl-default-builder.sh",
+				},
+			},
+		},
+		{
+			name:    "derivation with multiple outputs",
+			fixture: "test-fixtures/derivations/nix/store/fwmy7i1q39ybrw6r1zvf6xnd2h7c2vj4-glibc-2.37-8.drv",
+			want: &derivation{
+				outputs: map[string]string{
+					"bin": "/nix/store/b8vy2q2kx7h0rxzxyn4hgf3m7p6d1j0a-glibc-2.37-8-bin",
+					"out": "/nix/store/m3wq9s0rvk6yy8c3mbdr7l4d4ic0xz2p-glibc-2.37-8",
+				},
+				inputDerivations: map[string][]string{},
+				inputSources: []string{
+					"/nix/store/qb9k1h0zx6m3i7df2s8y0cgnjaw4r5vl-default-builder.sh",
+				},
+			},
+		},
+		{
+			name:  "versioned derivation with a floating content-addressed output",
+			input: `DrvWithVersion("xp-dyn-drv",[("out","","r:sha256","")],[("/nix/store/fwmy7i1q39ybrw6r1zvf6xnd2h7c2vj4-glibc-2.37-8.drv",(["out"],[]))],[],"x86_64-linux","/bin/sh",[],[])`,
+			want: &derivation{
+				outputs: map[string]string{},
+				inputDerivations: map[string][]string{
+					"/nix/store/fwmy7i1q39ybrw6r1zvf6xnd2h7c2vj4-glibc-2.37-8.drv": nil,
+				},
+			},
+		},
+		{
+			name:    "unterminated string",
+			input:   `Derive([("out","/nix/store/7q3a4j1c0ngs8ffjmgi1xcg2lcw5hb0d-hello-2.12.1`,
+			wantErr: require.Error,
+		},
+		{
+			name:    "not a derivation",
+			input:   `{"out": "/nix/store/7q3a4j1c0ngs8ffjmgi1xcg2lcw5hb0d-hello-2.12.1"}`,
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+
+			input := tt.input
+			if tt.fixture != "" {
+				contents, err := os.ReadFile(tt.fixture)
+				require.NoError(t, err)
+				input = string(contents)
+			}
+
+			got, err := parseDerivation(strings.NewReader(input))
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_derivation_references(t *testing.T) {
+	derivations := map[string]*derivation{
+		"fwmy7i1q39ybrw6r1zvf6xnd2h7c2vj4-glibc-2.37-8.drv": {
+			outputs: map[string]string{
+				"bin": "/nix/store/b8vy2q2kx7h0rxzxyn4hgf3m7p6d1j0a-glibc-2.37-8-bin",
+				"out": "/nix/store/m3wq9s0rvk6yy8c3mbdr7l4d4ic0xz2p-glibc-2.37-8",
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		d    derivation
+		want []string
+	}{
+		{
+			name: "selected outputs of input derivations and input sources",
+			d: derivation{
+				inputDerivations: map[string][]string{
+					"/nix/store/fwmy7i1q39ybrw6r1zvf6xnd2h7c2vj4-glibc-2.37-8.drv": {"out"},
+					// not present in the store, so the outputs are unknown
+					"/nix/store/pw4lm2d3w1ii9bv0zd8c9jbghrqfkg1s-bash-5.2-p15.drv": {"out"},
+				},
+				inputSources: []string{"/nix/store/qb9k1h0zx6m3i7df2s8y0cgnjaw4r5vl-default-builder.sh"},
+			},
+			want: []string{
+				"/nix/store/m3wq9s0rvk6yy8c3mbdr7l4d4ic0xz2p-glibc-2.37-8",
+				"/nix/store/qb9k1h0zx6m3i7df2s8y0cgnjaw4r5vl-default-builder.sh",
+			},
+		},
+		{
+			name: "all outputs when none are selected",
+			d: derivation{
+				inputDerivations: map[string][]string{
+					"/nix/store/fwmy7i1q39ybrw6r1zvf6xnd2h7c2vj4-glibc-2.37-8.drv": nil,
+				},
+			},
+			want: []string{
+				"/nix/store/b8vy2q2kx7h0rxzxyn4hgf3m7p6d1j0a-glibc-2.37-8-bin",
+				"/nix/store/m3wq9s0rvk6yy8c3mbdr7l4d4ic0xz2p-glibc-2.37-8",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.d.references(derivations))
+		})
+	}
+}
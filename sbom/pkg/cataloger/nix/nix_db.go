@@ -0,0 +1,138 @@
+package nix
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+const nixDBGlob = "**/nix/var/nix/db/db.sqlite"
+
+// storePathInfo is what is known about a store path from the nix database or from the derivation that produced it.
+type storePathInfo struct {
+	deriver    string
+	narHash    string
+	signatures []string
+	// references are the store paths that this store path depends on
+	references []string
+}
+
+// parseNixDB reads the store paths registered in the nix database (see the ValidPaths and Refs tables), keyed by store
+// path basename.
+func parseNixDB(resolver source.FileResolver, location source.Location) (map[string]*storePathInfo, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read nix database: %w", err)
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	f, err := os.CreateTemp("", internal.ApplicationName+"-nixdb")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp nix database file: %w", err)
+	}
+
+	defer func() {
+		if err := os.Remove(f.Name()); err != nil {
+			log.Errorf("failed to remove temp nix database file: %+v", err)
+		}
+	}()
+
+	_, err = io.Copy(f, reader)
+	internal.CloseAndLogError(f, f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy nix database contents to temp file: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("unable to open nix database: %w", err)
+	}
+	defer internal.CloseAndLogError(db, location.RealPath)
+
+	infos, pathsByID, err := queryValidPaths(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := queryRefs(db, infos, pathsByID); err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+func queryValidPaths(db *sql.DB) (map[string]*storePathInfo, map[int64]string, error) {
+	rows, err := db.Query("SELECT id, path, hash, deriver, sigs FROM ValidPaths")
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to query nix database valid paths: %w", err)
+	}
+	defer rows.Close()
+
+	infos := make(map[string]*storePathInfo)
+	pathsByID := make(map[int64]string)
+	for rows.Next() {
+		var (
+			id            int64
+			storePath     string
+			hash          string
+			deriver, sigs sql.NullString
+		)
+		if err := rows.Scan(&id, &storePath, &hash, &deriver, &sigs); err != nil {
+			return nil, nil, fmt.Errorf("unable to read nix database valid path: %w", err)
+		}
+
+		info := &storePathInfo{
+			deriver: deriver.String,
+			narHash: hash,
+		}
+		// signatures are space separated
+		if fields := strings.Fields(sigs.String); len(fields) > 0 {
+			info.signatures = fields
+		}
+
+		pathsByID[id] = storePath
+		infos[storePathBasename(storePath)] = info
+	}
+
+	return infos, pathsByID, rows.Err()
+}
+
+func queryRefs(db *sql.DB, infos map[string]*storePathInfo, pathsByID map[int64]string) error {
+	rows, err := db.Query("SELECT referrer, reference FROM Refs ORDER BY referrer, reference")
+	if err != nil {
+		return fmt.Errorf("unable to query nix database references: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var referrer, reference int64
+		if err := rows.Scan(&referrer, &reference); err != nil {
+			return fmt.Errorf("unable to read nix database reference: %w", err)
+		}
+
+		// store paths commonly refer to themselves, which is not a dependency
+		if referrer == reference {
+			continue
+		}
+
+		referrerPath, ok := pathsByID[referrer]
+		if !ok {
+			continue
+		}
+		referencePath, ok := pathsByID[reference]
+		if !ok {
+			continue
+		}
+
+		info := infos[storePathBasename(referrerPath)]
+		info.references = append(info.references, referencePath)
+	}
+
+	return rows.Err()
+}
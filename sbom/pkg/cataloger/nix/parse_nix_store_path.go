@@ -54,6 +54,12 @@ func findParentNixStorePath(source string) string {
 	return source[0:startOfSubPath]
 }
 
+// storePathBasename returns the name of the store path within the store (e.g. /nix/store/<hash>-glibc-2.34 becomes
+// <hash>-glibc-2.34), which is the same regardless of where the store is found.
+func storePathBasename(storePath string) string {
+	return path.Base(strings.TrimRight(storePath, "/"))
+}
+
 func parseNixStorePath(source string) *nixStorePath {
 	if strings.HasSuffix(source, ".drv") {
 		// ignore derivations
@@ -0,0 +1,22 @@
+/*
+Package ocaml provides a concrete Cataloger implementation for OCaml packages, from the state of opam switches and
+opam lock files.
+*/
+package ocaml
+
+import (
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+)
+
+// NewOpamSwitchCataloger returns a new cataloger for OCaml packages installed within an opam switch.
+func NewOpamSwitchCataloger() *generic.Cataloger {
+	return generic.NewCataloger("opam-switch-cataloger").
+		WithParserByGlobs(parseOpamSwitchState, "**/.opam-switch/switch-state")
+}
+
+// NewOpamLockCataloger returns a new cataloger for OCaml packages pinned within the opam files of a project (typically
+// generated by "opam lock"). Opam files within an opam switch or root are skipped.
+func NewOpamLockCataloger() *generic.Cataloger {
+	return generic.NewCataloger("opam-lock-cataloger").
+		WithParserByGlobs(parseOpamLock, "**/*.opam", "**/*.opam.locked")
+}
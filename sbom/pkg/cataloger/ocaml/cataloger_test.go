@@ -0,0 +1,149 @@
+package ocaml
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func Test_OpamSwitchCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain opam switch state files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/default/.opam-switch/switch-state",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewOpamSwitchCataloger())
+		})
+	}
+}
+
+func Test_OpamLockCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain opam files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/example.opam",
+				"src/example.opam.locked",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewOpamLockCataloger())
+		})
+	}
+}
+
+func TestOpamSwitchCataloger(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/switch")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := NewOpamSwitchCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	prefix := "root/.opam/default"
+	state := source.NewLocation(prefix + "/.opam-switch/switch-state")
+	expectedPackage := func(name, version string, m pkg.OpamMetadata, locations ...source.Location) pkg.Package {
+		return pkg.Package{
+			Name:         name,
+			Version:      version,
+			FoundBy:      "opam-switch-cataloger",
+			PURL:         "pkg:opam/" + name + "@" + version,
+			Locations:    source.NewLocationSet(append([]source.Location{state}, locations...)...),
+			Licenses:     m.Licenses,
+			Language:     pkg.OCaml,
+			Type:         pkg.OpamPkg,
+			MetadataType: pkg.OpamMetadataType,
+			Metadata:     m,
+		}
+	}
+
+	expected := []pkg.Package{
+		expectedPackage("base-threads", "base", pkg.OpamMetadata{}),
+		expectedPackage("dune", "3.10.0",
+			pkg.OpamMetadata{
+				Synopsis: "Fast, portable, and opinionated build system",
+				Homepage: "https://github.com/ocaml/dune",
+				Licenses: []string{"MIT"},
+				URL:      "https://github.com/ocaml/dune/releases/download/3.10.0/dune-3.10.0.tbz",
+				Checksums: []string{
+					"sha256=9ff03384a98a8df79852cc674f0b4738ba8aec17029b6e2eeb514f895e710355",
+					"sha512=3f1e8cbd4ae5a5d0f2aa2b3e5d9e0c2c8a7b6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e",
+				},
+				Depends: []string{"ocaml", "ocamlfind-secondary", "base-unix", "base-threads"},
+				Root:    true,
+				Files: []string{
+					prefix + "/bin/dune",
+					prefix + "/bin/jbuilder",
+					prefix + "/doc/dune/README.md",
+					prefix + "/lib/dune/META",
+				},
+			},
+			source.NewLocation(prefix+"/.opam-switch/packages/dune.3.10.0/opam"),
+			source.NewLocation(prefix+"/.opam-switch/install/dune.changes"),
+		),
+		expectedPackage("mylib", "dev", pkg.OpamMetadata{Root: true, Pinned: true}),
+		expectedPackage("ocaml", "4.14.1",
+			pkg.OpamMetadata{
+				Synopsis: "The OCaml compiler (virtual package)",
+				Homepage: "https://ocaml.org",
+				Licenses: []string{"LGPL-2.1-or-later WITH OCaml-LGPL-linking-exception"},
+				Depends:  []string{"ocaml-config", "ocaml-base-compiler", "ocaml-variants", "ocaml-system"},
+			},
+			source.NewLocation(prefix+"/.opam-switch/packages/ocaml.4.14.1/opam"),
+		),
+		expectedPackage("ocaml-base-compiler", "4.14.1", pkg.OpamMetadata{Root: true}),
+	}
+
+	require.Len(t, pkgs, len(expected))
+	for i := range expected {
+		pkgtest.AssertPackagesEqual(t, expected[i], pkgs[i])
+	}
+
+	var actual []string
+	for _, r := range relationships {
+		from, ok := r.From.(pkg.Package)
+		require.True(t, ok)
+		to, ok := r.To.(pkg.Package)
+		require.True(t, ok)
+		actual = append(actual, fmt.Sprintf("%s -[%s]-> %s", from.Name, r.Type, to.Name))
+	}
+
+	assert.ElementsMatch(t, []string{
+		"ocaml -[dependency-of]-> dune",
+		"base-threads -[dependency-of]-> dune",
+		"ocaml-base-compiler -[dependency-of]-> ocaml",
+		// base-unix, ocamlfind-secondary, ocaml-config and the alternative compilers are not installed
+	}, actual)
+}
@@ -0,0 +1,377 @@
+package ocaml
+
+import (
+	"fmt"
+	"strings"
+)
+
+// opamSection is a file (or a section within a file, such as url { ... }) in the opam file format
+// (see https://opam.ocaml.org/doc/Manual.html#Common-file-format).
+type opamSection struct {
+	fields   map[string]opamValue
+	sections map[string]opamSection
+}
+
+// opamValue is one of:
+//   - string: a quoted string
+//   - opamIdent: an unquoted identifier, boolean or integer (e.g. with-test)
+//   - opamOperator: a relational or logical operator (e.g. >= or &)
+//   - opamList: a list ([...]), a group ((...)) or a field with several values
+//   - opamOptions: a value followed by options ({...})
+type opamValue interface{}
+
+type (
+	opamIdent    string
+	opamOperator string
+	opamList     []opamValue
+)
+
+type opamOptions struct {
+	value   opamValue
+	options opamList
+}
+
+func (s opamSection) str(name string) string {
+	value, _ := s.fields[name].(string)
+	return value
+}
+
+// strings returns the strings of a field that may hold a string or a list of strings.
+func (s opamSection) strings(name string) []string {
+	return opamStrings(s.fields[name])
+}
+
+func opamStrings(value opamValue) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case opamOptions:
+		return opamStrings(value.value)
+	case opamList:
+		var values []string
+		for _, item := range value {
+			values = append(values, opamStrings(item)...)
+		}
+		return values
+	}
+	return nil
+}
+
+// opamDependency is a package named within a dependency formula (such as the depends field), along with its version
+// constraint, for example "dune" {>= "3.0" & with-test}.
+type opamDependency struct {
+	name    string
+	options opamList
+}
+
+// version returns the version that a dependency is pinned to (e.g. "dune" {= "3.10.0"}), if any.
+func (d opamDependency) version() string {
+	for i := 0; i+1 < len(d.options); i++ {
+		if op, ok := d.options[i].(opamOperator); ok && op == "=" {
+			if version, ok := d.options[i+1].(string); ok {
+				return version
+			}
+		}
+	}
+	return ""
+}
+
+// opamDependencies returns all packages named within a dependency formula. All alternatives of a disjunction
+// (e.g. ("a" | "b")) are included.
+func opamDependencies(value opamValue) []opamDependency {
+	switch value := value.(type) {
+	case string:
+		return []opamDependency{{name: value}}
+	case opamOptions:
+		if name, ok := value.value.(string); ok {
+			return []opamDependency{{name: name, options: value.options}}
+		}
+		return opamDependencies(value.value)
+	case opamList:
+		var dependencies []opamDependency
+		for _, item := range value {
+			dependencies = append(dependencies, opamDependencies(item)...)
+		}
+		return dependencies
+	}
+	return nil
+}
+
+type opamTokenKind int
+
+const (
+	opamStringToken opamTokenKind = iota
+	opamIdentToken
+	opamOperatorToken
+	opamSymbolToken
+)
+
+type opamToken struct {
+	kind  opamTokenKind
+	value string
+}
+
+type opamParser struct {
+	tokens []opamToken
+	pos    int
+}
+
+func parseOpamFile(contents string) (opamSection, error) {
+	tokens, err := tokenizeOpam(contents)
+	if err != nil {
+		return opamSection{}, err
+	}
+
+	p := &opamParser{tokens: tokens}
+	return p.section(false)
+}
+
+func (p *opamParser) peek(offset int) opamToken {
+	if p.pos+offset >= len(p.tokens) {
+		return opamToken{kind: opamSymbolToken}
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *opamParser) isSymbol(offset int, symbol string) bool {
+	t := p.peek(offset)
+	return t.kind == opamSymbolToken && t.value == symbol
+}
+
+func (p *opamParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// isItemStart returns whether the next tokens start a field (name:) or a section (name { or name "label" {).
+func (p *opamParser) isItemStart() bool {
+	if p.peek(0).kind != opamIdentToken {
+		return false
+	}
+	return p.isSymbol(1, ":") || p.isSymbol(1, "{") || (p.peek(1).kind == opamStringToken && p.isSymbol(2, "{"))
+}
+
+func (p *opamParser) section(nested bool) (opamSection, error) {
+	s := opamSection{
+		fields:   make(map[string]opamValue),
+		sections: make(map[string]opamSection),
+	}
+	for !p.done() {
+		if nested && p.isSymbol(0, "}") {
+			p.pos++
+			return s, nil
+		}
+		if !p.isItemStart() {
+			return s, fmt.Errorf("unexpected %q", p.peek(0).value)
+		}
+
+		name := p.peek(0).value
+		switch {
+		case p.isSymbol(1, ":"):
+			p.pos += 2
+			value, err := p.fieldValue()
+			if err != nil {
+				return s, err
+			}
+			s.fields[name] = value
+		default:
+			// a section, which may be labelled (e.g. extra-source "file.patch" { ... })
+			p.pos++
+			if p.peek(0).kind == opamStringToken {
+				p.pos++
+			}
+			p.pos++
+			sub, err := p.section(true)
+			if err != nil {
+				return s, err
+			}
+			if _, exists := s.sections[name]; !exists {
+				s.sections[name] = sub
+			}
+		}
+	}
+	if nested {
+		return s, fmt.Errorf("unterminated section")
+	}
+	return s, nil
+}
+
+// fieldValue parses the value of a field, which continues up to the start of the next field or section.
+func (p *opamParser) fieldValue() (opamValue, error) {
+	var values opamList
+	for !p.done() && !p.isItemStart() && !p.isSymbol(0, "}") {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	if len(values) == 1 {
+		return values[0], nil
+	}
+	return values, nil
+}
+
+func (p *opamParser) value() (opamValue, error) {
+	t := p.peek(0)
+	p.pos++
+
+	var value opamValue
+	switch t.kind {
+	case opamStringToken:
+		value = t.value
+	case opamIdentToken:
+		value = opamIdent(t.value)
+	case opamOperatorToken:
+		return opamOperator(t.value), nil
+	case opamSymbolToken:
+		switch t.value {
+		case "[":
+			list, err := p.list("]")
+			if err != nil {
+				return nil, err
+			}
+			value = list
+		case "(":
+			list, err := p.list(")")
+			if err != nil {
+				return nil, err
+			}
+			value = list
+		default:
+			return nil, fmt.Errorf("unexpected %q", t.value)
+		}
+	}
+
+	if p.isSymbol(0, "{") {
+		p.pos++
+		options, err := p.list("}")
+		if err != nil {
+			return nil, err
+		}
+		return opamOptions{value: value, options: options}, nil
+	}
+	return value, nil
+}
+
+func (p *opamParser) list(closing string) (opamList, error) {
+	list := opamList{}
+	for !p.done() {
+		if p.isSymbol(0, closing) {
+			p.pos++
+			return list, nil
+		}
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+	return nil, fmt.Errorf("missing %q", closing)
+}
+
+// tokenizeOpam splits an opam file into strings (unquoted), identifiers, operators and symbols, dropping comments.
+func tokenizeOpam(contents string) ([]opamToken, error) {
+	var tokens []opamToken
+	for i := 0; i < len(contents); {
+		c := contents[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '#':
+			for i < len(contents) && contents[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(contents[i:], "(*"):
+			end := strings.Index(contents[i+2:], "*)")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += 2 + end + 2
+		case strings.HasPrefix(contents[i:], `"""`):
+			end := strings.Index(contents[i+3:], `"""`)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, opamToken{kind: opamStringToken, value: contents[i+3 : i+3+end]})
+			i += 3 + end + 3
+		case c == '"':
+			value, n, err := opamQuotedString(contents[i:])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, opamToken{kind: opamStringToken, value: value})
+			i += n
+		case strings.ContainsRune("[](){}:", rune(c)):
+			tokens = append(tokens, opamToken{kind: opamSymbolToken, value: string(c)})
+			i++
+		case strings.ContainsRune("=!<>&|?+~", rune(c)):
+			start := i
+			for i < len(contents) && strings.ContainsRune("=!<>&|?+~", rune(contents[i])) {
+				i++
+			}
+			tokens = append(tokens, opamToken{kind: opamOperatorToken, value: contents[start:i]})
+		default:
+			start := i
+			for i < len(contents) && isOpamIdentChar(contents, i, start) {
+				i++
+			}
+			if i == start {
+				return nil, fmt.Errorf("unexpected %q", c)
+			}
+			tokens = append(tokens, opamToken{kind: opamIdentToken, value: contents[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+// isOpamIdentChar returns whether the character at the given index continues the identifier (or integer) that begins
+// at the given start. Identifiers may include a package prefix (e.g. ocaml:version) and a "+" after the first character.
+func isOpamIdentChar(contents string, i, start int) bool {
+	c := contents[i]
+	switch {
+	case c == '_' || c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9'):
+		return true
+	case c == '+':
+		return i > start && (i+1 >= len(contents) || contents[i+1] != '=')
+	case c == ':':
+		return i > start && i+1 < len(contents) && isOpamIdentStart(contents[i+1])
+	}
+	return false
+}
+
+func isOpamIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// opamQuotedString returns the (unescaped) contents of a quoted string at the start of the given string, along with
+// the length of the quoted string.
+func opamQuotedString(s string) (string, int, error) {
+	var value strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return value.String(), i + 1, nil
+		case '\\':
+			i++
+			if i >= len(s) {
+				return "", 0, fmt.Errorf("unterminated string")
+			}
+			switch e := s[i]; e {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			case '\n':
+				// an escaped newline continues the string, skipping leading blanks on the next line
+				for i+1 < len(s) && (s[i+1] == ' ' || s[i+1] == '\t') {
+					i++
+				}
+			default:
+				value.WriteByte(e)
+			}
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
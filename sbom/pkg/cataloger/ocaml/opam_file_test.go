@@ -0,0 +1,66 @@
+package ocaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseOpamFile(t *testing.T) {
+	contents := `
+opam-version: "2.0" # comment
+synopsis: "a \"quoted\" synopsis"
+license: ["MIT" "ISC"]
+depends: [
+  "ocaml" {>= "4.08" & < "5.0~"}
+  ("lwt" | "async" {= "v0.16.0"})
+  "alcotest" {with-test}
+]
+available: os != "win32" & ocaml:version >= "4.08"
+(* a block
+   comment *)
+description: """
+multi-line "description"
+"""
+url {
+  src: "https://example.com/example-1.0.tar.gz"
+  checksum: "md5=d41d8cd98f00b204e9800998ecf8427e"
+}
+extra-source "fix.patch" {
+  src: "https://example.com/fix.patch"
+}
+`
+	file, err := parseOpamFile(contents)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0", file.str("opam-version"))
+	assert.Equal(t, `a "quoted" synopsis`, file.str("synopsis"))
+	assert.Equal(t, []string{"MIT", "ISC"}, file.strings("license"))
+	assert.Equal(t, "\nmulti-line \"description\"\n", file.str("description"))
+	assert.Equal(t, "https://example.com/example-1.0.tar.gz", file.sections["url"].str("src"))
+	assert.Equal(t, []string{"md5=d41d8cd98f00b204e9800998ecf8427e"}, file.sections["url"].strings("checksum"))
+	assert.Equal(t, "https://example.com/fix.patch", file.sections["extra-source"].str("src"))
+
+	var names, versions []string
+	for _, d := range opamDependencies(file.fields["depends"]) {
+		names = append(names, d.name)
+		versions = append(versions, d.version())
+	}
+	assert.Equal(t, []string{"ocaml", "lwt", "async", "alcotest"}, names)
+	assert.Equal(t, []string{"", "", "v0.16.0", ""}, versions)
+}
+
+func Test_parseOpamFile_Invalid(t *testing.T) {
+	for _, contents := range []string{
+		`depends: ["ocaml"`,
+		`synopsis: "unterminated`,
+		`url { src: "https://example.com"`,
+		`"not a field"`,
+	} {
+		t.Run(contents, func(t *testing.T) {
+			_, err := parseOpamFile(contents)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package ocaml
+
+import (
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func newPackage(name, version string, m pkg.OpamMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(name, version),
+		Licenses:     m.Licenses,
+		Language:     pkg.OCaml,
+		Type:         pkg.OpamPkg,
+		MetadataType: pkg.OpamMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func packageURL(name, version string) string {
+	return packageurl.NewPackageURL(pkg.OpamPkg.PackageURLType(), "", name, version, nil, "").ToString()
+}
+
+// opamMetadata returns the metadata described by an opam file (see https://opam.ocaml.org/doc/Manual.html#opam).
+func opamMetadata(file opamSection) pkg.OpamMetadata {
+	m := pkg.OpamMetadata{
+		Synopsis: file.str("synopsis"),
+		Homepage: firstString(file.strings("homepage")),
+		Licenses: file.strings("license"),
+	}
+
+	if url, ok := file.sections["url"]; ok {
+		m.URL = url.str("src")
+		if m.URL == "" {
+			// opam 1.2 files name the source archive "archive"
+			m.URL = url.str("archive")
+		}
+		m.Checksums = url.strings("checksum")
+	}
+
+	names := internal.NewStringSet()
+	for _, d := range opamDependencies(file.fields["depends"]) {
+		if !names.Contains(d.name) {
+			names.Add(d.name)
+			m.Depends = append(m.Depends, d.name)
+		}
+	}
+	return m
+}
+
+func firstString(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
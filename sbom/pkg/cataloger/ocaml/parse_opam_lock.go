@@ -0,0 +1,79 @@
+package ocaml
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseOpamLock
+
+// parseOpamLock is a parser function for opam files of a project, returning the dependencies that are pinned to a
+// version (e.g. "dune" {= "3.10.0"}), as written by "opam lock". When the project declares a version, the project is
+// returned as well, along with its relationships to the pinned dependencies.
+func parseOpamLock(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	// in the case we find opam files within an opam switch or root (e.g. the sources of installed packages), skip those
+	// as they describe the installed packages rather than the dependencies of a project
+	if pathContainsOpamDirectory(reader.AccessPath()) {
+		return nil, nil, nil
+	}
+
+	file, err := readOpamFile(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse opam file: %w", err)
+	}
+
+	location := reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)
+
+	var pkgs []pkg.Package
+	var project *pkg.Package
+	if version := file.str("version"); version != "" {
+		name := file.str("name")
+		if name == "" {
+			// the name of a project opam file defaults to the name of the file (<name>.opam or <name>.opam.locked)
+			name = strings.TrimSuffix(strings.TrimSuffix(path.Base(reader.RealPath), ".locked"), ".opam")
+		}
+		p := newPackage(name, version, opamMetadata(file), location)
+		pkgs = append(pkgs, p)
+		project = &p
+	}
+
+	var relationships []artifact.Relationship
+	names := internal.NewStringSet()
+	for _, d := range opamDependencies(file.fields["depends"]) {
+		version := d.version()
+		if version == "" || names.Contains(d.name) {
+			continue
+		}
+		names.Add(d.name)
+
+		dep := newPackage(d.name, version, pkg.OpamMetadata{}, location)
+		pkgs = append(pkgs, dep)
+
+		if project != nil {
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   *project,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+
+	return pkgs, relationships, nil
+}
+
+// pathContainsOpamDirectory returns whether the path is within a local opam switch (_opam) or an opam root (.opam).
+func pathContainsOpamDirectory(p string) bool {
+	for _, dir := range strings.Split(p, "/") {
+		if dir == "_opam" || dir == ".opam" {
+			return true
+		}
+	}
+	return false
+}
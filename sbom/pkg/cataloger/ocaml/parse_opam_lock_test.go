@@ -0,0 +1,101 @@
+package ocaml
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseOpamLock(t *testing.T) {
+	fixture := "test-fixtures/myapp.opam.locked"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	dependency := func(name, version string) pkg.Package {
+		return pkg.Package{
+			Name:         name,
+			Version:      version,
+			PURL:         "pkg:opam/" + name + "@" + version,
+			Locations:    locations,
+			Language:     pkg.OCaml,
+			Type:         pkg.OpamPkg,
+			MetadataType: pkg.OpamMetadataType,
+			Metadata:     pkg.OpamMetadata{},
+		}
+	}
+
+	myapp := pkg.Package{
+		Name:         "myapp",
+		Version:      "~dev",
+		PURL:         "pkg:opam/myapp@~dev",
+		Locations:    locations,
+		Licenses:     []string{"ISC"},
+		Language:     pkg.OCaml,
+		Type:         pkg.OpamPkg,
+		MetadataType: pkg.OpamMetadataType,
+		Metadata: pkg.OpamMetadata{
+			Synopsis: "An example application",
+			Licenses: []string{"ISC"},
+			Depends:  []string{"base-threads", "dune", "ocaml", "lwt", "odoc"},
+		},
+	}
+	baseThreads := dependency("base-threads", "base")
+	dune := dependency("dune", "3.10.0")
+	ocaml := dependency("ocaml", "4.14.1")
+	lwt := dependency("lwt", "5.7.0")
+
+	// odoc is not pinned to a version
+	expectedPkgs := []pkg.Package{myapp, baseThreads, dune, ocaml, lwt}
+
+	var expectedRelationships []artifact.Relationship
+	for _, dep := range []pkg.Package{baseThreads, dune, ocaml, lwt} {
+		expectedRelationships = append(expectedRelationships, artifact.Relationship{
+			From: dep,
+			To:   myapp,
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseOpamLock, expectedPkgs, expectedRelationships)
+}
+
+func TestParseOpamLock_NoVersion(t *testing.T) {
+	// without a version only the pinned dependencies are reported, which are not related to the project
+	fixture := "example.opam"
+	expected := []pkg.Package{
+		{
+			Name:         "dune",
+			Version:      "3.10.0",
+			PURL:         "pkg:opam/dune@3.10.0",
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Language:     pkg.OCaml,
+			Type:         pkg.OpamPkg,
+			MetadataType: pkg.OpamMetadataType,
+			Metadata:     pkg.OpamMetadata{},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		FromString(fixture, "opam-version: \"2.0\"\ndepends: [\"dune\" {= \"3.10.0\"} \"ocaml\" {>= \"4.08\"}]\n").
+		Expects(expected, nil).
+		TestParser(t, parseOpamLock)
+}
+
+func TestParseOpamLock_OpamDirectories(t *testing.T) {
+	// opam files of the packages installed within an opam switch are not the dependencies of a project
+	tests := []string{
+		"_opam/.opam-switch/sources/dune.3.10.0/dune.opam",
+		"root/.opam/default/.opam-switch/sources/dune.3.10.0/dune.opam",
+	}
+
+	for _, fixture := range tests {
+		t.Run(fixture, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromString(fixture, "opam-version: \"2.0\"\nversion: \"3.10.0\"\ndepends: [\"ocaml\" {= \"4.14.1\"}]\n").
+				Expects(nil, nil).
+				TestParser(t, parseOpamLock)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package ocaml
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseOpamSwitchState
+
+// parseOpamSwitchState is a parser function for the state of an opam switch (<switch>/.opam-switch/switch-state),
+// returning all installed packages along with the relationships between them. Each package is described by the opam
+// file kept within .opam-switch/packages/<name>.<version>/, and the files it installed are listed within
+// .opam-switch/install/<name>.changes.
+func parseOpamSwitchState(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	state, err := readOpamFile(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse opam switch-state: %w", err)
+	}
+
+	metaDir := path.Dir(reader.RealPath)
+	prefix := path.Dir(metaDir)
+
+	roots := internal.NewStringSet(state.strings("roots")...)
+	pinned := internal.NewStringSet(state.strings("pinned")...)
+
+	var pkgs []pkg.Package
+	for _, nv := range state.strings("installed") {
+		// package names cannot contain a dot, while versions may
+		name, version, found := strings.Cut(nv, ".")
+		if !found {
+			continue
+		}
+
+		locations := []source.Location{reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)}
+
+		var m pkg.OpamMetadata
+		if resolver != nil {
+			if location := resolver.RelativeFileByPath(reader.Location, path.Join(metaDir, "packages", nv, "opam")); location != nil {
+				file, err := readOpamFileLocation(resolver, *location)
+				if err != nil {
+					log.WithFields("path", location.RealPath, "error", err).Debug("unable to parse opam file")
+				} else {
+					m = opamMetadata(file)
+					locations = append(locations, location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+				}
+			}
+
+			if location := resolver.RelativeFileByPath(reader.Location, path.Join(metaDir, "install", name+".changes")); location != nil {
+				changes, err := readOpamFileLocation(resolver, *location)
+				if err != nil {
+					log.WithFields("path", location.RealPath, "error", err).Debug("unable to parse opam changes file")
+				} else {
+					m.Files = installedFiles(changes, prefix)
+					locations = append(locations, location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+				}
+			}
+		}
+		m.Root = roots.Contains(nv)
+		m.Pinned = pinned.Contains(nv)
+
+		pkgs = append(pkgs, newPackage(name, version, m, locations...))
+	}
+
+	return pkgs, switchDependencyRelationships(pkgs), nil
+}
+
+// installedFiles returns the paths of the files (and links) that were added to the switch by a package, as recorded
+// within its changes file, for example:
+//
+//	added: [
+//	  "bin/dune" {"F:sha256=..."}
+//	  "lib/dune" {"D"}
+//	]
+func installedFiles(changes opamSection, prefix string) []string {
+	var files []string
+	added, _ := changes.fields["added"].(opamList)
+	for _, item := range added {
+		entry, ok := item.(opamOptions)
+		if !ok {
+			continue
+		}
+		file, _ := entry.value.(string)
+		kind := firstString(opamStrings(entry.options))
+		if file != "" && (strings.HasPrefix(kind, "F") || strings.HasPrefix(kind, "L")) {
+			files = append(files, path.Join(prefix, file))
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// switchDependencyRelationships relates each installed package to the installed packages that it depends on.
+func switchDependencyRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	installed := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		installed[p.Name] = p
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.OpamMetadata)
+		if !ok {
+			continue
+		}
+		for _, name := range m.Depends {
+			dep, ok := installed[name]
+			if !ok || dep.ID() == p.ID() {
+				continue
+			}
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
+}
+
+func readOpamFile(reader io.Reader) (opamSection, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return opamSection{}, err
+	}
+	return parseOpamFile(string(contents))
+}
+
+func readOpamFileLocation(resolver source.FileResolver, location source.Location) (opamSection, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return opamSection{}, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	return readOpamFile(reader)
+}
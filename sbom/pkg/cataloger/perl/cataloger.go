@@ -0,0 +1,67 @@
+/*
+Package perl provides a concrete Cataloger implementation for Perl distributions, from carton cpanfile.snapshot files
+and the metadata of installed distributions.
+*/
+package perl
+
+import (
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// NewCpanfileSnapshotCataloger returns a new cataloger for Perl distributions locked within carton cpanfile.snapshot
+// files.
+func NewCpanfileSnapshotCataloger() *generic.Cataloger {
+	return generic.NewCataloger("perl-cpanfile-snapshot-cataloger").
+		WithParserByGlobs(parseCpanfileSnapshot, "**/cpanfile.snapshot")
+}
+
+// NewInstalledCataloger returns a new cataloger for installed Perl distributions, from the MYMETA.json files that cpanm
+// keeps for each installed distribution and the .packlist files written by the installer.
+func NewInstalledCataloger() pkg.Cataloger {
+	return &installedCataloger{}
+}
+
+type installedCataloger struct{}
+
+func (c *installedCataloger) Name() string {
+	return "perl-installed-cataloger"
+}
+
+func (c *installedCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	pkgs, relationships, err := generic.NewCataloger(c.Name()).
+		WithParserByGlobs(parseMymeta, mymetaGlob).
+		WithParserByGlobs(parsePacklist, packlistGlob).
+		Catalog(resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return withoutClaimedPacklists(pkgs), relationships, nil
+}
+
+// withoutClaimedPacklists removes the packages found from a .packlist alone when the same .packlist is supporting
+// evidence for a package found from a MYMETA.json file, since both describe the same installed distribution.
+func withoutClaimedPacklists(pkgs []pkg.Package) []pkg.Package {
+	claimed := internal.NewStringSet()
+	for _, p := range pkgs {
+		for _, location := range p.Locations.ToSlice() {
+			if location.Annotations[pkg.EvidenceAnnotationKey] == pkg.SupportingEvidenceAnnotation {
+				claimed.Add(location.RealPath)
+			}
+		}
+	}
+
+	var result []pkg.Package
+	for _, p := range pkgs {
+		locations := p.Locations.ToSlice()
+		if len(locations) == 1 && claimed.Contains(locations[0].RealPath) {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package perl
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func Test_CpanfileSnapshotCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain cpanfile.snapshot files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/cpanfile.snapshot",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewCpanfileSnapshotCataloger())
+		})
+	}
+}
+
+func Test_InstalledCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain installed distribution metadata and packlist files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/lib/perl5/.meta/Foo-1.0/MYMETA.json",
+				"src/lib/perl5/auto/Foo/.packlist",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewInstalledCataloger())
+		})
+	}
+}
+
+func TestInstalledCataloger(t *testing.T) {
+	archlib := "usr/local/lib/x86_64-linux-gnu/perl/5.36.0"
+
+	expected := []pkg.Package{
+		{
+			Name:    "Module-Build",
+			Version: "0.4234",
+			FoundBy: "perl-installed-cataloger",
+			PURL:    "pkg:cpan/Module::Build@0.4234",
+			Locations: source.NewLocationSet(
+				source.NewLocation(archlib+"/.meta/Module-Build-0.4234/MYMETA.json"),
+				source.NewLocation(archlib+"/auto/Module/Build/.packlist"),
+			),
+			Licenses:     []string{"perl_5"},
+			Language:     pkg.Perl,
+			Type:         pkg.CpanPkg,
+			MetadataType: pkg.CpanMetadataType,
+			Metadata: pkg.CpanMetadata{
+				Abstract: "Build and install Perl modules",
+				Authors:  []string{"Ken Williams <kwilliams@cpan.org>", "Module-Build List <module-build@perl.org>"},
+				Licenses: []string{"perl_5"},
+				Provides: []string{"Module::Build", "Module::Build::Base"},
+				Requires: []string{"CPAN::Meta 2.142060", "ExtUtils::Install 0", "perl 5.006001"},
+				Files: []string{
+					"/usr/local/bin/config_data",
+					"/usr/local/share/man/man1/config_data.1p",
+					"/usr/local/share/perl/5.36.0/Module/Build.pm",
+					"/usr/local/share/perl/5.36.0/Module/Build/Base.pm",
+				},
+			},
+		},
+		{
+			// there is no MYMETA.json for Try-Tiny, so the version is read from the main module
+			Name:         "Try-Tiny",
+			Version:      "0.31",
+			FoundBy:      "perl-installed-cataloger",
+			PURL:         "pkg:cpan/Try::Tiny@0.31",
+			Locations:    source.NewLocationSet(source.NewLocation(archlib + "/auto/Try/Tiny/.packlist")),
+			Language:     pkg.Perl,
+			Type:         pkg.CpanPkg,
+			MetadataType: pkg.CpanMetadataType,
+			Metadata: pkg.CpanMetadata{
+				Files: []string{
+					"/usr/local/share/man/man3/Try::Tiny.3pm",
+					"/usr/local/share/perl/5.36.0/Try/Tiny.pm",
+					"/usr/local/share/perl/5.36.0/Try/Tiny.pm.orig",
+				},
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/installed").
+		Expects(expected, nil).
+		TestCataloger(t, NewInstalledCataloger())
+}
@@ -0,0 +1,42 @@
+package perl
+
+import (
+	"strings"
+
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func newPackage(name, version string, m pkg.CpanMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(name, version, m.CpanID),
+		Licenses:     m.Licenses,
+		Language:     pkg.Perl,
+		Type:         pkg.CpanPkg,
+		MetadataType: pkg.CpanMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// packageURL returns the package URL of a distribution. A distribution can only be identified by name along with the
+// author that released it (e.g. pkg:cpan/LEONT/Module-Build@0.4224), otherwise the main module of the distribution is
+// used (e.g. pkg:cpan/Module::Build@0.4224).
+func packageURL(name, version, cpanID string) string {
+	if cpanID == "" {
+		name = moduleName(name)
+	}
+	return packageurl.NewPackageURL(pkg.CpanPkg.PackageURLType(), cpanID, name, version, nil, "").ToString()
+}
+
+// moduleName returns the name of the main module of a distribution (e.g. Module-Build -> Module::Build).
+func moduleName(distribution string) string {
+	return strings.ReplaceAll(distribution, "-", "::")
+}
@@ -0,0 +1,158 @@
+package perl
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseCpanfileSnapshot
+
+// cpanAuthorPattern matches the author directory of a distribution pathname (e.g. L/LE/LEONT/Module-Build-0.4224.tar.gz)
+var cpanAuthorPattern = regexp.MustCompile(`(?:^|/)([A-Z])/([A-Z][-A-Z0-9])/([A-Z][-A-Z0-9]*)/[^/]+$`)
+
+// snapshotDistribution is an entry within the DISTRIBUTIONS section of a cpanfile.snapshot, for example:
+//
+//	DISTRIBUTIONS
+//	  Module-Build-0.4224
+//	    pathname: L/LE/LEONT/Module-Build-0.4224.tar.gz
+//	    provides:
+//	      Module::Build 0.4224
+//	    requirements:
+//	      CPAN::Meta 2.142060
+type snapshotDistribution struct {
+	distribution string
+	pathname     string
+	provides     []string
+	requirements []string
+}
+
+// parseCpanfileSnapshot is a parser function for carton cpanfile.snapshot contents, returning all locked distributions
+// along with the relationships between them.
+func parseCpanfileSnapshot(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var (
+		distributions []*snapshotDistribution
+		current       *snapshotDistribution
+		inSection     bool
+		field         string
+	)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		text := strings.TrimSpace(line)
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		switch indent := len(line) - len(strings.TrimLeft(line, " ")); {
+		case indent == 0:
+			inSection = text == "DISTRIBUTIONS"
+			current = nil
+		case !inSection:
+			continue
+		case indent <= 2:
+			current = &snapshotDistribution{distribution: text}
+			distributions = append(distributions, current)
+			field = ""
+		case current == nil:
+			continue
+		case indent <= 4:
+			key, value, _ := strings.Cut(text, ":")
+			field = strings.TrimSpace(key)
+			if field == "pathname" {
+				current.pathname = strings.TrimSpace(value)
+			}
+		default:
+			switch field {
+			case "provides":
+				current.provides = append(current.provides, strings.Fields(text)[0])
+			case "requirements":
+				current.requirements = append(current.requirements, strings.Join(strings.Fields(text), " "))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse cpanfile.snapshot: %w", err)
+	}
+
+	var pkgs []pkg.Package
+	for _, d := range distributions {
+		name, version := splitDistribution(d.distribution)
+		if name == "" || version == "" {
+			continue
+		}
+
+		m := pkg.CpanMetadata{
+			Pathname: d.pathname,
+			Provides: d.provides,
+			Requires: d.requirements,
+		}
+		if match := cpanAuthorPattern.FindStringSubmatch(d.pathname); match != nil && strings.HasPrefix(match[3], match[2]) {
+			m.CpanID = match[3]
+		}
+
+		pkgs = append(pkgs, newPackage(name, version, m, reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)))
+	}
+
+	return pkgs, snapshotDependencyRelationships(pkgs), nil
+}
+
+// splitDistribution splits a distribution name and version (e.g. Module-Build-0.4224) at the last dash.
+func splitDistribution(distribution string) (string, string) {
+	i := strings.LastIndex(distribution, "-")
+	if i <= 0 {
+		return distribution, ""
+	}
+	return distribution[:i], distribution[i+1:]
+}
+
+// snapshotDependencyRelationships relates each locked distribution to the locked distributions that provide the modules
+// it requires. Requirements that are not locked (such as core modules and perl itself) are not related.
+func snapshotDependencyRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	providers := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.CpanMetadata)
+		if !ok {
+			continue
+		}
+		for _, module := range m.Provides {
+			if _, exists := providers[module]; !exists {
+				providers[module] = p
+			}
+		}
+	}
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.CpanMetadata)
+		if !ok {
+			continue
+		}
+		for _, requirement := range m.Requires {
+			dep, ok := providers[strings.Fields(requirement)[0]]
+			if !ok {
+				continue
+			}
+			key := string(dep.ID()) + ":" + string(p.ID())
+			if dep.ID() == p.ID() || seen.Contains(key) {
+				continue
+			}
+			seen.Add(key)
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
+}
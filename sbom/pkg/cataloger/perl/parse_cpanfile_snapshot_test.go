@@ -0,0 +1,103 @@
+package perl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseCpanfileSnapshot(t *testing.T) {
+	fixture := "test-fixtures/cpanfile.snapshot"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	httpTiny := pkg.Package{
+		Name:         "HTTP-Tiny",
+		Version:      "0.088",
+		PURL:         "pkg:cpan/DAGOLDEN/HTTP-Tiny@0.088",
+		Locations:    locations,
+		Language:     pkg.Perl,
+		Type:         pkg.CpanPkg,
+		MetadataType: pkg.CpanMetadataType,
+		Metadata: pkg.CpanMetadata{
+			CpanID:   "DAGOLDEN",
+			Pathname: "D/DA/DAGOLDEN/HTTP-Tiny-0.088.tar.gz",
+			Provides: []string{"HTTP::Tiny"},
+			Requires: []string{"ExtUtils::MakeMaker 6.17", "perl 5.006"},
+		},
+	}
+	moduleBuild := pkg.Package{
+		Name:         "Module-Build",
+		Version:      "0.4234",
+		PURL:         "pkg:cpan/LEONT/Module-Build@0.4234",
+		Locations:    locations,
+		Language:     pkg.Perl,
+		Type:         pkg.CpanPkg,
+		MetadataType: pkg.CpanMetadataType,
+		Metadata: pkg.CpanMetadata{
+			CpanID:   "LEONT",
+			Pathname: "L/LE/LEONT/Module-Build-0.4234.tar.gz",
+			Provides: []string{"Module::Build", "Module::Build::Base", "Module::Build::Compat"},
+			Requires: []string{"CPAN::Meta 2.142060", "ExtUtils::MakeMaker 0", "HTTP::Tiny 0.056", "perl 5.006001"},
+		},
+	}
+	tryTiny := pkg.Package{
+		Name:         "Try-Tiny",
+		Version:      "0.31",
+		PURL:         "pkg:cpan/ETHER/Try-Tiny@0.31",
+		Locations:    locations,
+		Language:     pkg.Perl,
+		Type:         pkg.CpanPkg,
+		MetadataType: pkg.CpanMetadataType,
+		Metadata: pkg.CpanMetadata{
+			CpanID:   "ETHER",
+			Pathname: "E/ET/ETHER/Try-Tiny-0.31.tar.gz",
+			Provides: []string{"Try::Tiny"},
+			Requires: []string{"Carp 0", "Exporter 5.57", "perl 5.006"},
+		},
+	}
+
+	expectedPkgs := []pkg.Package{httpTiny, moduleBuild, tryTiny}
+
+	// only requirements provided by locked distributions are related
+	expectedRelationships := []artifact.Relationship{
+		{
+			From: httpTiny,
+			To:   moduleBuild,
+			Type: artifact.DependencyOfRelationship,
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseCpanfileSnapshot, expectedPkgs, expectedRelationships)
+}
+
+func TestParseCpanfileSnapshot_NoDistributions(t *testing.T) {
+	pkgtest.NewCatalogTester().
+		FromString("cpanfile.snapshot", "# carton snapshot format: version 1.0\nDISTRIBUTIONS\n").
+		Expects(nil, nil).
+		TestParser(t, parseCpanfileSnapshot)
+}
+
+func Test_splitDistribution(t *testing.T) {
+	tests := []struct {
+		input   string
+		name    string
+		version string
+	}{
+		{input: "Module-Build-0.4234", name: "Module-Build", version: "0.4234"},
+		{input: "libwww-perl-6.72", name: "libwww-perl", version: "6.72"},
+		{input: "version-0.9930", name: "version", version: "0.9930"},
+		{input: "perl", name: "perl"},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			name, version := splitDistribution(test.input)
+			assert.Equal(t, test.name, name)
+			assert.Equal(t, test.version, version)
+		})
+	}
+}
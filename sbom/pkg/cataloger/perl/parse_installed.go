@@ -0,0 +1,244 @@
+package perl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var (
+	_ generic.Parser = parseMymeta
+	_ generic.Parser = parsePacklist
+)
+
+const (
+	// cpanm keeps the metadata of each installed distribution within <archlib>/.meta/<distribution>-<version>/
+	mymetaGlob = "**/.meta/*/MYMETA.json"
+	// the installer lists the files of each distribution within <archlib>/auto/<main module path>/.packlist
+	packlistGlob = "**/auto/**/.packlist"
+)
+
+var (
+	// packlistAttributesPattern matches the optional attributes that follow a path within a .packlist (e.g. "type=link")
+	packlistAttributesPattern = regexp.MustCompile(`^(.*?)(?:\s+\S+=\S+)+$`)
+	moduleVersionPattern      = regexp.MustCompile(`\$VERSION\s*=\s*(?:version->declare\(|qv\()?\s*['"]?(v?[0-9][0-9._]*)`)
+)
+
+// cpanMeta is the subset of a CPAN::Meta file (see https://metacpan.org/pod/CPAN::Meta::Spec) that describes an
+// installed distribution. Both version 2 and version 1.4 of the specification are supported.
+type cpanMeta struct {
+	Name     string       `json:"name"`
+	Version  cpanMetaText `json:"version"`
+	Abstract string       `json:"abstract"`
+	Author   cpanMetaList `json:"author"`
+	License  cpanMetaList `json:"license"`
+	Prereqs  struct {
+		Runtime struct {
+			Requires map[string]cpanMetaText `json:"requires"`
+		} `json:"runtime"`
+	} `json:"prereqs"`
+	// version 1.4 lists the runtime requirements at the top level
+	Requires map[string]cpanMetaText    `json:"requires"`
+	Provides map[string]json.RawMessage `json:"provides"`
+}
+
+// cpanMetaText is a version, which may be written as a string or a number.
+type cpanMetaText string
+
+func (v *cpanMetaText) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case string:
+		*v = cpanMetaText(value)
+	case json.Number:
+		*v = cpanMetaText(value.String())
+	}
+	return nil
+}
+
+// cpanMetaList is a list of values, which version 1.4 of the specification writes as a single string.
+type cpanMetaList []string
+
+func (l *cpanMetaList) UnmarshalJSON(data []byte) error {
+	var values []string
+	if err := json.Unmarshal(data, &values); err == nil {
+		*l = values
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*l = []string{value}
+	return nil
+}
+
+// parseMymeta is a parser function for the MYMETA.json file of an installed distribution, which also claims the files
+// listed by the .packlist of the distribution.
+func parseMymeta(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var meta cpanMeta
+	if err := json.NewDecoder(reader).Decode(&meta); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse MYMETA.json: %w", err)
+	}
+
+	if meta.Name == "" {
+		return nil, nil, nil
+	}
+
+	m := pkg.CpanMetadata{
+		Abstract: meta.Abstract,
+		Authors:  meta.Author,
+	}
+	for module := range meta.Provides {
+		m.Provides = append(m.Provides, module)
+	}
+	sort.Strings(m.Provides)
+
+	for _, license := range meta.License {
+		if license != "" && license != "unknown" {
+			m.Licenses = append(m.Licenses, license)
+		}
+	}
+
+	requires := meta.Prereqs.Runtime.Requires
+	if len(requires) == 0 {
+		requires = meta.Requires
+	}
+	for module, version := range requires {
+		m.Requires = append(m.Requires, module+" "+string(version))
+	}
+	sort.Strings(m.Requires)
+
+	locations := []source.Location{reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)}
+
+	if metaDir := path.Dir(path.Dir(reader.RealPath)); path.Base(metaDir) == ".meta" {
+		packlistPath := path.Join(path.Dir(metaDir), "auto", strings.ReplaceAll(meta.Name, "-", "/"), ".packlist")
+		if packlist := resolver.RelativeFileByPath(reader.Location, packlistPath); packlist != nil {
+			files, err := readPacklistLocation(resolver, *packlist)
+			if err != nil {
+				log.WithFields("path", packlist.RealPath, "error", err).Debug("unable to read perl packlist")
+			}
+			m.Files = files
+			locations = append(locations, packlist.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+		}
+	}
+
+	return []pkg.Package{newPackage(meta.Name, string(meta.Version), m, locations...)}, nil, nil
+}
+
+// parsePacklist is a parser function for the .packlist of an installed distribution, reading the version of the
+// distribution from the main module.
+func parsePacklist(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	_, modulePath, found := strings.Cut(path.Dir(reader.RealPath), "/auto/")
+	if !found || modulePath == "" {
+		return nil, nil, nil
+	}
+
+	files, err := readPacklist(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse perl packlist: %w", err)
+	}
+
+	m := pkg.CpanMetadata{
+		Files: files,
+	}
+
+	version := moduleVersion(resolver, files, modulePath+".pm")
+
+	return []pkg.Package{newPackage(strings.ReplaceAll(modulePath, "/", "-"), version, m, reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation))}, nil, nil
+}
+
+// moduleVersion returns the $VERSION of the module installed at the given path (relative to the library directory), if
+// the module is listed within the given files.
+func moduleVersion(resolver source.FileResolver, files []string, modulePath string) string {
+	for _, file := range files {
+		if !strings.HasSuffix(file, "/"+modulePath) {
+			continue
+		}
+
+		locations, err := resolver.FilesByPath(file)
+		if err != nil || len(locations) == 0 {
+			continue
+		}
+
+		version, err := readModuleVersion(resolver, locations[0])
+		if err != nil {
+			log.WithFields("path", file, "error", err).Debug("unable to read perl module version")
+			continue
+		}
+		if version != "" {
+			return version
+		}
+	}
+	return ""
+}
+
+func readModuleVersion(resolver source.FileResolver, location source.Location) (string, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return "", err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "__END__") || strings.HasPrefix(line, "__DATA__") {
+			break
+		}
+		if match := moduleVersionPattern.FindStringSubmatch(line); match != nil {
+			return match[1], nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+func readPacklistLocation(resolver source.FileResolver, location source.Location) ([]string, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	return readPacklist(reader)
+}
+
+// readPacklist returns the paths listed within a .packlist, one per line and optionally followed by attributes.
+func readPacklist(reader io.Reader) ([]string, error) {
+	var files []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if match := packlistAttributesPattern.FindStringSubmatch(line); match != nil {
+			line = match[1]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
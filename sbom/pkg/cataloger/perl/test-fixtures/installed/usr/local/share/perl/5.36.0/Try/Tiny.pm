@@ -0,0 +1,12 @@
+package Try::Tiny; # git description: v0.30-11-g1b81d0a
+use 5.006;
+# ABSTRACT: Minimal try/catch with proper preservation of $@
+
+our $VERSION = '0.31';
+
+use strict;
+use warnings;
+
+1;
+
+__END__
@@ -18,3 +18,14 @@ func NewComposerLockCataloger() *generic.Cataloger {
 	return generic.NewCataloger("php-composer-lock-cataloger").
 		WithParserByGlobs(parseComposerLock, "**/composer.lock")
 }
+
+// NewCMSCataloger returns a new cataloger for WordPress and Drupal core, plugins, themes and modules, which are
+// typically installed without composer (e.g. by uploading them or through the admin interface).
+func NewCMSCataloger() *generic.Cataloger {
+	return generic.NewCataloger("php-cms-cataloger").
+		WithParserByGlobs(parseWordpressCore, wordpressCoreGlob).
+		WithParserByGlobs(parseWordpressPlugin, wordpressPluginGlobs...).
+		WithParserByGlobs(parseWordpressTheme, wordpressThemeGlob).
+		WithParserByGlobs(parseDrupalCore, drupalCoreGlob).
+		WithParserByGlobs(parseDrupalInfo, drupalInfoGlob)
+}
@@ -55,3 +55,36 @@ func Test_ComposerLockCataloger_Globs(t *testing.T) {
 		})
 	}
 }
+
+func Test_CMSCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain wordpress and drupal files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/wordpress/wp-includes/version.php",
+				"src/wordpress/wp-content/plugins/hello.php",
+				"src/wordpress/wp-content/plugins/example/example.php",
+				"src/wordpress/wp-content/themes/example/style.css",
+				"src/drupal/core/lib/Drupal.php",
+				"src/drupal/modules/example/example.info.yml",
+				"src/drupal/core/themes/olivero/olivero.info.yml",
+				"src/drupal/sites/default/modules/custom/custom.info.yml",
+				// note: src/config/settings.info.yml is not within a drupal extension directory
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewCMSCataloger())
+		})
+	}
+}
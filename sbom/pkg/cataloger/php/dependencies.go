@@ -0,0 +1,162 @@
+package php
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+const (
+	prodScope = "prod"
+	devScope  = "dev"
+
+	// platformPackageType is the type of the packages created for platform requirements
+	platformPackageType = "platform"
+)
+
+// platformPackagePattern matches the names of packages that are provided by the platform rather than installed by
+// composer (see https://getcomposer.org/doc/01-basic-usage.md#platform-packages)
+var platformPackagePattern = regexp.MustCompile(`(?i)^(php(-64bit|-ipv6|-zts|-debug)?|hhvm|(ext|lib)-[a-z0-9]([_.-]?[a-z0-9]+)*|composer(-(plugin|runtime)-api)?)$`)
+
+func isPlatformPackage(name string) bool {
+	return platformPackagePattern.MatchString(name)
+}
+
+// platformRequirements collects the platform packages required by the root project and by the installed packages.
+type platformRequirements struct {
+	names    map[string]string
+	scopes   map[string]string
+	versions map[string]string
+}
+
+func newPlatformRequirements(versions map[string]string) *platformRequirements {
+	r := &platformRequirements{
+		names:    make(map[string]string),
+		scopes:   make(map[string]string),
+		versions: make(map[string]string),
+	}
+	for name, version := range versions {
+		r.versions[strings.ToLower(name)] = version
+	}
+	return r
+}
+
+// add records a platform requirement, where the requirement of a production package takes precedence over the
+// requirement of a development package.
+func (r *platformRequirements) add(name, scope string) {
+	key := strings.ToLower(name)
+	if _, ok := r.names[key]; !ok {
+		r.names[key] = name
+		r.scopes[key] = scope
+		return
+	}
+	if scope == prodScope {
+		r.scopes[key] = scope
+	}
+}
+
+// addRequiredBy records the platform requirements of an installed package.
+func (r *platformRequirements) addRequiredBy(m pkg.PhpComposerJSONMetadata) {
+	for name := range m.Require {
+		if isPlatformPackage(name) {
+			r.add(name, m.Scope)
+		}
+	}
+}
+
+// packages returns a package for each platform requirement with a known version. The version of a platform package is
+// only known when the platform is overridden within the composer config, otherwise it depends on the runtime the
+// project is eventually deployed to.
+func (r *platformRequirements) packages(location source.Location) []pkg.Package {
+	var keys []string
+	for key := range r.names {
+		if r.versions[key] == "" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pkgs []pkg.Package
+	for _, key := range keys {
+		pkgs = append(pkgs, newComposerPlatformPackage(r.names[key], r.versions[key], r.scopes[key], location))
+	}
+	return pkgs
+}
+
+// composerRelationships creates relationships for the requirements of each package that are installed (or provided
+// by the platform). Development requirements of a package are only related when the required package is installed for
+// development, since composer never installs the development requirements of dependencies on their behalf.
+func composerRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	byName := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		byName[strings.ToLower(p.Name)] = p
+	}
+	// requirements may be satisfied by packages that provide them (e.g. "psr/log-implementation")
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.PhpComposerJSONMetadata)
+		if !ok {
+			continue
+		}
+		for name := range m.Provide {
+			if _, exists := byName[strings.ToLower(name)]; !exists {
+				byName[strings.ToLower(name)] = p
+			}
+		}
+	}
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	relate := func(dep, p pkg.Package) {
+		if dep.ID() == p.ID() {
+			return
+		}
+		key := string(dep.ID()) + string(p.ID())
+		if seen.Contains(key) {
+			return
+		}
+		seen.Add(key)
+		relationships = append(relationships, artifact.Relationship{
+			From: dep,
+			To:   p,
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.PhpComposerJSONMetadata)
+		if !ok {
+			continue
+		}
+		for _, name := range sortedKeys(m.Require) {
+			if dep, ok := byName[strings.ToLower(name)]; ok {
+				relate(dep, p)
+			}
+		}
+		for _, name := range sortedKeys(m.RequireDev) {
+			dep, ok := byName[strings.ToLower(name)]
+			if !ok {
+				continue
+			}
+			if dm, ok := dep.Metadata.(pkg.PhpComposerJSONMetadata); ok && dm.Scope == devScope {
+				relate(dep, p)
+			}
+		}
+	}
+
+	return relationships
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -24,6 +24,28 @@ func newComposerLockPackage(m pkg.PhpComposerJSONMetadata, location ...source.Lo
 	return p
 }
 
+// newComposerPlatformPackage creates a package for a platform requirement (e.g. "php" or "ext-json"), which is
+// provided by the PHP runtime rather than installed by composer, and therefore has no composer package URL.
+func newComposerPlatformPackage(name, version, scope string, location ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(location...),
+		Language:     pkg.PHP,
+		Type:         pkg.PhpPlatformPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    name,
+			Version: version,
+			Type:    platformPackageType,
+			Scope:   scope,
+		},
+	}
+
+	p.SetID()
+	return p
+}
+
 func packageURL(m pkg.PhpComposerJSONMetadata) string {
 	var name, vendor string
 	fields := strings.Split(m.Name, "/")
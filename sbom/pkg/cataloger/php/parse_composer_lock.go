@@ -17,12 +17,19 @@ var _ generic.Parser = parseComposerLock
 type composerLock struct {
 	Packages   []pkg.PhpComposerJSONMetadata `json:"packages"`
 	PackageDev []pkg.PhpComposerJSONMetadata `json:"packages-dev"`
+	// the platform requirements of the root project are written as an empty array (instead of an object) when there
+	// are none
+	Platform          json.RawMessage   `json:"platform"`
+	PlatformDev       json.RawMessage   `json:"platform-dev"`
+	PlatformOverrides map[string]string `json:"platform-overrides"`
 }
 
-// parseComposerLock is a parser function for Composer.lock contents, returning "Default" php packages discovered.
+// parseComposerLock is a parser function for Composer.lock contents, returning the php packages discovered (marked
+// as "prod" or "dev" packages) along with the platform packages they require and the relationships between them.
 func parseComposerLock(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	pkgs := make([]pkg.Package, 0)
 	dec := json.NewDecoder(reader)
+	location := reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)
 
 	for {
 		var lock composerLock
@@ -31,16 +38,36 @@ func parseComposerLock(_ source.FileResolver, _ *generic.Environment, reader sou
 		} else if err != nil {
 			return nil, nil, fmt.Errorf("failed to parse composer.lock file: %w", err)
 		}
+
+		platform := newPlatformRequirements(lock.PlatformOverrides)
+		for name := range platformMap(lock.Platform) {
+			platform.add(name, prodScope)
+		}
+		for name := range platformMap(lock.PlatformDev) {
+			platform.add(name, devScope)
+		}
+
 		for _, m := range lock.Packages {
-			pkgs = append(
-				pkgs,
-				newComposerLockPackage(
-					m,
-					reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
-				),
-			)
+			m.Scope = prodScope
+			platform.addRequiredBy(m)
+			pkgs = append(pkgs, newComposerLockPackage(m, location))
+		}
+		for _, m := range lock.PackageDev {
+			m.Scope = devScope
+			platform.addRequiredBy(m)
+			pkgs = append(pkgs, newComposerLockPackage(m, location))
 		}
+
+		pkgs = append(pkgs, platform.packages(location)...)
 	}
 
-	return pkgs, nil, nil
+	return pkgs, composerRelationships(pkgs), nil
+}
+
+func platformMap(data json.RawMessage) map[string]string {
+	var platform map[string]string
+	if err := json.Unmarshal(data, &platform); err != nil {
+		return nil
+	}
+	return platform
 }
@@ -10,108 +10,319 @@ import (
 )
 
 func TestParseComposerFileLock(t *testing.T) {
-	var expectedRelationships []artifact.Relationship
 	fixture := "test-fixtures/composer.lock"
 	locations := source.NewLocationSet(source.NewLocation(fixture))
-	expectedPkgs := []pkg.Package{
-		{
-			Name:         "adoy/fastcgi-client",
-			Version:      "1.0.2",
-			PURL:         "pkg:composer/adoy/fastcgi-client@1.0.2",
-			Locations:    locations,
-			Language:     pkg.PHP,
-			Type:         pkg.PhpComposerPkg,
-			MetadataType: pkg.PhpComposerJSONMetadataType,
-			Metadata: pkg.PhpComposerJSONMetadata{
-				Name:    "adoy/fastcgi-client",
-				Version: "1.0.2",
-				Source: pkg.PhpComposerExternalReference{
-					Type:      "git",
-					URL:       "https://github.com/adoy/PHP-FastCGI-Client.git",
-					Reference: "6d9a552f0206a1db7feb442824540aa6c55e5b27",
-				},
-				Dist: pkg.PhpComposerExternalReference{
-					Type:      "zip",
-					URL:       "https://api.github.com/repos/adoy/PHP-FastCGI-Client/zipball/6d9a552f0206a1db7feb442824540aa6c55e5b27",
-					Reference: "6d9a552f0206a1db7feb442824540aa6c55e5b27",
-				},
-				Type:            "library",
-				NotificationURL: "https://packagist.org/downloads/",
-				License: []string{
-					"MIT",
-				},
-				Authors: []pkg.PhpComposerAuthors{
-					{
-						Name:  "Pierrick Charron",
-						Email: "pierrick@adoy.net",
-					},
-				},
-				Description: "Lightweight, single file FastCGI client for PHP.",
-				Keywords: []string{
-					"fastcgi",
-					"fcgi",
+	fastcgiClient := pkg.Package{
+		Name:         "adoy/fastcgi-client",
+		Version:      "1.0.2",
+		PURL:         "pkg:composer/adoy/fastcgi-client@1.0.2",
+		Locations:    locations,
+		Language:     pkg.PHP,
+		Type:         pkg.PhpComposerPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "adoy/fastcgi-client",
+			Version: "1.0.2",
+			Source: pkg.PhpComposerExternalReference{
+				Type:      "git",
+				URL:       "https://github.com/adoy/PHP-FastCGI-Client.git",
+				Reference: "6d9a552f0206a1db7feb442824540aa6c55e5b27",
+			},
+			Dist: pkg.PhpComposerExternalReference{
+				Type:      "zip",
+				URL:       "https://api.github.com/repos/adoy/PHP-FastCGI-Client/zipball/6d9a552f0206a1db7feb442824540aa6c55e5b27",
+				Reference: "6d9a552f0206a1db7feb442824540aa6c55e5b27",
+			},
+			Type:            "library",
+			NotificationURL: "https://packagist.org/downloads/",
+			License: []string{
+				"MIT",
+			},
+			Authors: []pkg.PhpComposerAuthors{
+				{
+					Name:  "Pierrick Charron",
+					Email: "pierrick@adoy.net",
 				},
-				Time: "2019-12-11T13:49:21+00:00",
 			},
+			Description: "Lightweight, single file FastCGI client for PHP.",
+			Keywords: []string{
+				"fastcgi",
+				"fcgi",
+			},
+			Time:  "2019-12-11T13:49:21+00:00",
+			Scope: "prod",
 		},
-		{
-			Name:         "alcaeus/mongo-php-adapter",
-			Version:      "1.1.11",
-			Locations:    locations,
-			PURL:         "pkg:composer/alcaeus/mongo-php-adapter@1.1.11",
-			Language:     pkg.PHP,
-			Type:         pkg.PhpComposerPkg,
-			MetadataType: pkg.PhpComposerJSONMetadataType,
-			Metadata: pkg.PhpComposerJSONMetadata{
-				Name:    "alcaeus/mongo-php-adapter",
-				Version: "1.1.11",
-				Source: pkg.PhpComposerExternalReference{
-					Type:      "git",
-					URL:       "https://github.com/alcaeus/mongo-php-adapter.git",
-					Reference: "43b6add94c8b4cb9890d662cba4c0defde733dcf",
-				},
-				Dist: pkg.PhpComposerExternalReference{
-					Type:      "zip",
-					URL:       "https://api.github.com/repos/alcaeus/mongo-php-adapter/zipball/43b6add94c8b4cb9890d662cba4c0defde733dcf",
-					Reference: "43b6add94c8b4cb9890d662cba4c0defde733dcf",
-				},
-				Require: map[string]string{
-					"ext-ctype":       "*",
-					"ext-hash":        "*",
-					"ext-mongodb":     "^1.2.0",
-					"mongodb/mongodb": "^1.0.1",
-					"php":             "^5.6 || ^7.0",
-				},
-				Provide: map[string]string{
-					"ext-mongo": "1.6.14",
-				},
-				RequireDev: map[string]string{
-					"phpunit/phpunit":           "^5.7.27 || ^6.0 || ^7.0",
-					"squizlabs/php_codesniffer": "^3.2",
+	}
+	mongoPHPAdapter := pkg.Package{
+		Name:         "alcaeus/mongo-php-adapter",
+		Version:      "1.1.11",
+		Locations:    locations,
+		PURL:         "pkg:composer/alcaeus/mongo-php-adapter@1.1.11",
+		Language:     pkg.PHP,
+		Type:         pkg.PhpComposerPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "alcaeus/mongo-php-adapter",
+			Version: "1.1.11",
+			Source: pkg.PhpComposerExternalReference{
+				Type:      "git",
+				URL:       "https://github.com/alcaeus/mongo-php-adapter.git",
+				Reference: "43b6add94c8b4cb9890d662cba4c0defde733dcf",
+			},
+			Dist: pkg.PhpComposerExternalReference{
+				Type:      "zip",
+				URL:       "https://api.github.com/repos/alcaeus/mongo-php-adapter/zipball/43b6add94c8b4cb9890d662cba4c0defde733dcf",
+				Reference: "43b6add94c8b4cb9890d662cba4c0defde733dcf",
+			},
+			Require: map[string]string{
+				"ext-ctype":       "*",
+				"ext-hash":        "*",
+				"ext-mongodb":     "^1.2.0",
+				"mongodb/mongodb": "^1.0.1",
+				"php":             "^5.6 || ^7.0",
+			},
+			Provide: map[string]string{
+				"ext-mongo": "1.6.14",
+			},
+			RequireDev: map[string]string{
+				"phpunit/phpunit":           "^5.7.27 || ^6.0 || ^7.0",
+				"squizlabs/php_codesniffer": "^3.2",
+			},
+			Type:            "library",
+			NotificationURL: "https://packagist.org/downloads/",
+			License: []string{
+				"MIT",
+			},
+			Authors: []pkg.PhpComposerAuthors{
+				{
+					Name:  "alcaeus",
+					Email: "alcaeus@alcaeus.org",
 				},
-				Type:            "library",
-				NotificationURL: "https://packagist.org/downloads/",
-				License: []string{
-					"MIT",
+				{
+					Name:  "Olivier Lechevalier",
+					Email: "olivier.lechevalier@gmail.com",
 				},
-				Authors: []pkg.PhpComposerAuthors{
-					{
-						Name:  "alcaeus",
-						Email: "alcaeus@alcaeus.org",
-					},
-					{
-						Name:  "Olivier Lechevalier",
-						Email: "olivier.lechevalier@gmail.com",
-					},
+			},
+			Description: "Adapter to provide ext-mongo interface on top of mongo-php-libary",
+			Keywords: []string{
+				"database",
+				"mongodb",
+			},
+			Time:  "2019-11-11T20:47:32+00:00",
+			Scope: "prod",
+		},
+	}
+	gherkin := pkg.Package{
+		Name:         "behat/gherkin",
+		Version:      "v4.6.2",
+		PURL:         "pkg:composer/behat/gherkin@v4.6.2",
+		Locations:    locations,
+		Language:     pkg.PHP,
+		Type:         pkg.PhpComposerPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "behat/gherkin",
+			Version: "v4.6.2",
+			Source: pkg.PhpComposerExternalReference{
+				Type:      "git",
+				URL:       "https://github.com/Behat/Gherkin.git",
+				Reference: "51ac4500c4dc30cbaaabcd2f25694299df666a31",
+			},
+			Dist: pkg.PhpComposerExternalReference{
+				Type:      "zip",
+				URL:       "https://api.github.com/repos/Behat/Gherkin/zipball/51ac4500c4dc30cbaaabcd2f25694299df666a31",
+				Reference: "51ac4500c4dc30cbaaabcd2f25694299df666a31",
+			},
+			Require: map[string]string{
+				"php": ">=5.3.1",
+			},
+			RequireDev: map[string]string{
+				"phpunit/phpunit":        "~4.5|~5",
+				"symfony/phpunit-bridge": "~2.7|~3|~4",
+				"symfony/yaml":           "~2.3|~3|~4",
+			},
+			Suggest: map[string]string{
+				"symfony/yaml": "If you want to parse features, represented in YAML files",
+			},
+			Type:            "library",
+			NotificationURL: "https://packagist.org/downloads/",
+			License: []string{
+				"MIT",
+			},
+			Authors: []pkg.PhpComposerAuthors{
+				{
+					Name:     "Konstantin Kudryashov",
+					Email:    "ever.zet@gmail.com",
+					Homepage: "http://everzet.com",
 				},
-				Description: "Adapter to provide ext-mongo interface on top of mongo-php-libary",
-				Keywords: []string{
-					"database",
-					"mongodb",
+			},
+			Description: "Gherkin DSL parser for PHP 5.3",
+			Homepage:    "http://behat.org/",
+			Keywords: []string{
+				"BDD",
+				"Behat",
+				"Cucumber",
+				"DSL",
+				"gherkin",
+				"parser",
+			},
+			Time:  "2020-03-17T14:03:26+00:00",
+			Scope: "dev",
+		},
+	}
+	codeception := pkg.Package{
+		Name:         "codeception/codeception",
+		Version:      "4.1.6",
+		PURL:         "pkg:composer/codeception/codeception@4.1.6",
+		Locations:    locations,
+		Language:     pkg.PHP,
+		Type:         pkg.PhpComposerPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "codeception/codeception",
+			Version: "4.1.6",
+			Source: pkg.PhpComposerExternalReference{
+				Type:      "git",
+				URL:       "https://github.com/Codeception/Codeception.git",
+				Reference: "5515b6a6c6f1e1c909aaff2e5f3a15c177dfd1a9",
+			},
+			Dist: pkg.PhpComposerExternalReference{
+				Type:      "zip",
+				URL:       "https://api.github.com/repos/Codeception/Codeception/zipball/5515b6a6c6f1e1c909aaff2e5f3a15c177dfd1a9",
+				Reference: "5515b6a6c6f1e1c909aaff2e5f3a15c177dfd1a9",
+			},
+			Require: map[string]string{
+				"behat/gherkin":               "^4.4.0",
+				"codeception/lib-asserts":     "^1.0",
+				"codeception/phpunit-wrapper": ">6.0.15 <6.1.0 | ^6.6.1 | ^7.7.1 | ^8.1.1 | ^9.0",
+				"codeception/stub":            "^2.0 | ^3.0",
+				"ext-curl":                    "*",
+				"ext-json":                    "*",
+				"ext-mbstring":                "*",
+				"guzzlehttp/psr7":             "~1.4",
+				"php":                         ">=5.6.0 <8.0",
+				"symfony/console":             ">=2.7 <6.0",
+				"symfony/css-selector":        ">=2.7 <6.0",
+				"symfony/event-dispatcher":    ">=2.7 <6.0",
+				"symfony/finder":              ">=2.7 <6.0",
+				"symfony/yaml":                ">=2.7 <6.0",
+			},
+			RequireDev: map[string]string{
+				"codeception/module-asserts":          "*@dev",
+				"codeception/module-cli":              "*@dev",
+				"codeception/module-db":               "*@dev",
+				"codeception/module-filesystem":       "*@dev",
+				"codeception/module-phpbrowser":       "*@dev",
+				"codeception/specify":                 "~0.3",
+				"codeception/util-universalframework": "*@dev",
+				"monolog/monolog":                     "~1.8",
+				"squizlabs/php_codesniffer":           "~2.0",
+				"symfony/process":                     ">=2.7 <6.0",
+				"vlucas/phpdotenv":                    "^2.0 | ^3.0 | ^4.0",
+			},
+			Suggest: map[string]string{
+				"codeception/specify":                "BDD-style code blocks",
+				"codeception/verify":                 "BDD-style assertions",
+				"hoa/console":                        "For interactive console functionality",
+				"stecman/symfony-console-completion": "For BASH autocompletion",
+				"symfony/phpunit-bridge":             "For phpunit-bridge support",
+			},
+			Type:            "library",
+			NotificationURL: "https://packagist.org/downloads/",
+			Bin: []string{
+				"codecept",
+			},
+			License: []string{
+				"MIT",
+			},
+			Authors: []pkg.PhpComposerAuthors{
+				{
+					Name:     "Michael Bodnarchuk",
+					Email:    "davert@mail.ua",
+					Homepage: "http://codegyre.com",
 				},
-				Time: "2019-11-11T20:47:32+00:00",
 			},
+			Description: "BDD-style testing framework",
+			Homepage:    "http://codeception.com/",
+			Keywords: []string{
+				"BDD",
+				"TDD",
+				"acceptance testing",
+				"functional testing",
+				"unit testing",
+			},
+			Time:  "2020-06-07T16:31:51+00:00",
+			Scope: "dev",
+		},
+	}
+
+	expectedPkgs := []pkg.Package{fastcgiClient, mongoPHPAdapter, gherkin, codeception}
+
+	// platform requirements are only reported when the platform versions are overridden
+	expectedRelationships := []artifact.Relationship{
+		{From: gherkin, To: codeception, Type: artifact.DependencyOfRelationship},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseComposerLock, expectedPkgs, expectedRelationships)
+}
+
+func TestParseComposerLockPlatformOverrides(t *testing.T) {
+	fixture := "test-fixtures/platform-overrides/composer.lock"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	psrLog := pkg.Package{
+		Name:         "psr/log",
+		Version:      "1.1.4",
+		PURL:         "pkg:composer/psr/log@1.1.4",
+		Locations:    locations,
+		Language:     pkg.PHP,
+		Type:         pkg.PhpComposerPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "psr/log",
+			Version: "1.1.4",
+			Require: map[string]string{
+				"php": ">=5.3.0",
+			},
+			Type:  "library",
+			Scope: "prod",
 		},
 	}
+	php := pkg.Package{
+		Name:         "php",
+		Version:      "7.4.33",
+		Locations:    locations,
+		Language:     pkg.PHP,
+		Type:         pkg.PhpPlatformPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "php",
+			Version: "7.4.33",
+			Type:    "platform",
+			Scope:   "prod",
+		},
+	}
+	extJSON := pkg.Package{
+		Name:         "ext-json",
+		Version:      "7.4.33",
+		Locations:    locations,
+		Language:     pkg.PHP,
+		Type:         pkg.PhpPlatformPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "ext-json",
+			Version: "7.4.33",
+			Type:    "platform",
+			Scope:   "dev",
+		},
+	}
+
+	// ext-mbstring is required as well, but is not reported since its version is not overridden
+	expectedPkgs := []pkg.Package{psrLog, extJSON, php}
+
+	expectedRelationships := []artifact.Relationship{
+		{From: php, To: psrLog, Type: artifact.DependencyOfRelationship},
+	}
+
 	pkgtest.TestFileParser(t, fixture, parseComposerLock, expectedPkgs, expectedRelationships)
 }
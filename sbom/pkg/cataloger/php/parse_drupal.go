@@ -0,0 +1,128 @@
+package php
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var (
+	_ generic.Parser = parseDrupalCore
+	_ generic.Parser = parseDrupalInfo
+)
+
+const (
+	drupalCoreGlob = "**/core/lib/Drupal.php"
+	// extensions are installed within the modules, themes and profiles directories of core, of the site root, or of
+	// a site (sites/<site>/modules), possibly within subdirectories (e.g. modules/contrib)
+	drupalInfoGlob = "**/{modules,themes,profiles}/**/*.info.yml"
+)
+
+var drupalVersionPattern = regexp.MustCompile(`const\s+VERSION\s*=\s*['"]([^'"]+)['"]`)
+
+// drupalInfo is the subset of an extension info file (<machine name>.info.yml) that describes the extension.
+type drupalInfo struct {
+	Name                   string      `yaml:"name"`
+	Type                   string      `yaml:"type"`
+	Version                string      `yaml:"version"`
+	Project                string      `yaml:"project"`
+	Package                string      `yaml:"package"`
+	CoreVersionRequirement string      `yaml:"core_version_requirement"`
+	Dependencies           []string    `yaml:"dependencies"`
+	Hidden                 interface{} `yaml:"hidden"`
+}
+
+// parseDrupalCore parses the Drupal version from core/lib/Drupal.php.
+func parseDrupalCore(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read drupal version file: %w", err)
+	}
+
+	match := drupalVersionPattern.FindSubmatch(contents)
+	if match == nil {
+		return nil, nil, nil
+	}
+
+	m := pkg.DrupalMetadata{
+		DisplayName:   "Drupal",
+		ExtensionType: "core",
+		Project:       "drupal",
+	}
+
+	p := newDrupalPackage("drupal", string(match[1]), m, reader.Location)
+	return []pkg.Package{p}, nil, nil
+}
+
+// parseDrupalInfo parses a module, theme or profile info file. Only extensions with a release version are reported:
+// extensions bundled with core (which declare "version: VERSION") are represented by the core package, and hidden
+// extensions are test fixtures.
+func parseDrupalInfo(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var info drupalInfo
+	if err := yaml.NewDecoder(reader).Decode(&info); err != nil {
+		if err == io.EOF {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("unable to parse drupal info file: %w", err)
+	}
+
+	switch {
+	case info.Name == "" || info.Type == "":
+		// not every *.info.yml file is a drupal extension
+		return nil, nil, nil
+	case info.Version == "VERSION", isDrupalHidden(info.Hidden):
+		return nil, nil, nil
+	}
+
+	m := pkg.DrupalMetadata{
+		DisplayName:            info.Name,
+		ExtensionType:          info.Type,
+		Project:                info.Project,
+		Package:                info.Package,
+		CoreVersionRequirement: info.CoreVersionRequirement,
+		Dependencies:           info.Dependencies,
+	}
+
+	machineName := strings.TrimSuffix(path.Base(reader.RealPath), ".info.yml")
+	p := newDrupalPackage(machineName, info.Version, m, reader.Location)
+	return []pkg.Package{p}, nil, nil
+}
+
+// isDrupalHidden returns whether the "hidden" key of an info file is set, which drupal accepts as a boolean or string.
+func isDrupalHidden(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+func newDrupalPackage(name, version string, m pkg.DrupalMetadata, location source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:    name,
+		Version: version,
+		Locations: source.NewLocationSet(
+			location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+		),
+		PURL:         packageurl.NewPackageURL(pkg.DrupalPkg.PackageURLType(), "", name, version, nil, "").ToString(),
+		Language:     pkg.PHP,
+		Type:         pkg.DrupalPkg,
+		MetadataType: pkg.DrupalMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+	return p
+}
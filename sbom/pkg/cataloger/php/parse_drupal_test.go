@@ -0,0 +1,69 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestCMSCataloger_Drupal(t *testing.T) {
+	// note: core modules (with "version: VERSION") and hidden test modules are not expected
+	expectedPkgs := []pkg.Package{
+		{
+			Name:         "drupal",
+			Version:      "10.1.6",
+			FoundBy:      "php-cms-cataloger",
+			PURL:         "pkg:drupal/drupal@10.1.6",
+			Locations:    source.NewLocationSet(source.NewLocation("core/lib/Drupal.php")),
+			Language:     pkg.PHP,
+			Type:         pkg.DrupalPkg,
+			MetadataType: pkg.DrupalMetadataType,
+			Metadata: pkg.DrupalMetadata{
+				DisplayName:   "Drupal",
+				ExtensionType: "core",
+				Project:       "drupal",
+			},
+		},
+		{
+			Name:         "views_bulk_operations",
+			Version:      "4.2.5",
+			FoundBy:      "php-cms-cataloger",
+			PURL:         "pkg:drupal/views_bulk_operations@4.2.5",
+			Locations:    source.NewLocationSet(source.NewLocation("modules/contrib/views_bulk_operations/views_bulk_operations.info.yml")),
+			Language:     pkg.PHP,
+			Type:         pkg.DrupalPkg,
+			MetadataType: pkg.DrupalMetadataType,
+			Metadata: pkg.DrupalMetadata{
+				DisplayName:            "Views Bulk Operations",
+				ExtensionType:          "module",
+				Project:                "views_bulk_operations",
+				Package:                "Views",
+				CoreVersionRequirement: "^9.4 || ^10",
+				Dependencies:           []string{"drupal:views"},
+			},
+		},
+		{
+			Name:         "gin",
+			Version:      "8.x-3.0-rc7",
+			FoundBy:      "php-cms-cataloger",
+			PURL:         "pkg:drupal/gin@8.x-3.0-rc7",
+			Locations:    source.NewLocationSet(source.NewLocation("themes/contrib/gin/gin.info.yml")),
+			Language:     pkg.PHP,
+			Type:         pkg.DrupalPkg,
+			MetadataType: pkg.DrupalMetadataType,
+			Metadata: pkg.DrupalMetadata{
+				DisplayName:            "Gin",
+				ExtensionType:          "theme",
+				Project:                "gin",
+				CoreVersionRequirement: "^9 || ^10",
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/drupal").
+		Expects(expectedPkgs, nil).
+		TestCataloger(t, NewCMSCataloger())
+}
@@ -5,7 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path"
+	"sort"
+	"strings"
 
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
 	"github.com/nextlinux/sbom/sbom/artifact"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
@@ -17,11 +22,17 @@ var _ generic.Parser = parseComposerLock
 // Note: composer version 2 introduced a new structure for the installed.json file, so we support both
 type installedJSONComposerV2 struct {
 	Packages []pkg.PhpComposerJSONMetadata `json:"packages"`
+	// Dev indicates whether development packages were installed (composer v2 only)
+	Dev *bool `json:"dev"`
+	// DevPackageNames are the names of the installed development packages (composer v2 only)
+	DevPackageNames []string `json:"dev-package-names"`
 }
 
 func (w *installedJSONComposerV2) UnmarshalJSON(data []byte) error {
 	type compv2 struct {
-		Packages []pkg.PhpComposerJSONMetadata `json:"packages"`
+		Packages        []pkg.PhpComposerJSONMetadata `json:"packages"`
+		Dev             *bool                         `json:"dev"`
+		DevPackageNames []string                      `json:"dev-package-names"`
 	}
 	compv2er := new(compv2)
 	err := json.Unmarshal(data, &compv2er)
@@ -37,13 +48,31 @@ func (w *installedJSONComposerV2) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 	w.Packages = compv2er.Packages
+	w.Dev = compv2er.Dev
+	w.DevPackageNames = compv2er.DevPackageNames
 	return nil
 }
 
-// parseInstalledJSON is a parser function for Composer.lock contents, returning "Default" php packages discovered.
-func parseInstalledJSON(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+// scope returns whether the given package was installed for development, which is only known for composer v2.
+func (w installedJSONComposerV2) scope(name string) string {
+	if w.Dev == nil {
+		return ""
+	}
+	for _, devName := range w.DevPackageNames {
+		if strings.EqualFold(devName, name) {
+			return devScope
+		}
+	}
+	return prodScope
+}
+
+// parseInstalledJSON is a parser function for installed.json contents, returning the php packages discovered (along
+// with the files installed for each) and the relationships between them. Platform requirements are not reported, since
+// the platform versions are not recorded within installed.json.
+func parseInstalledJSON(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	var pkgs []pkg.Package
 	dec := json.NewDecoder(reader)
+	location := reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)
 
 	for {
 		var lock installedJSONComposerV2
@@ -52,15 +81,78 @@ func parseInstalledJSON(_ source.FileResolver, _ *generic.Environment, reader so
 		} else if err != nil {
 			return nil, nil, fmt.Errorf("failed to parse installed.json file: %w", err)
 		}
+
+		files := installedFiles(resolver, reader.Location, lock.Packages)
 		for _, pkgMeta := range lock.Packages {
-			pkgs = append(
-				pkgs,
-				newComposerLockPackage(pkgMeta,
-					reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
-				),
-			)
+			pkgMeta.Scope = lock.scope(pkgMeta.Name)
+			pkgMeta.Files = files[installPath(reader.Location, pkgMeta)]
+			pkgs = append(pkgs, newComposerLockPackage(pkgMeta, location))
 		}
 	}
 
-	return pkgs, nil, nil
+	return pkgs, composerRelationships(pkgs), nil
+}
+
+// installPath returns the directory the package is installed to, which is recorded relative to the installed.json
+// (within vendor/composer). Composer v1 does not record the install path, however, packages are always installed under
+// vendor/<name> in that case.
+func installPath(location source.Location, m pkg.PhpComposerJSONMetadata) string {
+	dir := path.Join("/", path.Dir(location.RealPath))
+	if m.InstallPath == "" {
+		return path.Join(path.Dir(dir), m.Name)
+	}
+	return path.Join(dir, m.InstallPath)
+}
+
+// installedFiles returns the paths of all files under the install path of each package, keyed by the install path.
+// The vendor directory is searched once for all packages, only packages installed elsewhere (e.g. from path
+// repositories) are searched for individually.
+func installedFiles(resolver source.FileResolver, location source.Location, packages []pkg.PhpComposerJSONMetadata) map[string][]string {
+	if resolver == nil || len(packages) == 0 {
+		return nil
+	}
+
+	// the directory of the installed.json may be relative to the root of the source
+	vendorDir := path.Dir(path.Join("/", path.Dir(location.RealPath)))
+	installPaths := internal.NewStringSet()
+	var globs []string
+	if vendorDir != "/" {
+		globs = append(globs, path.Join(vendorDir, "**"))
+	}
+	for _, m := range packages {
+		p := installPath(location, m)
+		if installPaths.Contains(p) {
+			continue
+		}
+		installPaths.Add(p)
+		if vendorDir == "/" || !strings.HasPrefix(p, vendorDir+"/") {
+			globs = append(globs, path.Join(p, "**"))
+		}
+	}
+
+	files := make(map[string][]string)
+	for _, glob := range globs {
+		locations, err := resolver.FilesByGlob(glob)
+		if err != nil {
+			log.WithFields("path", glob, "error", err).Debug("unable to find installed composer package files")
+			continue
+		}
+		for _, l := range locations {
+			// attribute the file to the package with the nearest install path
+			for dir := path.Dir(path.Join("/", l.RealPath)); ; dir = path.Dir(dir) {
+				if installPaths.Contains(dir) {
+					files[dir] = append(files[dir], l.RealPath)
+					break
+				}
+				if dir == vendorDir || dir == "/" {
+					break
+				}
+			}
+		}
+	}
+
+	for _, paths := range files {
+		sort.Strings(paths)
+	}
+	return files
 }
@@ -10,12 +10,28 @@ import (
 )
 
 func TestParseInstalledJsonComposerV1(t *testing.T) {
-	fixtures := []string{
-		"test-fixtures/vendor/composer_1/installed.json",
-		"test-fixtures/vendor/composer_2/installed.json",
+	tests := []struct {
+		fixture string
+		// composer v2 records which packages are installed for development and where packages are installed
+		scopes       map[string]string
+		installPaths map[string]string
+	}{
+		{
+			fixture: "test-fixtures/vendor/composer_1/installed.json",
+		},
+		{
+			fixture: "test-fixtures/vendor/composer_2/installed.json",
+			scopes: map[string]string{
+				"asm89/stack-cors": "prod",
+				"behat/mink":       "dev",
+			},
+			installPaths: map[string]string{
+				"asm89/stack-cors": "../asm89/stack-cors",
+				"behat/mink":       "../behat/mink",
+			},
+		},
 	}
 
-	var expectedRelationships []artifact.Relationship
 	var expectedPkgs = []pkg.Package{
 		{
 			Name:         "asm89/stack-cors",
@@ -128,14 +144,140 @@ func TestParseInstalledJsonComposerV1(t *testing.T) {
 		},
 	}
 
-	for _, fixture := range fixtures {
-		t.Run(fixture, func(t *testing.T) {
-			locations := source.NewLocationSet(source.NewLocation(fixture))
-			for i := range expectedPkgs {
-				expectedPkgs[i].Locations = locations
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			locations := source.NewLocationSet(source.NewLocation(test.fixture))
+
+			var pkgs []pkg.Package
+			for _, p := range expectedPkgs {
+				m := p.Metadata.(pkg.PhpComposerJSONMetadata)
+				m.Scope = test.scopes[p.Name]
+				m.InstallPath = test.installPaths[p.Name]
+				p.Metadata = m
+				p.Locations = locations
+				pkgs = append(pkgs, p)
 			}
-			pkgtest.TestFileParser(t, fixture, parseInstalledJSON, expectedPkgs, expectedRelationships)
+
+			pkgtest.TestFileParser(t, test.fixture, parseInstalledJSON, pkgs, nil)
 		})
 	}
+}
+
+func TestParseInstalledJsonInstallPath(t *testing.T) {
+	locations := source.NewLocationSet(source.NewLocation("vendor/composer/installed.json"))
+
+	monolog := pkg.Package{
+		Name:         "monolog/monolog",
+		Version:      "2.9.1",
+		FoundBy:      "php-composer-installed-cataloger",
+		PURL:         "pkg:composer/monolog/monolog@2.9.1",
+		Locations:    locations,
+		Language:     pkg.PHP,
+		Type:         pkg.PhpComposerPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "monolog/monolog",
+			Version: "2.9.1",
+			Source: pkg.PhpComposerExternalReference{
+				Type:      "git",
+				URL:       "https://github.com/Seldaek/monolog.git",
+				Reference: "f259e2b15fb95494c83f52d3caad003bbf5ffaa1",
+			},
+			Dist: pkg.PhpComposerExternalReference{
+				Type:      "zip",
+				URL:       "https://api.github.com/repos/Seldaek/monolog/zipball/f259e2b15fb95494c83f52d3caad003bbf5ffaa1",
+				Reference: "f259e2b15fb95494c83f52d3caad003bbf5ffaa1",
+			},
+			Require: map[string]string{
+				"php":     ">=7.2",
+				"psr/log": "^1.0.1 || ^2.0 || ^3.0",
+			},
+			Provide: map[string]string{
+				"psr/log-implementation": "1.0.0 || 2.0.0 || 3.0.0",
+			},
+			RequireDev: map[string]string{
+				"phpunit/phpunit": "^8.5.14",
+			},
+			Type:        "library",
+			License:     []string{"MIT"},
+			Description: "Sends your logs to files, sockets, inboxes, databases and various web services",
+			Scope:       "prod",
+			InstallPath: "../monolog/monolog",
+			Files: []string{
+				"vendor/monolog/monolog/composer.json",
+				"vendor/monolog/monolog/src/Monolog/Logger.php",
+			},
+		},
+	}
+	psrLog := pkg.Package{
+		Name:         "psr/log",
+		Version:      "1.1.4",
+		FoundBy:      "php-composer-installed-cataloger",
+		PURL:         "pkg:composer/psr/log@1.1.4",
+		Locations:    locations,
+		Language:     pkg.PHP,
+		Type:         pkg.PhpComposerPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "psr/log",
+			Version: "1.1.4",
+			Source: pkg.PhpComposerExternalReference{
+				Type:      "git",
+				URL:       "https://github.com/php-fig/log.git",
+				Reference: "d49695b909c3b7628b6289db5479a1c204601f11",
+			},
+			Dist: pkg.PhpComposerExternalReference{
+				Type:      "zip",
+				URL:       "https://api.github.com/repos/php-fig/log/zipball/d49695b909c3b7628b6289db5479a1c204601f11",
+				Reference: "d49695b909c3b7628b6289db5479a1c204601f11",
+			},
+			Require: map[string]string{
+				"php": ">=5.3.0",
+			},
+			Type:        "library",
+			License:     []string{"MIT"},
+			Description: "Common interface for logging libraries",
+			Scope:       "prod",
+			InstallPath: "../psr/log",
+			Files: []string{
+				"vendor/psr/log/src/LoggerInterface.php",
+			},
+		},
+	}
+	// the files of phpunit were removed after installation
+	phpunit := pkg.Package{
+		Name:         "phpunit/phpunit",
+		Version:      "8.5.33",
+		FoundBy:      "php-composer-installed-cataloger",
+		PURL:         "pkg:composer/phpunit/phpunit@8.5.33",
+		Locations:    locations,
+		Language:     pkg.PHP,
+		Type:         pkg.PhpComposerPkg,
+		MetadataType: pkg.PhpComposerJSONMetadataType,
+		Metadata: pkg.PhpComposerJSONMetadata{
+			Name:    "phpunit/phpunit",
+			Version: "8.5.33",
+			Require: map[string]string{
+				"ext-json": "*",
+				"php":      ">=7.2",
+			},
+			Type:        "library",
+			License:     []string{"BSD-3-Clause"},
+			Description: "The PHP Unit Testing framework.",
+			Scope:       "dev",
+			InstallPath: "../phpunit/phpunit",
+		},
+	}
+	expectedPkgs := []pkg.Package{monolog, psrLog, phpunit}
+
+	// the development requirements of monolog are only related since phpunit is installed for development
+	expectedRelationships := []artifact.Relationship{
+		{From: psrLog, To: monolog, Type: artifact.DependencyOfRelationship},
+		{From: phpunit, To: monolog, Type: artifact.DependencyOfRelationship},
+	}
 
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/install-path").
+		Expects(expectedPkgs, expectedRelationships).
+		TestCataloger(t, NewComposerInstalledCataloger())
 }
@@ -0,0 +1,228 @@
+package php
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var (
+	_ generic.Parser = parseWordpressCore
+	_ generic.Parser = parseWordpressPlugin
+	_ generic.Parser = parseWordpressTheme
+)
+
+const (
+	wordpressCoreGlob  = "**/wp-includes/version.php"
+	wordpressThemeGlob = "**/wp-content/themes/*/style.css"
+
+	// wordpress only reads the first 8 KiB of a file when looking for headers
+	wordpressHeaderSize = 8 * 1024
+)
+
+var wordpressPluginGlobs = []string{
+	// plugins within their own directory...
+	"**/wp-content/plugins/*/*.php",
+	// ...and single file plugins (e.g. hello.php)
+	"**/wp-content/plugins/*.php",
+}
+
+var (
+	wordpressVersionPattern            = regexp.MustCompile(`\$wp_version\s*=\s*['"]([^'"]+)['"]`)
+	wordpressRequiredPHPVersionPattern = regexp.MustCompile(`\$required_php_version\s*=\s*['"]([^'"]+)['"]`)
+)
+
+// parseWordpressCore parses the WordPress version from wp-includes/version.php.
+func parseWordpressCore(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read wordpress version file: %w", err)
+	}
+
+	match := wordpressVersionPattern.FindSubmatch(contents)
+	if match == nil {
+		return nil, nil, nil
+	}
+
+	var m pkg.WordpressMetadata
+	if phpMatch := wordpressRequiredPHPVersionPattern.FindSubmatch(contents); phpMatch != nil {
+		m.RequiresPHP = string(phpMatch[1])
+	}
+
+	p := newWordpressPackage("wordpress", string(match[1]), pkg.WordpressCorePkg, m,
+		reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+	)
+	return []pkg.Package{p}, nil, nil
+}
+
+// parseWordpressPlugin parses the header of a plugin file (any PHP file at the top of a plugin directory with a
+// "Plugin Name" header), falling back to the "Stable tag" of the readme.txt for the version.
+func parseWordpressPlugin(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	headers, err := parseWordpressHeaders(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse wordpress plugin headers: %w", err)
+	}
+
+	displayName := headers["plugin name"]
+	if displayName == "" {
+		// most PHP files within a plugin are not the main plugin file
+		return nil, nil, nil
+	}
+
+	// the slug is the name of the plugin directory (or the file name for single file plugins)
+	dir := path.Dir(reader.RealPath)
+	slug := path.Base(dir)
+	if slug == "plugins" {
+		slug = strings.TrimSuffix(path.Base(reader.RealPath), ".php")
+		dir = ""
+	}
+
+	m := pkg.WordpressMetadata{
+		DisplayName:       displayName,
+		Author:            headers["author"],
+		URI:               headers["plugin uri"],
+		License:           headers["license"],
+		RequiresWordpress: headers["requires at least"],
+		RequiresPHP:       headers["requires php"],
+	}
+
+	return newWordpressExtensionPackages(resolver, slug, dir, headers["version"], pkg.WordpressPluginPkg, m, reader.Location)
+}
+
+// parseWordpressTheme parses the header of the style.css of a theme, falling back to the "Stable tag" of the
+// readme.txt for the version.
+func parseWordpressTheme(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	headers, err := parseWordpressHeaders(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse wordpress theme headers: %w", err)
+	}
+
+	displayName := headers["theme name"]
+	if displayName == "" {
+		return nil, nil, nil
+	}
+
+	dir := path.Dir(reader.RealPath)
+	m := pkg.WordpressMetadata{
+		DisplayName:       displayName,
+		Author:            headers["author"],
+		URI:               headers["theme uri"],
+		License:           headers["license"],
+		RequiresWordpress: headers["requires at least"],
+		RequiresPHP:       headers["requires php"],
+		Template:          headers["template"],
+	}
+
+	return newWordpressExtensionPackages(resolver, path.Base(dir), dir, headers["version"], pkg.WordpressThemePkg, m, reader.Location)
+}
+
+func newWordpressExtensionPackages(resolver source.FileResolver, slug, dir, version string, ty pkg.Type, m pkg.WordpressMetadata, location source.Location) ([]pkg.Package, []artifact.Relationship, error) {
+	locations := []source.Location{location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)}
+
+	if dir != "" {
+		if readme := resolver.RelativeFileByPath(location, path.Join(dir, "readme.txt")); readme != nil {
+			stableTag, err := readWordpressStableTag(resolver, *readme)
+			if err != nil {
+				log.WithFields("path", readme.RealPath, "error", err).Debug("unable to read wordpress readme")
+			}
+			m.StableTag = stableTag
+			if stableTag != "" {
+				locations = append(locations, readme.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+			}
+		}
+	}
+
+	if version == "" && m.StableTag != "trunk" {
+		version = m.StableTag
+	}
+
+	return []pkg.Package{newWordpressPackage(slug, version, ty, m, locations...)}, nil, nil
+}
+
+func readWordpressStableTag(resolver source.FileResolver, location source.Location) (string, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return "", err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	headers, err := parseWordpressHeaders(reader)
+	if err != nil {
+		return "", err
+	}
+	return headers["stable tag"], nil
+}
+
+// parseWordpressHeaders returns the "Name: value" headers (keyed by lower case name) found within the first 8 KiB of a
+// plugin file, theme stylesheet or readme, for example:
+//
+//	/**
+//	 * Plugin Name: Akismet Anti-spam: Spam Protection
+//	 * Version: 5.3
+//	 */
+func parseWordpressHeaders(reader io.Reader) (map[string]string, error) {
+	headers := make(map[string]string)
+	scanner := bufio.NewScanner(io.LimitReader(reader, wordpressHeaderSize))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		line = strings.TrimPrefix(line, "<?php")
+		line = strings.TrimLeft(line, " \t/*#@=")
+
+		fields := strings.SplitN(line, ":", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name == "" || strings.ContainsAny(name, "$(){};'\"") {
+			continue
+		}
+
+		// wordpress strips trailing comment and PHP close tags from header values
+		value := strings.TrimSpace(fields[1])
+		value = strings.TrimSpace(strings.TrimSuffix(value, "?>"))
+		value = strings.TrimSpace(strings.TrimSuffix(value, "*/"))
+		if value == "" {
+			continue
+		}
+
+		// the first occurrence of a header wins
+		if _, ok := headers[name]; !ok {
+			headers[name] = value
+		}
+	}
+	// note: a partially read line at the 8 KiB limit is not an error
+	if err := scanner.Err(); err != nil && err != bufio.ErrTooLong {
+		return nil, err
+	}
+	return headers, nil
+}
+
+func newWordpressPackage(name, version string, ty pkg.Type, m pkg.WordpressMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageurl.NewPackageURL(ty.PackageURLType(), "", name, version, nil, "").ToString(),
+		Language:     pkg.PHP,
+		Type:         ty,
+		MetadataType: pkg.WordpressMetadataType,
+		Metadata:     m,
+	}
+	if m.License != "" {
+		p.Licenses = []string{m.License}
+	}
+
+	p.SetID()
+	return p
+}
@@ -0,0 +1,180 @@
+package php
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestCMSCataloger_Wordpress(t *testing.T) {
+	expectedPkgs := []pkg.Package{
+		{
+			Name:         "wordpress",
+			Version:      "6.4.2",
+			FoundBy:      "php-cms-cataloger",
+			PURL:         "pkg:wordpress-core/wordpress@6.4.2",
+			Locations:    source.NewLocationSet(source.NewLocation("wp-includes/version.php")),
+			Language:     pkg.PHP,
+			Type:         pkg.WordpressCorePkg,
+			MetadataType: pkg.WordpressMetadataType,
+			Metadata: pkg.WordpressMetadata{
+				RequiresPHP: "7.0.0",
+			},
+		},
+		{
+			Name:    "akismet",
+			Version: "5.3",
+			FoundBy: "php-cms-cataloger",
+			PURL:    "pkg:wordpress-plugin/akismet@5.3",
+			Locations: source.NewLocationSet(
+				source.NewLocation("wp-content/plugins/akismet/akismet.php"),
+				source.NewLocation("wp-content/plugins/akismet/readme.txt"),
+			),
+			Licenses:     []string{"GPLv2 or later"},
+			Language:     pkg.PHP,
+			Type:         pkg.WordpressPluginPkg,
+			MetadataType: pkg.WordpressMetadataType,
+			Metadata: pkg.WordpressMetadata{
+				DisplayName:       "Akismet Anti-spam: Spam Protection",
+				Author:            "Automattic - Anti-spam Team",
+				URI:               "https://akismet.com/",
+				License:           "GPLv2 or later",
+				RequiresWordpress: "5.8",
+				RequiresPHP:       "5.6.20",
+				StableTag:         "5.3",
+			},
+		},
+		{
+			// the version is taken from the readme when the plugin header does not declare one
+			Name:    "classic-editor",
+			Version: "1.6.3",
+			FoundBy: "php-cms-cataloger",
+			PURL:    "pkg:wordpress-plugin/classic-editor@1.6.3",
+			Locations: source.NewLocationSet(
+				source.NewLocation("wp-content/plugins/classic-editor/classic-editor.php"),
+				source.NewLocation("wp-content/plugins/classic-editor/readme.txt"),
+			),
+			Licenses:     []string{"GPLv2 or later"},
+			Language:     pkg.PHP,
+			Type:         pkg.WordpressPluginPkg,
+			MetadataType: pkg.WordpressMetadataType,
+			Metadata: pkg.WordpressMetadata{
+				DisplayName:       "Classic Editor",
+				Author:            "WordPress Contributors",
+				URI:               "https://wordpress.org/plugins/classic-editor/",
+				License:           "GPLv2 or later",
+				RequiresWordpress: "4.9",
+				RequiresPHP:       "5.2.4",
+				StableTag:         "1.6.3",
+			},
+		},
+		{
+			Name:         "hello",
+			Version:      "1.7.2",
+			FoundBy:      "php-cms-cataloger",
+			PURL:         "pkg:wordpress-plugin/hello@1.7.2",
+			Locations:    source.NewLocationSet(source.NewLocation("wp-content/plugins/hello.php")),
+			Language:     pkg.PHP,
+			Type:         pkg.WordpressPluginPkg,
+			MetadataType: pkg.WordpressMetadataType,
+			Metadata: pkg.WordpressMetadata{
+				DisplayName: "Hello Dolly",
+				Author:      "Matt Mullenweg",
+				URI:         "http://wordpress.org/plugins/hello-dolly/",
+			},
+		},
+		{
+			Name:         "twentytwentyfour",
+			Version:      "1.0",
+			FoundBy:      "php-cms-cataloger",
+			PURL:         "pkg:wordpress-theme/twentytwentyfour@1.0",
+			Locations:    source.NewLocationSet(source.NewLocation("wp-content/themes/twentytwentyfour/style.css")),
+			Licenses:     []string{"GNU General Public License v2 or later"},
+			Language:     pkg.PHP,
+			Type:         pkg.WordpressThemePkg,
+			MetadataType: pkg.WordpressMetadataType,
+			Metadata: pkg.WordpressMetadata{
+				DisplayName:       "Twenty Twenty-Four",
+				Author:            "the WordPress team",
+				URI:               "https://wordpress.org/themes/twentytwentyfour/",
+				License:           "GNU General Public License v2 or later",
+				RequiresWordpress: "6.4",
+				RequiresPHP:       "7.0",
+			},
+		},
+		{
+			Name:         "child-theme",
+			Version:      "0.1.0",
+			FoundBy:      "php-cms-cataloger",
+			PURL:         "pkg:wordpress-theme/child-theme@0.1.0",
+			Locations:    source.NewLocationSet(source.NewLocation("wp-content/themes/child-theme/style.css")),
+			Language:     pkg.PHP,
+			Type:         pkg.WordpressThemePkg,
+			MetadataType: pkg.WordpressMetadataType,
+			Metadata: pkg.WordpressMetadata{
+				DisplayName: "Child Theme",
+				Template:    "twentytwentyfour",
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/wordpress").
+		Expects(expectedPkgs, nil).
+		TestCataloger(t, NewCMSCataloger())
+}
+
+func Test_parseWordpressHeaders(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{
+			name: "docblock",
+			input: `<?php
+/**
+ * Plugin Name: Example: The Plugin
+ * Version:     1.2.3
+ */
+`,
+			want: map[string]string{
+				"plugin name": "Example: The Plugin",
+				"version":     "1.2.3",
+			},
+		},
+		{
+			name:  "trailing comment and close tags",
+			input: "<?php /* Plugin Name: Example */ ?>\n# Version: 1.0 ?>\n",
+			want: map[string]string{
+				"plugin name": "Example",
+				"version":     "1.0",
+			},
+		},
+		{
+			name:  "first header wins",
+			input: "Version: 1.0\nVersion: 2.0\n",
+			want: map[string]string{
+				"version": "1.0",
+			},
+		},
+		{
+			name:  "ignore code",
+			input: "$args = array( 'key' => 'a:b' );\nif ( true ) { echo 'a: b'; }\n",
+			want:  map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWordpressHeaders(strings.NewReader(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
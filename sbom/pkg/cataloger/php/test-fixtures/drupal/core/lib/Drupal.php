@@ -0,0 +1,23 @@
+<?php
+
+/**
+ * @file
+ * Contains \Drupal.
+ */
+
+/**
+ * Static Service Container wrapper.
+ */
+class Drupal {
+
+  /**
+   * The current system version.
+   */
+  const VERSION = '10.1.6';
+
+  /**
+   * Core API compatibility.
+   */
+  const CORE_COMPATIBILITY = '8.x';
+
+}
@@ -0,0 +1,7 @@
+<?php
+
+// this is not the main plugin file, so has no headers
+class Akismet {
+	const API_HOST = 'rest.akismet.com';
+	const API_PORT = 80;
+}
@@ -0,0 +1,16 @@
+<?php
+/**
+ * Classic Editor
+ *
+ * Plugin Name: Classic Editor
+ * Plugin URI:  https://wordpress.org/plugins/classic-editor/
+ * Description: Enables the WordPress classic editor and the old-style Edit Post screen with TinyMCE, Meta Boxes, etc.
+ * Author:      WordPress Contributors
+ * License:     GPLv2 or later
+ * Requires at least: 4.9
+ * Requires PHP: 5.2.4
+ */
+
+if ( ! defined( 'ABSPATH' ) ) {
+	die( 'Invalid request.' );
+}
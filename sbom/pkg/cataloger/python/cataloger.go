@@ -1,22 +1,46 @@
 package python
 
 import (
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
 )
 
 const eggInfoGlob = "**/*.egg-info"
 
-// NewPythonIndexCataloger returns a new cataloger for python packages referenced from poetry lock files, requirements.txt files, and setup.py files.
+// NewPythonIndexCataloger returns a new cataloger for python packages referenced from poetry, pdm, uv, and Pipfile lock files,
+// requirements.txt files, pyproject.toml files, and setup.py files.
 func NewPythonIndexCataloger() *generic.Cataloger {
 	return generic.NewCataloger("python-index-cataloger").
 		WithParserByGlobs(parseRequirementsTxt, "**/*requirements*.txt").
 		WithParserByGlobs(parsePoetryLock, "**/poetry.lock").
 		WithParserByGlobs(parsePipfileLock, "**/Pipfile.lock").
+		WithParserByGlobs(parsePdmLock, "**/pdm.lock").
+		WithParserByGlobs(parseUvLock, "**/uv.lock").
+		WithParserByGlobs(parsePyprojectToml, "**/pyproject.toml").
 		WithParserByGlobs(parseSetup, "**/setup.py")
 }
 
-// NewPythonPackageCataloger returns a new cataloger for python packages within egg or wheel installation directories.
-func NewPythonPackageCataloger() *generic.Cataloger {
-	return generic.NewCataloger("python-package-cataloger").
-		WithParserByGlobs(parseWheelOrEgg, eggInfoGlob, "**/*dist-info/METADATA", "**/*egg-info/PKG-INFO")
+// NewPythonPackageCataloger returns a new cataloger for python packages within egg or wheel installation directories,
+// including the dependency relationships between packages installed within the same site-packages directory.
+func NewPythonPackageCataloger() pkg.Cataloger {
+	return &packageCataloger{}
+}
+
+type packageCataloger struct{}
+
+func (c *packageCataloger) Name() string {
+	return "python-package-cataloger"
+}
+
+func (c *packageCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	pkgs, relationships, err := generic.NewCataloger(c.Name()).
+		WithParserByGlobs(parseWheelOrEgg, eggInfoGlob, "**/*dist-info/METADATA", "**/*egg-info/PKG-INFO").
+		Catalog(resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pkgs, append(relationships, installedDependencyRelationships(pkgs)...), nil
 }
@@ -55,6 +55,7 @@ func Test_PackageCataloger(t *testing.T) {
 					Platform:             "UNKNOWN",
 					Author:               "Kenneth Reitz",
 					AuthorEmail:          "me@kennethreitz.org",
+					ProvidesExtra:        []string{"security", "socks"},
 					SitePackagesRootPath: "test-fixtures",
 					Files: []pkg.PythonFileRecord{
 						{Path: "requests-2.22.0.dist-info/INSTALLER", Digest: &pkg.PythonFileDigest{"sha256", "zuuue4knoyJ-UwPPXg8fezS7VCrXJQrAP7zeNuwvFQg"}, Size: "4"},
@@ -183,6 +184,7 @@ func Test_PackageCataloger(t *testing.T) {
 					Platform:             "UNKNOWN",
 					Author:               "Kenneth Reitz",
 					AuthorEmail:          "me@kennethreitz.org",
+					ProvidesExtra:        []string{"security", "socks"},
 					SitePackagesRootPath: "test-fixtures",
 				},
 			},
@@ -249,6 +251,9 @@ func Test_IndexCataloger_Globs(t *testing.T) {
 				"src/setup.py",
 				"src/poetry.lock",
 				"src/Pipfile.lock",
+				"src/pdm.lock",
+				"src/uv.lock",
+				"src/pyproject.toml",
 			},
 		},
 	}
@@ -0,0 +1,225 @@
+package python
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+)
+
+var (
+	requirementPattern       = regexp.MustCompile(`^\s*([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)\s*(?:\[([^\]]*)\])?\s*(.*)$`)
+	nameSeparatorPattern     = regexp.MustCompile(`[-_.]+`)
+	markerExtraPattern       = regexp.MustCompile(`\bextra\s*==\s*["']([^"']+)["']`)
+	specifierOperatorPattern = regexp.MustCompile(`^(===|==|!=|~=|<=|>=|<|>)\s*(.*)$`)
+)
+
+// pythonRequirement is a dependency specification as described by PEP 508
+// (see https://peps.python.org/pep-0508/), for instance `requests[security] (>=2.8.1) ; python_version < "3"`.
+type pythonRequirement struct {
+	Name      string
+	Extras    []string
+	Specifier string
+	Markers   string
+	URL       string
+}
+
+// parseRequirement parses a single PEP 508 dependency specification (as found in Requires-Dist fields).
+func parseRequirement(value string) (pythonRequirement, bool) {
+	var markers string
+	if i := strings.Index(value, ";"); i >= 0 {
+		markers = strings.TrimSpace(value[i+1:])
+		value = value[:i]
+	}
+
+	match := requirementPattern.FindStringSubmatch(value)
+	if match == nil {
+		return pythonRequirement{}, false
+	}
+
+	r := pythonRequirement{
+		Name:    match[1],
+		Markers: markers,
+	}
+
+	for _, extra := range strings.Split(match[2], ",") {
+		if extra = strings.TrimSpace(extra); extra != "" {
+			r.Extras = append(r.Extras, extra)
+		}
+	}
+
+	rest := strings.TrimSpace(match[3])
+	if strings.HasPrefix(rest, "@") {
+		r.URL = strings.TrimSpace(strings.TrimPrefix(rest, "@"))
+	} else {
+		r.Specifier = formatSpecifier(rest)
+	}
+
+	return r, true
+}
+
+// formatSpecifier normalizes a version specifier (e.g. "(>=1.0,<2)") to the form used for version constraints
+// (e.g. ">= 1.0, < 2").
+func formatSpecifier(specifier string) string {
+	specifier = strings.TrimSpace(specifier)
+	specifier = strings.TrimSuffix(strings.TrimPrefix(specifier, "("), ")")
+
+	var clauses []string
+	for _, clause := range strings.Split(specifier, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		if match := specifierOperatorPattern.FindStringSubmatch(clause); match != nil {
+			clause = match[1] + " " + strings.TrimSpace(match[2])
+		}
+		clauses = append(clauses, clause)
+	}
+	return strings.Join(clauses, ", ")
+}
+
+// markerExtras returns the extras that a requirement is conditional on (e.g. `extra == "socks"`), if any.
+func markerExtras(markers string) []string {
+	var extras []string
+	for _, match := range markerExtraPattern.FindAllStringSubmatch(markers, -1) {
+		extras = append(extras, normalizeName(match[1]))
+	}
+	return extras
+}
+
+// normalizeName returns the normalized form of a python package (or extra) name
+// (see https://peps.python.org/pep-0503/#normalized-names).
+func normalizeName(name string) string {
+	return strings.ToLower(nameSeparatorPattern.ReplaceAllString(strings.TrimSpace(name), "-"))
+}
+
+// installedDependencyRelationships resolves the Requires-Dist entries of installed packages to the packages installed
+// within the same site-packages directory. Requirements that only apply when an extra is requested are followed only
+// when another installed package requests that extra. All other environment markers are assumed to be satisfied.
+func installedDependencyRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	// site-packages root -> normalized package name -> package indexes
+	installed := make(map[string]map[string][]int)
+	for i, p := range pkgs {
+		m, ok := p.Metadata.(pkg.PythonPackageMetadata)
+		if !ok {
+			continue
+		}
+		if installed[m.SitePackagesRootPath] == nil {
+			installed[m.SitePackagesRootPath] = make(map[string][]int)
+		}
+		name := normalizeName(p.Name)
+		installed[m.SitePackagesRootPath][name] = append(installed[m.SitePackagesRootPath][name], i)
+	}
+
+	activeExtras := make([]internal.StringSet, len(pkgs))
+	queue := make([]int, 0, len(pkgs))
+	for i := range pkgs {
+		activeExtras[i] = internal.NewStringSet()
+		queue = append(queue, i)
+	}
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+
+	// packages are revisited whenever a new extra is requested of them, until no new extras are found
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+
+		m, ok := pkgs[i].Metadata.(pkg.PythonPackageMetadata)
+		if !ok {
+			continue
+		}
+
+		for _, value := range m.RequiresDist {
+			r, ok := parseRequirement(value)
+			if !ok || !extrasSatisfied(markerExtras(r.Markers), activeExtras[i]) {
+				continue
+			}
+
+			for _, j := range installed[m.SitePackagesRootPath][normalizeName(r.Name)] {
+				if j == i {
+					continue
+				}
+
+				key := string(pkgs[j].ID()) + ":" + string(pkgs[i].ID())
+				if !seen.Contains(key) {
+					seen.Add(key)
+					relationships = append(relationships, artifact.Relationship{
+						From: pkgs[j],
+						To:   pkgs[i],
+						Type: artifact.DependencyOfRelationship,
+					})
+				}
+
+				requested := false
+				for _, extra := range r.Extras {
+					if extra = normalizeName(extra); !activeExtras[j].Contains(extra) {
+						activeExtras[j].Add(extra)
+						requested = true
+					}
+				}
+				if requested {
+					queue = append(queue, j)
+				}
+			}
+		}
+	}
+
+	return relationships
+}
+
+// lockedDependencyRelationships relates each package within a lock file to the locked packages named by its
+// requirements. When a name is locked at several versions, a pinned ("== x") requirement selects the matching version.
+func lockedDependencyRelationships(pkgs []pkg.Package, requirements func(pkg.Package) []pythonRequirement) []artifact.Relationship {
+	locked := make(map[string][]pkg.Package)
+	for _, p := range pkgs {
+		name := normalizeName(p.Name)
+		locked[name] = append(locked[name], p)
+	}
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	for _, p := range pkgs {
+		for _, r := range requirements(p) {
+			candidates := locked[normalizeName(r.Name)]
+			if version := strings.TrimPrefix(r.Specifier, "== "); len(candidates) > 1 && version != r.Specifier {
+				var matched []pkg.Package
+				for _, c := range candidates {
+					if c.Version == version {
+						matched = append(matched, c)
+					}
+				}
+				candidates = matched
+			}
+
+			for _, dep := range candidates {
+				key := string(dep.ID()) + ":" + string(p.ID())
+				if dep.ID() == p.ID() || seen.Contains(key) {
+					continue
+				}
+				seen.Add(key)
+				relationships = append(relationships, artifact.Relationship{
+					From: dep,
+					To:   p,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+	return relationships
+}
+
+func extrasSatisfied(required []string, active internal.StringSet) bool {
+	if len(required) == 0 {
+		return true
+	}
+	for _, extra := range required {
+		if active.Contains(extra) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package python
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func Test_parseRequirement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected pythonRequirement
+	}{
+		{
+			input:    "idna (<4,>=2.5)",
+			expected: pythonRequirement{Name: "idna", Specifier: "< 4, >= 2.5"},
+		},
+		{
+			input:    "pysocks!=1.5.7,<2.0,>=1.5.6; extra == \"socks\"",
+			expected: pythonRequirement{Name: "pysocks", Specifier: "!= 1.5.7, < 2.0, >= 1.5.6", Markers: `extra == "socks"`},
+		},
+		{
+			input:    "requests[security, socks] ; python_version < \"3\"",
+			expected: pythonRequirement{Name: "requests", Extras: []string{"security", "socks"}, Markers: `python_version < "3"`},
+		},
+		{
+			input:    "pip @ https://github.com/pypa/pip/archive/22.0.2.zip",
+			expected: pythonRequirement{Name: "pip", URL: "https://github.com/pypa/pip/archive/22.0.2.zip"},
+		},
+		{
+			input:    "zope.interface",
+			expected: pythonRequirement{Name: "zope.interface"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			actual, ok := parseRequirement(test.input)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func Test_normalizeName(t *testing.T) {
+	assert.Equal(t, "charset-normalizer", normalizeName("charset_normalizer"))
+	assert.Equal(t, "zope-interface", normalizeName("Zope.Interface"))
+	assert.Equal(t, "use-chardet-on-py3", normalizeName("use__chardet-on.py3"))
+}
+
+func Test_PackageCataloger_DependencyRelationships(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/site-packages-dependencies")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := NewPythonPackageCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 7)
+
+	var actual []string
+	for _, r := range relationships {
+		from, ok := r.From.(pkg.Package)
+		require.True(t, ok)
+		to, ok := r.To.(pkg.Package)
+		require.True(t, ok)
+		actual = append(actual, fmt.Sprintf("%s -[%s]-> %s", from.Name, r.Type, to.Name))
+	}
+
+	assert.ElementsMatch(t, []string{
+		"requests -[dependency-of]-> myapp",
+		"charset-normalizer -[dependency-of]-> requests",
+		"idna -[dependency-of]-> requests",
+		"urllib3 -[dependency-of]-> requests",
+		"certifi -[dependency-of]-> requests",
+		// the socks extra of requests is requested by myapp
+		"PySocks -[dependency-of]-> requests",
+		// pytest (the test extra of myapp), chardet (not installed), and the pysocks dependency of urllib3 (the socks
+		// extra is not requested) are not related
+	}, actual)
+}
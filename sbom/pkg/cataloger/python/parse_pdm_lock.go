@@ -0,0 +1,150 @@
+package python
+
+import (
+	"fmt"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// integrity check
+var _ generic.Parser = parsePdmLock
+
+type pdmLock struct {
+	Packages []pdmPackage `toml:"package"`
+	Metadata struct {
+		// lock files before version 4 list the files of each package (keyed by "name version") within the metadata
+		Files map[string][]pdmFile `toml:"files"`
+	} `toml:"metadata"`
+}
+
+type pdmPackage struct {
+	Name         string    `toml:"name"`
+	Version      string    `toml:"version"`
+	Marker       string    `toml:"marker"`
+	Extras       []string  `toml:"extras"`
+	Dependencies []string  `toml:"dependencies"`
+	Files        []pdmFile `toml:"files"`
+	// direct references (VCS, URL, or local path)
+	Git      string `toml:"git"`
+	Revision string `toml:"revision"`
+	URL      string `toml:"url"`
+	Path     string `toml:"path"`
+}
+
+type pdmFile struct {
+	File string `toml:"file"`
+	URL  string `toml:"url"`
+	Hash string `toml:"hash"`
+}
+
+func (p pdmPackage) directURL() string {
+	switch {
+	case p.Git != "" && p.Revision != "":
+		return fmt.Sprintf("git+%s@%s", p.Git, p.Revision)
+	case p.Git != "":
+		return "git+" + p.Git
+	case p.URL != "":
+		return p.URL
+	}
+	return p.Path
+}
+
+// parsePdmLock is a parser function for pdm.lock contents, returning all python packages discovered along with the
+// relationships between them.
+func parsePdmLock(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load pdm.lock for parsing: %w", err)
+	}
+
+	var lock pdmLock
+	if err := tree.Unmarshal(&lock); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse pdm.lock: %w", err)
+	}
+
+	// packages locked with extras are listed again (e.g. "requests" with extras = ["socks"]), only adding the
+	// dependencies of the extras, so are merged into the same package
+	var keys []string
+	entries := make(map[string]*pkg.PythonLockfileMetadata)
+	names := make(map[string]pdmPackage)
+	for _, p := range lock.Packages {
+		key := normalizeName(p.Name) + "@" + p.Version
+		m, ok := entries[key]
+		if !ok {
+			m = &pkg.PythonLockfileMetadata{
+				URL:    p.directURL(),
+				Hashes: pdmHashes(p.Files, lock.Metadata.Files[p.Name+" "+p.Version]),
+			}
+			entries[key] = m
+			keys = append(keys, key)
+		}
+		if len(p.Extras) == 0 {
+			m.Markers = p.Marker
+			names[key] = p
+		} else if _, ok := names[key]; !ok {
+			names[key] = p
+		}
+		for _, dependency := range p.Dependencies {
+			// the entries of extras depend on the package itself
+			if r, ok := parseRequirement(dependency); ok && normalizeName(r.Name) == normalizeName(p.Name) {
+				continue
+			}
+			m.Dependencies = appendUnique(m.Dependencies, dependency)
+		}
+	}
+
+	var pkgs []pkg.Package
+	for _, key := range keys {
+		p := names[key]
+		pkgs = append(pkgs, newPackageForLockfile(p.Name, p.Version, *entries[key], reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)))
+	}
+
+	return pkgs, lockfileRelationships(pkgs), nil
+}
+
+func pdmHashes(fileSets ...[]pdmFile) []string {
+	var hashes []string
+	for _, files := range fileSets {
+		for _, f := range files {
+			if f.Hash != "" {
+				hashes = appendUnique(hashes, f.Hash)
+			}
+		}
+	}
+	return hashes
+}
+
+// lockfileRelationships creates relationships for the dependencies of each package that are also locked within the same
+// lock file.
+func lockfileRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	return lockedDependencyRelationships(pkgs, func(p pkg.Package) []pythonRequirement {
+		m, ok := p.Metadata.(pkg.PythonLockfileMetadata)
+		if !ok {
+			return nil
+		}
+		var requirements []pythonRequirement
+		for _, dependency := range m.Dependencies {
+			if r, ok := parseRequirement(dependency); ok {
+				requirements = append(requirements, r)
+			}
+		}
+		return requirements
+	})
+}
+
+func appendUnique(values []string, additions ...string) []string {
+	seen := internal.NewStringSet(values...)
+	for _, v := range additions {
+		if !seen.Contains(v) {
+			seen.Add(v)
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,106 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParsePdmLock(t *testing.T) {
+	fixture := "test-fixtures/pdm/pdm.lock"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	certifi := pkg.Package{
+		Name:         "certifi",
+		Version:      "2023.7.22",
+		PURL:         "pkg:pypi/certifi@2023.7.22",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			Hashes: []string{
+				"sha256:92d6037539857d8206b8f6ae472e8b77db8058fec5937a1ef3f54304089edbb9",
+				"sha256:539cc1d13202e33ca466e88b2807e29f4c13049d6d87031a3c110744495cb082",
+			},
+		},
+	}
+	colorama := pkg.Package{
+		Name:         "colorama",
+		Version:      "0.4.6",
+		PURL:         "pkg:pypi/colorama@0.4.6",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			Hashes:  []string{"sha256:4f1d9991f5acc0ca119f9d443620b77f9d6b33703e51011c16baf57afb285fc6"},
+			Markers: `sys_platform == "win32"`,
+		},
+	}
+	pysocks := pkg.Package{
+		Name:         "pysocks",
+		Version:      "1.7.1",
+		PURL:         "pkg:pypi/pysocks@1.7.1",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			Hashes: []string{"sha256:2725bd0a9925919b9b51739eea5f9e2bae91e83288108a9ad338b2e3a4435ee5"},
+		},
+	}
+	pytest := pkg.Package{
+		Name:         "pytest",
+		Version:      "7.4.0",
+		PURL:         "pkg:pypi/pytest@7.4.0",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			Hashes:       []string{"sha256:78bf16451a2eb8c7a2ea98e32dc119fd2aa758f1d5d66dbf0a59d69a3969df32"},
+			Dependencies: []string{`colorama; sys_platform == "win32"`},
+		},
+	}
+	// the entry locked with the socks extra is merged into the package
+	requests := pkg.Package{
+		Name:         "requests",
+		Version:      "2.31.0",
+		PURL:         "pkg:pypi/requests@2.31.0",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			Hashes:       []string{"sha256:58cd2187c01e70e6e26505bca751777aa9f2ee0b7f4300988b709f44e013003f"},
+			Dependencies: []string{"certifi>=2017.4.17", "urllib3<3,>=1.21.1", "PySocks!=1.5.7,>=1.5.6"},
+		},
+	}
+	urllib3 := pkg.Package{
+		Name:         "urllib3",
+		Version:      "2.0.4",
+		PURL:         "pkg:pypi/urllib3@2.0.4",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			URL: "git+https://github.com/urllib3/urllib3.git@2c7f4d3bc3a3d1e8ccf2a2d3e1b9c7b6a3f1e5c9",
+		},
+	}
+
+	expectedPkgs := []pkg.Package{certifi, colorama, pysocks, pytest, requests, urllib3}
+
+	expectedRelationships := []artifact.Relationship{
+		{From: colorama, To: pytest, Type: artifact.DependencyOfRelationship},
+		{From: certifi, To: requests, Type: artifact.DependencyOfRelationship},
+		{From: urllib3, To: requests, Type: artifact.DependencyOfRelationship},
+		{From: pysocks, To: requests, Type: artifact.DependencyOfRelationship},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parsePdmLock, expectedPkgs, expectedRelationships)
+}
@@ -36,6 +36,8 @@ type Dependency struct {
 	Hashes  []string `json:"hashes"`
 	Version string   `json:"version"`
 	Index   string   `json:"index"`
+	Markers string   `json:"markers"`
+	Extras  []string `json:"extras"`
 }
 
 var _ generic.Parser = parsePipfileLock
@@ -65,7 +67,7 @@ func parsePipfileLock(_ source.FileResolver, _ *generic.Environment, reader sour
 				index = "https://pypi.org/simple"
 			}
 			version := strings.TrimPrefix(pkgMeta.Version, "==")
-			pkgs = append(pkgs, newPackageForIndexWithMetadata(name, version, pkg.PythonPipfileLockMetadata{Index: index, Hashes: pkgMeta.Hashes}, reader.Location))
+			pkgs = append(pkgs, newPackageForIndexWithMetadata(name, version, pkg.PythonPipfileLockMetadata{Index: index, Hashes: pkgMeta.Hashes, Markers: pkgMeta.Markers, Extras: pkgMeta.Extras}, reader.Location))
 		}
 	}
 
@@ -59,6 +59,8 @@ func TestParsePipFileLock(t *testing.T) {
 					"sha256:02f46fc0e3c5ac58b80d4d56eb0a7c7d97fcef69ace9326289fb9f1955e65cfe",
 					"sha256:0563c1b3826945eecd62186f3f5c7d31abb7391fedc893b7e2b26303b5a9f3fe",
 				},
+				Markers: "python_version >= '3.6'",
+				Extras:  []string{"speedups"},
 			},
 		},
 		{
@@ -79,7 +81,7 @@ func TestParsePipFileLock(t *testing.T) {
 		},
 	}
 
-	// TODO: relationships are not under test
+	// Pipfile.lock files do not describe the dependencies of each package
 	var expectedRelationships []artifact.Relationship
 
 	pkgtest.TestFileParser(t, fixture, parsePipfileLock, expectedPkgs, expectedRelationships)
@@ -2,6 +2,7 @@ package python
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/pelletier/go-toml"
 
@@ -15,13 +16,21 @@ import (
 var _ generic.Parser = parsePoetryLock
 
 type poetryMetadata struct {
-	Packages []struct {
-		Name        string `toml:"name"`
-		Version     string `toml:"version"`
-		Category    string `toml:"category"`
-		Description string `toml:"description"`
-		Optional    bool   `toml:"optional"`
-	} `toml:"package"`
+	Packages []poetryPackage `toml:"package"`
+}
+
+type poetryPackage struct {
+	Name        string              `toml:"name"`
+	Version     string              `toml:"version"`
+	Category    string              `toml:"category"`
+	Description string              `toml:"description"`
+	Optional    bool                `toml:"optional"`
+	Marker      string              `toml:"marker"`
+	Extras      map[string][]string `toml:"extras"`
+	Source      struct {
+		Type string `toml:"type"`
+		URL  string `toml:"url"`
+	} `toml:"source"`
 }
 
 // parsePoetryLock is a parser function for poetry.lock contents, returning all python packages discovered.
@@ -37,17 +46,105 @@ func parsePoetryLock(_ source.FileResolver, _ *generic.Environment, reader sourc
 		return nil, nil, fmt.Errorf("unable to parse poetry.lock: %w", err)
 	}
 
+	// the dependencies tables have heterogeneous values (strings, tables, and arrays of tables), so are read from the
+	// tree directly
+	packageTrees, _ := tree.Get("package").([]*toml.Tree)
+
 	var pkgs []pkg.Package
-	for _, p := range metadata.Packages {
+	for i, p := range metadata.Packages {
+		var dependencies []pkg.PythonPoetryLockDependencyEntry
+		if i < len(packageTrees) {
+			dependencies = poetryDependencies(packageTrees[i])
+		}
+
 		pkgs = append(
 			pkgs,
-			newPackageForIndex(
+			newPackageForPoetryLock(
 				p.Name,
 				p.Version,
+				pkg.PythonPoetryLockMetadata{
+					Index:        p.Source.URL,
+					Markers:      p.Marker,
+					Dependencies: dependencies,
+					Extras:       poetryExtras(p.Extras),
+				},
 				reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
 			),
 		)
 	}
 
-	return pkgs, nil, nil
+	return pkgs, poetryRelationships(pkgs), nil
+}
+
+func poetryDependencies(packageTree *toml.Tree) []pkg.PythonPoetryLockDependencyEntry {
+	dependenciesTree, ok := packageTree.Get("dependencies").(*toml.Tree)
+	if !ok {
+		return nil
+	}
+
+	names := dependenciesTree.Keys()
+	sort.Strings(names)
+
+	var dependencies []pkg.PythonPoetryLockDependencyEntry
+	for _, name := range names {
+		switch value := dependenciesTree.Get(name).(type) {
+		case string:
+			dependencies = append(dependencies, pkg.PythonPoetryLockDependencyEntry{Name: name, Version: value})
+		case *toml.Tree:
+			dependencies = append(dependencies, poetryDependency(name, value))
+		case []*toml.Tree:
+			// multiple constraints for the same dependency, each applying to different environments
+			for _, constraint := range value {
+				dependencies = append(dependencies, poetryDependency(name, constraint))
+			}
+		}
+	}
+	return dependencies
+}
+
+func poetryDependency(name string, constraint *toml.Tree) pkg.PythonPoetryLockDependencyEntry {
+	dependency := pkg.PythonPoetryLockDependencyEntry{Name: name}
+	dependency.Version, _ = constraint.Get("version").(string)
+	dependency.Optional, _ = constraint.Get("optional").(bool)
+	dependency.Markers, _ = constraint.Get("markers").(string)
+	if extras, ok := constraint.Get("extras").([]interface{}); ok {
+		for _, extra := range extras {
+			if s, ok := extra.(string); ok {
+				dependency.Extras = append(dependency.Extras, s)
+			}
+		}
+	}
+	return dependency
+}
+
+func poetryExtras(extras map[string][]string) []pkg.PythonPoetryLockExtraEntry {
+	var names []string
+	for name := range extras {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var entries []pkg.PythonPoetryLockExtraEntry
+	for _, name := range names {
+		entries = append(entries, pkg.PythonPoetryLockExtraEntry{Name: name, Dependencies: extras[name]})
+	}
+	return entries
+}
+
+// poetryRelationships creates relationships for the (non-optional) dependencies of each package that are also locked
+// within the same poetry.lock file.
+func poetryRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	return lockedDependencyRelationships(pkgs, func(p pkg.Package) []pythonRequirement {
+		m, ok := p.Metadata.(pkg.PythonPoetryLockMetadata)
+		if !ok {
+			return nil
+		}
+		var requirements []pythonRequirement
+		for _, dependency := range m.Dependencies {
+			if !dependency.Optional {
+				requirements = append(requirements, pythonRequirement{Name: dependency.Name})
+			}
+		}
+		return requirements
+	})
 }
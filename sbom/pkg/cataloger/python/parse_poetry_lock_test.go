@@ -14,41 +14,174 @@ func TestParsePoetryLock(t *testing.T) {
 	locations := source.NewLocationSet(source.NewLocation(fixture))
 	expectedPkgs := []pkg.Package{
 		{
-			Name:      "added-value",
-			Version:   "0.14.2",
-			PURL:      "pkg:pypi/added-value@0.14.2",
-			Locations: locations,
-			Language:  pkg.Python,
-			Type:      pkg.PythonPkg,
+			Name:         "added-value",
+			Version:      "0.14.2",
+			PURL:         "pkg:pypi/added-value@0.14.2",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonPoetryLockMetadataType,
+			Metadata: pkg.PythonPoetryLockMetadata{
+				Dependencies: []pkg.PythonPoetryLockDependencyEntry{
+					{Name: "docutils", Version: "*"},
+					{Name: "natsort", Version: "*"},
+					{Name: "six", Version: "*"},
+					{Name: "sphinx", Version: "*"},
+				},
+				Extras: []pkg.PythonPoetryLockExtraEntry{
+					{Name: "deploy", Dependencies: []string{"bumpversion", "twine", "wheel"}},
+					{Name: "docs", Dependencies: []string{"sphinx", "sphinx-rtd-theme"}},
+					{Name: "test", Dependencies: []string{"pytest", "pytest-cov", "coveralls", "beautifulsoup4", "hypothesis"}},
+				},
+			},
 		},
 		{
-			Name:      "alabaster",
-			Version:   "0.7.12",
-			PURL:      "pkg:pypi/alabaster@0.7.12",
-			Locations: locations,
-			Language:  pkg.Python,
-			Type:      pkg.PythonPkg,
+			Name:         "alabaster",
+			Version:      "0.7.12",
+			PURL:         "pkg:pypi/alabaster@0.7.12",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonPoetryLockMetadataType,
+			Metadata:     pkg.PythonPoetryLockMetadata{},
 		},
 		{
-			Name:      "appnope",
-			Version:   "0.1.0",
-			PURL:      "pkg:pypi/appnope@0.1.0",
-			Locations: locations,
-			Language:  pkg.Python,
-			Type:      pkg.PythonPkg,
+			Name:         "appnope",
+			Version:      "0.1.0",
+			PURL:         "pkg:pypi/appnope@0.1.0",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonPoetryLockMetadataType,
+			Metadata: pkg.PythonPoetryLockMetadata{
+				Markers: `python_version >= "3.4" and sys_platform == "darwin" or sys_platform == "darwin"`,
+			},
 		},
 		{
-			Name:      "asciitree",
-			Version:   "0.3.3",
-			PURL:      "pkg:pypi/asciitree@0.3.3",
-			Locations: locations,
-			Language:  pkg.Python,
-			Type:      pkg.PythonPkg,
+			Name:         "asciitree",
+			Version:      "0.3.3",
+			PURL:         "pkg:pypi/asciitree@0.3.3",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonPoetryLockMetadataType,
+			Metadata:     pkg.PythonPoetryLockMetadata{},
 		},
 	}
 
-	// TODO: relationships are not under test
+	// the dependencies of added-value are not within the lock file
 	var expectedRelationships []artifact.Relationship
 
 	pkgtest.TestFileParser(t, fixture, parsePoetryLock, expectedPkgs, expectedRelationships)
 }
+
+func TestParsePoetryLock_Dependencies(t *testing.T) {
+	fixture := "test-fixtures/poetry/dependencies/poetry.lock"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	certifi := pkg.Package{
+		Name:         "certifi",
+		Version:      "2023.7.22",
+		PURL:         "pkg:pypi/certifi@2023.7.22",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonPoetryLockMetadataType,
+		Metadata:     pkg.PythonPoetryLockMetadata{},
+	}
+	charsetNormalizer := pkg.Package{
+		Name:         "charset-normalizer",
+		Version:      "3.2.0",
+		PURL:         "pkg:pypi/charset-normalizer@3.2.0",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonPoetryLockMetadataType,
+		Metadata:     pkg.PythonPoetryLockMetadata{},
+	}
+	idna := pkg.Package{
+		Name:         "idna",
+		Version:      "3.4",
+		PURL:         "pkg:pypi/idna@3.4",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonPoetryLockMetadataType,
+		Metadata:     pkg.PythonPoetryLockMetadata{},
+	}
+	numpy := pkg.Package{
+		Name:         "numpy",
+		Version:      "1.25.2",
+		PURL:         "pkg:pypi/numpy@1.25.2",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonPoetryLockMetadataType,
+		Metadata: pkg.PythonPoetryLockMetadata{
+			Index: "https://pypi.example.com/simple",
+		},
+	}
+	pandas := pkg.Package{
+		Name:         "pandas",
+		Version:      "2.0.3",
+		PURL:         "pkg:pypi/pandas@2.0.3",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonPoetryLockMetadataType,
+		Metadata: pkg.PythonPoetryLockMetadata{
+			Dependencies: []pkg.PythonPoetryLockDependencyEntry{
+				{Name: "numpy", Version: ">=1.21.0", Markers: `python_version >= "3.10"`},
+				{Name: "numpy", Version: ">=1.23.2", Markers: `python_version >= "3.11"`},
+			},
+			Extras: []pkg.PythonPoetryLockExtraEntry{
+				{Name: "performance", Dependencies: []string{"bottleneck (>=1.3.2)", "numba (>=0.53.1)", "numexpr (>=2.7.1)"}},
+			},
+		},
+	}
+	requests := pkg.Package{
+		Name:         "requests",
+		Version:      "2.31.0",
+		PURL:         "pkg:pypi/requests@2.31.0",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonPoetryLockMetadataType,
+		Metadata: pkg.PythonPoetryLockMetadata{
+			Dependencies: []pkg.PythonPoetryLockDependencyEntry{
+				{Name: "PySocks", Version: ">=1.5.6,<1.5.7 || >1.5.7", Optional: true, Markers: `extra == "socks"`},
+				{Name: "certifi", Version: ">=2017.4.17"},
+				{Name: "charset-normalizer", Version: ">=2,<4"},
+				{Name: "idna", Version: ">=2.5,<4"},
+				{Name: "urllib3", Version: ">=1.21.1,<3"},
+			},
+			Extras: []pkg.PythonPoetryLockExtraEntry{
+				{Name: "socks", Dependencies: []string{"PySocks (>=1.5.6,!=1.5.7)"}},
+				{Name: "use-chardet-on-py3", Dependencies: []string{"chardet (>=3.0.2,<6)"}},
+			},
+		},
+	}
+	urllib3 := pkg.Package{
+		Name:         "urllib3",
+		Version:      "2.0.4",
+		PURL:         "pkg:pypi/urllib3@2.0.4",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonPoetryLockMetadataType,
+		Metadata:     pkg.PythonPoetryLockMetadata{},
+	}
+
+	expectedPkgs := []pkg.Package{certifi, charsetNormalizer, idna, numpy, pandas, requests, urllib3}
+
+	// optional dependencies (e.g. PySocks) are only installed when an extra is requested, so are not related
+	expectedRelationships := []artifact.Relationship{
+		{From: numpy, To: pandas, Type: artifact.DependencyOfRelationship},
+		{From: certifi, To: requests, Type: artifact.DependencyOfRelationship},
+		{From: charsetNormalizer, To: requests, Type: artifact.DependencyOfRelationship},
+		{From: idna, To: requests, Type: artifact.DependencyOfRelationship},
+		{From: urllib3, To: requests, Type: artifact.DependencyOfRelationship},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parsePoetryLock, expectedPkgs, expectedRelationships)
+}
@@ -0,0 +1,144 @@
+package python
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// integrity check
+var _ generic.Parser = parsePyprojectToml
+
+type pyprojectToml struct {
+	// see https://packaging.python.org/en/latest/specifications/pyproject-toml/#dependencies-optional-dependencies
+	Project struct {
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	// see https://packaging.python.org/en/latest/specifications/dependency-groups/ (groups may also include other
+	// groups with {include-group = "name"} tables, which are not requirements)
+	DependencyGroups map[string][]interface{} `toml:"dependency-groups"`
+	Tool             struct {
+		// see https://hatch.pypa.io/latest/config/environment/overview/#dependencies
+		Hatch struct {
+			Envs map[string]struct {
+				Dependencies      []string `toml:"dependencies"`
+				ExtraDependencies []string `toml:"extra-dependencies"`
+			} `toml:"envs"`
+		} `toml:"hatch"`
+	} `toml:"tool"`
+}
+
+// requirements returns all requirements declared by the project: the project dependencies first, followed by the
+// optional dependencies, dependency groups, and hatch environment dependencies (each ordered by name).
+func (p pyprojectToml) requirements() []string {
+	requirements := append([]string{}, p.Project.Dependencies...)
+
+	var extras []string
+	for name := range p.Project.OptionalDependencies {
+		extras = append(extras, name)
+	}
+	sort.Strings(extras)
+	for _, name := range extras {
+		requirements = append(requirements, p.Project.OptionalDependencies[name]...)
+	}
+
+	var groups []string
+	for name := range p.DependencyGroups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	for _, name := range groups {
+		for _, value := range p.DependencyGroups[name] {
+			if r, ok := value.(string); ok {
+				requirements = append(requirements, r)
+			}
+		}
+	}
+
+	var envs []string
+	for name := range p.Tool.Hatch.Envs {
+		envs = append(envs, name)
+	}
+	sort.Strings(envs)
+	for _, name := range envs {
+		env := p.Tool.Hatch.Envs[name]
+		requirements = append(requirements, env.Dependencies...)
+		requirements = append(requirements, env.ExtraDependencies...)
+	}
+
+	return requirements
+}
+
+// parsePyprojectToml is a parser function for pyproject.toml contents, returning the python packages declared as
+// dependencies of the project (PEP 621), dependency groups (PEP 735), and hatch environments. These are usually not
+// pinned, so are reported with their version constraint.
+func parsePyprojectToml(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load pyproject.toml for parsing: %w", err)
+	}
+
+	var project pyprojectToml
+	if err := tree.Unmarshal(&project); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse pyproject.toml: %w", err)
+	}
+
+	var pkgs []pkg.Package
+	seen := internal.NewStringSet()
+	for _, value := range project.requirements() {
+		r, ok := parseRequirement(value)
+		if !ok {
+			log.WithFields("path", reader.RealPath).Debugf("unable to parse pyproject.toml requirement: %q", value)
+			continue
+		}
+
+		// the same requirement may be declared within several groups
+		key := normalizeName(r.Name) + r.Specifier + r.URL + r.Markers
+		if seen.Contains(key) {
+			continue
+		}
+		seen.Add(key)
+
+		extras := r.Extras
+		if extras == nil {
+			extras = []string{}
+		}
+
+		pkgs = append(
+			pkgs,
+			newPackageForRequirementsWithMetadata(
+				r.Name,
+				pinnedVersion(r.Specifier),
+				pkg.PythonRequirementsMetadata{
+					Name:              r.Name,
+					Extras:            extras,
+					VersionConstraint: r.Specifier,
+					URL:               r.URL,
+					Markers:           parseMarkers(value),
+				},
+				reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+			),
+		)
+	}
+
+	return pkgs, nil, nil
+}
+
+// pinnedVersion returns the version of a specifier that pins a single version (e.g. "== 1.0"), otherwise "".
+func pinnedVersion(specifier string) string {
+	version := strings.TrimPrefix(specifier, "== ")
+	if version == specifier || strings.ContainsAny(version, ",*") {
+		return ""
+	}
+	return version
+}
@@ -0,0 +1,176 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParsePyprojectToml(t *testing.T) {
+	fixture := "test-fixtures/pyproject/pyproject.toml"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	expectedPkgs := []pkg.Package{
+		{
+			Name:         "requests",
+			PURL:         "pkg:pypi/requests",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "requests",
+				Extras:            []string{"socks"},
+				VersionConstraint: ">= 2.31, < 3",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
+		{
+			Name:         "attrs",
+			Version:      "23.1.0",
+			PURL:         "pkg:pypi/attrs@23.1.0",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "attrs",
+				Extras:            []string{},
+				VersionConstraint: "== 23.1.0",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
+		{
+			Name:         "tomli",
+			PURL:         "pkg:pypi/tomli",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "tomli",
+				Extras:            []string{},
+				VersionConstraint: ">= 1.1.0",
+				URL:               "",
+				Markers:           map[string]string{"python_version": `< "3.11"`},
+			},
+		},
+		{
+			// a direct reference
+			Name:         "pip",
+			PURL:         "pkg:pypi/pip",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "pip",
+				Extras:            []string{},
+				VersionConstraint: "",
+				URL:               "https://github.com/pypa/pip/archive/22.0.2.zip",
+				Markers:           map[string]string{},
+			},
+		},
+		{
+			Name:         "click",
+			PURL:         "pkg:pypi/click",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "click",
+				Extras:            []string{},
+				VersionConstraint: "",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
+		{
+			Name:         "rich",
+			PURL:         "pkg:pypi/rich",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "rich",
+				Extras:            []string{},
+				VersionConstraint: "~= 13.4",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
+		{
+			Name:         "coverage",
+			PURL:         "pkg:pypi/coverage",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "coverage",
+				Extras:            []string{"toml"},
+				VersionConstraint: "",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
+		{
+			// declared by both the test dependency group and the default hatch environment
+			Name:         "pytest",
+			PURL:         "pkg:pypi/pytest",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "pytest",
+				Extras:            []string{},
+				VersionConstraint: ">= 7",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
+		{
+			Name:         "mypy",
+			PURL:         "pkg:pypi/mypy",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "mypy",
+				Extras:            []string{},
+				VersionConstraint: ">= 1.4",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
+		{
+			// from the lint hatch environment
+			Name:         "ruff",
+			Version:      "0.0.280",
+			PURL:         "pkg:pypi/ruff@0.0.280",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "ruff",
+				Extras:            []string{},
+				VersionConstraint: "== 0.0.280",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
+	}
+
+	var expectedRelationships []artifact.Relationship
+
+	pkgtest.TestFileParser(t, fixture, parsePyprojectToml, expectedPkgs, expectedRelationships)
+}
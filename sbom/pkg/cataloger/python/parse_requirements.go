@@ -3,6 +3,7 @@ package python
 import (
 	"bufio"
 	"fmt"
+	"path"
 	"regexp"
 	"strings"
 	"unicode"
@@ -21,14 +22,15 @@ var (
 	urlRegex    = regexp.MustCompile("@.*git.*")
 )
 
-// parseRequirementsTxt takes a Python requirements.txt file, returning all Python packages that are locked to a
-// specific version.
+// parseRequirementsTxt takes a Python requirements.txt file, returning all Python packages listed. Packages that are
+// not locked to a specific version are reported with their version constraint only.
 func parseRequirementsTxt(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	var packages []pkg.Package
 
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		line := scanner.Text()
+		rawLine := line
 		rawLineNoComments := removeTrailingComment(line)
 		line = trimRequirementsTxtLine(line)
 
@@ -37,14 +39,60 @@ func parseRequirementsTxt(_ source.FileResolver, _ *generic.Environment, reader
 			continue
 		}
 
-		if strings.HasPrefix(line, "-e") {
-			// editable packages aren't parsed (yet)
+		if value, ok := editableRequirement(rawLine); ok {
+			name, url := parseEditableRequirement(value)
+			if name == "" {
+				log.WithFields("path", reader.RealPath).Debugf("unable to determine package name for editable requirement: %q", value)
+				continue
+			}
+			packages = append(
+				packages,
+				newPackageForRequirementsWithMetadata(
+					name,
+					"",
+					pkg.PythonRequirementsMetadata{
+						Name:    name,
+						Extras:  []string{},
+						URL:     url,
+						Markers: map[string]string{},
+					},
+					reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+				),
+			)
+			continue
+		}
+
+		if strings.HasPrefix(line, "-") {
+			// other options (e.g. "-r other-requirements.txt" or "--hash=...") do not describe a package
 			continue
 		}
 
 		if !strings.Contains(line, "==") {
-			// a package without a version, or a range (unpinned) which does not tell us
-			// exactly what will be installed.
+			// a package without a version, or a range (unpinned) which does not tell us exactly what will be
+			// installed, so only the version constraint is known
+			name, versionConstraint, url := parseUnpinnedRequirement(line)
+			if name == "" {
+				log.WithFields("path", reader.RealPath).Debugf("unable to parse requirements.txt line: %q", line)
+				continue
+			}
+			if gitURL := parseURL(rawLineNoComments); gitURL != "" {
+				url = gitURL
+			}
+			packages = append(
+				packages,
+				newPackageForRequirementsWithMetadata(
+					name,
+					"",
+					pkg.PythonRequirementsMetadata{
+						Name:              name,
+						Extras:            parseExtras(rawLineNoComments),
+						VersionConstraint: versionConstraint,
+						URL:               url,
+						Markers:           parseMarkers(rawLineNoComments),
+					},
+					reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+				),
+			)
 			continue
 		}
 
@@ -95,6 +143,73 @@ func parseRequirementsTxt(_ source.FileResolver, _ *generic.Environment, reader
 	return packages, nil, nil
 }
 
+// editableRequirement returns the project path or URL of an editable requirement (e.g. "-e git+https://...#egg=name").
+// The raw line is used since the "#egg=" fragment is otherwise treated as a comment.
+func editableRequirement(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	for _, option := range []string{"--editable", "-e"} {
+		if !strings.HasPrefix(line, option) {
+			continue
+		}
+		value := strings.TrimSpace(strings.TrimPrefix(line, option))
+		value = strings.TrimSpace(strings.TrimPrefix(value, "="))
+		// drop any trailing comment, which must be preceded by whitespace
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		return value, value != ""
+	}
+	return "", false
+}
+
+// parseEditableRequirement returns the package name and URL of an editable requirement, where the name is taken from
+// the "#egg=" fragment when present, otherwise from the last element of the path (e.g. "pecan" for
+// "https://github.com/pecan/pecan.git").
+func parseEditableRequirement(value string) (string, string) {
+	url := value
+	var name string
+	if i := strings.Index(value, "#"); i >= 0 {
+		url = value[:i]
+		for _, fragment := range strings.Split(value[i+1:], "&") {
+			if egg := strings.TrimPrefix(fragment, "egg="); egg != fragment {
+				name = extrasRegex.ReplaceAllString(egg, "")
+			}
+		}
+	}
+
+	if name == "" {
+		base := path.Base(strings.TrimRight(url, "/"))
+		if i := strings.Index(base, "@"); i >= 0 {
+			// a VCS revision (e.g. "repo.git@v1.0")
+			base = base[:i]
+		}
+		name = strings.TrimSuffix(base, ".git")
+	}
+
+	if !requirementPattern.MatchString(name) || strings.ContainsAny(name, " /") {
+		return "", url
+	}
+	return name, url
+}
+
+// parseUnpinnedRequirement returns the name, version constraint (e.g. ">= 1.0.0"), and direct URL (for "name @ url"
+// requirements) of a requirement that has already had extras and environment markers removed.
+func parseUnpinnedRequirement(line string) (string, string, string) {
+	match := requirementPattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", "", ""
+	}
+	name, rest := match[1], strings.TrimSpace(match[3])
+
+	if strings.HasPrefix(rest, "@") {
+		return name, "", strings.TrimSpace(strings.TrimPrefix(rest, "@"))
+	}
+	if rest != "" && !specifierOperatorPattern.MatchString(rest) {
+		return "", "", ""
+	}
+	return name, formatSpecifier(rest), ""
+}
+
 func parseVersionAndHashes(version string) (string, []string) {
 	parts := strings.Split(version, "--hash=")
 	if len(parts) < 2 {
@@ -61,6 +61,125 @@ func TestParseRequirementsTxt(t *testing.T) {
 				Markers:           map[string]string{"python_version": "< '3.8'"},
 			},
 		},
+		{
+			Name:         "sqlalchemy",
+			PURL:         "pkg:pypi/sqlalchemy",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "sqlalchemy",
+				Extras:            []string{},
+				VersionConstraint: ">= 1.0.0",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
+		{
+			// editable requirements are named after the project path
+			Name:         "pecan",
+			PURL:         "pkg:pypi/pecan",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:    "pecan",
+				Extras:  []string{},
+				URL:     "https://github.com/pecan/pecan.git",
+				Markers: map[string]string{},
+			},
+		},
+		{
+			// the name is taken from the egg fragment
+			Name:         "sample-lib",
+			PURL:         "pkg:pypi/sample-lib",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:    "sample-lib",
+				Extras:  []string{},
+				URL:     "git+https://github.com/owner/sample.git@v1.2",
+				Markers: map[string]string{},
+			},
+		},
+		{
+			Name:         "local-tool",
+			PURL:         "pkg:pypi/local-tool",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:    "local-tool",
+				Extras:  []string{},
+				URL:     "./libs/local-tool",
+				Markers: map[string]string{},
+			},
+		},
+		{
+			Name:         "coverage",
+			PURL:         "pkg:pypi/coverage",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "coverage",
+				Extras:            []string{},
+				VersionConstraint: "!= 3.5",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
+		{
+			Name:         "numpyNew",
+			PURL:         "pkg:pypi/numpyNew",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "numpyNew",
+				Extras:            []string{},
+				VersionConstraint: "",
+				URL:               "",
+				Markers:           map[string]string{"sys_platform": "== 'win32'"},
+			},
+		},
+		{
+			Name:         "numpy",
+			PURL:         "pkg:pypi/numpy",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "numpy",
+				Extras:            []string{},
+				VersionConstraint: ">= 3.4.1",
+				URL:               "",
+				Markers:           map[string]string{"sys_platform": "== 'win32'"},
+			},
+		},
+		{
+			Name:         "Mopidy-Dirble",
+			PURL:         "pkg:pypi/Mopidy-Dirble",
+			Locations:    locations,
+			Language:     pkg.Python,
+			Type:         pkg.PythonPkg,
+			MetadataType: pkg.PythonRequirementsMetadataType,
+			Metadata: pkg.PythonRequirementsMetadata{
+				Name:              "Mopidy-Dirble",
+				Extras:            []string{},
+				VersionConstraint: "~= 1.1",
+				URL:               "",
+				Markers:           map[string]string{},
+			},
+		},
 		{
 			Name:         "argh",
 			Version:      "0.26.2",
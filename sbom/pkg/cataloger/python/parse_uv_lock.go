@@ -0,0 +1,186 @@
+package python
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// integrity check
+var _ generic.Parser = parseUvLock
+
+type uvLock struct {
+	Packages []uvPackage `toml:"package"`
+}
+
+type uvPackage struct {
+	Name                 string                    `toml:"name"`
+	Version              string                    `toml:"version"`
+	Source               uvSource                  `toml:"source"`
+	Sdist                uvArtifact                `toml:"sdist"`
+	Wheels               []uvArtifact              `toml:"wheels"`
+	Dependencies         []uvDependency            `toml:"dependencies"`
+	OptionalDependencies map[string][]uvDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]uvDependency `toml:"dev-dependencies"`
+}
+
+type uvSource struct {
+	Registry  string `toml:"registry"`
+	Git       string `toml:"git"`
+	URL       string `toml:"url"`
+	Path      string `toml:"path"`
+	Directory string `toml:"directory"`
+	Editable  string `toml:"editable"`
+	Virtual   string `toml:"virtual"`
+}
+
+type uvArtifact struct {
+	URL  string `toml:"url"`
+	Hash string `toml:"hash"`
+}
+
+type uvDependency struct {
+	Name    string   `toml:"name"`
+	Version string   `toml:"version"`
+	Extra   []string `toml:"extra"`
+	Marker  string   `toml:"marker"`
+}
+
+// requirement returns the dependency in the form of a PEP 508 requirement (e.g. `pysocks==1.7.1 ; extra == "socks"`),
+// where a version is only present when several versions of the dependency are locked.
+func (d uvDependency) requirement() string {
+	r := d.Name
+	if len(d.Extra) > 0 {
+		r += "[" + strings.Join(d.Extra, ",") + "]"
+	}
+	if d.Version != "" {
+		r += "==" + d.Version
+	}
+	if d.Marker != "" {
+		r += " ; " + d.Marker
+	}
+	return r
+}
+
+func (s uvSource) url() string {
+	for _, u := range []string{s.Git, s.URL, s.Path, s.Directory, s.Editable, s.Virtual} {
+		if u != "" {
+			return u
+		}
+	}
+	return ""
+}
+
+// dependencies returns the dependencies of the package given the extras that are requested of it, including all
+// development dependency groups.
+func (p uvPackage) dependencies(extras internal.StringSet) []uvDependency {
+	dependencies := append([]uvDependency{}, p.Dependencies...)
+
+	var names []string
+	for name := range p.OptionalDependencies {
+		if extras.Contains(normalizeName(name)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dependencies = append(dependencies, p.OptionalDependencies[name]...)
+	}
+
+	names = nil
+	for name := range p.DevDependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dependencies = append(dependencies, p.DevDependencies[name]...)
+	}
+
+	return dependencies
+}
+
+// parseUvLock is a parser function for uv.lock contents, returning all python packages discovered along with the
+// relationships between them.
+func parseUvLock(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load uv.lock for parsing: %w", err)
+	}
+
+	var lock uvLock
+	if err := tree.Unmarshal(&lock); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse uv.lock: %w", err)
+	}
+
+	requested := uvRequestedExtras(lock.Packages)
+
+	var pkgs []pkg.Package
+	for _, p := range lock.Packages {
+		var hashes []string
+		for _, a := range append([]uvArtifact{p.Sdist}, p.Wheels...) {
+			if a.Hash != "" {
+				hashes = appendUnique(hashes, a.Hash)
+			}
+		}
+
+		var dependencies []string
+		for _, d := range p.dependencies(requested[normalizeName(p.Name)]) {
+			dependencies = appendUnique(dependencies, d.requirement())
+		}
+
+		pkgs = append(
+			pkgs,
+			newPackageForLockfile(
+				p.Name,
+				p.Version,
+				pkg.PythonLockfileMetadata{
+					Index:        p.Source.Registry,
+					URL:          p.Source.url(),
+					Hashes:       hashes,
+					Dependencies: dependencies,
+				},
+				reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+			),
+		)
+	}
+
+	return pkgs, lockfileRelationships(pkgs), nil
+}
+
+// uvRequestedExtras returns the extras requested of each (normalized) package name by the other packages in the lock,
+// which determines which optional dependencies are installed.
+func uvRequestedExtras(packages []uvPackage) map[string]internal.StringSet {
+	requested := make(map[string]internal.StringSet)
+	for _, p := range packages {
+		requested[normalizeName(p.Name)] = internal.NewStringSet()
+	}
+
+	// requesting an extra may bring in optional dependencies that request further extras
+	for changed := true; changed; {
+		changed = false
+		for _, p := range packages {
+			for _, d := range p.dependencies(requested[normalizeName(p.Name)]) {
+				extras, ok := requested[normalizeName(d.Name)]
+				if !ok {
+					continue
+				}
+				for _, extra := range d.Extra {
+					if extra = normalizeName(extra); !extras.Contains(extra) {
+						extras.Add(extra)
+						changed = true
+					}
+				}
+			}
+		}
+	}
+
+	return requested
+}
@@ -0,0 +1,125 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseUvLock(t *testing.T) {
+	fixture := "test-fixtures/uv/uv.lock"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	certifi := pkg.Package{
+		Name:         "certifi",
+		Version:      "2024.8.30",
+		PURL:         "pkg:pypi/certifi@2024.8.30",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			Index: "https://pypi.org/simple",
+			Hashes: []string{
+				"sha256:bec941d2aa8195e248a60b31ff9f0558284cf01a52591ceda73ea9afffd69fd9",
+				"sha256:922820b53db7a7257ffbda3f597266d435245903d80737e34f8a45ff3e3230d8",
+			},
+		},
+	}
+	idna := pkg.Package{
+		Name:         "idna",
+		Version:      "3.10",
+		PURL:         "pkg:pypi/idna@3.10",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			Index:  "https://pypi.org/simple",
+			Hashes: []string{"sha256:12f65c9b470abda6dc35cf8e63cc574b1c52b11df2c86030af0ac09b01b13ea9"},
+		},
+	}
+	myproject := pkg.Package{
+		Name:         "myproject",
+		Version:      "0.1.0",
+		PURL:         "pkg:pypi/myproject@0.1.0",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			URL:          ".",
+			Dependencies: []string{"requests[socks]", "pytest"},
+		},
+	}
+	pysocks := pkg.Package{
+		Name:         "pysocks",
+		Version:      "1.7.1",
+		PURL:         "pkg:pypi/pysocks@1.7.1",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			Index:  "https://pypi.org/simple",
+			Hashes: []string{"sha256:2725bd0a9925919b9b51739eea5f9e2bae91e83288108a9ad338b2e3a4435ee5"},
+		},
+	}
+	pytest := pkg.Package{
+		Name:         "pytest",
+		Version:      "8.3.3",
+		PURL:         "pkg:pypi/pytest@8.3.3",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			Index:        "https://pypi.org/simple",
+			Hashes:       []string{"sha256:a6853c7375b2663155079443d2e45de913a911a11d669df02a50814944db57b2"},
+			Dependencies: []string{"colorama ; sys_platform == 'win32'"},
+		},
+	}
+	// the socks extra is requested by myproject, the use-chardet-on-py3 extra is not
+	requests := pkg.Package{
+		Name:         "requests",
+		Version:      "2.32.3",
+		PURL:         "pkg:pypi/requests@2.32.3",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			Index:        "https://pypi.org/simple",
+			Hashes:       []string{"sha256:55365417734eb18255590a9ff9eb97e9e1da868d4ccd6402399eaf68af20a760"},
+			Dependencies: []string{"certifi", "idna", "urllib3", "pysocks"},
+		},
+	}
+	urllib3 := pkg.Package{
+		Name:         "urllib3",
+		Version:      "2.2.3",
+		PURL:         "pkg:pypi/urllib3@2.2.3",
+		Locations:    locations,
+		Language:     pkg.Python,
+		Type:         pkg.PythonPkg,
+		MetadataType: pkg.PythonLockfileMetadataType,
+		Metadata: pkg.PythonLockfileMetadata{
+			URL: "https://github.com/urllib3/urllib3?rev=2.2.3#9a3a7a5da4b0e9c6b5b1b52a0b1e4fa5c4f9f04c",
+		},
+	}
+
+	expectedPkgs := []pkg.Package{certifi, idna, myproject, pysocks, pytest, requests, urllib3}
+
+	expectedRelationships := []artifact.Relationship{
+		{From: requests, To: myproject, Type: artifact.DependencyOfRelationship},
+		{From: pytest, To: myproject, Type: artifact.DependencyOfRelationship},
+		{From: certifi, To: requests, Type: artifact.DependencyOfRelationship},
+		{From: idna, To: requests, Type: artifact.DependencyOfRelationship},
+		{From: urllib3, To: requests, Type: artifact.DependencyOfRelationship},
+		{From: pysocks, To: requests, Type: artifact.DependencyOfRelationship},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseUvLock, expectedPkgs, expectedRelationships)
+}
@@ -18,6 +18,8 @@ import (
 // returning all Python packages listed.
 func parseWheelOrEggMetadata(path string, reader io.Reader) (pkg.PythonPackageMetadata, error) {
 	fields := make(map[string]string)
+	// some fields may be repeated, each occurrence being a separate value (e.g. "Requires-Dist")
+	multiFields := make(map[string][]string)
 	var key string
 
 	scanner := bufio.NewScanner(reader)
@@ -41,6 +43,11 @@ func parseWheelOrEggMetadata(path string, reader io.Reader) (pkg.PythonPackageMe
 		switch {
 		case strings.HasPrefix(line, " "):
 			// a field-body continuation
+			if values := multiFields[key]; isMultipleUseField(key) && len(values) > 0 {
+				values[len(values)-1] += " " + strings.TrimSpace(line)
+				continue
+			}
+
 			updatedValue, err := handleFieldBodyContinuation(key, line, fields)
 			if err != nil {
 				return pkg.PythonPackageMetadata{}, err
@@ -54,6 +61,11 @@ func parseWheelOrEggMetadata(path string, reader io.Reader) (pkg.PythonPackageMe
 				key = strings.ReplaceAll(strings.TrimSpace(line[0:i]), "-", "")
 				val := strings.TrimSpace(line[i+1:])
 
+				if isMultipleUseField(key) {
+					multiFields[key] = append(multiFields[key], val)
+					continue
+				}
+
 				fields[key] = val
 			} else {
 				log.Warnf("cannot parse field from path: %q from line: %q", path, line)
@@ -70,6 +82,9 @@ func parseWheelOrEggMetadata(path string, reader io.Reader) (pkg.PythonPackageMe
 		return pkg.PythonPackageMetadata{}, fmt.Errorf("unable to parse APK metadata: %w", err)
 	}
 
+	metadata.RequiresDist = multiFields["RequiresDist"]
+	metadata.ProvidesExtra = multiFields["ProvidesExtra"]
+
 	// add additional metadata not stored in the egg/wheel metadata file
 
 	metadata.SitePackagesRootPath = determineSitePackagesRootPath(path)
@@ -77,6 +92,16 @@ func parseWheelOrEggMetadata(path string, reader io.Reader) (pkg.PythonPackageMe
 	return metadata, nil
 }
 
+// isMultipleUseField indicates if the given (dash-less) field key may be specified more than once within core metadata
+// (see https://packaging.python.org/en/latest/specifications/core-metadata/).
+func isMultipleUseField(key string) bool {
+	switch key {
+	case "RequiresDist", "ProvidesExtra":
+		return true
+	}
+	return false
+}
+
 // isEggRegularFile determines if the specified path is the regular file variant
 // of egg metadata (as opposed to a directory that contains more metadata
 // files).
@@ -23,6 +23,7 @@ func TestParseWheelEggMetadata(t *testing.T) {
 				Platform:             "UNKNOWN",
 				Author:               "Kenneth Reitz",
 				AuthorEmail:          "me@kennethreitz.org",
+				ProvidesExtra:        []string{"security", "socks"},
 				SitePackagesRootPath: "test-fixtures",
 			},
 		},
@@ -0,0 +1,41 @@
+/*
+Package r provides a concrete Cataloger implementation for R packages, from renv.lock files and installed package
+DESCRIPTION files.
+*/
+package r
+
+import (
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// NewRenvLockCataloger returns a new cataloger for R packages locked within renv.lock files.
+func NewRenvLockCataloger() *generic.Cataloger {
+	return generic.NewCataloger("r-renv-lock-cataloger").
+		WithParserByGlobs(parseRenvLock, "**/renv.lock")
+}
+
+// NewPackageCataloger returns a new cataloger for installed R packages (the DESCRIPTION file within each package
+// directory of a library), including the dependency relationships between installed packages.
+func NewPackageCataloger() pkg.Cataloger {
+	return &packageCataloger{}
+}
+
+type packageCataloger struct{}
+
+func (c *packageCataloger) Name() string {
+	return "r-package-cataloger"
+}
+
+func (c *packageCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	pkgs, relationships, err := generic.NewCataloger(c.Name()).
+		WithParserByGlobs(parseDescription, "**/DESCRIPTION").
+		Catalog(resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pkgs, append(relationships, installedDependencyRelationships(pkgs)...), nil
+}
@@ -0,0 +1,57 @@
+package r
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+)
+
+func Test_RenvLockCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain renv lock files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/renv.lock",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewRenvLockCataloger())
+		})
+	}
+}
+
+func Test_PackageCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []string
+	}{
+		{
+			name:    "obtain installed package DESCRIPTION files",
+			fixture: "test-fixtures/glob-paths",
+			expected: []string{
+				"src/library/example/DESCRIPTION",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, NewPackageCataloger())
+		})
+	}
+}
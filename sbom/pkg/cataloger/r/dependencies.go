@@ -0,0 +1,127 @@
+package r
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+)
+
+// requirementName returns the package name of a requirement (e.g. "rlang" for "rlang (>= 1.0.0)").
+func requirementName(requirement string) string {
+	if i := strings.Index(requirement, "("); i >= 0 {
+		requirement = requirement[:i]
+	}
+	return strings.TrimSpace(requirement)
+}
+
+// requiredNames returns the names of the packages that an R package requires to be installed (Depends, Imports and
+// LinkingTo, but not Suggests). The requirement on R itself is not a package.
+func requiredNames(m pkg.RPackageMetadata) []string {
+	var names []string
+	for _, requirements := range [][]string{m.Depends, m.Imports, m.LinkingTo, m.Requirements} {
+		for _, r := range requirements {
+			if name := requirementName(r); name != "" && name != "R" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// installedDependencyRelationships resolves the requirements of installed packages to the installed packages. A
+// package installed within the same library is preferred, otherwise the package may be installed in any other
+// library (e.g. base packages are within the library of the R installation, while others are within a site library).
+func installedDependencyRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	// library path -> package name -> package indexes
+	installed := make(map[string]map[string][]int)
+	libraries := make([]string, len(pkgs))
+	for i, p := range pkgs {
+		libraries[i] = libraryPath(p)
+		if installed[libraries[i]] == nil {
+			installed[libraries[i]] = make(map[string][]int)
+		}
+		installed[libraries[i]][p.Name] = append(installed[libraries[i]][p.Name], i)
+	}
+
+	var libraryPaths []string
+	for library := range installed {
+		libraryPaths = append(libraryPaths, library)
+	}
+	sort.Strings(libraryPaths)
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	for i, p := range pkgs {
+		m, ok := p.Metadata.(pkg.RPackageMetadata)
+		if !ok {
+			continue
+		}
+
+		for _, name := range requiredNames(m) {
+			candidates := installed[libraries[i]][name]
+			if len(candidates) == 0 {
+				for _, library := range libraryPaths {
+					candidates = append(candidates, installed[library][name]...)
+				}
+			}
+
+			for _, j := range candidates {
+				key := string(pkgs[j].ID()) + ":" + string(p.ID())
+				if j == i || seen.Contains(key) {
+					continue
+				}
+				seen.Add(key)
+				relationships = append(relationships, artifact.Relationship{
+					From: pkgs[j],
+					To:   p,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+	return relationships
+}
+
+// libraryPath returns the library that a package is installed within (the parent of the package directory).
+func libraryPath(p pkg.Package) string {
+	locations := p.Locations.ToSlice()
+	if len(locations) == 0 {
+		return ""
+	}
+	return path.Dir(path.Dir(locations[0].RealPath))
+}
+
+// lockedDependencyRelationships relates each package within a lock file to the locked packages that it requires.
+func lockedDependencyRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	locked := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		locked[p.Name] = p
+	}
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.RPackageMetadata)
+		if !ok {
+			continue
+		}
+
+		for _, name := range requiredNames(m) {
+			dep, ok := locked[name]
+			if !ok || dep.Name == p.Name || seen.Contains(dep.Name+":"+p.Name) {
+				continue
+			}
+			seen.Add(dep.Name + ":" + p.Name)
+			relationships = append(relationships, artifact.Relationship{
+				From: dep,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
+}
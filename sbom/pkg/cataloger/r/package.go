@@ -0,0 +1,31 @@
+package r
+
+import (
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func newPackage(name, version, license string, m pkg.RPackageMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(name, version),
+		Language:     pkg.R,
+		Type:         pkg.RPkg,
+		MetadataType: pkg.RPackageMetadataType,
+		Metadata:     m,
+	}
+	if license != "" {
+		p.Licenses = []string{license}
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func packageURL(name, version string) string {
+	return packageurl.NewPackageURL(pkg.RPkg.PackageURLType(), "", name, version, nil, "").ToString()
+}
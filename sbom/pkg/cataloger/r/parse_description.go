@@ -0,0 +1,111 @@
+package r
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseDescription
+
+var (
+	whitespacePattern       = regexp.MustCompile(`\s+`)
+	urlSeparatorPattern     = regexp.MustCompile(`[\s,]+`)
+	descriptionFieldPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9@/._-]*):\s*(.*)$`)
+)
+
+// parseDescription is a parser function for the DESCRIPTION file of an R package, returning the package itself.
+// Files without a Package and Version field are not R package descriptions.
+func parseDescription(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	fields, err := parseDCF(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse R package DESCRIPTION: %w", err)
+	}
+
+	name, version := fields["Package"], fields["Version"]
+	if name == "" || version == "" {
+		return nil, nil, nil
+	}
+
+	m := pkg.RPackageMetadata{
+		Title:            fields["Title"],
+		Author:           fields["Author"],
+		Maintainer:       fields["Maintainer"],
+		URL:              splitURLs(fields["URL"]),
+		Repository:       fields["Repository"],
+		Built:            fields["Built"],
+		NeedsCompilation: strings.EqualFold(fields["NeedsCompilation"], "yes"),
+		Depends:          splitRequirements(fields["Depends"]),
+		Imports:          splitRequirements(fields["Imports"]),
+		LinkingTo:        splitRequirements(fields["LinkingTo"]),
+		Suggests:         splitRequirements(fields["Suggests"]),
+	}
+
+	p := newPackage(name, version, fields["License"], m,
+		reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+	)
+
+	return []pkg.Package{p}, nil, nil
+}
+
+// parseDCF parses a file in the Debian control file format used by R for DESCRIPTION files: each field is a
+// "Name: value" line, where the value may continue on the following lines as long as they are indented.
+func parseDCF(reader io.Reader) (map[string]string, error) {
+	fields := make(map[string]string)
+	var name string
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if line[0] == ' ' || line[0] == '\t' {
+			if name != "" {
+				fields[name] = strings.TrimSpace(fields[name] + " " + strings.TrimSpace(line))
+			}
+			continue
+		}
+
+		match := descriptionFieldPattern.FindStringSubmatch(line)
+		if match == nil {
+			name = ""
+			continue
+		}
+		name = match[1]
+		fields[name] = strings.TrimSpace(match[2])
+	}
+
+	return fields, scanner.Err()
+}
+
+// splitRequirements returns the individual requirements of a Depends, Imports, LinkingTo or Suggests field (e.g.
+// "R (>= 3.5.0), rlang (>= 1.0.0)").
+func splitRequirements(value string) []string {
+	var requirements []string
+	for _, r := range strings.Split(value, ",") {
+		r = whitespacePattern.ReplaceAllString(strings.TrimSpace(r), " ")
+		if r != "" {
+			requirements = append(requirements, r)
+		}
+	}
+	return requirements
+}
+
+func splitURLs(value string) []string {
+	var urls []string
+	for _, u := range urlSeparatorPattern.Split(value, -1) {
+		if u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
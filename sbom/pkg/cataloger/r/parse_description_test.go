@@ -0,0 +1,89 @@
+package r
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseDescription(t *testing.T) {
+	fixture := "test-fixtures/installed/site-library/rlang/DESCRIPTION"
+	expected := []pkg.Package{
+		{
+			Name:         "rlang",
+			Version:      "1.1.1",
+			PURL:         "pkg:cran/rlang@1.1.1",
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Licenses:     []string{"MIT + file LICENSE"},
+			Language:     pkg.R,
+			Type:         pkg.RPkg,
+			MetadataType: pkg.RPackageMetadataType,
+			Metadata: pkg.RPackageMetadata{
+				Title:            "Functions for Base Types and Core R and 'Tidyverse' Features",
+				Author:           "Lionel Henry [aut, cre], Hadley Wickham [aut], mikefc [cph]",
+				Maintainer:       "Lionel Henry <lionel@posit.co>",
+				URL:              []string{"https://rlang.r-lib.org", "https://github.com/r-lib/rlang"},
+				Repository:       "CRAN",
+				Built:            "R 4.3.1; x86_64-pc-linux-gnu; 2023-07-11 17:05:11 UTC; unix",
+				NeedsCompilation: true,
+				Depends:          []string{"R (>= 3.5.0)"},
+				Imports:          []string{"utils"},
+				Suggests: []string{
+					"cli (>= 3.1.0)", "covr", "crayon", "fs", "glue (>= 1.6.2)", "knitr", "magrittr", "methods",
+					"pillar", "rmarkdown", "stats", "testthat (>= 3.0.0)", "tibble", "usethis", "vctrs (>= 0.2.3)",
+					"withr",
+				},
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseDescription, expected, nil)
+}
+
+func TestParseDescription_NotAPackage(t *testing.T) {
+	pkgtest.NewCatalogTester().
+		FromString("DESCRIPTION", "Description: not an R package\n").
+		Expects(nil, nil).
+		TestParser(t, parseDescription)
+}
+
+func Test_PackageCataloger_DependencyRelationships(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/installed")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	pkgs, relationships, err := NewPackageCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 5)
+
+	var actual []string
+	for _, r := range relationships {
+		from, ok := r.From.(pkg.Package)
+		require.True(t, ok)
+		to, ok := r.To.(pkg.Package)
+		require.True(t, ok)
+		actual = append(actual, fmt.Sprintf("%s -[%s]-> %s", from.Name, r.Type, to.Name))
+	}
+
+	assert.ElementsMatch(t, []string{
+		"glue -[dependency-of]-> lifecycle",
+		"rlang -[dependency-of]-> lifecycle",
+		// base packages are installed within the library of the R installation, rather than the site library
+		"utils -[dependency-of]-> rlang",
+		"methods -[dependency-of]-> glue",
+		"utils -[dependency-of]-> methods",
+		// cli and stats are not installed
+	}, actual)
+}
+
+func Test_splitRequirements(t *testing.T) {
+	assert.Equal(t, []string{"R (>= 3.5.0)", "rlang (>= 1.0.0)", "utils"}, splitRequirements("R (>=   3.5.0), rlang (>=\n 1.0.0),\n utils,"))
+	assert.Nil(t, splitRequirements(""))
+}
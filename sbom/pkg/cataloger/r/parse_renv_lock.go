@@ -0,0 +1,96 @@
+package r
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseRenvLock
+
+type renvLock struct {
+	Packages map[string]renvPackage `json:"Packages"`
+}
+
+type renvPackage struct {
+	Package      string   `json:"Package"`
+	Version      string   `json:"Version"`
+	Source       string   `json:"Source"`
+	Repository   string   `json:"Repository"`
+	Hash         string   `json:"Hash"`
+	Requirements []string `json:"Requirements"`
+	// renv 1.1 and later record the DESCRIPTION fields of each package as well
+	Title     string   `json:"Title"`
+	License   string   `json:"License"`
+	Depends   renvList `json:"Depends"`
+	Imports   renvList `json:"Imports"`
+	LinkingTo renvList `json:"LinkingTo"`
+}
+
+// renvList is a list of requirements, which is written as an array, but may also be a comma separated string.
+type renvList []string
+
+func (l *renvList) UnmarshalJSON(data []byte) error {
+	var values []string
+	if err := json.Unmarshal(data, &values); err == nil {
+		*l = splitRequirements(strings.Join(values, ","))
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*l = splitRequirements(value)
+	return nil
+}
+
+// parseRenvLock is a parser function for renv.lock contents, returning all locked R packages along with the
+// relationships between them.
+func parseRenvLock(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var lock renvLock
+	if err := json.NewDecoder(reader).Decode(&lock); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse renv.lock: %w", err)
+	}
+
+	names := make([]string, 0, len(lock.Packages))
+	for name := range lock.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pkgs []pkg.Package
+	for _, key := range names {
+		entry := lock.Packages[key]
+		name := entry.Package
+		if name == "" {
+			name = key
+		}
+		if entry.Version == "" {
+			continue
+		}
+
+		m := pkg.RPackageMetadata{
+			Title:        entry.Title,
+			Repository:   entry.Repository,
+			Source:       entry.Source,
+			Hash:         entry.Hash,
+			Depends:      entry.Depends,
+			Imports:      entry.Imports,
+			LinkingTo:    entry.LinkingTo,
+			Requirements: entry.Requirements,
+		}
+
+		pkgs = append(pkgs, newPackage(name, entry.Version, entry.License, m,
+			reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+		))
+	}
+
+	return pkgs, lockedDependencyRelationships(pkgs), nil
+}
@@ -0,0 +1,87 @@
+package r
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseRenvLock(t *testing.T) {
+	fixture := "test-fixtures/renv.lock"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	cli := pkg.Package{
+		Name:         "cli",
+		Version:      "3.6.1",
+		PURL:         "pkg:cran/cli@3.6.1",
+		Locations:    locations,
+		Language:     pkg.R,
+		Type:         pkg.RPkg,
+		MetadataType: pkg.RPackageMetadataType,
+		Metadata: pkg.RPackageMetadata{
+			Repository:   "CRAN",
+			Source:       "Repository",
+			Hash:         "89e6d8219950eac806ae0c489052048a",
+			Requirements: []string{"utils"},
+		},
+	}
+	glue := pkg.Package{
+		Name:         "glue",
+		Version:      "1.6.2",
+		PURL:         "pkg:cran/glue@1.6.2",
+		Locations:    locations,
+		Language:     pkg.R,
+		Type:         pkg.RPkg,
+		MetadataType: pkg.RPackageMetadataType,
+		Metadata: pkg.RPackageMetadata{
+			Repository:   "CRAN",
+			Source:       "Repository",
+			Hash:         "4f2596dfb05dac67b9dc558e5c6fba2e",
+			Requirements: []string{"methods"},
+		},
+	}
+	lifecycle := pkg.Package{
+		Name:         "lifecycle",
+		Version:      "1.0.3",
+		PURL:         "pkg:cran/lifecycle@1.0.3",
+		Locations:    locations,
+		Licenses:     []string{"MIT + file LICENSE"},
+		Language:     pkg.R,
+		Type:         pkg.RPkg,
+		MetadataType: pkg.RPackageMetadataType,
+		Metadata: pkg.RPackageMetadata{
+			Title:      "Manage the Life Cycle of your Package Functions",
+			Repository: "CRAN",
+			Source:     "Repository",
+			Hash:       "001cecbeac1cff9301bdc3775ee46a86",
+			Depends:    []string{"R (>= 3.4)"},
+			Imports:    []string{"cli (>= 3.4.0)", "glue", "rlang (>= 1.0.6)"},
+		},
+	}
+	rlang := pkg.Package{
+		Name:         "rlang",
+		Version:      "1.1.1",
+		PURL:         "pkg:cran/rlang@1.1.1",
+		Locations:    locations,
+		Language:     pkg.R,
+		Type:         pkg.RPkg,
+		MetadataType: pkg.RPackageMetadataType,
+		Metadata: pkg.RPackageMetadata{
+			Source:       "GitHub",
+			Hash:         "dc079ccd156cde8647360f473c1fa718",
+			Requirements: []string{"R", "utils"},
+		},
+	}
+
+	expectedPkgs := []pkg.Package{cli, glue, lifecycle, rlang}
+	expectedRelationships := []artifact.Relationship{
+		{From: cli, To: lifecycle, Type: artifact.DependencyOfRelationship},
+		{From: glue, To: lifecycle, Type: artifact.DependencyOfRelationship},
+		{From: rlang, To: lifecycle, Type: artifact.DependencyOfRelationship},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseRenvLock, expectedPkgs, expectedRelationships)
+}
@@ -1,19 +1,24 @@
 package ruby
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/nextlinux/packageurl-go"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/source"
 )
 
-func newGemfileLockPackage(name, version string, locations ...source.Location) pkg.Package {
+func newGemfileLockPackage(name, version string, m pkg.GemfileLockMetadata, locations ...source.Location) pkg.Package {
 	p := pkg.Package{
-		Name:      name,
-		Version:   version,
-		PURL:      packageURL(name, version),
-		Locations: source.NewLocationSet(locations...),
-		Language:  pkg.Ruby,
-		Type:      pkg.GemPkg,
+		Name:         name,
+		Version:      version,
+		PURL:         packageURL(name, version, gemfileLockQualifiers(m)),
+		Locations:    source.NewLocationSet(locations...),
+		Language:     pkg.Ruby,
+		Type:         pkg.GemPkg,
+		MetadataType: pkg.GemfileLockMetadataType,
+		Metadata:     m,
 	}
 
 	p.SetID()
@@ -26,7 +31,7 @@ func newGemspecPackage(m pkg.GemMetadata, locations ...source.Location) pkg.Pack
 		Name:         m.Name,
 		Version:      m.Version,
 		Locations:    source.NewLocationSet(locations...),
-		PURL:         packageURL(m.Name, m.Version),
+		PURL:         packageURL(m.Name, m.Version, nil),
 		Licenses:     m.Licenses,
 		Language:     pkg.Ruby,
 		Type:         pkg.GemPkg,
@@ -39,9 +44,22 @@ func newGemspecPackage(m pkg.GemMetadata, locations ...source.Location) pkg.Pack
 	return p
 }
 
-func packageURL(name, version string) string {
-	var qualifiers packageurl.Qualifiers
+// gemfileLockQualifiers returns the PURL qualifiers for a gem locked from a git source, pinning the revision that was
+// checked out (e.g. vcs_url=git+https://github.com/rails/turbolinks.git@80216ce9d89920bf073709405e3fce6d0a3ccd9a).
+func gemfileLockQualifiers(m pkg.GemfileLockMetadata) packageurl.Qualifiers {
+	if m.Source != gitSource || m.Remote == "" || m.Revision == "" {
+		return nil
+	}
+	vcsURL := m.Remote
+	if !strings.HasPrefix(vcsURL, "git+") {
+		vcsURL = "git+" + vcsURL
+	}
+	return packageurl.Qualifiers{
+		{Key: pkg.PURLQualifierVCSURL, Value: fmt.Sprintf("%s@%s", vcsURL, m.Revision)},
+	}
+}
 
+func packageURL(name, version string, qualifiers packageurl.Qualifiers) string {
 	return packageurl.NewPackageURL(
 		packageurl.TypeGem,
 		"",
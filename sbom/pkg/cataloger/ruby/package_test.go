@@ -22,7 +22,7 @@ func Test_packageURL(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(fmt.Sprintf("%s@%s", test.name, test.version), func(t *testing.T) {
-			actual := packageURL(test.name, test.version)
+			actual := packageURL(test.name, test.version, nil)
 			if diff := cmp.Diff(test.expected, actual); diff != "" {
 				t.Errorf("unexpected packageURL (-want +got):\n%s", diff)
 			}
@@ -2,6 +2,7 @@ package ruby
 
 import (
 	"bufio"
+	"path"
 	"strings"
 
 	"github.com/nextlinux/sbom/internal"
@@ -15,12 +16,40 @@ var _ generic.Parser = parseGemFileLockEntries
 
 var sectionsOfInterest = internal.NewStringSet("GEM", "GIT", "PATH", "PLUGIN SOURCE")
 
+const (
+	gitSource  = "git"
+	pathSource = "path"
+
+	dependenciesSection = "DEPENDENCIES"
+	bundledWithSection  = "BUNDLED WITH"
+)
+
+// gemfileLockSource holds the attributes of a source block (e.g. GIT), which apply to all the specs within the block.
+type gemfileLockSource struct {
+	kind     string
+	remote   string
+	revision string
+	branch   string
+	tag      string
+	ref      string
+}
+
+type gemfileLockEntry struct {
+	name         string
+	version      string
+	source       gemfileLockSource
+	dependencies []string
+}
+
 // parseGemFileLockEntries is a parser function for Gemfile.lock contents, returning all Gems discovered.
-func parseGemFileLockEntries(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
-	var pkgs []pkg.Package
+func parseGemFileLockEntries(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	scanner := bufio.NewScanner(reader)
 
-	var currentSection string
+	var currentSection, bundledWith string
+	var currentSource gemfileLockSource
+	var entries []*gemfileLockEntry
+	var current *gemfileLockEntry
+	direct := internal.NewStringSet()
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -29,35 +58,192 @@ func parseGemFileLockEntries(_ source.FileResolver, _ *generic.Environment, read
 		if len(line) > 1 && line[0] != ' ' {
 			// start of section
 			currentSection = sanitizedLine
-			continue
-		} else if !sectionsOfInterest.Contains(currentSection) {
-			// skip this line, we're in the wrong section
+			currentSource = gemfileLockSource{kind: strings.ToLower(currentSection)}
+			current = nil
 			continue
 		}
 
-		if isDependencyLine(line) {
+		switch {
+		case currentSection == dependenciesSection:
+			// e.g. "rails (= 4.1.1)" or "turbolinks!" (where "!" denotes a gem from a GIT or PATH source)
+			if fields := strings.Fields(sanitizedLine); len(fields) > 0 {
+				direct.Add(strings.TrimSuffix(fields[0], "!"))
+			}
+		case currentSection == bundledWithSection:
+			if sanitizedLine != "" {
+				bundledWith = sanitizedLine
+			}
+		case !sectionsOfInterest.Contains(currentSection):
+			// skip this line, we're in the wrong section
+			continue
+		case isDependencyLine(line):
 			candidate := strings.Fields(sanitizedLine)
 			if len(candidate) != 2 {
+				current = nil
 				continue
 			}
-			pkgs = append(pkgs,
-				newGemfileLockPackage(
-					candidate[0],
-					strings.Trim(candidate[1], "()"),
-					reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
-				),
-			)
+			current = &gemfileLockEntry{
+				name:    candidate[0],
+				version: strings.Trim(candidate[1], "()"),
+				source:  currentSource,
+			}
+			entries = append(entries, current)
+		case isSpecDependencyLine(line):
+			if current != nil {
+				current.dependencies = append(current.dependencies, sanitizedLine)
+			}
+		default:
+			currentSource.set(sanitizedLine)
 		}
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, nil, err
 	}
-	return pkgs, nil, nil
+
+	location := reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)
+
+	var pkgs []pkg.Package
+	names := internal.NewStringSet()
+	for _, entry := range entries {
+		names.Add(entry.name)
+		pkgs = append(pkgs, newGemfileLockPackage(entry.name, entry.version, entry.metadata(direct.Contains(entry.name)), location))
+	}
+
+	// bundler is not listed within the specs, but is the version of bundler that last resolved the lock
+	if bundledWith != "" && !names.Contains("bundler") {
+		pkgs = append(pkgs, newGemfileLockPackage("bundler", bundledWith, pkg.GemfileLockMetadata{}, location))
+	}
+
+	root := findGemspecRoot(resolver, reader.Location, pkgs)
+
+	return pkgs, gemfileLockRelationships(pkgs, root), nil
+}
+
+// set records an attribute of a source block (e.g. "remote: https://rubygems.org/").
+func (s *gemfileLockSource) set(line string) {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return
+	}
+	value = strings.TrimSpace(value)
+	switch key {
+	case "remote":
+		// older locks may list several remotes for a single GEM block, the first of which is the primary one
+		if s.remote == "" {
+			s.remote = value
+		}
+	case "revision":
+		s.revision = value
+	case "branch":
+		s.branch = value
+	case "tag":
+		s.tag = value
+	case "ref":
+		s.ref = value
+	}
+}
+
+func (e gemfileLockEntry) metadata(direct bool) pkg.GemfileLockMetadata {
+	return pkg.GemfileLockMetadata{
+		Source:       e.source.kind,
+		Remote:       e.source.remote,
+		Revision:     e.source.revision,
+		Branch:       e.source.branch,
+		Tag:          e.source.tag,
+		Ref:          e.source.ref,
+		Direct:       direct,
+		Dependencies: e.dependencies,
+	}
+}
+
+// findGemspecRoot returns the gem that is developed within the directory of the Gemfile.lock (i.e. the gem locked from
+// the "." PATH source), provided the gemspec for it can be found next to the lock. The gemspec is added as supporting
+// evidence for the package.
+func findGemspecRoot(resolver source.FileResolver, lockLocation source.Location, pkgs []pkg.Package) *pkg.Package {
+	if resolver == nil {
+		return nil
+	}
+	dir := path.Dir(lockLocation.RealPath)
+	for i, p := range pkgs {
+		m, ok := p.Metadata.(pkg.GemfileLockMetadata)
+		if !ok || m.Source != pathSource || path.Clean(m.Remote) != "." {
+			continue
+		}
+		gemspec := resolver.RelativeFileByPath(lockLocation, path.Join(dir, p.Name+".gemspec"))
+		if gemspec == nil {
+			continue
+		}
+		pkgs[i].Locations.Add(gemspec.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+		pkgs[i].SetID()
+		return &pkgs[i]
+	}
+	return nil
 }
 
+// gemfileLockRelationships relates each gem to the gems it depends on, and (when known) the root gem to the direct
+// dependencies of the Gemfile. Platform specific variants of a gem are all related, since the lock does not state
+// which platform is in use.
+func gemfileLockRelationships(pkgs []pkg.Package, root *pkg.Package) []artifact.Relationship {
+	byName := make(map[string][]pkg.Package)
+	for _, p := range pkgs {
+		byName[p.Name] = append(byName[p.Name], p)
+	}
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	relate := func(dep, p pkg.Package) {
+		if dep.ID() == p.ID() {
+			return
+		}
+		key := string(dep.ID()) + string(p.ID())
+		if seen.Contains(key) {
+			return
+		}
+		seen.Add(key)
+		relationships = append(relationships, artifact.Relationship{
+			From: dep,
+			To:   p,
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.GemfileLockMetadata)
+		if !ok {
+			continue
+		}
+		for _, dependency := range m.Dependencies {
+			// e.g. "actionpack (= 4.1.1)"
+			name := strings.Fields(dependency)[0]
+			for _, dep := range byName[name] {
+				relate(dep, p)
+			}
+		}
+	}
+
+	if root != nil {
+		for _, p := range pkgs {
+			if m, ok := p.Metadata.(pkg.GemfileLockMetadata); ok && m.Direct {
+				relate(p, *root)
+			}
+		}
+	}
+
+	return relationships
+}
+
+// isDependencyLine returns true for the lines listing a locked gem within a source block (indented by four spaces).
 func isDependencyLine(line string) bool {
 	if len(line) < 5 {
 		return false
 	}
 	return strings.Count(line[:5], " ") == 4
 }
+
+// isSpecDependencyLine returns true for the lines listing the dependencies of a locked gem (indented by six spaces).
+func isSpecDependencyLine(line string) bool {
+	if len(line) < 7 {
+		return false
+	}
+	return strings.Count(line[:7], " ") == 6
+}
@@ -3,6 +3,7 @@ package ruby
 import (
 	"testing"
 
+	"github.com/nextlinux/sbom/sbom/artifact"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
 	"github.com/nextlinux/sbom/sbom/source"
@@ -11,60 +12,243 @@ import (
 func TestParseGemfileLockEntries(t *testing.T) {
 	fixture := "test-fixtures/Gemfile.lock"
 	locations := source.NewLocationSet(source.NewLocation(fixture))
+	rubygems := func(direct bool, dependencies ...string) pkg.GemfileLockMetadata {
+		return pkg.GemfileLockMetadata{Source: "gem", Remote: "https://rubygems.org/", Direct: direct, Dependencies: dependencies}
+	}
 	var expectedPkgs = []pkg.Package{
-		{Name: "actionmailer", Version: "4.1.1", PURL: "pkg:gem/actionmailer@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "actionpack", Version: "4.1.1", PURL: "pkg:gem/actionpack@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "actionview", Version: "4.1.1", PURL: "pkg:gem/actionview@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "activemodel", Version: "4.1.1", PURL: "pkg:gem/activemodel@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "activerecord", Version: "4.1.1", PURL: "pkg:gem/activerecord@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "activesupport", Version: "4.1.1", PURL: "pkg:gem/activesupport@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "arel", Version: "5.0.1.20140414130214", PURL: "pkg:gem/arel@5.0.1.20140414130214", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "bootstrap-sass", Version: "3.1.1.1", PURL: "pkg:gem/bootstrap-sass@3.1.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "builder", Version: "3.2.2", PURL: "pkg:gem/builder@3.2.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "coffee-rails", Version: "4.0.1", PURL: "pkg:gem/coffee-rails@4.0.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "coffee-script", Version: "2.2.0", PURL: "pkg:gem/coffee-script@2.2.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "coffee-script-source", Version: "1.7.0", PURL: "pkg:gem/coffee-script-source@1.7.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "erubis", Version: "2.7.0", PURL: "pkg:gem/erubis@2.7.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "execjs", Version: "2.0.2", PURL: "pkg:gem/execjs@2.0.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "google-cloud-errors", Version: "1.3.0", PURL: "pkg:gem/google-cloud-errors@1.3.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "hike", Version: "1.2.3", PURL: "pkg:gem/hike@1.2.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "i18n", Version: "0.6.9", PURL: "pkg:gem/i18n@0.6.9", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "jbuilder", Version: "2.0.7", PURL: "pkg:gem/jbuilder@2.0.7", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "jquery-rails", Version: "3.1.0", PURL: "pkg:gem/jquery-rails@3.1.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "json", Version: "1.8.1", PURL: "pkg:gem/json@1.8.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "kgio", Version: "2.9.2", PURL: "pkg:gem/kgio@2.9.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "libv8", Version: "3.16.14.3", PURL: "pkg:gem/libv8@3.16.14.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "mail", Version: "2.5.4", PURL: "pkg:gem/mail@2.5.4", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "mime-types", Version: "1.25.1", PURL: "pkg:gem/mime-types@1.25.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "minitest", Version: "5.3.4", PURL: "pkg:gem/minitest@5.3.4", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "multi_json", Version: "1.10.1", PURL: "pkg:gem/multi_json@1.10.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "mysql2", Version: "0.3.16", PURL: "pkg:gem/mysql2@0.3.16", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "polyglot", Version: "0.3.4", PURL: "pkg:gem/polyglot@0.3.4", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "rack", Version: "1.5.2", PURL: "pkg:gem/rack@1.5.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "rack-test", Version: "0.6.2", PURL: "pkg:gem/rack-test@0.6.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "rails", Version: "4.1.1", PURL: "pkg:gem/rails@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "railties", Version: "4.1.1", PURL: "pkg:gem/railties@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "raindrops", Version: "0.13.0", PURL: "pkg:gem/raindrops@0.13.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "rake", Version: "10.3.2", PURL: "pkg:gem/rake@10.3.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "rdoc", Version: "4.1.1", PURL: "pkg:gem/rdoc@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "ref", Version: "1.0.5", PURL: "pkg:gem/ref@1.0.5", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "sass", Version: "3.2.19", PURL: "pkg:gem/sass@3.2.19", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "sass-rails", Version: "4.0.3", PURL: "pkg:gem/sass-rails@4.0.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "sdoc", Version: "0.4.0", PURL: "pkg:gem/sdoc@0.4.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "spring", Version: "1.1.3", PURL: "pkg:gem/spring@1.1.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "sprockets", Version: "2.11.0", PURL: "pkg:gem/sprockets@2.11.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "sprockets-rails", Version: "2.1.3", PURL: "pkg:gem/sprockets-rails@2.1.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "sqlite3", Version: "1.3.9", PURL: "pkg:gem/sqlite3@1.3.9", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "therubyracer", Version: "0.12.1", PURL: "pkg:gem/therubyracer@0.12.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "thor", Version: "0.19.1", PURL: "pkg:gem/thor@0.19.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "thread_safe", Version: "0.3.3", PURL: "pkg:gem/thread_safe@0.3.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "tilt", Version: "1.4.1", PURL: "pkg:gem/tilt@1.4.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "treetop", Version: "1.4.15", PURL: "pkg:gem/treetop@1.4.15", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "turbolinks", Version: "3.0.0", PURL: "pkg:gem/turbolinks@3.0.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "tzinfo", Version: "1.2.0", PURL: "pkg:gem/tzinfo@1.2.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "uglifier", Version: "2.5.0", PURL: "pkg:gem/uglifier@2.5.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
-		{Name: "unicorn", Version: "4.8.3", PURL: "pkg:gem/unicorn@4.8.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg},
+		{Name: "actionmailer", Version: "4.1.1", PURL: "pkg:gem/actionmailer@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "actionpack (= 4.1.1)", "actionview (= 4.1.1)", "mail (~> 2.5.4)")},
+		{Name: "actionpack", Version: "4.1.1", PURL: "pkg:gem/actionpack@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "actionview (= 4.1.1)", "activesupport (= 4.1.1)", "rack (~> 1.5.2)", "rack-test (~> 0.6.2)")},
+		{Name: "actionview", Version: "4.1.1", PURL: "pkg:gem/actionview@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "activesupport (= 4.1.1)", "builder (~> 3.1)", "erubis (~> 2.7.0)")},
+		{Name: "activemodel", Version: "4.1.1", PURL: "pkg:gem/activemodel@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "activesupport (= 4.1.1)", "builder (~> 3.1)")},
+		{Name: "activerecord", Version: "4.1.1", PURL: "pkg:gem/activerecord@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "activemodel (= 4.1.1)", "activesupport (= 4.1.1)", "arel (~> 5.0.0)")},
+		{Name: "activesupport", Version: "4.1.1", PURL: "pkg:gem/activesupport@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "i18n (~> 0.6, >= 0.6.9)", "json (~> 1.7, >= 1.7.7)", "minitest (~> 5.1)", "thread_safe (~> 0.1)", "tzinfo (~> 1.1)")},
+		{Name: "arel", Version: "5.0.1.20140414130214", PURL: "pkg:gem/arel@5.0.1.20140414130214", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "bootstrap-sass", Version: "3.1.1.1", PURL: "pkg:gem/bootstrap-sass@3.1.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true, "sass (~> 3.2)")},
+		{Name: "builder", Version: "3.2.2", PURL: "pkg:gem/builder@3.2.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "coffee-rails", Version: "4.0.1", PURL: "pkg:gem/coffee-rails@4.0.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true, "coffee-script (>= 2.2.0)", "railties (>= 4.0.0, < 5.0)")},
+		{Name: "coffee-script", Version: "2.2.0", PURL: "pkg:gem/coffee-script@2.2.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "coffee-script-source", "execjs")},
+		{Name: "coffee-script-source", Version: "1.7.0", PURL: "pkg:gem/coffee-script-source@1.7.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "erubis", Version: "2.7.0", PURL: "pkg:gem/erubis@2.7.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "execjs", Version: "2.0.2", PURL: "pkg:gem/execjs@2.0.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "google-cloud-errors", Version: "1.3.0", PURL: "pkg:gem/google-cloud-errors@1.3.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: pkg.GemfileLockMetadata{Source: "path", Remote: "../google-cloud-errors", Direct: true}},
+		{Name: "hike", Version: "1.2.3", PURL: "pkg:gem/hike@1.2.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "i18n", Version: "0.6.9", PURL: "pkg:gem/i18n@0.6.9", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "jbuilder", Version: "2.0.7", PURL: "pkg:gem/jbuilder@2.0.7", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true, "activesupport (>= 3.0.0, < 5)", "multi_json (~> 1.2)")},
+		{Name: "jquery-rails", Version: "3.1.0", PURL: "pkg:gem/jquery-rails@3.1.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true, "railties (>= 3.0, < 5.0)", "thor (>= 0.14, < 2.0)")},
+		{Name: "json", Version: "1.8.1", PURL: "pkg:gem/json@1.8.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "kgio", Version: "2.9.2", PURL: "pkg:gem/kgio@2.9.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "libv8", Version: "3.16.14.3", PURL: "pkg:gem/libv8@3.16.14.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "mail", Version: "2.5.4", PURL: "pkg:gem/mail@2.5.4", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "mime-types (~> 1.16)", "treetop (~> 1.4.8)")},
+		{Name: "mime-types", Version: "1.25.1", PURL: "pkg:gem/mime-types@1.25.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "minitest", Version: "5.3.4", PURL: "pkg:gem/minitest@5.3.4", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "multi_json", Version: "1.10.1", PURL: "pkg:gem/multi_json@1.10.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "mysql2", Version: "0.3.16", PURL: "pkg:gem/mysql2@0.3.16", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true)},
+		{Name: "polyglot", Version: "0.3.4", PURL: "pkg:gem/polyglot@0.3.4", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "rack", Version: "1.5.2", PURL: "pkg:gem/rack@1.5.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "rack-test", Version: "0.6.2", PURL: "pkg:gem/rack-test@0.6.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "rack (>= 1.0)")},
+		{Name: "rails", Version: "4.1.1", PURL: "pkg:gem/rails@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true, "actionmailer (= 4.1.1)", "actionpack (= 4.1.1)", "actionview (= 4.1.1)", "activemodel (= 4.1.1)", "activerecord (= 4.1.1)", "activesupport (= 4.1.1)", "bundler (>= 1.3.0, < 2.0)", "railties (= 4.1.1)", "sprockets-rails (~> 2.0)")},
+		{Name: "railties", Version: "4.1.1", PURL: "pkg:gem/railties@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "actionpack (= 4.1.1)", "activesupport (= 4.1.1)", "rake (>= 0.8.7)", "thor (>= 0.18.1, < 2.0)")},
+		{Name: "raindrops", Version: "0.13.0", PURL: "pkg:gem/raindrops@0.13.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "rake", Version: "10.3.2", PURL: "pkg:gem/rake@10.3.2", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "rdoc", Version: "4.1.1", PURL: "pkg:gem/rdoc@4.1.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "json (~> 1.4)")},
+		{Name: "ref", Version: "1.0.5", PURL: "pkg:gem/ref@1.0.5", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "sass", Version: "3.2.19", PURL: "pkg:gem/sass@3.2.19", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "sass-rails", Version: "4.0.3", PURL: "pkg:gem/sass-rails@4.0.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true, "railties (>= 4.0.0, < 5.0)", "sass (~> 3.2.0)", "sprockets (~> 2.8, <= 2.11.0)", "sprockets-rails (~> 2.0)")},
+		{Name: "sdoc", Version: "0.4.0", PURL: "pkg:gem/sdoc@0.4.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true, "json (~> 1.8)", "rdoc (~> 4.0, < 5.0)")},
+		{Name: "spring", Version: "1.1.3", PURL: "pkg:gem/spring@1.1.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true)},
+		{Name: "sprockets", Version: "2.11.0", PURL: "pkg:gem/sprockets@2.11.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "hike (~> 1.2)", "multi_json (~> 1.0)", "rack (~> 1.0)", "tilt (~> 1.1, != 1.3.0)")},
+		{Name: "sprockets-rails", Version: "2.1.3", PURL: "pkg:gem/sprockets-rails@2.1.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "actionpack (>= 3.0)", "activesupport (>= 3.0)", "sprockets (~> 2.8)")},
+		{Name: "sqlite3", Version: "1.3.9", PURL: "pkg:gem/sqlite3@1.3.9", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true)},
+		{Name: "therubyracer", Version: "0.12.1", PURL: "pkg:gem/therubyracer@0.12.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true, "libv8 (~> 3.16.14.0)", "ref")},
+		{Name: "thor", Version: "0.19.1", PURL: "pkg:gem/thor@0.19.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "thread_safe", Version: "0.3.3", PURL: "pkg:gem/thread_safe@0.3.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "tilt", Version: "1.4.1", PURL: "pkg:gem/tilt@1.4.1", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false)},
+		{Name: "treetop", Version: "1.4.15", PURL: "pkg:gem/treetop@1.4.15", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "polyglot", "polyglot (>= 0.3.1)")},
+		{Name: "turbolinks", Version: "3.0.0", PURL: "pkg:gem/turbolinks@3.0.0?vcs_url=git+https://github.com/rails/turbolinks.git%4080216ce9d89920bf073709405e3fce6d0a3ccd9a", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: pkg.GemfileLockMetadata{Source: "git", Remote: "https://github.com/rails/turbolinks.git", Revision: "80216ce9d89920bf073709405e3fce6d0a3ccd9a", Direct: true, Dependencies: []string{"coffee-rails"}}},
+		{Name: "tzinfo", Version: "1.2.0", PURL: "pkg:gem/tzinfo@1.2.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(false, "thread_safe (~> 0.1)")},
+		{Name: "uglifier", Version: "2.5.0", PURL: "pkg:gem/uglifier@2.5.0", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true, "execjs (>= 0.3.0)", "json (>= 1.8.0)")},
+		{Name: "unicorn", Version: "4.8.3", PURL: "pkg:gem/unicorn@4.8.3", Locations: locations, Language: pkg.Ruby, Type: pkg.GemPkg, MetadataType: pkg.GemfileLockMetadataType, Metadata: rubygems(true, "kgio (~> 2.6)", "rack", "raindrops (~> 0.7)")},
+	}
+
+	byName := make(map[string]pkg.Package)
+	for _, p := range expectedPkgs {
+		byName[p.Name] = p
+	}
+
+	// each pair is a dependency followed by the gem that depends on it, in the order the gems are locked (note that
+	// bundler is not within the lock, so the rails dependency on it is not related)
+	var expectedRelationships []artifact.Relationship
+	for _, pair := range [][2]string{
+		{"coffee-rails", "turbolinks"},
+		{"actionpack", "actionmailer"},
+		{"actionview", "actionmailer"},
+		{"mail", "actionmailer"},
+		{"actionview", "actionpack"},
+		{"activesupport", "actionpack"},
+		{"rack", "actionpack"},
+		{"rack-test", "actionpack"},
+		{"activesupport", "actionview"},
+		{"builder", "actionview"},
+		{"erubis", "actionview"},
+		{"activesupport", "activemodel"},
+		{"builder", "activemodel"},
+		{"activemodel", "activerecord"},
+		{"activesupport", "activerecord"},
+		{"arel", "activerecord"},
+		{"i18n", "activesupport"},
+		{"json", "activesupport"},
+		{"minitest", "activesupport"},
+		{"thread_safe", "activesupport"},
+		{"tzinfo", "activesupport"},
+		{"sass", "bootstrap-sass"},
+		{"coffee-script", "coffee-rails"},
+		{"railties", "coffee-rails"},
+		{"coffee-script-source", "coffee-script"},
+		{"execjs", "coffee-script"},
+		{"activesupport", "jbuilder"},
+		{"multi_json", "jbuilder"},
+		{"railties", "jquery-rails"},
+		{"thor", "jquery-rails"},
+		{"mime-types", "mail"},
+		{"treetop", "mail"},
+		{"rack", "rack-test"},
+		{"actionmailer", "rails"},
+		{"actionpack", "rails"},
+		{"actionview", "rails"},
+		{"activemodel", "rails"},
+		{"activerecord", "rails"},
+		{"activesupport", "rails"},
+		{"railties", "rails"},
+		{"sprockets-rails", "rails"},
+		{"actionpack", "railties"},
+		{"activesupport", "railties"},
+		{"rake", "railties"},
+		{"thor", "railties"},
+		{"json", "rdoc"},
+		{"railties", "sass-rails"},
+		{"sass", "sass-rails"},
+		{"sprockets", "sass-rails"},
+		{"sprockets-rails", "sass-rails"},
+		{"json", "sdoc"},
+		{"rdoc", "sdoc"},
+		{"hike", "sprockets"},
+		{"multi_json", "sprockets"},
+		{"rack", "sprockets"},
+		{"tilt", "sprockets"},
+		{"actionpack", "sprockets-rails"},
+		{"activesupport", "sprockets-rails"},
+		{"sprockets", "sprockets-rails"},
+		{"libv8", "therubyracer"},
+		{"ref", "therubyracer"},
+		{"polyglot", "treetop"},
+		{"thread_safe", "tzinfo"},
+		{"execjs", "uglifier"},
+		{"json", "uglifier"},
+		{"kgio", "unicorn"},
+		{"rack", "unicorn"},
+		{"raindrops", "unicorn"},
+	} {
+		expectedRelationships = append(expectedRelationships, artifact.Relationship{
+			From: byName[pair[0]],
+			To:   byName[pair[1]],
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseGemFileLockEntries, expectedPkgs, expectedRelationships)
+}
+
+func TestParseGemfileLockEntries_GemspecRoot(t *testing.T) {
+	lockLocation := source.NewLocation("Gemfile.lock")
+
+	// the gem developed alongside the lock is the root that the direct dependencies are related to
+	mygem := pkg.Package{
+		Name:         "mygem",
+		Version:      "0.1.0",
+		FoundBy:      "ruby-gemfile-cataloger",
+		PURL:         "pkg:gem/mygem@0.1.0",
+		Locations:    source.NewLocationSet(lockLocation, source.NewLocation("mygem.gemspec")),
+		Language:     pkg.Ruby,
+		Type:         pkg.GemPkg,
+		MetadataType: pkg.GemfileLockMetadataType,
+		Metadata: pkg.GemfileLockMetadata{
+			Source:       "path",
+			Remote:       ".",
+			Direct:       true,
+			Dependencies: []string{"rack (~> 3.0)"},
+		},
+	}
+	sinatra := pkg.Package{
+		Name:         "sinatra",
+		Version:      "4.0.0",
+		FoundBy:      "ruby-gemfile-cataloger",
+		PURL:         "pkg:gem/sinatra@4.0.0?vcs_url=git+https://github.com/sinatra/sinatra.git%407d5d6e3c5b8b4a2e0d5b1f2c3a4e5d6f7a8b9c0d",
+		Locations:    source.NewLocationSet(lockLocation),
+		Language:     pkg.Ruby,
+		Type:         pkg.GemPkg,
+		MetadataType: pkg.GemfileLockMetadataType,
+		Metadata: pkg.GemfileLockMetadata{
+			Source:       "git",
+			Remote:       "https://github.com/sinatra/sinatra.git",
+			Revision:     "7d5d6e3c5b8b4a2e0d5b1f2c3a4e5d6f7a8b9c0d",
+			Branch:       "main",
+			Direct:       true,
+			Dependencies: []string{"rack (>= 3.0.0, < 4)", "tilt (~> 2.0)"},
+		},
+	}
+	gem := func(name, version string, direct bool, dependencies ...string) pkg.Package {
+		return pkg.Package{
+			Name:         name,
+			Version:      version,
+			FoundBy:      "ruby-gemfile-cataloger",
+			PURL:         "pkg:gem/" + name + "@" + version,
+			Locations:    source.NewLocationSet(lockLocation),
+			Language:     pkg.Ruby,
+			Type:         pkg.GemPkg,
+			MetadataType: pkg.GemfileLockMetadataType,
+			Metadata: pkg.GemfileLockMetadata{
+				Source:       "gem",
+				Remote:       "https://rubygems.org/",
+				Direct:       direct,
+				Dependencies: dependencies,
+			},
+		}
+	}
+	nokogiriDarwin := gem("nokogiri", "1.15.4-arm64-darwin", true, "racc (~> 1.4)")
+	nokogiriLinux := gem("nokogiri", "1.15.4-x86_64-linux", true, "racc (~> 1.4)")
+	racc := gem("racc", "1.7.1", false)
+	rack := gem("rack", "3.0.8", false)
+	rake := gem("rake", "13.0.6", true)
+	tilt := gem("tilt", "2.2.0", false)
+	// taken from the BUNDLED WITH section
+	bundler := pkg.Package{
+		Name:         "bundler",
+		Version:      "2.4.19",
+		FoundBy:      "ruby-gemfile-cataloger",
+		PURL:         "pkg:gem/bundler@2.4.19",
+		Locations:    source.NewLocationSet(lockLocation),
+		Language:     pkg.Ruby,
+		Type:         pkg.GemPkg,
+		MetadataType: pkg.GemfileLockMetadataType,
+		Metadata:     pkg.GemfileLockMetadata{},
+	}
+
+	expectedPkgs := []pkg.Package{mygem, sinatra, nokogiriDarwin, nokogiriLinux, racc, rack, rake, tilt, bundler}
+
+	expectedRelationships := []artifact.Relationship{
+		{From: rack, To: mygem, Type: artifact.DependencyOfRelationship},
+		{From: rack, To: sinatra, Type: artifact.DependencyOfRelationship},
+		{From: tilt, To: sinatra, Type: artifact.DependencyOfRelationship},
+		{From: racc, To: nokogiriDarwin, Type: artifact.DependencyOfRelationship},
+		{From: racc, To: nokogiriLinux, Type: artifact.DependencyOfRelationship},
+		{From: sinatra, To: mygem, Type: artifact.DependencyOfRelationship},
+		{From: nokogiriDarwin, To: mygem, Type: artifact.DependencyOfRelationship},
+		{From: nokogiriLinux, To: mygem, Type: artifact.DependencyOfRelationship},
+		{From: rake, To: mygem, Type: artifact.DependencyOfRelationship},
 	}
 
-	pkgtest.TestFileParser(t, fixture, parseGemFileLockEntries, expectedPkgs, nil)
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/gemspec-root").
+		Expects(expectedPkgs, expectedRelationships).
+		TestCataloger(t, NewGemFileLockCataloger())
 }
@@ -0,0 +1,160 @@
+package rust
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// cargoDependencyTables are the tables of a Cargo.toml that may declare path dependencies
+var cargoDependencyTables = []string{"dependencies", "dev-dependencies", "build-dependencies", "workspace.dependencies"}
+
+type cargoManifest struct {
+	Package struct {
+		Name string `toml:"name"`
+	} `toml:"package"`
+	Workspace struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+	} `toml:"workspace"`
+}
+
+// cargoWorkspace describes the crates of a workspace that are built from local sources, as declared by the Cargo.toml
+// files next to (and below) a Cargo.lock. All paths are relative to the directory of the Cargo.lock.
+type cargoWorkspace struct {
+	// members are the directories of the workspace members (including the root package) keyed by crate name
+	members map[string]string
+	// manifests are the locations of the Cargo.toml of each workspace member keyed by crate name
+	manifests map[string]source.Location
+	// paths are the directories of path dependencies keyed by crate name
+	paths map[string]string
+}
+
+// localPath returns the directory of a crate built from local sources, if it is known.
+func (w *cargoWorkspace) localPath(name string) string {
+	if w == nil {
+		return ""
+	}
+	if dir, ok := w.members[name]; ok {
+		return dir
+	}
+	return w.paths[name]
+}
+
+// readCargoWorkspace reads the root Cargo.toml next to the given Cargo.lock, along with the Cargo.toml of each of the
+// workspace members, returning nil when there is no root Cargo.toml.
+func readCargoWorkspace(resolver source.FileResolver, lockLocation source.Location) *cargoWorkspace {
+	if resolver == nil {
+		return nil
+	}
+
+	dir := path.Dir(lockLocation.RealPath)
+	rootLocation := resolver.RelativeFileByPath(lockLocation, path.Join(dir, "Cargo.toml"))
+	if rootLocation == nil {
+		return nil
+	}
+
+	root, tree, err := readCargoManifest(resolver, *rootLocation)
+	if err != nil {
+		log.WithFields("path", rootLocation.RealPath, "error", err).Debug("unable to read root Cargo.toml")
+		return nil
+	}
+
+	w := &cargoWorkspace{
+		members:   make(map[string]string),
+		manifests: make(map[string]source.Location),
+		paths:     make(map[string]string),
+	}
+	w.add(root, tree, ".", *rootLocation)
+
+	excluded := internal.NewStringSet()
+	for _, exclude := range root.Workspace.Exclude {
+		excluded.Add(path.Clean(exclude))
+	}
+
+	for _, member := range root.Workspace.Members {
+		// members may be globs (e.g. "crates/*"), and the directory of the lock may be relative to the root of the source
+		locations, err := resolver.FilesByGlob(path.Join("/", dir, member, "Cargo.toml"))
+		if err != nil {
+			log.WithFields("member", member, "error", err).Debug("unable to find cargo workspace member")
+			continue
+		}
+		for _, location := range locations {
+			memberDir := path.Dir(location.RealPath)
+			if memberDir == dir {
+				continue
+			}
+			relDir := strings.TrimPrefix(strings.TrimPrefix(memberDir, dir), "/")
+			if excluded.Contains(relDir) {
+				continue
+			}
+			manifest, tree, err := readCargoManifest(resolver, location)
+			if err != nil {
+				log.WithFields("path", location.RealPath, "error", err).Debug("unable to read cargo workspace member")
+				continue
+			}
+			w.add(manifest, tree, relDir, location)
+		}
+	}
+
+	return w
+}
+
+// add records the crate of a workspace member within the given directory, along with its path dependencies.
+func (w *cargoWorkspace) add(manifest *cargoManifest, tree *toml.Tree, dir string, location source.Location) {
+	if manifest.Package.Name != "" {
+		w.members[manifest.Package.Name] = dir
+		w.manifests[manifest.Package.Name] = location
+	}
+
+	for _, table := range cargoDependencyTables {
+		dependencies, ok := tree.Get(table).(*toml.Tree)
+		if !ok {
+			continue
+		}
+		for _, key := range dependencies.Keys() {
+			dependency, ok := dependencies.Get(key).(*toml.Tree)
+			if !ok {
+				continue
+			}
+			depPath, ok := dependency.Get("path").(string)
+			if !ok {
+				continue
+			}
+			// dependencies may be renamed, e.g. foo = { package = "bar", path = "../bar" }
+			name := key
+			if pkgName, ok := dependency.Get("package").(string); ok {
+				name = pkgName
+			}
+			if _, ok := w.paths[name]; !ok {
+				w.paths[name] = path.Clean(path.Join(dir, depPath))
+			}
+		}
+	}
+}
+
+func readCargoManifest(resolver source.FileResolver, location source.Location) (*cargoManifest, *toml.Tree, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	tree, err := toml.LoadReader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load Cargo.toml for parsing: %w", err)
+	}
+
+	var manifest cargoManifest
+	if err := tree.Unmarshal(&manifest); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse Cargo.toml: %w", err)
+	}
+
+	return &manifest, tree, nil
+}
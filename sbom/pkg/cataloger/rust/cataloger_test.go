@@ -3,6 +3,7 @@ package rust
 import (
 	"testing"
 
+	"github.com/nextlinux/sbom/sbom/artifact"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
 	"github.com/nextlinux/sbom/sbom/source"
@@ -10,43 +11,47 @@ import (
 
 func TestNewAuditBinaryCataloger(t *testing.T) {
 
-	expectedPkgs := []pkg.Package{
-		{
-			Name:         "auditable",
-			Version:      "0.1.0",
-			PURL:         "pkg:cargo/auditable@0.1.0",
-			FoundBy:      "cargo-auditable-binary-cataloger",
-			Locations:    source.NewLocationSet(source.NewVirtualLocation("/hello-auditable", "/hello-auditable")),
-			Language:     pkg.Rust,
-			Type:         pkg.RustPkg,
-			MetadataType: pkg.RustCargoPackageMetadataType,
-			Metadata: pkg.CargoPackageMetadata{
-				Name:    "auditable",
-				Version: "0.1.0",
-				Source:  "local",
-			},
+	auditable := pkg.Package{
+		Name:         "auditable",
+		Version:      "0.1.0",
+		FoundBy:      "cargo-auditable-binary-cataloger",
+		PURL:         "pkg:cargo/auditable@0.1.0",
+		Locations:    source.NewLocationSet(source.NewVirtualLocation("/hello-auditable", "/hello-auditable")),
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata: pkg.CargoPackageMetadata{
+			Name:    "auditable",
+			Version: "0.1.0",
+			Source:  "local",
 		},
-		{
-			Name:         "hello-auditable",
-			Version:      "0.1.0",
-			PURL:         "pkg:cargo/hello-auditable@0.1.0",
-			FoundBy:      "cargo-auditable-binary-cataloger",
-			Locations:    source.NewLocationSet(source.NewVirtualLocation("/hello-auditable", "/hello-auditable")),
-			Language:     pkg.Rust,
-			Type:         pkg.RustPkg,
-			MetadataType: pkg.RustCargoPackageMetadataType,
-			Metadata: pkg.CargoPackageMetadata{
-				Name:    "hello-auditable",
-				Version: "0.1.0",
-				Source:  "local",
-			},
+	}
+	helloAuditable := pkg.Package{
+		Name:         "hello-auditable",
+		Version:      "0.1.0",
+		FoundBy:      "cargo-auditable-binary-cataloger",
+		PURL:         "pkg:cargo/hello-auditable@0.1.0",
+		Locations:    source.NewLocationSet(source.NewVirtualLocation("/hello-auditable", "/hello-auditable")),
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata: pkg.CargoPackageMetadata{
+			Name:    "hello-auditable",
+			Version: "0.1.0",
+			Source:  "local",
 		},
 	}
 
+	expectedPkgs := []pkg.Package{auditable, helloAuditable}
+
+	expectedRelationships := []artifact.Relationship{
+		{From: auditable, To: helloAuditable, Type: artifact.DependencyOfRelationship},
+	}
+
 	pkgtest.NewCatalogTester().
 		WithImageResolver(t, "image-audit").
 		IgnoreLocationLayer(). // this fixture can be rebuilt, thus the layer ID will change
-		Expects(expectedPkgs, nil).
+		Expects(expectedPkgs, expectedRelationships).
 		TestCataloger(t, NewAuditBinaryCataloger())
 }
 
@@ -1,6 +1,8 @@
 package rust
 
 import (
+	"strings"
+
 	"github.com/microsoft/go-rustaudit"
 
 	"github.com/nextlinux/packageurl-go"
@@ -8,13 +10,26 @@ import (
 	"github.com/nextlinux/sbom/sbom/source"
 )
 
+// crates.io is the default registry, thus needs no qualifiers to be identified
+var cratesIoSources = map[string]bool{
+	"registry+https://github.com/rust-lang/crates.io-index": true,
+	"sparse+https://index.crates.io/":                       true,
+}
+
 // Pkg returns the standard `pkg.Package` representation of the package referenced within the Cargo.lock metadata.
-func newPackageFromCargoMetadata(m pkg.CargoPackageMetadata, locations ...source.Location) pkg.Package {
+// The local path is the directory of a crate without a source (a workspace member or path dependency) relative to the
+// Cargo.lock, when it is known.
+func newPackageFromCargoMetadata(m pkg.CargoPackageMetadata, localPath string, locations ...source.Location) pkg.Package {
+	qualifiers := sourceQualifiers(m.Source)
+	if m.Source == "" && localPath != "" {
+		qualifiers = packageurl.Qualifiers{{Key: pkg.PURLQualifierDownloadURL, Value: "file://" + localPath}}
+	}
+
 	p := pkg.Package{
 		Name:         m.Name,
 		Version:      m.Version,
 		Locations:    source.NewLocationSet(locations...),
-		PURL:         packageURL(m.Name, m.Version),
+		PURL:         packageURL(m.Name, m.Version, qualifiers),
 		Language:     pkg.Rust,
 		Type:         pkg.RustPkg,
 		MetadataType: pkg.RustCargoPackageMetadataType,
@@ -26,14 +41,16 @@ func newPackageFromCargoMetadata(m pkg.CargoPackageMetadata, locations ...source
 	return p
 }
 
-func newPackagesFromAudit(location source.Location, versionInfo rustaudit.VersionInfo) []pkg.Package {
-	var pkgs []pkg.Package
+// newPackagesFromAudit returns the runtime packages recorded within the audit data, keyed by their index within the
+// audit data (which the dependencies of each package refer to).
+func newPackagesFromAudit(location source.Location, versionInfo rustaudit.VersionInfo) map[uint]pkg.Package {
+	pkgs := make(map[uint]pkg.Package)
 
-	for _, dep := range versionInfo.Packages {
+	for i, dep := range versionInfo.Packages {
 		dep := dep
 		p := newPackageFromAudit(&dep, location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation))
 		if pkg.IsValid(&p) && dep.Kind == rustaudit.Runtime {
-			pkgs = append(pkgs, p)
+			pkgs[uint(i)] = p
 		}
 	}
 
@@ -44,7 +61,7 @@ func newPackageFromAudit(dep *rustaudit.Package, locations ...source.Location) p
 	p := pkg.Package{
 		Name:         dep.Name,
 		Version:      dep.Version,
-		PURL:         packageURL(dep.Name, dep.Version),
+		PURL:         packageURL(dep.Name, dep.Version, nil),
 		Language:     pkg.Rust,
 		Type:         pkg.RustPkg,
 		Locations:    source.NewLocationSet(locations...),
@@ -62,13 +79,41 @@ func newPackageFromAudit(dep *rustaudit.Package, locations ...source.Location) p
 }
 
 // packageURL returns the PURL for the specific rust package (see https://github.com/package-url/purl-spec)
-func packageURL(name, version string) string {
+func packageURL(name, version string, qualifiers packageurl.Qualifiers) string {
 	return packageurl.NewPackageURL(
 		packageurl.TypeCargo,
 		"",
 		name,
 		version,
-		nil,
+		qualifiers,
 		"",
 	).ToString()
 }
+
+// sourceQualifiers returns the PURL qualifiers that distinguish crates from alternate registries and git repositories
+// from crates published on crates.io, given the source of the crate within a Cargo.lock (e.g.
+// "git+https://github.com/org/repo?branch=main#<commit>").
+func sourceQualifiers(src string) packageurl.Qualifiers {
+	if src == "" || cratesIoSources[src] {
+		return nil
+	}
+
+	kind, url, found := strings.Cut(src, "+")
+	if !found {
+		return nil
+	}
+
+	switch kind {
+	case "registry", "sparse":
+		return packageurl.Qualifiers{{Key: pkg.PURLQualifierRepositoryURL, Value: url}}
+	case "git":
+		url, commit, _ := strings.Cut(url, "#")
+		// the query holds the requested branch, tag, or rev, which is superseded by the locked commit
+		url, _, _ = strings.Cut(url, "?")
+		if commit != "" {
+			url += "@" + commit
+		}
+		return packageurl.Qualifiers{{Key: pkg.PURLQualifierVCSURL, Value: "git+" + url}}
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ func Test_packageURL(t *testing.T) {
 	type args struct {
 		name    string
 		version string
+		source  string
 	}
 	tests := []struct {
 		name string
@@ -24,10 +25,46 @@ func Test_packageURL(t *testing.T) {
 			},
 			want: "pkg:cargo/name@v0.1.0",
 		},
+		{
+			name: "crates.io registry",
+			args: args{
+				name:    "name",
+				version: "v0.1.0",
+				source:  "registry+https://github.com/rust-lang/crates.io-index",
+			},
+			want: "pkg:cargo/name@v0.1.0",
+		},
+		{
+			name: "crates.io sparse registry",
+			args: args{
+				name:    "name",
+				version: "v0.1.0",
+				source:  "sparse+https://index.crates.io/",
+			},
+			want: "pkg:cargo/name@v0.1.0",
+		},
+		{
+			name: "alternate registry",
+			args: args{
+				name:    "name",
+				version: "v0.1.0",
+				source:  "sparse+https://registry.example.com/index/",
+			},
+			want: "pkg:cargo/name@v0.1.0?repository_url=https://registry.example.com/index/",
+		},
+		{
+			name: "git repository",
+			args: args{
+				name:    "name",
+				version: "v0.1.0",
+				source:  "git+https://github.com/test/test?tag=v0.1.0#aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			},
+			want: "pkg:cargo/name@v0.1.0?vcs_url=git+https://github.com/test/test%40aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.want, packageURL(tt.args.name, tt.args.version))
+			assert.Equal(t, tt.want, packageURL(tt.args.name, tt.args.version, sourceQualifiers(tt.args.source)))
 		})
 	}
 }
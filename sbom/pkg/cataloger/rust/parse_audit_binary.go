@@ -2,9 +2,11 @@ package rust
 
 import (
 	"errors"
+	"sort"
 
 	rustaudit "github.com/microsoft/go-rustaudit"
 
+	"github.com/nextlinux/sbom/internal"
 	"github.com/nextlinux/sbom/internal/log"
 	"github.com/nextlinux/sbom/sbom/artifact"
 	"github.com/nextlinux/sbom/sbom/pkg"
@@ -16,17 +18,49 @@ import (
 // Catalog identifies executables then attempts to read Rust dependency information from them
 func parseAuditBinary(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	var pkgs []pkg.Package
+	var relationships []artifact.Relationship
 
 	unionReader, err := unionreader.GetUnionReader(reader.ReadCloser)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	seen := internal.NewStringSet()
 	for _, versionInfo := range parseAuditBinaryEntry(unionReader, reader.RealPath) {
-		pkgs = append(pkgs, newPackagesFromAudit(reader.Location, versionInfo)...)
+		auditPkgs := newPackagesFromAudit(reader.Location, versionInfo)
+
+		var indexes []uint
+		for i := range auditPkgs {
+			indexes = append(indexes, i)
+		}
+		sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+		for _, i := range indexes {
+			p := auditPkgs[i]
+			pkgs = append(pkgs, p)
+
+			// dependencies refer to other packages by their index within the audit data, where build-time
+			// dependencies were not kept as packages
+			for _, d := range versionInfo.Packages[i].Dependencies {
+				dep, ok := auditPkgs[d]
+				if !ok {
+					continue
+				}
+				key := string(dep.ID()) + string(p.ID())
+				if seen.Contains(key) {
+					continue
+				}
+				seen.Add(key)
+				relationships = append(relationships, artifact.Relationship{
+					From: dep,
+					To:   p,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
 	}
 
-	return pkgs, nil, nil
+	return pkgs, relationships, nil
 }
 
 // scanFile scans file to try to report the Rust crate dependencies
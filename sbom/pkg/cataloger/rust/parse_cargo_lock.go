@@ -2,9 +2,11 @@ package rust
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pelletier/go-toml"
 
+	"github.com/nextlinux/sbom/internal"
 	"github.com/nextlinux/sbom/sbom/artifact"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
@@ -17,8 +19,10 @@ type cargoLockFile struct {
 	Packages []pkg.CargoPackageMetadata `toml:"package"`
 }
 
-// parseCargoLock is a parser function for Cargo.lock contents, returning all rust cargo crates discovered.
-func parseCargoLock(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+// parseCargoLock is a parser function for Cargo.lock contents, returning all rust cargo crates discovered along with
+// the relationships between them. Crates that are members of the workspace are identified from the Cargo.toml files
+// next to the Cargo.lock, when they are available.
+func parseCargoLock(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	tree, err := toml.LoadReader(reader)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to load Cargo.lock for parsing: %w", err)
@@ -30,20 +34,101 @@ func parseCargoLock(_ source.FileResolver, _ *generic.Environment, reader source
 		return nil, nil, fmt.Errorf("unable to parse Cargo.lock: %w", err)
 	}
 
+	workspace := readCargoWorkspace(resolver, reader.Location)
+
 	var pkgs []pkg.Package
 
 	for _, p := range m.Packages {
 		if p.Dependencies == nil {
 			p.Dependencies = make([]string, 0)
 		}
+
+		locations := []source.Location{reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)}
+		// workspace members are built from local sources, thus have no source within the lock
+		if workspace != nil && p.Source == "" {
+			if manifest, ok := workspace.manifests[p.Name]; ok {
+				p.WorkspaceMember = true
+				locations = append(locations, manifest.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+			}
+		}
+
 		pkgs = append(
 			pkgs,
 			newPackageFromCargoMetadata(
 				p,
-				reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+				workspace.localPath(p.Name),
+				locations...,
 			),
 		)
 	}
 
-	return pkgs, nil, nil
+	return pkgs, cargoLockRelationships(pkgs), nil
+}
+
+// cargoLockRelationships creates relationships for the dependencies of each crate, which are referenced by name within
+// the lock, followed by the version and source when several crates share the same name (e.g. "rand 0.8.5" or
+// "rand 0.8.5 (registry+https://github.com/rust-lang/crates.io-index)").
+func cargoLockRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	byName := make(map[string][]pkg.Package)
+	for _, p := range pkgs {
+		byName[p.Name] = append(byName[p.Name], p)
+	}
+
+	var relationships []artifact.Relationship
+	seen := internal.NewStringSet()
+	for _, p := range pkgs {
+		m, ok := p.Metadata.(pkg.CargoPackageMetadata)
+		if !ok {
+			continue
+		}
+		for _, dependency := range m.Dependencies {
+			dep := findCargoDependency(byName, dependency)
+			if dep == nil || dep.ID() == p.ID() {
+				continue
+			}
+			key := string(dep.ID()) + string(p.ID())
+			if seen.Contains(key) {
+				continue
+			}
+			seen.Add(key)
+			relationships = append(relationships, artifact.Relationship{
+				From: *dep,
+				To:   p,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+
+	return relationships
+}
+
+func findCargoDependency(byName map[string][]pkg.Package, dependency string) *pkg.Package {
+	fields := strings.Fields(dependency)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	var version, src string
+	if len(fields) > 1 {
+		version = fields[1]
+	}
+	if len(fields) > 2 {
+		src = strings.TrimSuffix(strings.TrimPrefix(fields[2], "("), ")")
+	}
+
+	for _, candidate := range byName[fields[0]] {
+		m, ok := candidate.Metadata.(pkg.CargoPackageMetadata)
+		if !ok {
+			continue
+		}
+		if version != "" && m.Version != version {
+			continue
+		}
+		if src != "" && m.Source != src {
+			continue
+		}
+		candidate := candidate
+		return &candidate
+	}
+	return nil
 }
@@ -195,9 +195,180 @@ func TestParseCargoLock(t *testing.T) {
 		},
 	}
 
-	// TODO: no relationships are under test yet
-	var expectedRelationships []artifact.Relationship
+	ansiTerm := expectedPkgs[0]
+	matches := expectedPkgs[1]
+	memchr := expectedPkgs[2]
+	nom := expectedPkgs[4]
+	unicodeBidi := expectedPkgs[5]
+	versionCheck := expectedPkgs[6]
+	winapi := expectedPkgs[7]
+	winapiI686 := expectedPkgs[8]
+	winapiX8664 := expectedPkgs[9]
+
+	expectedRelationships := []artifact.Relationship{
+		{From: winapi, To: ansiTerm, Type: artifact.DependencyOfRelationship},
+		{From: memchr, To: nom, Type: artifact.DependencyOfRelationship},
+		{From: versionCheck, To: nom, Type: artifact.DependencyOfRelationship},
+		{From: matches, To: unicodeBidi, Type: artifact.DependencyOfRelationship},
+		{From: winapiI686, To: winapi, Type: artifact.DependencyOfRelationship},
+		{From: winapiX8664, To: winapi, Type: artifact.DependencyOfRelationship},
+	}
 
 	pkgtest.TestFileParser(t, fixture, parseCargoLock, expectedPkgs, expectedRelationships)
 
 }
+
+func TestParseCargoLock_Workspace(t *testing.T) {
+	lockLocation := source.NewLocation("Cargo.lock")
+
+	// workspace members and path dependencies have no source within the lock
+	app := pkg.Package{
+		Name:         "app",
+		Version:      "0.1.0",
+		FoundBy:      "rust-cargo-lock-cataloger",
+		PURL:         "pkg:cargo/app@0.1.0?download_url=file://.",
+		Locations:    source.NewLocationSet(lockLocation, source.NewLocation("Cargo.toml")),
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata: pkg.CargoPackageMetadata{
+			Name:            "app",
+			Version:         "0.1.0",
+			Dependencies:    []string{"core-lib", "helper", "internal-util", "my-fork", "serde"},
+			WorkspaceMember: true,
+		},
+	}
+	coreLib := pkg.Package{
+		Name:         "core-lib",
+		Version:      "0.1.0",
+		FoundBy:      "rust-cargo-lock-cataloger",
+		PURL:         "pkg:cargo/core-lib@0.1.0?download_url=file://crates/core-lib",
+		Locations:    source.NewLocationSet(lockLocation, source.NewLocation("crates/core-lib/Cargo.toml")),
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata: pkg.CargoPackageMetadata{
+			Name:            "core-lib",
+			Version:         "0.1.0",
+			Dependencies:    []string{"serde"},
+			WorkspaceMember: true,
+		},
+	}
+	helper := pkg.Package{
+		Name:         "helper",
+		Version:      "0.2.0",
+		FoundBy:      "rust-cargo-lock-cataloger",
+		PURL:         "pkg:cargo/helper@0.2.0?download_url=file://vendor/helper",
+		Locations:    source.NewLocationSet(lockLocation),
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata: pkg.CargoPackageMetadata{
+			Name:         "helper",
+			Version:      "0.2.0",
+			Dependencies: []string{},
+		},
+	}
+	internalUtil := pkg.Package{
+		Name:         "internal-util",
+		Version:      "1.2.0",
+		FoundBy:      "rust-cargo-lock-cataloger",
+		PURL:         "pkg:cargo/internal-util@1.2.0?repository_url=https://registry.example.com/git/index",
+		Locations:    source.NewLocationSet(lockLocation),
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata: pkg.CargoPackageMetadata{
+			Name:         "internal-util",
+			Version:      "1.2.0",
+			Source:       "registry+https://registry.example.com/git/index",
+			Checksum:     "5f0c0c8a8d3b35d6f9c1e0d4a2b9c7e8f1a3d5b7c9e0f2a4b6c8d0e2f4a6b8c0",
+			Dependencies: []string{"itoa 0.4.8"},
+		},
+	}
+	itoaOld := pkg.Package{
+		Name:         "itoa",
+		Version:      "0.4.8",
+		FoundBy:      "rust-cargo-lock-cataloger",
+		PURL:         "pkg:cargo/itoa@0.4.8",
+		Locations:    source.NewLocationSet(lockLocation),
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata: pkg.CargoPackageMetadata{
+			Name:         "itoa",
+			Version:      "0.4.8",
+			Source:       "registry+https://github.com/rust-lang/crates.io-index",
+			Checksum:     "b71991ff56294aa922b450139ee08b3bfc70982c6b2c7562771375cf73542dd4",
+			Dependencies: []string{},
+		},
+	}
+	itoa := pkg.Package{
+		Name:         "itoa",
+		Version:      "1.0.9",
+		FoundBy:      "rust-cargo-lock-cataloger",
+		PURL:         "pkg:cargo/itoa@1.0.9",
+		Locations:    source.NewLocationSet(lockLocation),
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata: pkg.CargoPackageMetadata{
+			Name:         "itoa",
+			Version:      "1.0.9",
+			Source:       "registry+https://github.com/rust-lang/crates.io-index",
+			Checksum:     "af150ab688ff2122fcef229be89cb50dd66af9e01a4ff320cc137eecc9bacc38",
+			Dependencies: []string{},
+		},
+	}
+	myFork := pkg.Package{
+		Name:         "my-fork",
+		Version:      "0.3.0",
+		FoundBy:      "rust-cargo-lock-cataloger",
+		PURL:         "pkg:cargo/my-fork@0.3.0?vcs_url=git+https://github.com/example/my-fork%400123456789abcdef0123456789abcdef01234567",
+		Locations:    source.NewLocationSet(lockLocation),
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata: pkg.CargoPackageMetadata{
+			Name:         "my-fork",
+			Version:      "0.3.0",
+			Source:       "git+https://github.com/example/my-fork?branch=main#0123456789abcdef0123456789abcdef01234567",
+			Dependencies: []string{},
+		},
+	}
+	serde := pkg.Package{
+		Name:         "serde",
+		Version:      "1.0.190",
+		FoundBy:      "rust-cargo-lock-cataloger",
+		PURL:         "pkg:cargo/serde@1.0.190",
+		Locations:    source.NewLocationSet(lockLocation),
+		Language:     pkg.Rust,
+		Type:         pkg.RustPkg,
+		MetadataType: pkg.RustCargoPackageMetadataType,
+		Metadata: pkg.CargoPackageMetadata{
+			Name:         "serde",
+			Version:      "1.0.190",
+			Source:       "registry+https://github.com/rust-lang/crates.io-index",
+			Checksum:     "91d3c334ca1ee894a2c6f6ad698fe8c435b76d504b13d436f0685d648d6d96f7",
+			Dependencies: []string{"itoa 1.0.9"},
+		},
+	}
+
+	expectedPkgs := []pkg.Package{app, coreLib, helper, internalUtil, itoaOld, itoa, myFork, serde}
+
+	expectedRelationships := []artifact.Relationship{
+		{From: coreLib, To: app, Type: artifact.DependencyOfRelationship},
+		{From: helper, To: app, Type: artifact.DependencyOfRelationship},
+		{From: internalUtil, To: app, Type: artifact.DependencyOfRelationship},
+		{From: myFork, To: app, Type: artifact.DependencyOfRelationship},
+		{From: serde, To: app, Type: artifact.DependencyOfRelationship},
+		{From: serde, To: coreLib, Type: artifact.DependencyOfRelationship},
+		{From: itoaOld, To: internalUtil, Type: artifact.DependencyOfRelationship},
+		{From: itoa, To: serde, Type: artifact.DependencyOfRelationship},
+	}
+
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/workspace").
+		Expects(expectedPkgs, expectedRelationships).
+		TestCataloger(t, NewCargoLockCataloger())
+}
@@ -1,5 +1,5 @@
 /*
-Package swift provides a concrete Cataloger implementation for Podfile.lock files.
+Package swift provides a concrete Cataloger implementation for Podfile.lock and Package.resolved files.
 */
 package swift
 
@@ -12,3 +12,9 @@ func NewCocoapodsCataloger() *generic.Cataloger {
 	return generic.NewCataloger("cocoapods-cataloger").
 		WithParserByGlobs(parsePodfileLock, "**/Podfile.lock")
 }
+
+// NewSwiftPackageManagerCataloger returns a new Swift Package Manager resolved file cataloger object.
+func NewSwiftPackageManagerCataloger() *generic.Cataloger {
+	return generic.NewCataloger("swift-package-manager-cataloger").
+		WithParserByGlobs(parsePackageResolved, "**/Package.resolved", "**/.package.resolved")
+}
@@ -3,22 +3,34 @@ package swift
 import (
 	"testing"
 
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
 	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
 )
 
 func Test_Cataloger_Globs(t *testing.T) {
 	tests := []struct {
-		name     string
-		fixture  string
-		expected []string
+		name      string
+		fixture   string
+		cataloger *generic.Cataloger
+		expected  []string
 	}{
 		{
-			name:    "obtain swift files",
-			fixture: "test-fixtures/glob-paths",
+			name:      "obtain cocoapods files",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewCocoapodsCataloger(),
 			expected: []string{
 				"src/Podfile.lock",
 			},
 		},
+		{
+			name:      "obtain swift package manager files",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewSwiftPackageManagerCataloger(),
+			expected: []string{
+				"src/Package.resolved",
+				"src/.package.resolved",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -26,7 +38,7 @@ func Test_Cataloger_Globs(t *testing.T) {
 			pkgtest.NewCatalogTester().
 				FromDirectory(t, test.fixture).
 				ExpectsResolverContentQueries(test.expected).
-				TestCataloger(t, NewCocoapodsCataloger())
+				TestCataloger(t, test.cataloger)
 		})
 	}
 }
@@ -1,6 +1,10 @@
 package swift
 
 import (
+	"net/url"
+	"path"
+	"strings"
+
 	"github.com/nextlinux/packageurl-go"
 	"github.com/nextlinux/sbom/sbom/pkg"
 	"github.com/nextlinux/sbom/sbom/source"
@@ -37,3 +41,67 @@ func packageURL(name, version string) string {
 		"",
 	).ToString()
 }
+
+func newSwiftPackageManagerPackage(name, version string, m pkg.SwiftPackageManagerMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		PURL:         swiftPackageManagerPackageURL(name, version, m.RepositoryURL),
+		Locations:    source.NewLocationSet(locations...),
+		Type:         pkg.SwiftPkg,
+		Language:     pkg.Swift,
+		MetadataType: pkg.SwiftPackageManagerMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// swiftPackageManagerPackageURL returns the PURL for a swift package, where the namespace and name are taken from the
+// location of the package source (e.g. https://github.com/apple/swift-numerics.git becomes
+// pkg:swift/github.com/apple/swift-numerics). Packages from a registry use the scope of the package identity as the
+// namespace instead (e.g. mona.LinkedList becomes pkg:swift/mona/LinkedList).
+func swiftPackageManagerPackageURL(name, version, repositoryURL string) string {
+	var namespace string
+	if sourcePath := swiftSourcePath(repositoryURL); sourcePath != "" {
+		namespace, name = path.Dir(sourcePath), path.Base(sourcePath)
+		if namespace == "." {
+			namespace = ""
+		}
+	} else if scope, id, ok := strings.Cut(name, "."); ok {
+		namespace, name = scope, id
+	}
+
+	return packageurl.NewPackageURL(
+		packageurl.TypeSwift,
+		namespace,
+		name,
+		version,
+		nil,
+		"",
+	).ToString()
+}
+
+// swiftSourcePath returns the host and path of a remote package repository without the scheme, user info or ".git"
+// suffix (e.g. "github.com/apple/swift-numerics"), supporting both URLs and scp-like git locations
+// (e.g. git@github.com:apple/swift-numerics.git). An empty string is returned for local paths.
+func swiftSourcePath(repositoryURL string) string {
+	if repositoryURL == "" {
+		return ""
+	}
+	var host, repoPath string
+	if u, err := url.Parse(repositoryURL); err == nil && u.Host != "" {
+		host, repoPath = u.Hostname(), u.Path
+	} else if at := strings.Index(repositoryURL, "@"); at >= 0 && strings.Contains(repositoryURL[at:], ":") {
+		host, repoPath, _ = strings.Cut(repositoryURL[at+1:], ":")
+	} else {
+		return ""
+	}
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if repoPath == "" {
+		return host
+	}
+	return host + "/" + repoPath
+}
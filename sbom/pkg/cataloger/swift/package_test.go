@@ -31,3 +31,42 @@ func Test_packageURL(t *testing.T) {
 		})
 	}
 }
+
+func Test_swiftPackageManagerPackageURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       string
+		repositoryURL string
+		want          string
+	}{
+		{
+			name:          "swift-numerics",
+			version:       "1.0.2",
+			repositoryURL: "https://github.com/apple/swift-numerics.git",
+			want:          "pkg:swift/github.com/apple/swift-numerics@1.0.2",
+		},
+		{
+			name:          "SnapKit",
+			version:       "5.6.0",
+			repositoryURL: "git@github.com:SnapKit/SnapKit.git",
+			want:          "pkg:swift/github.com/SnapKit/SnapKit@5.6.0",
+		},
+		{
+			name:          "mona.linkedlist",
+			version:       "1.2.0",
+			repositoryURL: "",
+			want:          "pkg:swift/mona/linkedlist@1.2.0",
+		},
+		{
+			name:          "localkit",
+			version:       "1.0.0",
+			repositoryURL: "/Users/dev/LocalKit",
+			want:          "pkg:swift/localkit@1.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, swiftPackageManagerPackageURL(tt.name, tt.version, tt.repositoryURL))
+		})
+	}
+}
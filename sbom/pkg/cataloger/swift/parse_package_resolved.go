@@ -0,0 +1,188 @@
+package swift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parsePackageResolved
+
+var (
+	// match example:    .package(url: "https://github.com/apple/swift-log.git", from: "1.5.0")   --->   url: "https://github.com/apple/swift-log.git", from: "1.5.0"
+	packageDependencyPattern = regexp.MustCompile(`\.package\s*\(([^)]*)\)`)
+
+	// match example:    url: "https://github.com/apple/swift-log.git"   --->   url, https://github.com/apple/swift-log.git
+	packageDependencyArgumentPattern = regexp.MustCompile(`\b(url|path|id)\s*:\s*"([^"]+)"`)
+)
+
+// packageResolved represents a Package.resolved file, where version 1 nests the pins within an "object" and names
+// each pin by package name, while later versions name each pin by identity.
+type packageResolved struct {
+	Version int                 `json:"version"`
+	Object  packageResolvedPins `json:"object"`
+	packageResolvedPins
+}
+
+type packageResolvedPins struct {
+	Pins []packageResolvedPin `json:"pins"`
+}
+
+type packageResolvedPin struct {
+	// version 1
+	Package       string `json:"package"`
+	RepositoryURL string `json:"repositoryURL"`
+	// version 2 and later
+	Identity string `json:"identity"`
+	Kind     string `json:"kind"`
+	Location string `json:"location"`
+
+	State struct {
+		Branch   string `json:"branch"`
+		Revision string `json:"revision"`
+		Version  string `json:"version"`
+	} `json:"state"`
+}
+
+func (p packageResolvedPin) name() string {
+	if p.Identity != "" {
+		return p.Identity
+	}
+	return p.Package
+}
+
+func (p packageResolvedPin) location() string {
+	if p.Location != "" {
+		return p.Location
+	}
+	return p.RepositoryURL
+}
+
+// identity returns the identity SwiftPM assigns to the pinned package, which is how the package is matched with the
+// dependencies declared within Package.swift.
+func (p packageResolvedPin) identity() string {
+	if p.Identity != "" {
+		return strings.ToLower(p.Identity)
+	}
+	return packageIdentity(p.location())
+}
+
+// parsePackageResolved is a parser function for SwiftPM Package.resolved contents, returning all pinned packages.
+func parsePackageResolved(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var resolved packageResolved
+	if err := json.NewDecoder(reader).Decode(&resolved); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse Package.resolved file: %w", err)
+	}
+
+	pins := resolved.Pins
+	if resolved.Version < 2 {
+		pins = resolved.Object.Pins
+	}
+
+	manifestLocation, direct := readPackageManifest(resolver, reader.Location)
+
+	var pkgs []pkg.Package
+	for _, pin := range pins {
+		locations := []source.Location{reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)}
+		isDirect := direct != nil && direct.Contains(pin.identity())
+		if isDirect {
+			locations = append(locations, manifestLocation.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.SupportingEvidenceAnnotation))
+		}
+
+		// packages pinned to a branch or revision have no version, so the revision is the most specific version available
+		version := pin.State.Version
+		if version == "" {
+			version = pin.State.Revision
+		}
+
+		pkgs = append(pkgs, newSwiftPackageManagerPackage(
+			pin.name(),
+			version,
+			pkg.SwiftPackageManagerMetadata{
+				RepositoryURL: pin.location(),
+				Revision:      pin.State.Revision,
+				Branch:        pin.State.Branch,
+				Direct:        isDirect,
+			},
+			locations...,
+		))
+	}
+
+	return pkgs, nil, nil
+}
+
+// readPackageManifest reads the Package.swift next to the given Package.resolved, returning the identities of the
+// package dependencies declared within it. Nil is returned when there is no manifest (e.g. for Xcode projects, where
+// the Package.resolved is within the project bundle).
+func readPackageManifest(resolver source.FileResolver, resolvedLocation source.Location) (*source.Location, internal.StringSet) {
+	if resolver == nil {
+		return nil, nil
+	}
+
+	manifestLocation := resolver.RelativeFileByPath(resolvedLocation, path.Join(path.Dir(resolvedLocation.RealPath), "Package.swift"))
+	if manifestLocation == nil {
+		return nil, nil
+	}
+
+	reader, err := resolver.FileContentsByLocation(*manifestLocation)
+	if err != nil {
+		log.WithFields("path", manifestLocation.RealPath, "error", err).Debug("unable to read Package.swift")
+		return nil, nil
+	}
+	defer internal.CloseAndLogError(reader, manifestLocation.RealPath)
+
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		log.WithFields("path", manifestLocation.RealPath, "error", err).Debug("unable to read Package.swift")
+		return nil, nil
+	}
+
+	return manifestLocation, packageManifestDependencies(string(contents))
+}
+
+// packageManifestDependencies returns the identities of the package dependencies declared within a Package.swift,
+// e.g. .package(url: "https://github.com/apple/swift-log.git", from: "1.5.0") has the identity "swift-log".
+func packageManifestDependencies(contents string) internal.StringSet {
+	var lines []string
+	for _, line := range strings.Split(contents, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	identities := internal.NewStringSet()
+	for _, match := range packageDependencyPattern.FindAllStringSubmatch(strings.Join(lines, "\n"), -1) {
+		argument := packageDependencyArgumentPattern.FindStringSubmatch(match[1])
+		if argument == nil {
+			continue
+		}
+		if argument[1] == "id" {
+			// registry identities (e.g. "mona.LinkedList") are used as-is
+			identities.Add(strings.ToLower(argument[2]))
+			continue
+		}
+		identities.Add(packageIdentity(argument[2]))
+	}
+	return identities
+}
+
+// packageIdentity returns the identity of a package from its URL or path, which is the last path component without
+// any ".git" suffix (e.g. https://github.com/apple/swift-log.git has the identity "swift-log").
+func packageIdentity(location string) string {
+	location = strings.TrimSuffix(strings.TrimRight(location, "/"), ".git")
+	if i := strings.LastIndexAny(location, "/:"); i >= 0 {
+		location = location[i+1:]
+	}
+	return strings.ToLower(location)
+}
@@ -0,0 +1,134 @@
+package swift
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParsePackageResolved_V1(t *testing.T) {
+	fixture := "test-fixtures/package-resolved-v1/Package.resolved"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	expectedPkgs := []pkg.Package{
+		{
+			Name:         "Alamofire",
+			Version:      "5.4.3",
+			PURL:         "pkg:swift/github.com/Alamofire/Alamofire@5.4.3",
+			Locations:    locations,
+			Language:     pkg.Swift,
+			Type:         pkg.SwiftPkg,
+			MetadataType: pkg.SwiftPackageManagerMetadataType,
+			Metadata: pkg.SwiftPackageManagerMetadata{
+				RepositoryURL: "https://github.com/Alamofire/Alamofire.git",
+				Revision:      "f96b619bcb2383b43d898402283924b80e2c4bae",
+			},
+		},
+		{
+			// pinned to a branch, so the revision is used as the version
+			Name:         "SnapKit",
+			Version:      "e74fe2a978d1216c3602b129c2a7b4f5a6e0ad97",
+			PURL:         "pkg:swift/github.com/SnapKit/SnapKit@e74fe2a978d1216c3602b129c2a7b4f5a6e0ad97",
+			Locations:    locations,
+			Language:     pkg.Swift,
+			Type:         pkg.SwiftPkg,
+			MetadataType: pkg.SwiftPackageManagerMetadataType,
+			Metadata: pkg.SwiftPackageManagerMetadata{
+				RepositoryURL: "git@github.com:SnapKit/SnapKit.git",
+				Revision:      "e74fe2a978d1216c3602b129c2a7b4f5a6e0ad97",
+				Branch:        "develop",
+			},
+		},
+	}
+
+	var expectedRelationships []artifact.Relationship
+
+	pkgtest.TestFileParser(t, fixture, parsePackageResolved, expectedPkgs, expectedRelationships)
+}
+
+func TestParsePackageResolved_V2(t *testing.T) {
+	resolvedLocation := source.NewLocation("Package.resolved")
+	direct := source.NewLocationSet(resolvedLocation, source.NewLocation("Package.swift"))
+	transitive := source.NewLocationSet(resolvedLocation)
+
+	expectedPkgs := []pkg.Package{
+		{
+			Name:         "localkit",
+			Version:      "0c5b0a9e3d5e1c6f2b8a4d7e9f1a3c5b7d9e1f3a",
+			PURL:         "pkg:swift/localkit@0c5b0a9e3d5e1c6f2b8a4d7e9f1a3c5b7d9e1f3a",
+			Locations:    transitive,
+			FoundBy:      "swift-package-manager-cataloger",
+			Language:     pkg.Swift,
+			Type:         pkg.SwiftPkg,
+			MetadataType: pkg.SwiftPackageManagerMetadataType,
+			Metadata: pkg.SwiftPackageManagerMetadata{
+				RepositoryURL: "/Users/dev/LocalKit",
+				Revision:      "0c5b0a9e3d5e1c6f2b8a4d7e9f1a3c5b7d9e1f3a",
+			},
+		},
+		{
+			Name:         "mona.linkedlist",
+			Version:      "1.2.0",
+			PURL:         "pkg:swift/mona/linkedlist@1.2.0",
+			Locations:    direct,
+			FoundBy:      "swift-package-manager-cataloger",
+			Language:     pkg.Swift,
+			Type:         pkg.SwiftPkg,
+			MetadataType: pkg.SwiftPackageManagerMetadataType,
+			Metadata: pkg.SwiftPackageManagerMetadata{
+				Direct: true,
+			},
+		},
+		{
+			Name:         "swift-argument-parser",
+			Version:      "1.2.3",
+			PURL:         "pkg:swift/github.com/apple/swift-argument-parser@1.2.3",
+			Locations:    direct,
+			FoundBy:      "swift-package-manager-cataloger",
+			Language:     pkg.Swift,
+			Type:         pkg.SwiftPkg,
+			MetadataType: pkg.SwiftPackageManagerMetadataType,
+			Metadata: pkg.SwiftPackageManagerMetadata{
+				RepositoryURL: "https://github.com/apple/swift-argument-parser",
+				Revision:      "8f4d2753f0e4778c76d5f05ad16c74f707390531",
+				Direct:        true,
+			},
+		},
+		{
+			Name:         "swift-collections",
+			Version:      "1.0.4",
+			PURL:         "pkg:swift/github.com/apple/swift-collections@1.0.4",
+			Locations:    transitive,
+			FoundBy:      "swift-package-manager-cataloger",
+			Language:     pkg.Swift,
+			Type:         pkg.SwiftPkg,
+			MetadataType: pkg.SwiftPackageManagerMetadataType,
+			Metadata: pkg.SwiftPackageManagerMetadata{
+				RepositoryURL: "https://github.com/apple/swift-collections.git",
+				Revision:      "937e904258d22af6e447a0b72c0bc67583ef64a2",
+			},
+		},
+		{
+			Name:         "swift-log",
+			Version:      "1.5.3",
+			PURL:         "pkg:swift/github.com/apple/swift-log@1.5.3",
+			Locations:    direct,
+			FoundBy:      "swift-package-manager-cataloger",
+			Language:     pkg.Swift,
+			Type:         pkg.SwiftPkg,
+			MetadataType: pkg.SwiftPackageManagerMetadataType,
+			Metadata: pkg.SwiftPackageManagerMetadata{
+				RepositoryURL: "https://github.com/apple/swift-log.git",
+				Revision:      "532d8b529501fb73a2455b179e0bbb6d49b652ed",
+				Direct:        true,
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/package-resolved-v2").
+		Expects(expectedPkgs, nil).
+		TestCataloger(t, NewSwiftPackageManagerCataloger())
+}
@@ -0,0 +1,22 @@
+// swift-tools-version:5.7
+import PackageDescription
+
+let package = Package(
+    name: "example-cli",
+    dependencies: [
+        .package(url: "https://github.com/apple/swift-argument-parser", from: "1.2.0"),
+        .package(url: "https://github.com/apple/swift-log.git", .upToNextMajor(from: "1.5.0")),
+        .package(id: "mona.LinkedList", from: "1.2.0"),
+        // .package(url: "https://github.com/apple/swift-collections.git", from: "1.0.0"),
+    ],
+    targets: [
+        .executableTarget(
+            name: "example-cli",
+            dependencies: [
+                .product(name: "ArgumentParser", package: "swift-argument-parser"),
+                .product(name: "Logging", package: "swift-log"),
+                .product(name: "LinkedList", package: "mona.LinkedList"),
+            ]
+        ),
+    ]
+)
@@ -0,0 +1,22 @@
+/*
+Package terraform provides a concrete Cataloger implementation for terraform providers, as locked within
+.terraform.lock.hcl files and as installed by terraform init.
+*/
+package terraform
+
+import (
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+)
+
+// NewLockCataloger returns a new cataloger object for the providers locked within .terraform.lock.hcl files.
+func NewLockCataloger() *generic.Cataloger {
+	return generic.NewCataloger("terraform-lock-cataloger").
+		WithParserByGlobs(parseTerraformLock, "**/.terraform.lock.hcl")
+}
+
+// NewProviderBinaryCataloger returns a new cataloger object for the provider plugins installed by terraform init
+// (e.g. .terraform/providers/registry.terraform.io/hashicorp/aws/5.31.0/linux_amd64/terraform-provider-aws_v5.31.0_x5).
+func NewProviderBinaryCataloger() *generic.Cataloger {
+	return generic.NewCataloger("terraform-provider-binary-cataloger").
+		WithParserByGlobs(parseProviderBinary, "**/terraform-provider-*")
+}
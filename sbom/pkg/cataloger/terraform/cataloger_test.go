@@ -0,0 +1,43 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+)
+
+func TestCataloger_Globs(t *testing.T) {
+	tests := []struct {
+		name      string
+		fixture   string
+		cataloger *generic.Cataloger
+		expected  []string
+	}{
+		{
+			name:      "obtain terraform lock files",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewLockCataloger(),
+			expected: []string{
+				"src/.terraform.lock.hcl",
+			},
+		},
+		{
+			name:      "obtain terraform provider binaries",
+			fixture:   "test-fixtures/glob-paths",
+			cataloger: NewProviderBinaryCataloger(),
+			expected: []string{
+				".terraform/providers/registry.terraform.io/hashicorp/random/3.6.0/linux_amd64/terraform-provider-random_v3.6.0_x5",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromDirectory(t, test.fixture).
+				ExpectsResolverContentQueries(test.expected).
+				TestCataloger(t, test.cataloger)
+		})
+	}
+}
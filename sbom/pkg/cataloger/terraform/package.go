@@ -0,0 +1,92 @@
+package terraform
+
+import (
+	"strings"
+
+	"github.com/nextlinux/packageurl-go"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+// defaultRegistry is the hostname that provider addresses are relative to when no hostname is given
+const defaultRegistry = "registry.terraform.io"
+
+// providerAddress is the source address of a provider, e.g. registry.terraform.io/hashicorp/aws
+type providerAddress struct {
+	hostname  string
+	namespace string
+	name      string
+}
+
+// parseProviderAddress parses a provider source address, which is of the form [<hostname>/]<namespace>/<type>.
+func parseProviderAddress(address string) (providerAddress, bool) {
+	fields := strings.Split(strings.ToLower(strings.TrimSpace(address)), "/")
+	switch len(fields) {
+	case 2:
+		fields = append([]string{defaultRegistry}, fields...)
+	case 3:
+	default:
+		return providerAddress{}, false
+	}
+	for _, field := range fields {
+		if field == "" {
+			return providerAddress{}, false
+		}
+	}
+	return providerAddress{hostname: fields[0], namespace: fields[1], name: fields[2]}, true
+}
+
+func (a providerAddress) String() string {
+	return a.hostname + "/" + a.namespace + "/" + a.name
+}
+
+func newTerraformLockPackage(address providerAddress, version string, m pkg.TerraformLockProviderMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         address.name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(address, version),
+		Type:         pkg.TerraformPkg,
+		MetadataType: pkg.TerraformLockProviderMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func newTerraformProviderBinaryPackage(address providerAddress, version string, m pkg.TerraformProviderBinaryMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         address.name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		PURL:         packageURL(address, version),
+		Type:         pkg.TerraformPkg,
+		MetadataType: pkg.TerraformProviderBinaryMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// packageURL returns the PURL for a provider, where the namespace of the provider is the PURL namespace and providers
+// from registries other than the public terraform registry are qualified by the registry,
+// e.g. pkg:terraform/hashicorp/aws@5.31.0 or pkg:terraform/hashicorp/aws@5.31.0?repository_url=registry.opentofu.org.
+func packageURL(address providerAddress, version string) string {
+	var qualifiers packageurl.Qualifiers
+	if address.hostname != defaultRegistry {
+		qualifiers = append(qualifiers, packageurl.Qualifier{Key: "repository_url", Value: address.hostname})
+	}
+
+	return packageurl.NewPackageURL(
+		pkg.TerraformPkg.PackageURLType(),
+		address.namespace,
+		address.name,
+		version,
+		qualifiers,
+		"",
+	).ToString()
+}
@@ -0,0 +1,44 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseProviderAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    providerAddress
+		wantOK  bool
+	}{
+		{
+			address: "registry.terraform.io/hashicorp/aws",
+			want:    providerAddress{hostname: "registry.terraform.io", namespace: "hashicorp", name: "aws"},
+			wantOK:  true,
+		},
+		{
+			address: "hashicorp/aws",
+			want:    providerAddress{hostname: "registry.terraform.io", namespace: "hashicorp", name: "aws"},
+			wantOK:  true,
+		},
+		{
+			address: "Registry.OpenTofu.org/Integrations/GitHub",
+			want:    providerAddress{hostname: "registry.opentofu.org", namespace: "integrations", name: "github"},
+			wantOK:  true,
+		},
+		{
+			address: "aws",
+		},
+		{
+			address: "registry.terraform.io//aws",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			got, ok := parseProviderAddress(tt.address)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
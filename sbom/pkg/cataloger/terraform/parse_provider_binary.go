@@ -0,0 +1,60 @@
+package terraform
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseProviderBinary
+
+// match example:    linux_amd64
+var platformPattern = regexp.MustCompile(`^[a-z0-9]+_[a-z0-9]+$`)
+
+// parseProviderBinary is a parser function for provider plugins installed by terraform init, which are recognised by
+// the layout of the directory they are installed within (the same layout is used for plugin cache directories and
+// local mirrors):
+//
+//	<hostname>/<namespace>/<type>/<version>/<os>_<arch>/terraform-provider-<type>_v<version>_x5
+//
+// Plugins outside of this layout (e.g. the legacy .terraform/plugins/<os>_<arch> directory) are not cataloged, since
+// the namespace of the provider cannot be determined.
+func parseProviderBinary(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	fields := strings.Split(path.Clean(reader.Location.RealPath), "/")
+	if len(fields) < 6 {
+		return nil, nil, nil
+	}
+	fields = fields[len(fields)-6:]
+	hostname, namespace, name, version, platform, file := fields[0], fields[1], fields[2], fields[3], fields[4], fields[5]
+
+	// e.g. terraform-provider-aws_v5.31.0_x5 or terraform-provider-aws.exe
+	suffix := strings.TrimPrefix(file, "terraform-provider-"+name)
+	if suffix == file || (suffix != "" && !strings.HasPrefix(suffix, "_") && !strings.HasPrefix(suffix, ".")) {
+		return nil, nil, nil
+	}
+	if !platformPattern.MatchString(platform) {
+		return nil, nil, nil
+	}
+
+	address, ok := parseProviderAddress(hostname + "/" + namespace + "/" + name)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	p := newTerraformProviderBinaryPackage(
+		address,
+		version,
+		pkg.TerraformProviderBinaryMetadata{
+			Source:   address.String(),
+			Platform: platform,
+		},
+		reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation),
+	)
+
+	return []pkg.Package{p}, nil, nil
+}
@@ -0,0 +1,49 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseProviderBinary(t *testing.T) {
+	awsPath := ".terraform/providers/registry.terraform.io/hashicorp/aws/5.31.0/linux_amd64/terraform-provider-aws_v5.31.0_x5"
+	githubPath := ".terraform/providers/registry.opentofu.org/integrations/github/5.42.0/darwin_arm64/terraform-provider-github_v5.42.0"
+
+	// the legacy .terraform/plugins layout is not cataloged
+	expected := []pkg.Package{
+		{
+			Name:         "aws",
+			Version:      "5.31.0",
+			PURL:         "pkg:terraform/hashicorp/aws@5.31.0",
+			Locations:    source.NewLocationSet(source.NewLocation(awsPath)),
+			Type:         pkg.TerraformPkg,
+			FoundBy:      "terraform-provider-binary-cataloger",
+			MetadataType: pkg.TerraformProviderBinaryMetadataType,
+			Metadata: pkg.TerraformProviderBinaryMetadata{
+				Source:   "registry.terraform.io/hashicorp/aws",
+				Platform: "linux_amd64",
+			},
+		},
+		{
+			Name:         "github",
+			Version:      "5.42.0",
+			PURL:         "pkg:terraform/integrations/github@5.42.0?repository_url=registry.opentofu.org",
+			Locations:    source.NewLocationSet(source.NewLocation(githubPath)),
+			Type:         pkg.TerraformPkg,
+			FoundBy:      "terraform-provider-binary-cataloger",
+			MetadataType: pkg.TerraformProviderBinaryMetadataType,
+			Metadata: pkg.TerraformProviderBinaryMetadata{
+				Source:   "registry.opentofu.org/integrations/github",
+				Platform: "darwin_arm64",
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		FromDirectory(t, "test-fixtures/providers").
+		Expects(expected, nil).
+		TestCataloger(t, NewProviderBinaryCataloger())
+}
@@ -0,0 +1,124 @@
+package terraform
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/generic"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+var _ generic.Parser = parseTerraformLock
+
+var (
+	// match example:    provider "registry.terraform.io/hashicorp/aws" {   --->   registry.terraform.io/hashicorp/aws
+	providerBlockPattern = regexp.MustCompile(`^provider\s+"([^"]+)"\s*\{$`)
+
+	// match example:    version     = "5.31.0"   --->   version, "5.31.0"
+	attributePattern = regexp.MustCompile(`^([A-Za-z0-9_-]+)\s*=\s*(.*)$`)
+
+	// match example:    "h1:Lx5bn1kCaS1X+YfzDqPSqv8gl9+HUHdwhZpD0gP4LCw=",   --->   h1:Lx5bn1kCaS1X+YfzDqPSqv8gl9+HUHdwhZpD0gP4LCw=
+	quotedStringPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+)
+
+type lockedProvider struct {
+	source      string
+	version     string
+	constraints string
+	hashes      []string
+}
+
+// parseTerraformLock is a parser function for .terraform.lock.hcl contents, returning all locked providers. The lock
+// file is written by terraform init and only ever holds provider blocks with simple attributes, so the HCL is read
+// line by line rather than by a full HCL parser.
+func parseTerraformLock(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var providers []*lockedProvider
+	var current *lockedProvider
+	inHashes := false
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		switch {
+		case current == nil:
+			if match := providerBlockPattern.FindStringSubmatch(line); match != nil {
+				current = &lockedProvider{source: match[1]}
+				providers = append(providers, current)
+			}
+		case inHashes:
+			current.hashes = append(current.hashes, quotedStrings(line)...)
+			if strings.HasPrefix(line, "]") {
+				inHashes = false
+			}
+		case line == "}":
+			current = nil
+		default:
+			match := attributePattern.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			key, value := match[1], strings.TrimSpace(match[2])
+			switch key {
+			case "version":
+				current.version = unquote(value)
+			case "constraints":
+				current.constraints = unquote(value)
+			case "hashes":
+				// hashes are usually listed one per line, though may all be on a single line
+				current.hashes = append(current.hashes, quotedStrings(value)...)
+				inHashes = strings.HasPrefix(value, "[") && !strings.HasSuffix(value, "]")
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse .terraform.lock.hcl file: %w", err)
+	}
+
+	location := reader.Location.WithAnnotation(pkg.EvidenceAnnotationKey, pkg.PrimaryEvidenceAnnotation)
+
+	var pkgs []pkg.Package
+	for _, provider := range providers {
+		address, ok := parseProviderAddress(provider.source)
+		if !ok {
+			log.WithFields("path", reader.RealPath, "provider", provider.source).Debug("skipping invalid provider address from .terraform.lock.hcl file")
+			continue
+		}
+		pkgs = append(pkgs, newTerraformLockPackage(
+			address,
+			provider.version,
+			pkg.TerraformLockProviderMetadata{
+				Source:      address.String(),
+				Constraints: provider.constraints,
+				Hashes:      provider.hashes,
+			},
+			location,
+		))
+	}
+
+	return pkgs, nil, nil
+}
+
+func quotedStrings(s string) []string {
+	var values []string
+	for _, match := range quotedStringPattern.FindAllString(s, -1) {
+		values = append(values, unquote(match))
+	}
+	return values
+}
+
+func unquote(s string) string {
+	if value, err := strconv.Unquote(s); err == nil {
+		return value
+	}
+	return strings.Trim(s, `"`)
+}
@@ -0,0 +1,64 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/pkg"
+	"github.com/nextlinux/sbom/sbom/pkg/cataloger/internal/pkgtest"
+	"github.com/nextlinux/sbom/sbom/source"
+)
+
+func TestParseTerraformLock(t *testing.T) {
+	fixture := "test-fixtures/.terraform.lock.hcl"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	expected := []pkg.Package{
+		{
+			Name:         "aws",
+			Version:      "5.31.0",
+			PURL:         "pkg:terraform/hashicorp/aws@5.31.0",
+			Locations:    locations,
+			Type:         pkg.TerraformPkg,
+			MetadataType: pkg.TerraformLockProviderMetadataType,
+			Metadata: pkg.TerraformLockProviderMetadata{
+				Source:      "registry.terraform.io/hashicorp/aws",
+				Constraints: "~> 5.0, >= 4.67.0",
+				Hashes: []string{
+					"h1:ltxyuBWIy9cq0kIKDJH1jeWJy/y7XJLjS4QrsQK4plA=",
+					"zh:0cdb9c2083bf0902442384f7309367791e4640581652dda456f2d6d7abf0de8d",
+					"zh:2fe4884cb9642f48a5889f8dff8f5f511418a18537a9dfa77ada3bcdad391e4e",
+				},
+			},
+		},
+		{
+			Name:         "random",
+			Version:      "3.6.0",
+			PURL:         "pkg:terraform/hashicorp/random@3.6.0",
+			Locations:    locations,
+			Type:         pkg.TerraformPkg,
+			MetadataType: pkg.TerraformLockProviderMetadataType,
+			Metadata: pkg.TerraformLockProviderMetadata{
+				Source: "registry.terraform.io/hashicorp/random",
+				Hashes: []string{
+					"h1:R5Ucn26riKIEijcsiOMBR3uOAjuOMfI1x7XvH4P6B1w=",
+				},
+			},
+		},
+		{
+			Name:         "github",
+			Version:      "5.42.0",
+			PURL:         "pkg:terraform/integrations/github@5.42.0?repository_url=registry.opentofu.org",
+			Locations:    locations,
+			Type:         pkg.TerraformPkg,
+			MetadataType: pkg.TerraformLockProviderMetadataType,
+			Metadata: pkg.TerraformLockProviderMetadata{
+				Source:      "registry.opentofu.org/integrations/github",
+				Constraints: "5.42.0",
+			},
+		},
+	}
+
+	var expectedRelationships []artifact.Relationship
+
+	pkgtest.TestFileParser(t, fixture, parseTerraformLock, expected, expectedRelationships)
+}
@@ -35,13 +35,15 @@ func (m ConanLockMetadata) PackageURL(_ *linux.Release) string {
 }
 
 // NameAndVersion returns the name and version of the package.
-// If ref is not in the format of "name/version@user/channel", then an empty string is returned for both.
+// If ref is not in the format of "name/version@user/channel" (optionally followed by the "#revision%timestamp" of
+// conan 2 references), then an empty string is returned for both.
 func (m ConanLockMetadata) NameAndVersion() (name, version string) {
 	if len(m.Ref) < 1 {
 		return name, version
 	}
 
-	splits := strings.Split(strings.Split(m.Ref, "@")[0], "/")
+	ref := strings.Split(m.Ref, "#")[0]
+	splits := strings.Split(strings.Split(ref, "@")[0], "/")
 	if len(splits) < 2 {
 		return name, version
 	}
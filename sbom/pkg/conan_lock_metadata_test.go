@@ -15,6 +15,13 @@ func TestConanLockMetadata_PackageURL(t *testing.T) {
 			},
 			want: "pkg:conan/farmerbrown5@3.13.9",
 		},
+		{
+			name: "conan 2 reference with revision",
+			m: ConanLockMetadata{
+				Ref: "zlib/1.2.13#97d5730b529b4224045fe7090592d4c1%1692672717.68",
+			},
+			want: "pkg:conan/zlib@1.2.13",
+		},
 	}
 
 	for _, test := range tests {
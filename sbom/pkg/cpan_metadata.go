@@ -0,0 +1,29 @@
+package pkg
+
+import "sort"
+
+var _ FileOwner = (*CpanMetadata)(nil)
+
+// CpanMetadata represents all captured data for a Perl distribution, from an entry within a carton cpanfile.snapshot,
+// an installed MYMETA.json file or an installed .packlist file.
+type CpanMetadata struct {
+	// CpanID is the PAUSE ID of the author that released the distribution (e.g. "LEONT")
+	CpanID string `json:"cpanId,omitempty"`
+	// Pathname is the path of the distribution archive within CPAN (e.g. "L/LE/LEONT/Module-Build-0.4224.tar.gz")
+	Pathname string   `json:"pathname,omitempty"`
+	Abstract string   `json:"abstract,omitempty"`
+	Authors  []string `json:"authors,omitempty"`
+	Licenses []string `json:"licenses,omitempty"`
+	// Provides are the modules provided by the distribution
+	Provides []string `json:"provides,omitempty"`
+	// Requires are the modules required at runtime, with the minimum version when one is specified (e.g. "CPAN::Meta 2.142060")
+	Requires []string `json:"requires,omitempty"`
+	// Files are the paths of the installed files, as listed by the .packlist
+	Files []string `json:"files,omitempty"`
+}
+
+func (m CpanMetadata) OwnedFiles() (result []string) {
+	result = append(result, m.Files...)
+	sort.Strings(result)
+	return result
+}
@@ -1,9 +1,21 @@
 package pkg
 
+import "sort"
+
+var _ FileOwner = (*DotnetDepsMetadata)(nil)
+
 type DotnetDepsMetadata struct {
 	Name     string `mapstructure:"name" json:"name"`
 	Version  string `mapstructure:"version" json:"version"`
 	Path     string `mapstructure:"path" json:"path"`
 	Sha512   string `mapstructure:"sha512" json:"sha512"`
 	HashPath string `mapstructure:"hashPath" json:"hashPath"`
+	// Files are the paths of the runtime assemblies deployed alongside the deps.json file
+	Files []string `mapstructure:"files" json:"files,omitempty"`
+}
+
+func (m DotnetDepsMetadata) OwnedFiles() (result []string) {
+	result = append(result, m.Files...)
+	sort.Strings(result)
+	return result
 }
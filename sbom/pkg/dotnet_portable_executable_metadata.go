@@ -0,0 +1,15 @@
+package pkg
+
+// DotnetPortableExecutableMetadata represents the version resource (VS_VERSIONINFO) of a .NET assembly.
+type DotnetPortableExecutableMetadata struct {
+	AssemblyName     string `json:"assemblyName"`
+	FileVersion      string `json:"fileVersion,omitempty"`
+	ProductVersion   string `json:"productVersion,omitempty"`
+	ProductName      string `json:"productName,omitempty"`
+	CompanyName      string `json:"companyName,omitempty"`
+	FileDescription  string `json:"fileDescription,omitempty"`
+	InternalName     string `json:"internalName,omitempty"`
+	OriginalFilename string `json:"originalFilename,omitempty"`
+	LegalCopyright   string `json:"legalCopyright,omitempty"`
+	Comments         string `json:"comments,omitempty"`
+}
@@ -0,0 +1,16 @@
+package pkg
+
+// DrupalMetadata represents all captured data for a Drupal core installation, module, theme or profile, from the
+// *.info.yml file of the extension (or core/lib/Drupal.php for core).
+type DrupalMetadata struct {
+	// DisplayName is the human-readable name of the extension
+	DisplayName string `json:"displayName,omitempty"`
+	// ExtensionType is the kind of extension (module, theme or profile)
+	ExtensionType string `json:"extensionType,omitempty"`
+	// Project is the drupal.org project that the extension is packaged with (submodules share the project of the
+	// main module)
+	Project                string   `json:"project,omitempty"`
+	Package                string   `json:"package,omitempty"`
+	CoreVersionRequirement string   `json:"coreVersionRequirement,omitempty"`
+	Dependencies           []string `json:"dependencies,omitempty"`
+}
@@ -0,0 +1,16 @@
+package pkg
+
+// GemfileLockMetadata represents all captured data for a gem entry within a Gemfile.lock file.
+type GemfileLockMetadata struct {
+	// Source is the kind of source block the gem is locked within (e.g. "gem", "git", "path" or "plugin source")
+	Source string `json:"source,omitempty"`
+	// Remote is the gem server or git repository the gem is fetched from, or the path of the gem for path sources
+	Remote   string `json:"remote,omitempty"`
+	Revision string `json:"revision,omitempty"`
+	Branch   string `json:"branch,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+	Ref      string `json:"ref,omitempty"`
+	// Direct is true when the gem is listed within the DEPENDENCIES section (i.e. it is declared by the Gemfile)
+	Direct       bool     `json:"direct,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
@@ -0,0 +1,20 @@
+package pkg
+
+const (
+	// GithubActionUse is an action referenced by a step (e.g. "uses: actions/checkout@v4")
+	GithubActionUse = "action"
+	// GithubWorkflowUse is a reusable workflow referenced by a job (e.g. "uses: octo-org/ci/.github/workflows/build.yml@v1")
+	GithubWorkflowUse = "workflow"
+	// GithubDockerUse is a container image referenced by a step (e.g. "uses: docker://alpine:3.18")
+	GithubDockerUse = "docker"
+)
+
+// GithubActionsUseMetadata represents all captured data for an action, reusable workflow or container image referenced
+// within a GitHub Actions workflow or action definition.
+type GithubActionsUseMetadata struct {
+	Kind string `json:"kind"`
+	// Ref is the git ref (or image tag or digest) that the reference resolves to
+	Ref string `json:"ref,omitempty"`
+	// Pinned is true when the ref cannot be moved, i.e. a full commit SHA (or an image digest)
+	Pinned bool `json:"pinned"`
+}
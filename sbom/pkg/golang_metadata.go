@@ -2,11 +2,24 @@ package pkg
 
 // GolangBinMetadata represents all captured data for a Golang binary
 type GolangBinMetadata struct {
-	BuildSettings     map[string]string `json:"goBuildSettings,omitempty" cyclonedx:"goBuildSettings"`
-	GoCompiledVersion string            `json:"goCompiledVersion" cyclonedx:"goCompiledVersion"`
-	Architecture      string            `json:"architecture" cyclonedx:"architecture"`
-	H1Digest          string            `json:"h1Digest,omitempty" cyclonedx:"h1Digest"`
-	MainModule        string            `json:"mainModule,omitempty" cyclonedx:"mainModule"`
+	BuildSettings     map[string]string        `json:"goBuildSettings,omitempty" cyclonedx:"goBuildSettings"`
+	GoCompiledVersion string                   `json:"goCompiledVersion" cyclonedx:"goCompiledVersion"`
+	Architecture      string                   `json:"architecture" cyclonedx:"architecture"`
+	H1Digest          string                   `json:"h1Digest,omitempty" cyclonedx:"h1Digest"`
+	MainModule        string                   `json:"mainModule,omitempty" cyclonedx:"mainModule"`
+	Replacement       *GolangModuleReplacement `json:"replacement,omitempty" cyclonedx:"replacement"`
+	TrimPath          bool                     `json:"trimPath,omitempty" cyclonedx:"trimPath"`
+	CGOEnabled        bool                     `json:"cgoEnabled,omitempty" cyclonedx:"cgoEnabled"`
+	LDFlags           string                   `json:"ldflags,omitempty" cyclonedx:"ldflags"`
+}
+
+// GolangModuleReplacement describes a replace directive that was applied to a module when the binary was built. The
+// package describes the replacement target, while the original module path and version are kept here.
+type GolangModuleReplacement struct {
+	OriginalPath    string `json:"originalPath"`
+	OriginalVersion string `json:"originalVersion,omitempty"`
+	TargetPath      string `json:"targetPath"`
+	TargetVersion   string `json:"targetVersion,omitempty"`
 }
 
 // GolangModMetadata represents all captured data for a Golang source scan with go.mod/go.sum
@@ -22,9 +22,21 @@ type JavaMetadata struct {
 	PomProperties  *PomProperties `mapstructure:"PomProperties" json:"pomProperties,omitempty" cyclonedx:"-"`
 	PomProject     *PomProject    `mapstructure:"PomProject" json:"pomProject,omitempty"`
 	ArchiveDigests []file.Digest  `hash:"ignore" json:"digest,omitempty"`
-	Parent         *Package       `hash:"ignore" json:"-"` // note: the parent cannot be included in the minimal definition of uniqueness since this field is not reproducible in an encode-decode cycle (is lossy).
+	Unresolved     bool           `json:"unresolved,omitempty"`
+	Layer          string         `json:"layer,omitempty"`       // the layer of the application that the jar belongs to, as defined by the layer scheme
+	LayerScheme    string         `json:"layerScheme,omitempty"` // how the application is layered (e.g. the layers.idx of a Spring Boot archive or the directories of a Quarkus fast-jar application)
+	Parent         *Package       `hash:"ignore" json:"-"`       // note: the parent cannot be included in the minimal definition of uniqueness since this field is not reproducible in an encode-decode cycle (is lossy).
 }
 
+const (
+	// SpringBootLayerScheme indicates that the layer was taken from the layers.idx of a Spring Boot archive (e.g.
+	// dependencies, snapshot-dependencies, or application).
+	SpringBootLayerScheme = "spring-boot"
+	// QuarkusFastJarLayerScheme indicates that the layer is the directory (boot, main, or application) of a Quarkus
+	// fast-jar application that the jar was found in.
+	QuarkusFastJarLayerScheme = "quarkus-fast-jar"
+)
+
 // PomProperties represents the fields of interest extracted from a Java archive's pom.properties file.
 type PomProperties struct {
 	Path       string            `mapstructure:"path" json:"path"`
@@ -32,6 +44,7 @@ type PomProperties struct {
 	GroupID    string            `mapstructure:"groupId" json:"groupId" cyclonedx:"groupID"`
 	ArtifactID string            `mapstructure:"artifactId" json:"artifactId" cyclonedx:"artifactID"`
 	Version    string            `mapstructure:"version" json:"version"`
+	Scope      string            `mapstructure:"scope" json:"scope,omitempty"`
 	Extra      map[string]string `mapstructure:",remain" json:"extraFields,omitempty"`
 }
 
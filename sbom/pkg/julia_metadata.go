@@ -0,0 +1,17 @@
+package pkg
+
+// JuliaPackageMetadata represents all captured data for a Julia package, from an entry within a Manifest.toml file or
+// from the Project.toml file of the package itself.
+type JuliaPackageMetadata struct {
+	UUID string `json:"uuid"`
+	// GitTreeSHA1 is the git tree hash of the package source, which is how Julia identifies a package version
+	GitTreeSHA1 string `json:"gitTreeSha1,omitempty"`
+	// RepoURL and RepoRev are present for packages added from a git repository rather than a registry
+	RepoURL string `json:"repoURL,omitempty"`
+	RepoRev string `json:"repoRev,omitempty"`
+	// Path is present for packages tracking a local directory (e.g. with Pkg.develop)
+	Path    string   `json:"path,omitempty"`
+	Authors []string `json:"authors,omitempty"`
+	// Deps are the names of the direct dependencies of the package
+	Deps []string `json:"deps,omitempty"`
+}
@@ -21,8 +21,13 @@ const (
 	Haskell         Language = "haskell"
 	Java            Language = "java"
 	JavaScript      Language = "javascript"
+	Julia           Language = "julia"
+	Lua             Language = "lua"
+	OCaml           Language = "ocaml"
+	Perl            Language = "perl"
 	PHP             Language = "php"
 	Python          Language = "python"
+	R               Language = "R"
 	Ruby            Language = "ruby"
 	Rust            Language = "rust"
 	Swift           Language = "swift"
@@ -39,8 +44,13 @@ var AllLanguages = []Language{
 	Haskell,
 	Java,
 	JavaScript,
+	Julia,
+	Lua,
+	OCaml,
+	Perl,
 	PHP,
 	Python,
+	R,
 	Ruby,
 	Rust,
 	Swift,
@@ -64,7 +74,7 @@ func LanguageByName(name string) Language {
 	switch strings.ToLower(name) {
 	case packageurl.TypeMaven, string(purlGradlePkgType), string(JavaPkg), string(Java):
 		return Java
-	case packageurl.TypeComposer, string(PhpComposerPkg), string(PHP):
+	case packageurl.TypeComposer, string(PhpComposerPkg), string(PHP), purlDrupalPkgType, purlWordpressCorePkgType, purlWordpressPluginPkgType, purlWordpressThemePkgType:
 		return PHP
 	case packageurl.TypeGolang, string(GoModulePkg), string(Go):
 		return Go
@@ -82,10 +92,20 @@ func LanguageByName(name string) Language {
 		return Dotnet
 	case packageurl.TypeCocoapods, packageurl.TypeSwift, string(CocoapodsPkg):
 		return Swift
-	case packageurl.TypeConan, string(CPP):
+	case packageurl.TypeConan, purlVcpkgPkgType, string(CPP):
 		return CPP
 	case packageurl.TypeHackage, string(Haskell):
 		return Haskell
+	case purlCranPkgType, "r-package", "r":
+		return R
+	case purlJuliaPkgType, string(Julia):
+		return Julia
+	case purlCpanPkgType, string(CpanPkg), string(Perl):
+		return Perl
+	case purlLuaRocksPkgType, string(LuaRocksPkg), string(Lua):
+		return Lua
+	case purlOpamPkgType, string(OCaml):
+		return OCaml
 	case packageurl.TypeHex, "beam", "elixir", "erlang":
 		// should we support returning multiple languages to support this case?
 		// answer: no. We want this to definitively answer "which language does this package represent?"
@@ -58,6 +58,38 @@ func TestLanguageFromPURL(t *testing.T) {
 			purl: "pkg:conan/catch2@2.13.8",
 			want: CPP,
 		},
+		{
+			purl: "pkg:vcpkg/zlib@1.3?port_version=1&triplet=x64-linux",
+			want: CPP,
+		},
+		{
+			purl: "pkg:wordpress-plugin/akismet@5.3",
+			want: PHP,
+		},
+		{
+			purl: "pkg:drupal/views_bulk_operations@4.2.5",
+			want: PHP,
+		},
+		{
+			purl: "pkg:cran/rlang@1.1.1",
+			want: R,
+		},
+		{
+			purl: "pkg:cpan/LEONT/Module-Build@0.4224",
+			want: Perl,
+		},
+		{
+			purl: "pkg:luarocks/luasocket@3.1.0-1",
+			want: Lua,
+		},
+		{
+			purl: "pkg:opam/dune@3.10.0",
+			want: OCaml,
+		},
+		{
+			purl: "pkg:julia/JSON@0.21.4?uuid=682c06a0-de6a-54ab-a142-c8b1cf79cde6",
+			want: Julia,
+		},
 		{
 			purl: "pkg:hackage/HTTP@4000.3.16",
 			want: Haskell,
@@ -219,6 +251,10 @@ func TestLanguageByName(t *testing.T) {
 			name:     "conan",
 			language: CPP,
 		},
+		{
+			name:     "vcpkg",
+			language: CPP,
+		},
 		{
 			name:     "c++",
 			language: CPP,
@@ -231,6 +267,46 @@ func TestLanguageByName(t *testing.T) {
 			name:     "haskell",
 			language: Haskell,
 		},
+		{
+			name:     "cran",
+			language: R,
+		},
+		{
+			name:     "R-package",
+			language: R,
+		},
+		{
+			name:     "R",
+			language: R,
+		},
+		{
+			name:     "julia",
+			language: Julia,
+		},
+		{
+			name:     "cpan",
+			language: Perl,
+		},
+		{
+			name:     "perl",
+			language: Perl,
+		},
+		{
+			name:     "luarocks",
+			language: Lua,
+		},
+		{
+			name:     "lua-rocks",
+			language: Lua,
+		},
+		{
+			name:     "opam",
+			language: OCaml,
+		},
+		{
+			name:     "ocaml",
+			language: OCaml,
+		},
 	}
 
 	for _, test := range tests {
@@ -13,6 +13,9 @@ type LinuxKernelMetadata struct {
 	SwapDevice      int    `mapstructure:"swapDevice" json:"swapDevice,omitempty" cyclonedx:"swapDevice"`
 	RootDevice      int    `mapstructure:"rootDevice" json:"rootDevice,omitempty" cyclonedx:"rootDevice"`
 	VideoMode       string `mapstructure:"videoMode" json:"videoMode,omitempty" cyclonedx:"videoMode"`
+	// SecurityConfig is the value of security-relevant build options found in the kernel config (e.g.
+	// /boot/config-<version>), where "n" indicates an option that is not set
+	SecurityConfig map[string]string `mapstructure:"securityConfig" json:"securityConfig,omitempty" cyclonedx:"securityConfig"`
 }
 
 type LinuxKernelModuleMetadata struct {
@@ -26,6 +29,8 @@ type LinuxKernelModuleMetadata struct {
 	KernelVersion string                                `mapstructure:"kernelVersion" json:"kernelVersion,omitempty" cyclonedx:"kernelVersion"`
 	VersionMagic  string                                `mapstructure:"versionMagic" json:"versionMagic,omitempty" cyclonedx:"versionMagic"`
 	Parameters    map[string]LinuxKernelModuleParameter `mapstructure:"parameters" json:"parameters,omitempty" cyclonedx:"parameters"`
+	// BuiltIn indicates that the module is compiled into the kernel (from modules.builtin) rather than a loadable .ko file
+	BuiltIn bool `mapstructure:"builtIn" json:"builtIn,omitempty" cyclonedx:"builtIn"`
 }
 
 type LinuxKernelModuleParameter struct {
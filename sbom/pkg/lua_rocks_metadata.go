@@ -0,0 +1,25 @@
+package pkg
+
+import "sort"
+
+var _ FileOwner = (*LuaRocksMetadata)(nil)
+
+// LuaRocksMetadata represents all captured data for a Lua rock, from its rockspec (and the rock_manifest of an
+// installed rock).
+type LuaRocksMetadata struct {
+	Summary  string `json:"summary,omitempty"`
+	Homepage string `json:"homepage,omitempty"`
+	License  string `json:"license,omitempty"`
+	// SourceURL is the location that the rock is built from (the source.url of the rockspec)
+	SourceURL string `json:"sourceURL,omitempty"`
+	// Dependencies are the rocks required by the rock (e.g. "lua >= 5.1")
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Files are the paths of the installed files, as listed by the rock_manifest
+	Files []string `json:"files,omitempty"`
+}
+
+func (m LuaRocksMetadata) OwnedFiles() (result []string) {
+	result = append(result, m.Files...)
+	sort.Strings(result)
+	return result
+}
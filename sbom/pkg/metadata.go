@@ -10,100 +10,157 @@ type MetadataType string
 const (
 	// this is the full set of data shapes that can be represented within the pkg.Package.Metadata field
 
-	UnknownMetadataType            MetadataType = "UnknownMetadata"
-	AlpmMetadataType               MetadataType = "AlpmMetadata"
-	ApkMetadataType                MetadataType = "ApkMetadata"
-	BinaryMetadataType             MetadataType = "BinaryMetadata"
-	CocoapodsMetadataType          MetadataType = "CocoapodsMetadataType"
-	ConanLockMetadataType          MetadataType = "ConanLockMetadataType"
-	ConanMetadataType              MetadataType = "ConanMetadataType"
-	DartPubMetadataType            MetadataType = "DartPubMetadata"
-	DotnetDepsMetadataType         MetadataType = "DotnetDepsMetadata"
-	DpkgMetadataType               MetadataType = "DpkgMetadata"
-	GemMetadataType                MetadataType = "GemMetadata"
-	GolangBinMetadataType          MetadataType = "GolangBinMetadata"
-	GolangModMetadataType          MetadataType = "GolangModMetadata"
-	HackageMetadataType            MetadataType = "HackageMetadataType"
-	JavaMetadataType               MetadataType = "JavaMetadata"
-	KbPackageMetadataType          MetadataType = "KbPackageMetadata"
-	LinuxKernelMetadataType        MetadataType = "LinuxKernelMetadata"
-	LinuxKernelModuleMetadataType  MetadataType = "LinuxKernelModuleMetadata"
-	MixLockMetadataType            MetadataType = "MixLockMetadataType"
-	NixStoreMetadataType           MetadataType = "NixStoreMetadata"
-	NpmPackageJSONMetadataType     MetadataType = "NpmPackageJsonMetadata"
-	NpmPackageLockJSONMetadataType MetadataType = "NpmPackageLockJsonMetadata"
-	PhpComposerJSONMetadataType    MetadataType = "PhpComposerJsonMetadata"
-	PortageMetadataType            MetadataType = "PortageMetadata"
-	PythonPackageMetadataType      MetadataType = "PythonPackageMetadata"
-	PythonPipfileLockMetadataType  MetadataType = "PythonPipfileLockMetadata"
-	PythonRequirementsMetadataType MetadataType = "PythonRequirementsMetadata"
-	RebarLockMetadataType          MetadataType = "RebarLockMetadataType"
-	RpmMetadataType                MetadataType = "RpmMetadata"
-	RustCargoPackageMetadataType   MetadataType = "RustCargoPackageMetadata"
+	UnknownMetadataType                  MetadataType = "UnknownMetadata"
+	AlpmMetadataType                     MetadataType = "AlpmMetadata"
+	AndroidAppMetadataType               MetadataType = "AndroidAppMetadata"
+	AndroidNativeLibraryMetadataType     MetadataType = "AndroidNativeLibraryMetadata"
+	ApkMetadataType                      MetadataType = "ApkMetadata"
+	BinaryMetadataType                   MetadataType = "BinaryMetadata"
+	CocoapodsMetadataType                MetadataType = "CocoapodsMetadataType"
+	ConanLockMetadataType                MetadataType = "ConanLockMetadataType"
+	ConanMetadataType                    MetadataType = "ConanMetadataType"
+	CpanMetadataType                     MetadataType = "CpanMetadata"
+	DartPubMetadataType                  MetadataType = "DartPubMetadata"
+	DotnetDepsMetadataType               MetadataType = "DotnetDepsMetadata"
+	DotnetPortableExecutableMetadataType MetadataType = "DotnetPortableExecutableMetadata"
+	DpkgMetadataType                     MetadataType = "DpkgMetadata"
+	DrupalMetadataType                   MetadataType = "DrupalMetadata"
+	GemMetadataType                      MetadataType = "GemMetadata"
+	GemfileLockMetadataType              MetadataType = "GemfileLockMetadata"
+	GithubActionsUseMetadataType         MetadataType = "GithubActionsUseMetadata"
+	GolangBinMetadataType                MetadataType = "GolangBinMetadata"
+	GolangModMetadataType                MetadataType = "GolangModMetadata"
+	HackageMetadataType                  MetadataType = "HackageMetadataType"
+	JavaMetadataType                     MetadataType = "JavaMetadata"
+	JuliaPackageMetadataType             MetadataType = "JuliaPackageMetadata"
+	KbPackageMetadataType                MetadataType = "KbPackageMetadata"
+	LinuxKernelMetadataType              MetadataType = "LinuxKernelMetadata"
+	LinuxKernelModuleMetadataType        MetadataType = "LinuxKernelModuleMetadata"
+	LuaRocksMetadataType                 MetadataType = "LuaRocksMetadata"
+	MixLockMetadataType                  MetadataType = "MixLockMetadataType"
+	NixStoreMetadataType                 MetadataType = "NixStoreMetadata"
+	NpmPackageJSONMetadataType           MetadataType = "NpmPackageJsonMetadata"
+	NpmPackageLockJSONMetadataType       MetadataType = "NpmPackageLockJsonMetadata"
+	OpamMetadataType                     MetadataType = "OpamMetadata"
+	PhpComposerJSONMetadataType          MetadataType = "PhpComposerJsonMetadata"
+	PortageMetadataType                  MetadataType = "PortageMetadata"
+	PythonPackageMetadataType            MetadataType = "PythonPackageMetadata"
+	PythonPipfileLockMetadataType        MetadataType = "PythonPipfileLockMetadata"
+	PythonPoetryLockMetadataType         MetadataType = "PythonPoetryLockMetadata"
+	PythonLockfileMetadataType           MetadataType = "PythonLockfileMetadata"
+	PythonRequirementsMetadataType       MetadataType = "PythonRequirementsMetadata"
+	RPackageMetadataType                 MetadataType = "RPackageMetadata"
+	RebarLockMetadataType                MetadataType = "RebarLockMetadataType"
+	RpmMetadataType                      MetadataType = "RpmMetadata"
+	RustCargoPackageMetadataType         MetadataType = "RustCargoPackageMetadata"
+	SwiftPackageManagerMetadataType      MetadataType = "SwiftPackageManagerMetadata"
+	TerraformLockProviderMetadataType    MetadataType = "TerraformLockProviderMetadata"
+	TerraformProviderBinaryMetadataType  MetadataType = "TerraformProviderBinaryMetadata"
+	VcpkgInstalledMetadataType           MetadataType = "VcpkgInstalledMetadata"
+	VcpkgManifestMetadataType            MetadataType = "VcpkgManifestMetadata"
+	WordpressMetadataType                MetadataType = "WordpressMetadata"
 )
 
 var AllMetadataTypes = []MetadataType{
 	AlpmMetadataType,
+	AndroidAppMetadataType,
+	AndroidNativeLibraryMetadataType,
 	ApkMetadataType,
 	BinaryMetadataType,
 	CocoapodsMetadataType,
 	ConanLockMetadataType,
 	ConanMetadataType,
+	CpanMetadataType,
 	DartPubMetadataType,
 	DotnetDepsMetadataType,
+	DotnetPortableExecutableMetadataType,
 	DpkgMetadataType,
+	DrupalMetadataType,
 	GemMetadataType,
+	GemfileLockMetadataType,
+	GithubActionsUseMetadataType,
 	GolangBinMetadataType,
 	GolangModMetadataType,
 	HackageMetadataType,
 	JavaMetadataType,
+	JuliaPackageMetadataType,
 	KbPackageMetadataType,
 	LinuxKernelMetadataType,
 	LinuxKernelModuleMetadataType,
+	LuaRocksMetadataType,
 	MixLockMetadataType,
 	NixStoreMetadataType,
 	NpmPackageJSONMetadataType,
 	NpmPackageLockJSONMetadataType,
+	OpamMetadataType,
 	PhpComposerJSONMetadataType,
 	PortageMetadataType,
 	PythonPackageMetadataType,
 	PythonPipfileLockMetadataType,
+	PythonPoetryLockMetadataType,
+	PythonLockfileMetadataType,
 	PythonRequirementsMetadataType,
+	RPackageMetadataType,
 	RebarLockMetadataType,
 	RpmMetadataType,
 	RustCargoPackageMetadataType,
+	SwiftPackageManagerMetadataType,
+	TerraformLockProviderMetadataType,
+	TerraformProviderBinaryMetadataType,
+	VcpkgInstalledMetadataType,
+	VcpkgManifestMetadataType,
+	WordpressMetadataType,
 }
 
 var MetadataTypeByName = map[MetadataType]reflect.Type{
-	AlpmMetadataType:               reflect.TypeOf(AlpmMetadata{}),
-	ApkMetadataType:                reflect.TypeOf(ApkMetadata{}),
-	BinaryMetadataType:             reflect.TypeOf(BinaryMetadata{}),
-	CocoapodsMetadataType:          reflect.TypeOf(CocoapodsMetadata{}),
-	ConanLockMetadataType:          reflect.TypeOf(ConanLockMetadata{}),
-	ConanMetadataType:              reflect.TypeOf(ConanMetadata{}),
-	DartPubMetadataType:            reflect.TypeOf(DartPubMetadata{}),
-	DotnetDepsMetadataType:         reflect.TypeOf(DotnetDepsMetadata{}),
-	DpkgMetadataType:               reflect.TypeOf(DpkgMetadata{}),
-	GemMetadataType:                reflect.TypeOf(GemMetadata{}),
-	GolangBinMetadataType:          reflect.TypeOf(GolangBinMetadata{}),
-	GolangModMetadataType:          reflect.TypeOf(GolangModMetadata{}),
-	HackageMetadataType:            reflect.TypeOf(HackageMetadata{}),
-	JavaMetadataType:               reflect.TypeOf(JavaMetadata{}),
-	KbPackageMetadataType:          reflect.TypeOf(KbPackageMetadata{}),
-	LinuxKernelMetadataType:        reflect.TypeOf(LinuxKernelMetadata{}),
-	LinuxKernelModuleMetadataType:  reflect.TypeOf(LinuxKernelModuleMetadata{}),
-	MixLockMetadataType:            reflect.TypeOf(MixLockMetadata{}),
-	NixStoreMetadataType:           reflect.TypeOf(NixStoreMetadata{}),
-	NpmPackageJSONMetadataType:     reflect.TypeOf(NpmPackageJSONMetadata{}),
-	NpmPackageLockJSONMetadataType: reflect.TypeOf(NpmPackageLockJSONMetadata{}),
-	PhpComposerJSONMetadataType:    reflect.TypeOf(PhpComposerJSONMetadata{}),
-	PortageMetadataType:            reflect.TypeOf(PortageMetadata{}),
-	PythonPackageMetadataType:      reflect.TypeOf(PythonPackageMetadata{}),
-	PythonPipfileLockMetadataType:  reflect.TypeOf(PythonPipfileLockMetadata{}),
-	PythonRequirementsMetadataType: reflect.TypeOf(PythonRequirementsMetadata{}),
-	RebarLockMetadataType:          reflect.TypeOf(RebarLockMetadata{}),
-	RpmMetadataType:                reflect.TypeOf(RpmMetadata{}),
-	RustCargoPackageMetadataType:   reflect.TypeOf(CargoPackageMetadata{}),
+	AlpmMetadataType:                     reflect.TypeOf(AlpmMetadata{}),
+	AndroidAppMetadataType:               reflect.TypeOf(AndroidAppMetadata{}),
+	AndroidNativeLibraryMetadataType:     reflect.TypeOf(AndroidNativeLibraryMetadata{}),
+	ApkMetadataType:                      reflect.TypeOf(ApkMetadata{}),
+	BinaryMetadataType:                   reflect.TypeOf(BinaryMetadata{}),
+	CocoapodsMetadataType:                reflect.TypeOf(CocoapodsMetadata{}),
+	ConanLockMetadataType:                reflect.TypeOf(ConanLockMetadata{}),
+	ConanMetadataType:                    reflect.TypeOf(ConanMetadata{}),
+	CpanMetadataType:                     reflect.TypeOf(CpanMetadata{}),
+	DartPubMetadataType:                  reflect.TypeOf(DartPubMetadata{}),
+	DotnetDepsMetadataType:               reflect.TypeOf(DotnetDepsMetadata{}),
+	DotnetPortableExecutableMetadataType: reflect.TypeOf(DotnetPortableExecutableMetadata{}),
+	DpkgMetadataType:                     reflect.TypeOf(DpkgMetadata{}),
+	DrupalMetadataType:                   reflect.TypeOf(DrupalMetadata{}),
+	GemMetadataType:                      reflect.TypeOf(GemMetadata{}),
+	GemfileLockMetadataType:              reflect.TypeOf(GemfileLockMetadata{}),
+	GithubActionsUseMetadataType:         reflect.TypeOf(GithubActionsUseMetadata{}),
+	GolangBinMetadataType:                reflect.TypeOf(GolangBinMetadata{}),
+	GolangModMetadataType:                reflect.TypeOf(GolangModMetadata{}),
+	HackageMetadataType:                  reflect.TypeOf(HackageMetadata{}),
+	JavaMetadataType:                     reflect.TypeOf(JavaMetadata{}),
+	JuliaPackageMetadataType:             reflect.TypeOf(JuliaPackageMetadata{}),
+	KbPackageMetadataType:                reflect.TypeOf(KbPackageMetadata{}),
+	LinuxKernelMetadataType:              reflect.TypeOf(LinuxKernelMetadata{}),
+	LinuxKernelModuleMetadataType:        reflect.TypeOf(LinuxKernelModuleMetadata{}),
+	LuaRocksMetadataType:                 reflect.TypeOf(LuaRocksMetadata{}),
+	MixLockMetadataType:                  reflect.TypeOf(MixLockMetadata{}),
+	NixStoreMetadataType:                 reflect.TypeOf(NixStoreMetadata{}),
+	NpmPackageJSONMetadataType:           reflect.TypeOf(NpmPackageJSONMetadata{}),
+	NpmPackageLockJSONMetadataType:       reflect.TypeOf(NpmPackageLockJSONMetadata{}),
+	OpamMetadataType:                     reflect.TypeOf(OpamMetadata{}),
+	PhpComposerJSONMetadataType:          reflect.TypeOf(PhpComposerJSONMetadata{}),
+	PortageMetadataType:                  reflect.TypeOf(PortageMetadata{}),
+	PythonPackageMetadataType:            reflect.TypeOf(PythonPackageMetadata{}),
+	PythonPipfileLockMetadataType:        reflect.TypeOf(PythonPipfileLockMetadata{}),
+	PythonPoetryLockMetadataType:         reflect.TypeOf(PythonPoetryLockMetadata{}),
+	PythonLockfileMetadataType:           reflect.TypeOf(PythonLockfileMetadata{}),
+	PythonRequirementsMetadataType:       reflect.TypeOf(PythonRequirementsMetadata{}),
+	RPackageMetadataType:                 reflect.TypeOf(RPackageMetadata{}),
+	RebarLockMetadataType:                reflect.TypeOf(RebarLockMetadata{}),
+	RpmMetadataType:                      reflect.TypeOf(RpmMetadata{}),
+	RustCargoPackageMetadataType:         reflect.TypeOf(CargoPackageMetadata{}),
+	SwiftPackageManagerMetadataType:      reflect.TypeOf(SwiftPackageManagerMetadata{}),
+	TerraformLockProviderMetadataType:    reflect.TypeOf(TerraformLockProviderMetadata{}),
+	TerraformProviderBinaryMetadataType:  reflect.TypeOf(TerraformProviderBinaryMetadata{}),
+	VcpkgInstalledMetadataType:           reflect.TypeOf(VcpkgInstalledMetadata{}),
+	VcpkgManifestMetadataType:            reflect.TypeOf(VcpkgManifestMetadata{}),
+	WordpressMetadataType:                reflect.TypeOf(WordpressMetadata{}),
 }
 
 func CleanMetadataType(typ MetadataType) MetadataType {
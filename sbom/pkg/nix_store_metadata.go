@@ -16,6 +16,15 @@ type NixStoreMetadata struct {
 
 	// Files is a listing a files that are under the nix/store path for this package
 	Files []string `mapstructure:"files" json:"files"`
+
+	// Deriver is the store path of the derivation (.drv) that produced this output (from the nix database or the derivation itself).
+	Deriver string `mapstructure:"deriver" json:"deriver,omitempty"`
+
+	// NarHash is the hash of the NAR serialization of the store path, as recorded in the nix database (e.g. "sha256:...").
+	NarHash string `mapstructure:"narHash" json:"narHash,omitempty"`
+
+	// Signatures are the signatures of the store path, as recorded in the nix database (e.g. "cache.nixos.org-1:...").
+	Signatures []string `mapstructure:"signatures" json:"signatures,omitempty"`
 }
 
 func (m NixStoreMetadata) OwnedFiles() (result []string) {
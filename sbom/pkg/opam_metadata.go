@@ -0,0 +1,30 @@
+package pkg
+
+import "sort"
+
+var _ FileOwner = (*OpamMetadata)(nil)
+
+// OpamMetadata represents all captured data for an OCaml package, from the opam file of a package installed within an
+// opam switch or from a dependency pinned within an opam lock file.
+type OpamMetadata struct {
+	Synopsis string   `json:"synopsis,omitempty"`
+	Homepage string   `json:"homepage,omitempty"`
+	Licenses []string `json:"licenses,omitempty"`
+	// URL is the source archive of the package (the url.src field) and Checksums are the checksums of the archive
+	URL       string   `json:"url,omitempty"`
+	Checksums []string `json:"checksums,omitempty"`
+	// Depends are the names of the packages that the package depends on
+	Depends []string `json:"depends,omitempty"`
+	// Root is true when the package was explicitly requested to be installed within the switch
+	Root bool `json:"root,omitempty"`
+	// Pinned is true when the package is pinned within the switch
+	Pinned bool `json:"pinned,omitempty"`
+	// Files are the paths of the installed files, as recorded within the switch
+	Files []string `json:"files,omitempty"`
+}
+
+func (m OpamMetadata) OwnedFiles() (result []string) {
+	result = append(result, m.Files...)
+	sort.Strings(result)
+	return result
+}
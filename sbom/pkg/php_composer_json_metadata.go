@@ -1,5 +1,9 @@
 package pkg
 
+import "sort"
+
+var _ FileOwner = (*PhpComposerJSONMetadata)(nil)
+
 // PhpComposerJSONMetadata represents information found from composer v1/v2 "installed.json" files as well as composer.lock files
 type PhpComposerJSONMetadata struct {
 	Name            string                       `json:"name"`
@@ -19,6 +23,18 @@ type PhpComposerJSONMetadata struct {
 	Homepage        string                       `json:"homepage,omitempty"`
 	Keywords        []string                     `json:"keywords,omitempty"`
 	Time            string                       `json:"time,omitempty"`
+	// Scope is "prod" or "dev", depending on whether the package is only installed for development (when known)
+	Scope string `json:"scope,omitempty"`
+	// InstallPath is the path of the installed package relative to the vendor/composer directory (installed.json only)
+	InstallPath string `json:"install-path,omitempty"`
+	// Files are the paths of the files found under the install path of the package
+	Files []string `json:"files,omitempty"`
+}
+
+func (m PhpComposerJSONMetadata) OwnedFiles() (result []string) {
+	result = append(result, m.Files...)
+	sort.Strings(result)
+	return result
 }
 
 type PhpComposerExternalReference struct {
@@ -0,0 +1,10 @@
+package pkg
+
+// PythonLockfileMetadata represents all captured data for a package entry within a pdm.lock or uv.lock file.
+type PythonLockfileMetadata struct {
+	Index        string   `json:"index,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	Hashes       []string `json:"hashes,omitempty"`
+	Markers      string   `json:"markers,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
@@ -39,6 +39,8 @@ type PythonPackageMetadata struct {
 	SitePackagesRootPath string                     `json:"sitePackagesRootPath"`
 	TopLevelPackages     []string                   `json:"topLevelPackages,omitempty"`
 	DirectURLOrigin      *PythonDirectURLOriginInfo `json:"directUrlOrigin,omitempty"`
+	RequiresDist         []string                   `json:"requiresDist,omitempty"`
+	ProvidesExtra        []string                   `json:"providesExtra,omitempty"`
 }
 
 type DirectURLOrigin struct {
@@ -1,6 +1,8 @@
 package pkg
 
 type PythonPipfileLockMetadata struct {
-	Hashes []string `mapstructure:"hashes" json:"hashes"`
-	Index  string   `mapstructure:"index" json:"index"`
+	Hashes  []string `mapstructure:"hashes" json:"hashes"`
+	Index   string   `mapstructure:"index" json:"index"`
+	Markers string   `mapstructure:"markers" json:"markers,omitempty"`
+	Extras  []string `mapstructure:"extras" json:"extras,omitempty"`
 }
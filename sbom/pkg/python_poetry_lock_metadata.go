@@ -0,0 +1,25 @@
+package pkg
+
+// PythonPoetryLockMetadata represents all captured data for a package entry within a poetry.lock file.
+type PythonPoetryLockMetadata struct {
+	Index        string                            `json:"index,omitempty"`
+	Markers      string                            `json:"markers,omitempty"`
+	Dependencies []PythonPoetryLockDependencyEntry `json:"dependencies,omitempty"`
+	Extras       []PythonPoetryLockExtraEntry      `json:"extras,omitempty"`
+}
+
+// PythonPoetryLockDependencyEntry represents a single dependency declared by a poetry.lock package entry.
+type PythonPoetryLockDependencyEntry struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version"`
+	Optional bool     `json:"optional"`
+	Markers  string   `json:"markers,omitempty"`
+	Extras   []string `json:"extras,omitempty"`
+}
+
+// PythonPoetryLockExtraEntry represents an extra declared by a poetry.lock package entry, along with the
+// requirements that it brings in.
+type PythonPoetryLockExtraEntry struct {
+	Name         string   `json:"name"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
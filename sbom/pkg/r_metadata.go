@@ -0,0 +1,26 @@
+package pkg
+
+// RPackageMetadata represents all captured data for an R package, from the DESCRIPTION file of an installed package or
+// from an entry within an renv.lock file.
+type RPackageMetadata struct {
+	Title      string   `json:"title,omitempty"`
+	Author     string   `json:"author,omitempty"`
+	Maintainer string   `json:"maintainer,omitempty"`
+	URL        []string `json:"url,omitempty"`
+	// Repository is the repository that the package was obtained from (e.g. "CRAN" or "Bioconductor")
+	Repository string `json:"repository,omitempty"`
+	// Source is the type of source that renv installed the package from (e.g. "Repository", "GitHub" or "Bioconductor")
+	Source string `json:"source,omitempty"`
+	// Hash is the hash that renv records for the package DESCRIPTION
+	Hash string `json:"hash,omitempty"`
+	// Built describes the R version and platform that an installed package was built with
+	Built            string `json:"built,omitempty"`
+	NeedsCompilation bool   `json:"needsCompilation,omitempty"`
+	// Depends, Imports and LinkingTo are the package requirements (e.g. "rlang (>= 1.0.0)")
+	Depends   []string `json:"depends,omitempty"`
+	Imports   []string `json:"imports,omitempty"`
+	LinkingTo []string `json:"linkingTo,omitempty"`
+	Suggests  []string `json:"suggests,omitempty"`
+	// Requirements are the names of the packages required by an renv.lock entry (as recorded by renv 1.0 and later)
+	Requirements []string `json:"requirements,omitempty"`
+}
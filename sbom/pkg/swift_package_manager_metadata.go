@@ -0,0 +1,10 @@
+package pkg
+
+// SwiftPackageManagerMetadata represents all captured data for a package pinned within a SwiftPM Package.resolved file.
+type SwiftPackageManagerMetadata struct {
+	RepositoryURL string `mapstructure:"repositoryURL" json:"repositoryURL,omitempty"`
+	Revision      string `mapstructure:"revision" json:"revision,omitempty"`
+	Branch        string `mapstructure:"branch" json:"branch,omitempty"`
+	// Direct is true when the package is declared as a dependency within the Package.swift manifest
+	Direct bool `mapstructure:"direct" json:"direct,omitempty"`
+}
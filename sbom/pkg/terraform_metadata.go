@@ -0,0 +1,19 @@
+package pkg
+
+// TerraformLockProviderMetadata represents all captured data for a provider locked within a .terraform.lock.hcl file.
+type TerraformLockProviderMetadata struct {
+	// Source is the fully qualified address of the provider (e.g. registry.terraform.io/hashicorp/aws)
+	Source      string `json:"source"`
+	Constraints string `json:"constraints,omitempty"`
+	// Hashes are the checksums of the provider packages that have been verified (e.g. "h1:..." or "zh:...")
+	Hashes []string `json:"hashes,omitempty"`
+}
+
+// TerraformProviderBinaryMetadata represents all captured data for a provider plugin installed by terraform init
+// (e.g. within .terraform/providers or a plugin cache directory).
+type TerraformProviderBinaryMetadata struct {
+	// Source is the fully qualified address of the provider (e.g. registry.terraform.io/hashicorp/aws)
+	Source string `json:"source"`
+	// Platform is the operating system and architecture the provider was built for (e.g. linux_amd64)
+	Platform string `json:"platform"`
+}
@@ -11,58 +11,88 @@ const (
 	// the full set of supported packages
 	UnknownPkg            Type = "UnknownPackage"
 	AlpmPkg               Type = "alpm"
+	AndroidPkg            Type = "android"
 	ApkPkg                Type = "apk"
 	BinaryPkg             Type = "binary"
 	CocoapodsPkg          Type = "pod"
 	ConanPkg              Type = "conan"
+	CpanPkg               Type = "cpan"
 	DartPubPkg            Type = "dart-pub"
 	DebPkg                Type = "deb"
 	DotnetPkg             Type = "dotnet"
+	DrupalPkg             Type = "drupal"
 	GemPkg                Type = "gem"
+	GithubActionPkg       Type = "github-action"
 	GoModulePkg           Type = "go-module"
 	GraalVMNativeImagePkg Type = "graalvm-native-image"
 	HackagePkg            Type = "hackage"
 	HexPkg                Type = "hex"
 	JavaPkg               Type = "java-archive"
 	JenkinsPluginPkg      Type = "jenkins-plugin"
+	JuliaPkg              Type = "julia"
 	KbPkg                 Type = "msrc-kb"
 	LinuxKernelPkg        Type = "linux-kernel"
 	LinuxKernelModulePkg  Type = "linux-kernel-module"
+	LuaRocksPkg           Type = "lua-rocks"
 	NixPkg                Type = "nix"
 	NpmPkg                Type = "npm"
+	OpamPkg               Type = "opam"
 	PhpComposerPkg        Type = "php-composer"
+	PhpPlatformPkg        Type = "php-platform"
 	PortagePkg            Type = "portage"
 	PythonPkg             Type = "python"
+	RPkg                  Type = "R-package"
 	RpmPkg                Type = "rpm"
 	RustPkg               Type = "rust-crate"
+	SwiftPkg              Type = "swift"
+	TerraformPkg          Type = "terraform"
+	VcpkgPkg              Type = "vcpkg"
+	WordpressCorePkg      Type = "wordpress-core"
+	WordpressPluginPkg    Type = "wordpress-plugin"
+	WordpressThemePkg     Type = "wordpress-theme"
 )
 
 // AllPkgs represents all supported package types
 var AllPkgs = []Type{
 	AlpmPkg,
+	AndroidPkg,
 	ApkPkg,
 	BinaryPkg,
 	CocoapodsPkg,
 	ConanPkg,
+	CpanPkg,
 	DartPubPkg,
 	DebPkg,
 	DotnetPkg,
+	DrupalPkg,
 	GemPkg,
+	GithubActionPkg,
 	GoModulePkg,
 	HackagePkg,
 	HexPkg,
 	JavaPkg,
 	JenkinsPluginPkg,
+	JuliaPkg,
 	KbPkg,
 	LinuxKernelPkg,
 	LinuxKernelModulePkg,
+	LuaRocksPkg,
 	NixPkg,
 	NpmPkg,
+	OpamPkg,
 	PhpComposerPkg,
+	PhpPlatformPkg,
 	PortagePkg,
 	PythonPkg,
+	RPkg,
 	RpmPkg,
 	RustPkg,
+	SwiftPkg,
+	TerraformPkg,
+	VcpkgPkg,
+	WordpressCorePkg,
+	WordpressPluginPkg,
+	WordpressThemePkg,
 }
 
 // PackageURLType returns the PURL package type for the current package.
@@ -76,6 +106,8 @@ func (t Type) PackageURLType() string {
 		return packageurl.TypeCocoapods
 	case ConanPkg:
 		return packageurl.TypeConan
+	case CpanPkg:
+		return purlCpanPkgType
 	case DartPubPkg:
 		return packageurl.TypePub
 	case DebPkg:
@@ -84,6 +116,8 @@ func (t Type) PackageURLType() string {
 		return packageurl.TypeDotnet
 	case GemPkg:
 		return packageurl.TypeGem
+	case GithubActionPkg:
+		return purlGithubActionsPkgType
 	case HexPkg:
 		return packageurl.TypeHex
 	case GoModulePkg:
@@ -92,10 +126,14 @@ func (t Type) PackageURLType() string {
 		return packageurl.TypeHackage
 	case JavaPkg, JenkinsPluginPkg:
 		return packageurl.TypeMaven
+	case JuliaPkg:
+		return purlJuliaPkgType
 	case LinuxKernelPkg:
 		return "generic/linux-kernel"
 	case LinuxKernelModulePkg:
 		return packageurl.TypeGeneric
+	case LuaRocksPkg:
+		return purlLuaRocksPkgType
 	case PhpComposerPkg:
 		return packageurl.TypeComposer
 	case PythonPkg:
@@ -106,10 +144,28 @@ func (t Type) PackageURLType() string {
 		return "nix"
 	case NpmPkg:
 		return packageurl.TypeNPM
+	case OpamPkg:
+		return purlOpamPkgType
+	case RPkg:
+		return purlCranPkgType
 	case RpmPkg:
 		return packageurl.TypeRPM
 	case RustPkg:
 		return "cargo"
+	case SwiftPkg:
+		return packageurl.TypeSwift
+	case TerraformPkg:
+		return purlTerraformPkgType
+	case VcpkgPkg:
+		return purlVcpkgPkgType
+	case DrupalPkg:
+		return purlDrupalPkgType
+	case WordpressCorePkg:
+		return purlWordpressCorePkgType
+	case WordpressPluginPkg:
+		return purlWordpressPluginPkgType
+	case WordpressThemePkg:
+		return purlWordpressThemePkgType
 	default:
 		// TODO: should this be a "generic" purl type instead?
 		return ""
@@ -151,6 +207,8 @@ func TypeByName(name string) Type {
 		return PythonPkg
 	case packageurl.TypeGem:
 		return GemPkg
+	case purlGithubActionsPkgType:
+		return GithubActionPkg
 	case "cargo", "crate":
 		return RustPkg
 	case packageurl.TypePub:
@@ -173,6 +231,30 @@ func TypeByName(name string) Type {
 		return LinuxKernelModulePkg
 	case "nix":
 		return NixPkg
+	case packageurl.TypeSwift:
+		return SwiftPkg
+	case purlTerraformPkgType:
+		return TerraformPkg
+	case purlVcpkgPkgType:
+		return VcpkgPkg
+	case purlCpanPkgType:
+		return CpanPkg
+	case purlCranPkgType:
+		return RPkg
+	case purlJuliaPkgType:
+		return JuliaPkg
+	case purlLuaRocksPkgType:
+		return LuaRocksPkg
+	case purlOpamPkgType:
+		return OpamPkg
+	case purlDrupalPkgType:
+		return DrupalPkg
+	case purlWordpressCorePkgType:
+		return WordpressCorePkg
+	case purlWordpressPluginPkgType:
+		return WordpressPluginPkg
+	case purlWordpressThemePkgType:
+		return WordpressThemePkg
 	default:
 		return UnknownPkg
 	}
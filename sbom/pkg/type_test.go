@@ -91,6 +91,58 @@ func TestTypeFromPURL(t *testing.T) {
 			purl:     "pkg:nix/glibc@2.34?hash=h0cnbmfcn93xm5dg2x27ixhag1cwndga",
 			expected: NixPkg,
 		},
+		{
+			purl:     "pkg:swift/github.com/apple/swift-numerics@1.0.2",
+			expected: SwiftPkg,
+		},
+		{
+			purl:     "pkg:githubactions/actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11",
+			expected: GithubActionPkg,
+		},
+		{
+			purl:     "pkg:terraform/hashicorp/aws@5.31.0",
+			expected: TerraformPkg,
+		},
+		{
+			purl:     "pkg:drupal/views_bulk_operations@4.2.5",
+			expected: DrupalPkg,
+		},
+		{
+			purl:     "pkg:cran/rlang@1.1.1",
+			expected: RPkg,
+		},
+		{
+			purl:     "pkg:cpan/LEONT/Module-Build@0.4224",
+			expected: CpanPkg,
+		},
+		{
+			purl:     "pkg:luarocks/luasocket@3.1.0-1",
+			expected: LuaRocksPkg,
+		},
+		{
+			purl:     "pkg:opam/dune@3.10.0",
+			expected: OpamPkg,
+		},
+		{
+			purl:     "pkg:julia/JSON@0.21.4?uuid=682c06a0-de6a-54ab-a142-c8b1cf79cde6",
+			expected: JuliaPkg,
+		},
+		{
+			purl:     "pkg:wordpress-core/wordpress@6.4.2",
+			expected: WordpressCorePkg,
+		},
+		{
+			purl:     "pkg:wordpress-plugin/akismet@5.3",
+			expected: WordpressPluginPkg,
+		},
+		{
+			purl:     "pkg:wordpress-theme/twentytwentyfour@1.0",
+			expected: WordpressThemePkg,
+		},
+		{
+			purl:     "pkg:vcpkg/zlib@1.3?port_version=1&triplet=x64-linux",
+			expected: VcpkgPkg,
+		},
 	}
 
 	var pkgTypes []string
@@ -106,6 +158,8 @@ func TestTypeFromPURL(t *testing.T) {
 	expectedTypes.Remove(string(PortagePkg))
 	expectedTypes.Remove(string(BinaryPkg))
 	expectedTypes.Remove(string(LinuxKernelModulePkg))
+	expectedTypes.Remove(string(AndroidPkg))
+	expectedTypes.Remove(string(PhpPlatformPkg))
 
 	for _, test := range tests {
 		t.Run(string(test.expected), func(t *testing.T) {
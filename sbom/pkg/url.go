@@ -9,16 +9,30 @@ import (
 )
 
 const (
-	PURLQualifierArch   = "arch"
-	PURLQualifierDistro = "distro"
-	PURLQualifierEpoch  = "epoch"
-	PURLQualifierVCSURL = "vcs_url"
+	PURLQualifierArch          = "arch"
+	PURLQualifierDistro        = "distro"
+	PURLQualifierDownloadURL   = "download_url"
+	PURLQualifierEpoch         = "epoch"
+	PURLQualifierRepositoryURL = "repository_url"
+	PURLQualifierVCSURL        = "vcs_url"
 
 	// PURLQualifierUpstream this qualifier is not in the pURL spec, but is used by grype to perform indirect matching based on source information
 	PURLQualifierUpstream = "upstream"
 
-	purlCargoPkgType  = "cargo"
-	purlGradlePkgType = "gradle"
+	purlCargoPkgType           = "cargo"
+	purlCpanPkgType            = "cpan"
+	purlCranPkgType            = "cran"
+	purlDrupalPkgType          = "drupal"
+	purlGithubActionsPkgType   = "githubactions"
+	purlGradlePkgType          = "gradle"
+	purlJuliaPkgType           = "julia"
+	purlLuaRocksPkgType        = "luarocks"
+	purlOpamPkgType            = "opam"
+	purlTerraformPkgType       = "terraform"
+	purlVcpkgPkgType           = "vcpkg"
+	purlWordpressCorePkgType   = "wordpress-core"
+	purlWordpressPluginPkgType = "wordpress-plugin"
+	purlWordpressThemePkgType  = "wordpress-theme"
 )
 
 func PURLQualifiers(vars map[string]string, release *linux.Release) (q packageurl.Qualifiers) {
@@ -0,0 +1,35 @@
+package pkg
+
+import "sort"
+
+var _ FileOwner = (*VcpkgInstalledMetadata)(nil)
+
+// VcpkgManifestMetadata represents all captured data for a dependency declared within a vcpkg.json manifest.
+type VcpkgManifestMetadata struct {
+	// VersionConstraint is the minimum version requested for the dependency (the "version>=" field)
+	VersionConstraint string   `json:"versionConstraint,omitempty"`
+	Features          []string `json:"features,omitempty"`
+	Platform          string   `json:"platform,omitempty"`
+	// Host is true when the dependency is built for the host (e.g. a build tool) rather than the target triplet
+	Host bool `json:"host,omitempty"`
+	// Registry is the repository of the registry the port is resolved from, as configured by vcpkg-configuration.json
+	Registry string `json:"registry,omitempty"`
+	// Baseline is the registry commit that versions are resolved against when not overridden
+	Baseline string `json:"baseline,omitempty"`
+}
+
+// VcpkgInstalledMetadata represents all captured data for a port installed within a vcpkg status database.
+type VcpkgInstalledMetadata struct {
+	PortVersion  int      `json:"portVersion,omitempty"`
+	Triplet      string   `json:"triplet"`
+	Abi          string   `json:"abi,omitempty"`
+	Features     []string `json:"features,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"`
+	Files        []string `json:"files,omitempty"`
+}
+
+func (m VcpkgInstalledMetadata) OwnedFiles() (result []string) {
+	result = append(result, m.Files...)
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,19 @@
+package pkg
+
+// WordpressMetadata represents all captured data for a WordPress core installation, plugin or theme, from the file
+// header of the plugin or theme (or wp-includes/version.php for core).
+type WordpressMetadata struct {
+	// DisplayName is the "Plugin Name" or "Theme Name" header
+	DisplayName string `json:"displayName,omitempty"`
+	Author      string `json:"author,omitempty"`
+	// URI is the "Plugin URI" or "Theme URI" header
+	URI     string `json:"uri,omitempty"`
+	License string `json:"license,omitempty"`
+	// RequiresWordpress is the minimum WordPress version (the "Requires at least" header)
+	RequiresWordpress string `json:"requiresWordpress,omitempty"`
+	RequiresPHP       string `json:"requiresPHP,omitempty"`
+	// Template is the parent theme of a child theme
+	Template string `json:"template,omitempty"`
+	// StableTag is the "Stable tag" of the readme.txt, which is used as the version when no version header is present
+	StableTag string `json:"stableTag,omitempty"`
+}
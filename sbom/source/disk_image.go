@@ -0,0 +1,131 @@
+package source
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/source/internal/disk"
+)
+
+// DiskImageMetadata represents all static metadata that describes a virtual machine disk image and the partition
+// within it that was cataloged.
+type DiskImageMetadata struct {
+	UserInput      string            `json:"userInput"`
+	Format         string            `json:"format"`
+	Size           int64             `json:"size"`
+	PartitionTable string            `json:"partitionTable"`
+	Partition      PartitionMetadata `json:"partition"`
+}
+
+// PartitionMetadata describes a single partition of a disk image (and the filesystem found within it).
+type PartitionMetadata struct {
+	Number     int    `json:"number"`
+	Type       string `json:"type"`
+	Name       string `json:"name,omitempty"`
+	UUID       string `json:"uuid,omitempty"`
+	Start      int64  `json:"start"`
+	Size       int64  `json:"size"`
+	Filesystem string `json:"filesystem"`
+}
+
+// paths that indicate a partition holds the root filesystem of an operating system (and not /boot, /home, etc.)
+var osReleasePaths = []string{
+	"etc/os-release",
+	"usr/lib/os-release",
+}
+
+// NewFromDiskImage creates a new source object tailored to catalog the operating system within a virtual machine disk
+// image (raw, qcow2, or vmdk). All contents are read directly from the image, which remains open until the provided
+// cleanup function is called.
+func NewFromDiskImage(path string) (Source, func(), error) {
+	return NewFromDiskImageWithName(path, "")
+}
+
+// NewFromDiskImageWithName creates a new source object tailored to catalog the operating system within a virtual
+// machine disk image, with an explicitly provided name.
+func NewFromDiskImageWithName(path string, name string) (Source, func(), error) {
+	img, err := disk.Open(path)
+	if err != nil {
+		return Source{}, func() {}, err
+	}
+
+	table, partitions, err := disk.ReadPartitions(img, img.Size())
+	if err != nil {
+		img.Close()
+		return Source{}, func() {}, fmt.Errorf("unable to read partitions of disk image=%q: %w", path, err)
+	}
+
+	partition, fsys, err := selectDiskPartition(img, partitions)
+	if err != nil {
+		img.Close()
+		return Source{}, func() {}, fmt.Errorf("unable to select partition of disk image=%q: %w", path, err)
+	}
+
+	log.WithFields("partition", partition.Number, "filesystem", fsys.Type).Debug("reading disk image partition")
+
+	cleanupFn := func() {
+		if err := img.Close(); err != nil {
+			log.Warnf("unable to close disk image: %+v", err)
+		}
+	}
+
+	s := Source{
+		mutex: &sync.Mutex{},
+		Metadata: Metadata{
+			Name:   name,
+			Scheme: DiskImageScheme,
+			Path:   path,
+			DiskImageMetadata: DiskImageMetadata{
+				UserInput:      path,
+				Format:         img.Format(),
+				Size:           img.Size(),
+				PartitionTable: table,
+				Partition: PartitionMetadata{
+					Number:     partition.Number,
+					Type:       partition.Type,
+					Name:       partition.Name,
+					UUID:       partition.UUID,
+					Start:      partition.Start,
+					Size:       partition.Size,
+					Filesystem: fsys.Type,
+				},
+			},
+		},
+		diskFilesystem: fsys,
+	}
+	s.SetID()
+	return s, cleanupFn, nil
+}
+
+// selectDiskPartition returns the partition most likely to hold the root filesystem: the first readable partition with
+// an os-release file, otherwise the largest readable partition.
+func selectDiskPartition(img disk.Image, partitions []disk.Partition) (disk.Partition, *disk.Filesystem, error) {
+	var largest *disk.Partition
+	var largestFS *disk.Filesystem
+	for i := range partitions {
+		partition := partitions[i]
+		fsys, err := disk.OpenFilesystem(partition.Reader(img), partition.Size)
+		if err != nil {
+			log.WithFields("partition", partition.Number, "type", partition.Type).Tracef("skipping disk image partition: %v", err)
+			continue
+		}
+
+		for _, p := range osReleasePaths {
+			if _, err := fsys.Stat(p); err == nil {
+				return partition, fsys, nil
+			}
+		}
+
+		if largest == nil || partition.Size > largest.Size {
+			largest = &partitions[i]
+			largestFS = fsys
+		}
+	}
+
+	if largest == nil {
+		return disk.Partition{}, nil, fmt.Errorf("no partitions with a supported filesystem (%s, %s, or %s) found",
+			disk.Ext4Filesystem, disk.XFSFilesystem, disk.VFATFilesystem)
+	}
+	return *largest, largestFS, nil
+}
@@ -0,0 +1,309 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/nextlinux/stereoscope/pkg/file"
+	"github.com/nextlinux/stereoscope/pkg/filetree"
+	"github.com/nextlinux/sbom/internal/log"
+	"github.com/nextlinux/sbom/sbom/source/internal/disk"
+)
+
+var _ FileResolver = (*diskImageResolver)(nil)
+
+// diskImageResolver implements path and content access for a filesystem within a disk image. All contents are read
+// directly from the image (nothing is extracted to the host) and all paths are relative to the root of the filesystem.
+type diskImageResolver struct {
+	name          string
+	fsys          *disk.Filesystem
+	tree          filetree.Reader
+	index         filetree.IndexReader
+	searchContext filetree.Searcher
+}
+
+func newDiskImageResolver(name string, fsys *disk.Filesystem, exclusions []string) (*diskImageResolver, error) {
+	// exclusions are relative to the root of the filesystem, the same as for a directory source
+	exclusionFunctions, err := getDirectoryExclusionFunctions("/", exclusions)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, index, err := indexDiskFilesystem(name, fsys, exclusionFunctions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &diskImageResolver{
+		name:          name,
+		fsys:          fsys,
+		tree:          tree,
+		index:         index,
+		searchContext: filetree.NewSearchContext(tree, index),
+	}, nil
+}
+
+// indexDiskFilesystem walks the entire filesystem, adding all directories, regular files, and symlinks to a new file
+// tree (special files are skipped). Unreadable entries are logged and skipped since images may be corrupt.
+func indexDiskFilesystem(name string, fsys *disk.Filesystem, visitors []pathIndexVisitor) (filetree.Reader, filetree.IndexReader, error) {
+	tree := filetree.New()
+	index := filetree.NewIndex()
+
+	stager, prog := indexingProgress(name)
+	defer prog.SetCompleted()
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			log.WithFields("path", p).Warnf("unable to read disk image path: %+v", err)
+			return nil
+		}
+		if p == "." {
+			return nil
+		}
+
+		realPath := path.Join("/", p)
+		stager.Current = realPath
+
+		info, err := d.Info()
+		if err != nil {
+			log.WithFields("path", realPath).Warnf("unable to read disk image path: %+v", err)
+			return nil
+		}
+
+		for _, visitor := range visitors {
+			if err := visitor(realPath, info, nil); err != nil {
+				if errors.Is(err, fs.SkipDir) {
+					return fs.SkipDir
+				}
+				return nil
+			}
+		}
+
+		metadata := file.Metadata{
+			Path:  realPath,
+			Type:  file.TypeFromMode(info.Mode()),
+			IsDir: info.IsDir(),
+			Mode:  info.Mode(),
+			Size:  info.Size(),
+		}
+
+		var ref *file.Reference
+		switch metadata.Type {
+		case file.TypeDirectory:
+			ref, err = tree.AddDir(file.Path(realPath))
+		case file.TypeRegular:
+			metadata.MIMEType = diskFileMIMEType(fsys, p)
+			ref, err = tree.AddFile(file.Path(realPath))
+		case file.TypeSymLink:
+			metadata.LinkDestination, err = diskLinkDestination(fsys, p)
+			if err != nil {
+				log.WithFields("path", realPath).Warnf("unable to read disk image symlink: %+v", err)
+				return nil
+			}
+			ref, err = tree.AddSymLink(file.Path(realPath), file.Path(metadata.LinkDestination))
+		default:
+			// devices, sockets, and named pipes are never cataloged
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to index disk image path=%q: %w", realPath, err)
+		}
+
+		index.Add(*ref, metadata)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tree, index, nil
+}
+
+// diskLinkDestination returns the absolute path (within the filesystem) that the given symlink refers to.
+func diskLinkDestination(fsys *disk.Filesystem, p string) (string, error) {
+	target, err := fsys.ReadLink(p)
+	if err != nil {
+		return "", err
+	}
+	if path.IsAbs(target) {
+		// absolute links are relative to the root of the filesystem (not the host)
+		return path.Clean(target), nil
+	}
+	return path.Join("/", path.Dir(p), target), nil
+}
+
+func diskFileMIMEType(fsys *disk.Filesystem, p string) string {
+	f, err := fsys.Open(p)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	return file.MIMEType(f)
+}
+
+// requestPath returns the absolute path within the filesystem for the given path. There is no meaningful working
+// directory within a disk image, so relative paths are always relative to the root of the filesystem.
+func (r diskImageResolver) requestPath(userPath string) string {
+	return path.Join("/", userPath)
+}
+
+// HasPath indicates if the given path exists in the underlying source.
+func (r *diskImageResolver) HasPath(userPath string) bool {
+	return r.tree.HasPath(file.Path(r.requestPath(userPath)))
+}
+
+// Stringer to represent a disk image data source
+func (r diskImageResolver) String() string {
+	return fmt.Sprintf("disk:%s", r.name)
+}
+
+// FilesByPath returns all file.References that match the given paths from the disk image filesystem.
+func (r diskImageResolver) FilesByPath(userPaths ...string) ([]Location, error) {
+	var references = make([]Location, 0)
+
+	for _, userPath := range userPaths {
+		requestPath := r.requestPath(userPath)
+
+		// we should be resolving symlinks and preserving this information as a VirtualPath to the real file
+		ref, err := r.searchContext.SearchByPath(requestPath, filetree.FollowBasenameLinks)
+		if err != nil {
+			log.Tracef("unable to evaluate symlink for path=%q : %+v", userPath, err)
+			continue
+		}
+
+		if !ref.HasReference() {
+			continue
+		}
+
+		entry, err := r.index.Get(*ref.Reference)
+		if err != nil {
+			log.Warnf("unable to get file by path=%q : %+v", userPath, err)
+			continue
+		}
+
+		// don't consider directories
+		if entry.Metadata.IsDir {
+			continue
+		}
+
+		references = append(references, NewVirtualLocationFromDirectory(string(ref.RealPath), requestPath, *ref.Reference))
+	}
+
+	return references, nil
+}
+
+// FilesByGlob returns all file.References that match the given path glob pattern from the disk image filesystem.
+func (r diskImageResolver) FilesByGlob(patterns ...string) ([]Location, error) {
+	uniqueFileIDs := file.NewFileReferenceSet()
+	uniqueLocations := make([]Location, 0)
+
+	for _, pattern := range patterns {
+		refVias, err := r.searchContext.SearchByGlob(pattern, filetree.FollowBasenameLinks)
+		if err != nil {
+			return nil, err
+		}
+		for _, refVia := range refVias {
+			if !refVia.HasReference() || uniqueFileIDs.Contains(*refVia.Reference) {
+				continue
+			}
+			entry, err := r.index.Get(*refVia.Reference)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get file metadata for reference %s: %w", refVia.Reference.RealPath, err)
+			}
+
+			// don't consider directories
+			if entry.Metadata.IsDir {
+				continue
+			}
+
+			loc := NewVirtualLocationFromDirectory(
+				string(refVia.Reference.RealPath), // the actual path within the filesystem
+				string(refVia.RequestPath),        // the path used to access this file within the filesystem
+				*refVia.Reference,
+			)
+			uniqueFileIDs.Add(*refVia.Reference)
+			uniqueLocations = append(uniqueLocations, loc)
+		}
+	}
+
+	return uniqueLocations, nil
+}
+
+// RelativeFileByPath fetches a single file at the given path relative to the layer squash of the given reference.
+// A disk image filesystem has no layers, so this is a simple path lookup.
+func (r *diskImageResolver) RelativeFileByPath(_ Location, path string) *Location {
+	paths, err := r.FilesByPath(path)
+	if err != nil {
+		return nil
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	return &paths[0]
+}
+
+// FileContentsByLocation fetches file contents for a single file reference, reading directly from the disk image.
+// If the path does not exist an error is returned.
+func (r diskImageResolver) FileContentsByLocation(location Location) (io.ReadCloser, error) {
+	if location.ref.RealPath == "" {
+		return nil, errors.New("empty path given")
+	}
+
+	entry, err := r.index.Get(location.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't consider directories
+	if entry.Type == file.TypeDirectory {
+		return nil, fmt.Errorf("cannot read contents of non-file %q", location.ref.RealPath)
+	}
+
+	return r.fsys.Open(strings.TrimPrefix(string(location.ref.RealPath), "/"))
+}
+
+func (r *diskImageResolver) AllLocations() <-chan Location {
+	results := make(chan Location)
+	go func() {
+		defer close(results)
+		for _, ref := range r.tree.AllFiles(file.AllTypes()...) {
+			results <- NewLocationFromDirectory(string(ref.RealPath), ref)
+		}
+	}()
+	return results
+}
+
+func (r *diskImageResolver) FileMetadataByLocation(location Location) (FileMetadata, error) {
+	entry, err := r.index.Get(location.ref)
+	if err != nil {
+		return FileMetadata{}, fmt.Errorf("location: %+v : %w", location, os.ErrNotExist)
+	}
+
+	return entry.Metadata, nil
+}
+
+func (r *diskImageResolver) FilesByMIMEType(types ...string) ([]Location, error) {
+	uniqueFileIDs := file.NewFileReferenceSet()
+	uniqueLocations := make([]Location, 0)
+
+	refVias, err := r.searchContext.SearchByMIMEType(types...)
+	if err != nil {
+		return nil, err
+	}
+	for _, refVia := range refVias {
+		if !refVia.HasReference() || uniqueFileIDs.Contains(*refVia.Reference) {
+			continue
+		}
+		location := NewLocationFromDirectory(string(refVia.Reference.RealPath), *refVia.Reference)
+		uniqueFileIDs.Add(*refVia.Reference)
+		uniqueLocations = append(uniqueLocations, location)
+	}
+
+	return uniqueLocations, nil
+}
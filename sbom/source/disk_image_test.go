@@ -0,0 +1,63 @@
+package source
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromDiskImage(t *testing.T) {
+	fixture := "internal/disk/test-fixtures/gpt-ext4.qcow2"
+
+	src, cleanup, err := NewFromDiskImage(fixture)
+	t.Cleanup(cleanup)
+	require.NoError(t, err)
+
+	assert.Equal(t, DiskImageScheme, src.Metadata.Scheme)
+	assert.Equal(t, fixture, src.Metadata.Path)
+	// contents are read from the image directly (not extracted to a path on the host)
+	assert.Empty(t, src.path)
+	assert.Equal(t, DiskImageMetadata{
+		UserInput:      fixture,
+		Format:         "qcow2",
+		Size:           4 * 1024 * 1024,
+		PartitionTable: "gpt",
+		Partition: PartitionMetadata{
+			Number:     2,
+			Type:       "Linux root (x86-64)",
+			Name:       "root",
+			UUID:       "9D5D4A4C-4F8E-4A3C-9C55-1F7D1E0A0002",
+			Start:      2048 * 512,
+			Size:       2 * 1024 * 1024,
+			Filesystem: "ext4",
+		},
+	}, src.Metadata.DiskImageMetadata)
+
+	resolver, err := src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+
+	// absolute symlinks and paths are resolved relative to the root of the partition
+	refs, err := resolver.FilesByPath("/etc/os-release")
+	require.NoError(t, err)
+	require.Len(t, refs, 1)
+	assert.Equal(t, "/usr/lib/os-release", refs[0].RealPath)
+
+	reader, err := resolver.FileContentsByLocation(refs[0])
+	require.NoError(t, err)
+	t.Cleanup(func() { reader.Close() })
+	contents, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "ID=debian")
+
+	refs, err = resolver.FilesByGlob("**/var/lib/dpkg/status")
+	require.NoError(t, err)
+	assert.Len(t, refs, 1)
+}
+
+func TestNewFromDiskImage_NotADiskImage(t *testing.T) {
+	_, cleanup, err := NewFromDiskImage("test-fixtures/mountinfo/host")
+	t.Cleanup(cleanup)
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+/*
+Package disk provides read-only access to virtual machine disk images (raw, qcow2, and vmdk), their partition tables
+(MBR and GPT), and the filesystems within partitions (ext2/3/4, xfs, and vfat) without mounting them or relying on
+any non-Go tooling.
+*/
+package disk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	RawFormat   = "raw"
+	QCOW2Format = "qcow2"
+	VMDKFormat  = "vmdk"
+)
+
+// Image is a virtual disk presented as a flat sequence of bytes, regardless of how the disk is stored on the host.
+type Image interface {
+	io.ReaderAt
+	io.Closer
+	// Size is the virtual size of the disk in bytes.
+	Size() int64
+	// Format is the on-disk format of the image (raw, qcow2, or vmdk).
+	Format() string
+}
+
+// Open returns a disk image for the given path, detecting the storage format from the file contents.
+func Open(path string) (Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open disk image=%q: %w", path, err)
+	}
+
+	magic := make([]byte, 512)
+	n, err := f.ReadAt(magic, 0)
+	if err != nil && err != io.EOF {
+		f.Close()
+		return nil, fmt.Errorf("unable to read disk image=%q: %w", path, err)
+	}
+	magic = magic[:n]
+
+	var img Image
+	switch {
+	case bytes.HasPrefix(magic, []byte(qcow2Magic)):
+		img, err = newQCOW2(f, path)
+	case bytes.HasPrefix(magic, []byte(vmdkSparseMagic)):
+		img, err = newVMDKSparse(f)
+	case bytes.Contains(magic, []byte(vmdkDescriptorMarker)):
+		f.Close()
+		return newVMDKFromDescriptor(path)
+	default:
+		img, err = newRaw(f)
+	}
+
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("unable to read disk image=%q: %w", path, err)
+	}
+	return img, nil
+}
+
+// zeroFill sets all bytes in the given buffer to zero (used for unallocated regions of sparse images).
+func zeroFill(p []byte) {
+	for i := range p {
+		p[i] = 0
+	}
+}
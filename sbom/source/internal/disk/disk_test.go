@@ -0,0 +1,115 @@
+package disk
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const osRelease = `PRETTY_NAME="Debian GNU/Linux 12 (bookworm)"
+NAME="Debian GNU/Linux"
+VERSION_ID="12"
+VERSION="12 (bookworm)"
+ID=debian
+`
+
+func openFixtureFilesystem(t *testing.T) *Filesystem {
+	t.Helper()
+
+	img, err := Open("test-fixtures/gpt-ext4.qcow2")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, img.Close())
+	})
+
+	assert.Equal(t, QCOW2Format, img.Format())
+	assert.Equal(t, int64(4*1024*1024), img.Size())
+
+	table, partitions, err := ReadPartitions(img, img.Size())
+	require.NoError(t, err)
+	assert.Equal(t, GPTPartitionTable, table)
+	require.Len(t, partitions, 2)
+
+	// the bios boot partition is not formatted
+	_, err = OpenFilesystem(partitions[0].Reader(img), partitions[0].Size)
+	assert.True(t, errors.Is(err, ErrUnsupportedFilesystem))
+
+	assert.Equal(t, "root", partitions[1].Name)
+	fsys, err := OpenFilesystem(partitions[1].Reader(img), partitions[1].Size)
+	require.NoError(t, err)
+	assert.Equal(t, Ext4Filesystem, fsys.Type)
+	return fsys
+}
+
+func TestFilesystem(t *testing.T) {
+	fsys := openFixtureFilesystem(t)
+
+	entries, err := fsys.ReadDir(".")
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.Equal(t, []string{"etc", "lost+found", "usr", "var"}, names)
+
+	// symlinks are never followed
+	info, err := fsys.Stat("etc/os-release")
+	require.NoError(t, err)
+	assert.Equal(t, fs.ModeSymlink, info.Mode().Type())
+
+	target, err := fsys.ReadLink("etc/os-release")
+	require.NoError(t, err)
+	assert.Equal(t, "../usr/lib/os-release", target)
+
+	f, err := fsys.Open("usr/lib/os-release")
+	require.NoError(t, err)
+	contents, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, osRelease, string(contents))
+
+	_, err = fsys.Open("usr/lib/missing")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+// walkPaths returns all paths within the filesystem, failing on any error.
+func walkPaths(t *testing.T, fsys *Filesystem) []string {
+	t.Helper()
+
+	var paths []string
+	require.NoError(t, fs.WalkDir(fsys, ".", func(p string, _ fs.DirEntry, err error) error {
+		paths = append(paths, p)
+		return err
+	}))
+	return paths
+}
+
+// readAll reads every path within a (possibly corrupt) filesystem, returning all paths that could be read. Errors are
+// expected, however reading must always terminate without panicking.
+func readAll(fsys *Filesystem) []string {
+	var paths []string
+	_ = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		paths = append(paths, p)
+
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			_, _ = fsys.ReadLink(p)
+		case d.Type().IsRegular():
+			f, err := fsys.Open(p)
+			if err != nil {
+				return nil
+			}
+			defer f.Close()
+			// sizes may be corrupt, so only read enough to exercise the driver
+			_, _ = io.Copy(io.Discard, io.LimitReader(f, 1024*1024))
+		}
+		return nil
+	})
+	return paths
+}
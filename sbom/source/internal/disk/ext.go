@@ -0,0 +1,503 @@
+package disk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+	"time"
+)
+
+// see https://www.kernel.org/doc/html/latest/filesystems/ext4/index.html for the on-disk format (ext2 and ext3 are
+// subsets of this format and are read the same way)
+
+const (
+	extSuperblockOffset = 1024
+	extMagic            = 0xef53
+	extRootInode        = 2
+
+	extIncompatMetaBG = 0x10
+	extIncompat64Bit  = 0x80
+
+	extExtentsFlag    = 0x80000
+	extInlineDataFlag = 0x10000000
+
+	extExtentMagic = 0xf30a
+	// extents with a length over this value are preallocated but unwritten
+	extMaxInitializedExtent = 32768
+	// the max depth of an extent tree (protects against cycles)
+	extMaxExtentDepth = 8
+
+	extFastSymlinkMaxSize = 60
+	// the max size of a symlink target (PATH_MAX) and of a directory to read into memory
+	extMaxSymlinkSize = 4096
+	extMaxDirSize     = 64 * 1024 * 1024
+
+	extXattrMagic       = 0xea020000
+	extXattrSystemIndex = 7
+)
+
+var _ driver = (*extDriver)(nil)
+
+type extDriver struct {
+	r              io.ReaderAt
+	blockSize      int64
+	inodesPerGroup uint32
+	inodeSize      int64
+	descSize       int64
+	descTableBlock int64
+	incompat       uint32
+
+	lock        sync.Mutex
+	inodeTables map[uint32]int64
+}
+
+type extInode struct {
+	number uint32
+	mode   uint16
+	size   int64
+	mtime  uint32
+	flags  uint32
+	block  []byte // the raw i_block area (extent tree root, block map, or inline data)
+	raw    []byte
+}
+
+func isExtFilesystem(r io.ReaderAt) bool {
+	magic := make([]byte, 2)
+	if _, err := r.ReadAt(magic, extSuperblockOffset+56); err != nil {
+		return false
+	}
+	return binary.LittleEndian.Uint16(magic) == extMagic
+}
+
+func newExtDriver(r io.ReaderAt) (*extDriver, error) {
+	sb := make([]byte, 1024)
+	if _, err := r.ReadAt(sb, extSuperblockOffset); err != nil {
+		return nil, fmt.Errorf("unable to read ext superblock: %w", err)
+	}
+
+	logBlockSize := binary.LittleEndian.Uint32(sb[24:28])
+	if logBlockSize > 6 {
+		return nil, fmt.Errorf("invalid ext block size: %d", logBlockSize)
+	}
+
+	d := &extDriver{
+		r:              r,
+		blockSize:      int64(1024) << logBlockSize,
+		inodesPerGroup: binary.LittleEndian.Uint32(sb[40:44]),
+		inodeSize:      128,
+		descSize:       32,
+		incompat:       binary.LittleEndian.Uint32(sb[96:100]),
+		inodeTables:    make(map[uint32]int64),
+	}
+
+	if d.inodesPerGroup == 0 {
+		return nil, fmt.Errorf("invalid ext inodes per group")
+	}
+
+	// revision 0 filesystems always have 128 byte inodes
+	if binary.LittleEndian.Uint32(sb[76:80]) > 0 {
+		d.inodeSize = int64(binary.LittleEndian.Uint16(sb[88:90]))
+		if d.inodeSize < 128 || d.inodeSize > d.blockSize || d.inodeSize&(d.inodeSize-1) != 0 {
+			return nil, fmt.Errorf("invalid ext inode size: %d", d.inodeSize)
+		}
+	}
+
+	if d.incompat&extIncompat64Bit != 0 {
+		if size := int64(binary.LittleEndian.Uint16(sb[254:256])); size >= 64 {
+			d.descSize = size
+		}
+	}
+
+	if d.incompat&extIncompatMetaBG != 0 {
+		return nil, fmt.Errorf("ext meta_bg feature is not supported")
+	}
+
+	// the group descriptor table immediately follows the superblock
+	firstDataBlock := int64(binary.LittleEndian.Uint32(sb[20:24]))
+	d.descTableBlock = firstDataBlock + 1
+
+	return d, nil
+}
+
+func (d *extDriver) root() (*node, error) {
+	in, err := d.inode(extRootInode)
+	if err != nil {
+		return nil, err
+	}
+	return d.node("", in), nil
+}
+
+func (d *extDriver) node(name string, in *extInode) *node {
+	return &node{
+		name:    name,
+		mode:    unixMode(uint32(in.mode)),
+		size:    in.size,
+		modTime: time.Unix(int64(in.mtime), 0),
+		id:      uint64(in.number),
+		ref:     in,
+	}
+}
+
+// inodeTable returns the byte offset of the inode table for the given block group.
+func (d *extDriver) inodeTable(group uint32) (int64, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if offset, ok := d.inodeTables[group]; ok {
+		return offset, nil
+	}
+
+	desc := make([]byte, d.descSize)
+	if _, err := d.r.ReadAt(desc, d.descTableBlock*d.blockSize+int64(group)*d.descSize); err != nil {
+		return 0, fmt.Errorf("unable to read ext group descriptor=%d: %w", group, err)
+	}
+
+	block := int64(binary.LittleEndian.Uint32(desc[8:12]))
+	if d.descSize >= 64 {
+		block |= int64(binary.LittleEndian.Uint32(desc[40:44])) << 32
+	}
+
+	offset := block * d.blockSize
+	d.inodeTables[group] = offset
+	return offset, nil
+}
+
+func (d *extDriver) inode(number uint32) (*extInode, error) {
+	if number == 0 {
+		return nil, fmt.Errorf("invalid ext inode number: 0")
+	}
+
+	group := (number - 1) / d.inodesPerGroup
+	index := int64((number - 1) % d.inodesPerGroup)
+
+	table, err := d.inodeTable(group)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, d.inodeSize)
+	if _, err := d.r.ReadAt(raw, table+index*d.inodeSize); err != nil {
+		return nil, fmt.Errorf("unable to read ext inode=%d: %w", number, err)
+	}
+
+	size := int64(binary.LittleEndian.Uint32(raw[4:8]))
+	if len(raw) >= 112 {
+		size |= int64(binary.LittleEndian.Uint32(raw[108:112])) << 32
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("invalid ext inode=%d size", number)
+	}
+
+	return &extInode{
+		number: number,
+		mode:   binary.LittleEndian.Uint16(raw[0:2]),
+		size:   size,
+		mtime:  binary.LittleEndian.Uint32(raw[16:20]),
+		flags:  binary.LittleEndian.Uint32(raw[32:36]),
+		block:  raw[40:100],
+		raw:    raw,
+	}, nil
+}
+
+func (d *extDriver) readDir(dir *node) ([]*node, error) {
+	in, ok := dir.ref.(*extInode)
+	if !ok {
+		return nil, fmt.Errorf("invalid ext directory reference")
+	}
+
+	var data []byte
+	if in.flags&extInlineDataFlag != 0 {
+		// inline directories start with the parent inode number (there are no "." or ".." entries)
+		data = append(in.block[4:len(in.block):len(in.block)], inlineDataXattr(in.raw)...)
+	} else {
+		if in.size > extMaxDirSize {
+			return nil, fmt.Errorf("ext directory inode=%d is too large: %d bytes", in.number, in.size)
+		}
+		contents, err := d.contents(in)
+		if err != nil {
+			return nil, err
+		}
+		data = make([]byte, in.size)
+		if _, err := contents.ReadAt(data, 0); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("unable to read ext directory inode=%d: %w", in.number, err)
+		}
+	}
+
+	var results []*node
+	for offset := 0; offset+8 <= len(data); {
+		number := binary.LittleEndian.Uint32(data[offset : offset+4])
+		recLen := int(binary.LittleEndian.Uint16(data[offset+4 : offset+6]))
+		nameLen := int(data[offset+6])
+
+		if recLen < 8 || offset+recLen > len(data) {
+			break
+		}
+
+		if number != 0 && offset+8+nameLen <= len(data) {
+			name := string(data[offset+8 : offset+8+nameLen])
+			if name != "." && name != ".." && name != "" {
+				child, err := d.inode(number)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, d.node(name, child))
+			}
+		}
+
+		offset += recLen
+	}
+	return results, nil
+}
+
+func (d *extDriver) open(file *node) (io.ReaderAt, error) {
+	in, ok := file.ref.(*extInode)
+	if !ok {
+		return nil, fmt.Errorf("invalid ext file reference")
+	}
+	return d.contents(in)
+}
+
+func (d *extDriver) readLink(link *node) (string, error) {
+	in, ok := link.ref.(*extInode)
+	if !ok {
+		return "", fmt.Errorf("invalid ext symlink reference")
+	}
+
+	// fast symlinks store the target within the inode itself
+	if in.flags&(extExtentsFlag|extInlineDataFlag) == 0 && in.size < extFastSymlinkMaxSize {
+		return string(in.block[:in.size]), nil
+	}
+
+	if in.size > extMaxSymlinkSize {
+		return "", fmt.Errorf("ext symlink inode=%d is too large: %d bytes", in.number, in.size)
+	}
+	contents, err := d.contents(in)
+	if err != nil {
+		return "", err
+	}
+	target := make([]byte, in.size)
+	if _, err := contents.ReadAt(target, 0); err != nil && err != io.EOF {
+		return "", fmt.Errorf("unable to read ext symlink inode=%d: %w", in.number, err)
+	}
+	return string(target), nil
+}
+
+// contents returns a reader for the data blocks of the given inode.
+func (d *extDriver) contents(in *extInode) (io.ReaderAt, error) {
+	if in.flags&extInlineDataFlag != 0 {
+		// the first 60 bytes are within i_block and the remainder is stored in the "system.data" extended attribute
+		data := append(in.block[:len(in.block):len(in.block)], inlineDataXattr(in.raw)...)
+		if in.size > int64(len(data)) {
+			return nil, fmt.Errorf("ext inline data inode=%d is larger than the available data", in.number)
+		}
+		return bytes.NewReader(data[:in.size]), nil
+	}
+
+	var runs []blockRun
+	var err error
+	if in.flags&extExtentsFlag != 0 {
+		err = d.extentRuns(in.block, 0, &runs)
+	} else {
+		runs, err = d.blockMapRuns(in)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ext inode=%d blocks: %w", in.number, err)
+	}
+	return newBlockRunReader(d.r, d.blockSize, runs), nil
+}
+
+// inlineDataXattr returns the value of the "system.data" extended attribute stored in the inode body (if any).
+func inlineDataXattr(raw []byte) []byte {
+	if len(raw) <= 130 {
+		return nil
+	}
+
+	start := 128 + int(binary.LittleEndian.Uint16(raw[128:130]))
+	if start+4 > len(raw) || binary.LittleEndian.Uint32(raw[start:start+4]) != extXattrMagic {
+		return nil
+	}
+
+	// value offsets are relative to the first entry (just after the magic header)
+	entries := start + 4
+	for offset := entries; offset+16 <= len(raw); {
+		nameLen := int(raw[offset])
+		if nameLen == 0 && binary.LittleEndian.Uint32(raw[offset:offset+4]) == 0 {
+			break
+		}
+		nameIndex := raw[offset+1]
+		valueOffset := int(binary.LittleEndian.Uint16(raw[offset+2 : offset+4]))
+		valueSize := int(binary.LittleEndian.Uint32(raw[offset+8 : offset+12]))
+		if offset+16+nameLen > len(raw) {
+			break
+		}
+		name := string(raw[offset+16 : offset+16+nameLen])
+
+		if nameIndex == extXattrSystemIndex && name == "data" {
+			if entries+valueOffset+valueSize > len(raw) {
+				return nil
+			}
+			return raw[entries+valueOffset : entries+valueOffset+valueSize]
+		}
+
+		// entries are padded to 4 byte boundaries
+		offset += (16 + nameLen + 3) &^ 3
+	}
+	return nil
+}
+
+// extentRuns walks an extent tree node (the root lives within the inode, all others in their own block).
+func (d *extDriver) extentRuns(data []byte, depth int, runs *[]blockRun) error {
+	if depth > extMaxExtentDepth {
+		return fmt.Errorf("ext extent tree is too deep")
+	}
+	if len(data) < 12 || binary.LittleEndian.Uint16(data[0:2]) != extExtentMagic {
+		return fmt.Errorf("invalid ext extent header")
+	}
+
+	entries := int(binary.LittleEndian.Uint16(data[2:4]))
+	treeDepth := binary.LittleEndian.Uint16(data[6:8])
+	for i := 0; i < entries; i++ {
+		offset := 12 + i*12
+		if offset+12 > len(data) {
+			return fmt.Errorf("ext extent entries exceed node")
+		}
+		entry := data[offset : offset+12]
+
+		if treeDepth == 0 {
+			length := int64(binary.LittleEndian.Uint16(entry[4:6]))
+			zero := false
+			if length > extMaxInitializedExtent {
+				length -= extMaxInitializedExtent
+				zero = true
+			}
+			*runs = append(*runs, blockRun{
+				logical:  int64(binary.LittleEndian.Uint32(entry[0:4])),
+				physical: int64(binary.LittleEndian.Uint16(entry[6:8]))<<32 | int64(binary.LittleEndian.Uint32(entry[8:12])),
+				length:   length,
+				zero:     zero,
+			})
+			continue
+		}
+
+		leaf := int64(binary.LittleEndian.Uint16(entry[8:10]))<<32 | int64(binary.LittleEndian.Uint32(entry[4:8]))
+		child := make([]byte, d.blockSize)
+		if _, err := d.r.ReadAt(child, leaf*d.blockSize); err != nil {
+			return fmt.Errorf("unable to read ext extent block: %w", err)
+		}
+		if err := d.extentRuns(child, depth+1, runs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blockMapRuns resolves the (ext2/ext3 style) direct and indirect block pointers for the given inode.
+func (d *extDriver) blockMapRuns(in *extInode) ([]blockRun, error) {
+	blocks := (in.size + d.blockSize - 1) / d.blockSize
+	pointers := make([]uint32, 15)
+	for i := range pointers {
+		pointers[i] = binary.LittleEndian.Uint32(in.block[i*4 : i*4+4])
+	}
+
+	var runs []blockRun
+	add := func(logical int64, physical uint32) {
+		if physical == 0 {
+			return
+		}
+		if last := len(runs) - 1; last >= 0 && runs[last].logical+runs[last].length == logical && runs[last].physical+runs[last].length == int64(physical) {
+			runs[last].length++
+			return
+		}
+		runs = append(runs, blockRun{logical: logical, physical: int64(physical), length: 1})
+	}
+
+	logical := int64(0)
+	for i := 0; i < 12 && logical < blocks; i++ {
+		add(logical, pointers[i])
+		logical++
+	}
+
+	perBlock := d.blockSize / 4
+	for level, pointer := range pointers[12:] {
+		if logical >= blocks {
+			break
+		}
+		span := perBlock
+		for l := 0; l < level; l++ {
+			span *= perBlock
+		}
+		if pointer == 0 {
+			logical += span
+			continue
+		}
+		var err error
+		logical, err = d.indirectRuns(pointer, level, logical, blocks, add)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return runs, nil
+}
+
+// indirectRuns reads an indirect block (with the given number of additional levels of indirection beneath it).
+func (d *extDriver) indirectRuns(block uint32, level int, logical, blocks int64, add func(int64, uint32)) (int64, error) {
+	data := make([]byte, d.blockSize)
+	if _, err := d.r.ReadAt(data, int64(block)*d.blockSize); err != nil {
+		return logical, fmt.Errorf("unable to read ext indirect block: %w", err)
+	}
+
+	perBlock := d.blockSize / 4
+	span := int64(1)
+	for l := 0; l < level; l++ {
+		span *= perBlock
+	}
+
+	for i := int64(0); i < perBlock && logical < blocks; i++ {
+		pointer := binary.LittleEndian.Uint32(data[i*4 : i*4+4])
+		switch {
+		case pointer == 0:
+			logical += span
+		case level == 0:
+			add(logical, pointer)
+			logical++
+		default:
+			var err error
+			if logical, err = d.indirectRuns(pointer, level-1, logical, blocks, add); err != nil {
+				return logical, err
+			}
+		}
+	}
+	return logical, nil
+}
+
+// unixMode converts a unix st_mode value into an fs.FileMode.
+func unixMode(mode uint32) fs.FileMode {
+	result := fs.FileMode(mode & 0o777)
+	switch mode & 0xf000 {
+	case 0x4000:
+		result |= fs.ModeDir
+	case 0xa000:
+		result |= fs.ModeSymlink
+	case 0x2000:
+		result |= fs.ModeDevice | fs.ModeCharDevice
+	case 0x6000:
+		result |= fs.ModeDevice
+	case 0x1000:
+		result |= fs.ModeNamedPipe
+	case 0xc000:
+		result |= fs.ModeSocket
+	}
+	if mode&0o4000 != 0 {
+		result |= fs.ModeSetuid
+	}
+	if mode&0o2000 != 0 {
+		result |= fs.ModeSetgid
+	}
+	if mode&0o1000 != 0 {
+		result |= fs.ModeSticky
+	}
+	return result
+}
@@ -0,0 +1,395 @@
+package disk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// see "Microsoft Extensible Firmware Initiative FAT32 File System Specification" for the on-disk format (which
+// covers FAT12 and FAT16 as well)
+
+const (
+	fatAttrReadOnly  = 0x01
+	fatAttrVolumeID  = 0x08
+	fatAttrDirectory = 0x10
+	fatAttrLongName  = 0x0f
+
+	fatDirEntrySize = 32
+	// directories are limited to 65536 entries
+	fatMaxDirSize = 65536 * fatDirEntrySize
+	// the max size of the file allocation table to hold in memory
+	fatMaxTableSize = 64 * 1024 * 1024
+)
+
+var _ driver = (*fatDriver)(nil)
+
+type fatDriver struct {
+	r            io.ReaderAt
+	bits         int
+	clusterSize  int64
+	clusterCount uint32
+	dataStart    int64
+	rootStart    int64 // FAT12/16 only: the byte offset of the fixed size root directory
+	rootSize     int64 // FAT12/16 only: the byte size of the fixed size root directory
+	rootCluster  uint32
+	table        []byte
+}
+
+// fatRef refers to the first cluster of a file or directory (cluster 0 is the fixed FAT12/16 root directory).
+type fatRef struct {
+	cluster uint32
+}
+
+func isFATFilesystem(r io.ReaderAt) bool {
+	boot := make([]byte, 512)
+	if _, err := r.ReadAt(boot, 0); err != nil {
+		return false
+	}
+	if boot[510] != 0x55 || boot[511] != 0xaa || (boot[0] != 0xeb && boot[0] != 0xe9) {
+		return false
+	}
+	_, err := parseFATBootSector(boot)
+	return err == nil
+}
+
+type fatBootSector struct {
+	bytesPerSector    int64
+	sectorsPerCluster int64
+	reservedSectors   int64
+	numFATs           int64
+	rootEntries       int64
+	totalSectors      int64
+	fatSectors        int64
+	rootCluster       uint32
+}
+
+func parseFATBootSector(boot []byte) (*fatBootSector, error) {
+	b := &fatBootSector{
+		bytesPerSector:    int64(binary.LittleEndian.Uint16(boot[11:13])),
+		sectorsPerCluster: int64(boot[13]),
+		reservedSectors:   int64(binary.LittleEndian.Uint16(boot[14:16])),
+		numFATs:           int64(boot[16]),
+		rootEntries:       int64(binary.LittleEndian.Uint16(boot[17:19])),
+		totalSectors:      int64(binary.LittleEndian.Uint16(boot[19:21])),
+		fatSectors:        int64(binary.LittleEndian.Uint16(boot[22:24])),
+	}
+
+	if b.totalSectors == 0 {
+		b.totalSectors = int64(binary.LittleEndian.Uint32(boot[32:36]))
+	}
+	if b.fatSectors == 0 {
+		b.fatSectors = int64(binary.LittleEndian.Uint32(boot[36:40]))
+		b.rootCluster = binary.LittleEndian.Uint32(boot[44:48])
+	}
+
+	switch {
+	case b.bytesPerSector != 512 && b.bytesPerSector != 1024 && b.bytesPerSector != 2048 && b.bytesPerSector != 4096:
+		return nil, fmt.Errorf("invalid FAT bytes per sector: %d", b.bytesPerSector)
+	case b.sectorsPerCluster == 0 || b.sectorsPerCluster&(b.sectorsPerCluster-1) != 0:
+		return nil, fmt.Errorf("invalid FAT sectors per cluster: %d", b.sectorsPerCluster)
+	case b.reservedSectors == 0 || b.numFATs == 0 || b.fatSectors == 0 || b.totalSectors == 0:
+		return nil, fmt.Errorf("invalid FAT boot sector")
+	}
+	return b, nil
+}
+
+func newFATDriver(r io.ReaderAt, size int64) (*fatDriver, error) {
+	boot := make([]byte, 512)
+	if _, err := r.ReadAt(boot, 0); err != nil {
+		return nil, fmt.Errorf("unable to read FAT boot sector: %w", err)
+	}
+
+	b, err := parseFATBootSector(boot)
+	if err != nil {
+		return nil, err
+	}
+
+	rootSectors := (b.rootEntries*fatDirEntrySize + b.bytesPerSector - 1) / b.bytesPerSector
+	firstDataSector := b.reservedSectors + b.numFATs*b.fatSectors + rootSectors
+	if b.totalSectors <= firstDataSector || b.totalSectors*b.bytesPerSector > size {
+		return nil, fmt.Errorf("invalid FAT geometry")
+	}
+	clusterCount := uint32((b.totalSectors - firstDataSector) / b.sectorsPerCluster)
+
+	d := &fatDriver{
+		r:            r,
+		clusterSize:  b.sectorsPerCluster * b.bytesPerSector,
+		clusterCount: clusterCount,
+		dataStart:    firstDataSector * b.bytesPerSector,
+		rootStart:    (b.reservedSectors + b.numFATs*b.fatSectors) * b.bytesPerSector,
+		rootSize:     b.rootEntries * fatDirEntrySize,
+		rootCluster:  b.rootCluster,
+	}
+
+	switch {
+	case clusterCount < 4085:
+		d.bits = 12
+	case clusterCount < 65525:
+		d.bits = 16
+	default:
+		d.bits = 32
+	}
+
+	tableSize := b.fatSectors * b.bytesPerSector
+	if tableSize > fatMaxTableSize {
+		return nil, fmt.Errorf("FAT allocation table is too large: %d bytes", tableSize)
+	}
+	d.table = make([]byte, tableSize)
+	if _, err := r.ReadAt(d.table, b.reservedSectors*b.bytesPerSector); err != nil {
+		return nil, fmt.Errorf("unable to read FAT allocation table: %w", err)
+	}
+
+	return d, nil
+}
+
+func (d *fatDriver) root() (*node, error) {
+	cluster := uint32(0)
+	if d.bits == 32 {
+		cluster = d.rootCluster
+	}
+	return &node{
+		mode: fs.ModeDir | 0o755,
+		id:   uint64(cluster),
+		ref:  fatRef{cluster: cluster},
+	}, nil
+}
+
+// next returns the cluster following the given cluster in a chain, and false at the end of the chain.
+func (d *fatDriver) next(cluster uint32) (uint32, bool) {
+	var value, eoc uint32
+	switch d.bits {
+	case 12:
+		offset := int(cluster + cluster/2)
+		if offset+2 > len(d.table) {
+			return 0, false
+		}
+		value = uint32(binary.LittleEndian.Uint16(d.table[offset : offset+2]))
+		if cluster%2 == 1 {
+			value >>= 4
+		} else {
+			value &= 0x0fff
+		}
+		eoc = 0x0ff7
+	case 16:
+		offset := int(cluster * 2)
+		if offset+2 > len(d.table) {
+			return 0, false
+		}
+		value = uint32(binary.LittleEndian.Uint16(d.table[offset : offset+2]))
+		eoc = 0xfff7
+	default:
+		offset := int(cluster * 4)
+		if offset+4 > len(d.table) {
+			return 0, false
+		}
+		value = binary.LittleEndian.Uint32(d.table[offset:offset+4]) & 0x0fffffff
+		eoc = 0x0ffffff7
+	}
+
+	// values at or over the EOC marker are bad clusters or end-of-chain markers
+	if value < 2 || value >= eoc || value >= d.clusterCount+2 {
+		return 0, false
+	}
+	return value, true
+}
+
+// chain returns the block runs (in units of clusters relative to cluster 2) for the chain starting at the given cluster,
+// up to the given number of clusters (which protects against cycles).
+func (d *fatDriver) chain(start uint32, maxClusters int64) []blockRun {
+	var runs []blockRun
+	cluster, ok := start, start >= 2
+	for logical := int64(0); ok && logical < maxClusters && logical <= int64(d.clusterCount); logical++ {
+		physical := int64(cluster) - 2
+		if last := len(runs) - 1; last >= 0 && runs[last].physical+runs[last].length == physical {
+			runs[last].length++
+		} else {
+			runs = append(runs, blockRun{logical: logical, physical: physical, length: 1})
+		}
+		cluster, ok = d.next(cluster)
+	}
+	return runs
+}
+
+// chainReader returns a reader for (up to the given number of bytes of) the chain starting at the given cluster, and the
+// number of bytes in the chain.
+func (d *fatDriver) chainReader(start uint32, maxSize int64) (*blockRunReader, int64) {
+	runs := d.chain(start, (maxSize+d.clusterSize-1)/d.clusterSize)
+	var clusters int64
+	for _, run := range runs {
+		clusters += run.length
+	}
+	data := io.NewSectionReader(d.r, d.dataStart, int64(d.clusterCount)*d.clusterSize)
+	return newBlockRunReader(data, d.clusterSize, runs), clusters * d.clusterSize
+}
+
+func (d *fatDriver) readDir(dir *node) ([]*node, error) {
+	ref, ok := dir.ref.(fatRef)
+	if !ok {
+		return nil, fmt.Errorf("invalid FAT directory reference")
+	}
+
+	var data []byte
+	if ref.cluster == 0 {
+		data = make([]byte, d.rootSize)
+		if _, err := d.r.ReadAt(data, d.rootStart); err != nil {
+			return nil, fmt.Errorf("unable to read FAT root directory: %w", err)
+		}
+	} else {
+		reader, size := d.chainReader(ref.cluster, fatMaxDirSize)
+		data = make([]byte, size)
+		if _, err := reader.ReadAt(data, 0); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("unable to read FAT directory: %w", err)
+		}
+	}
+
+	return parseFATDirectory(data), nil
+}
+
+func parseFATDirectory(data []byte) []*node {
+	var results []*node
+	var longName []uint16
+	var longNameChecksum byte
+
+	for offset := 0; offset+fatDirEntrySize <= len(data); offset += fatDirEntrySize {
+		entry := data[offset : offset+fatDirEntrySize]
+		switch entry[0] {
+		case 0x00:
+			// no further entries
+			return results
+		case 0xe5:
+			// deleted entry
+			longName = nil
+			continue
+		}
+
+		attr := entry[11]
+		if attr&fatAttrLongName == fatAttrLongName {
+			order := entry[0]
+			if order&0x40 != 0 {
+				longName = nil
+			}
+			longNameChecksum = entry[13]
+			longName = append(fatLongNamePart(entry), longName...)
+			continue
+		}
+
+		if attr&fatAttrVolumeID != 0 {
+			longName = nil
+			continue
+		}
+
+		name := fatShortName(entry)
+		if longName != nil && fatShortNameChecksum(entry[0:11]) == longNameChecksum {
+			name = decodeFATLongName(longName)
+		}
+		longName = nil
+
+		if name == "." || name == ".." || name == "" {
+			continue
+		}
+
+		cluster := uint32(binary.LittleEndian.Uint16(entry[20:22]))<<16 | uint32(binary.LittleEndian.Uint16(entry[26:28]))
+
+		n := &node{
+			name:    name,
+			size:    int64(binary.LittleEndian.Uint32(entry[28:32])),
+			modTime: fatTime(binary.LittleEndian.Uint16(entry[24:26]), binary.LittleEndian.Uint16(entry[22:24])),
+			id:      uint64(cluster),
+			ref:     fatRef{cluster: cluster},
+		}
+
+		switch {
+		case attr&fatAttrDirectory != 0:
+			n.mode = fs.ModeDir | 0o755
+			n.size = 0
+		case attr&fatAttrReadOnly != 0:
+			n.mode = 0o444
+		default:
+			n.mode = 0o644
+		}
+
+		results = append(results, n)
+	}
+	return results
+}
+
+func (d *fatDriver) open(file *node) (io.ReaderAt, error) {
+	ref, ok := file.ref.(fatRef)
+	if !ok {
+		return nil, fmt.Errorf("invalid FAT file reference")
+	}
+	reader, _ := d.chainReader(ref.cluster, file.size)
+	return reader, nil
+}
+
+func (d *fatDriver) readLink(*node) (string, error) {
+	return "", fmt.Errorf("FAT filesystems do not support symlinks")
+}
+
+func fatShortName(entry []byte) string {
+	raw := make([]byte, 11)
+	copy(raw, entry[0:11])
+	if raw[0] == 0x05 {
+		raw[0] = 0xe5
+	}
+
+	base := string(bytes.TrimRight(raw[0:8], " "))
+	ext := string(bytes.TrimRight(raw[8:11], " "))
+
+	// windows NT records lowercase names in reserved flags (instead of using long names)
+	if entry[12]&0x08 != 0 {
+		base = strings.ToLower(base)
+	}
+	if entry[12]&0x10 != 0 {
+		ext = strings.ToLower(ext)
+	}
+
+	if ext == "" {
+		return base
+	}
+	return base + "." + ext
+}
+
+func fatShortNameChecksum(name []byte) byte {
+	var sum byte
+	for _, b := range name {
+		sum = ((sum & 1) << 7) + (sum >> 1) + b
+	}
+	return sum
+}
+
+func fatLongNamePart(entry []byte) []uint16 {
+	var units []uint16
+	for _, r := range [][2]int{{1, 11}, {14, 26}, {28, 32}} {
+		for i := r[0]; i < r[1]; i += 2 {
+			units = append(units, binary.LittleEndian.Uint16(entry[i:i+2]))
+		}
+	}
+	return units
+}
+
+func decodeFATLongName(units []uint16) string {
+	for i, u := range units {
+		if u == 0x0000 || u == 0xffff {
+			units = units[:i]
+			break
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+func fatTime(date, t uint16) time.Time {
+	if date == 0 {
+		return time.Time{}
+	}
+	return time.Date(
+		1980+int(date>>9), time.Month((date>>5)&0x0f), int(date&0x1f),
+		int(t>>11), int((t>>5)&0x3f), int(t&0x1f)*2, 0, time.UTC,
+	)
+}
@@ -0,0 +1,432 @@
+package disk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFATSectorSize = 512
+
+// fatImage builds a FAT12, FAT16, or FAT32 filesystem (with one sector per cluster) in memory.
+type fatImage struct {
+	data         []byte
+	bits         int
+	fatStart     int64
+	rootStart    int64
+	dataStart    int64
+	nextCluster  uint32
+	rootCluster  uint32
+	clusterCount uint32
+	// named records the first cluster of files and directories by path
+	named map[string]uint32
+}
+
+func newFATImage(bits int) *fatImage {
+	var totalSectors, reservedSectors, rootEntries int64
+	switch bits {
+	case 12:
+		totalSectors, reservedSectors, rootEntries = 2048, 1, 512
+	case 16:
+		totalSectors, reservedSectors, rootEntries = 8192, 1, 512
+	default:
+		totalSectors, reservedSectors, rootEntries = 68000, 32, 0
+	}
+
+	// two copies of the allocation table, each large enough to describe every sector
+	const numFATs = 2
+	fatSectors := (totalSectors*int64(bits)/8 + testFATSectorSize - 1) / testFATSectorSize
+	rootSectors := rootEntries * fatDirEntrySize / testFATSectorSize
+
+	f := &fatImage{
+		data:        make([]byte, totalSectors*testFATSectorSize),
+		bits:        bits,
+		fatStart:    reservedSectors * testFATSectorSize,
+		rootStart:   (reservedSectors + numFATs*fatSectors) * testFATSectorSize,
+		dataStart:   (reservedSectors + numFATs*fatSectors + rootSectors) * testFATSectorSize,
+		nextCluster: 2,
+		named:       make(map[string]uint32),
+	}
+	f.clusterCount = uint32(int64(len(f.data))-f.dataStart) / testFATSectorSize
+
+	boot := f.data[0:512]
+	copy(boot[0:3], []byte{0xeb, 0x3c, 0x90})
+	copy(boot[3:11], "TESTFAT ")
+	binary.LittleEndian.PutUint16(boot[11:13], testFATSectorSize)
+	boot[13] = 1
+	binary.LittleEndian.PutUint16(boot[14:16], uint16(reservedSectors))
+	boot[16] = numFATs
+	binary.LittleEndian.PutUint16(boot[17:19], uint16(rootEntries))
+	if totalSectors < 65536 {
+		binary.LittleEndian.PutUint16(boot[19:21], uint16(totalSectors))
+	} else {
+		binary.LittleEndian.PutUint32(boot[32:36], uint32(totalSectors))
+	}
+	if bits == 32 {
+		binary.LittleEndian.PutUint32(boot[36:40], uint32(fatSectors))
+		f.rootCluster = f.alloc(1)
+		binary.LittleEndian.PutUint32(boot[44:48], f.rootCluster)
+	} else {
+		binary.LittleEndian.PutUint16(boot[22:24], uint16(fatSectors))
+	}
+	boot[510] = 0x55
+	boot[511] = 0xaa
+	return f
+}
+
+// setFAT sets the allocation table entry for the given cluster.
+func (f *fatImage) setFAT(cluster, value uint32) {
+	table := f.data[f.fatStart:]
+	switch f.bits {
+	case 12:
+		offset := cluster + cluster/2
+		if cluster%2 == 0 {
+			table[offset] = byte(value)
+			table[offset+1] = table[offset+1]&0xf0 | byte(value>>8)&0x0f
+		} else {
+			table[offset] = table[offset]&0x0f | byte(value<<4)
+			table[offset+1] = byte(value >> 4)
+		}
+	case 16:
+		binary.LittleEndian.PutUint16(table[cluster*2:], uint16(value))
+	default:
+		binary.LittleEndian.PutUint32(table[cluster*4:], value)
+	}
+}
+
+func (f *fatImage) endOfChain() uint32 {
+	return uint32(1)<<f.bits - 1
+}
+
+// alloc allocates a chain of the given number of clusters. Every other cluster is skipped so that chains with more
+// than one cluster are fragmented.
+func (f *fatImage) alloc(clusters int) uint32 {
+	first := f.nextCluster
+	for i := 0; i < clusters; i++ {
+		cluster := f.nextCluster
+		f.nextCluster += 2
+		next := f.endOfChain()
+		if i < clusters-1 {
+			next = f.nextCluster
+		}
+		f.setFAT(cluster, next)
+	}
+	return first
+}
+
+func (f *fatImage) cluster(cluster uint32) []byte {
+	offset := f.dataStart + int64(cluster-2)*testFATSectorSize
+	return f.data[offset : offset+testFATSectorSize]
+}
+
+// writeChain writes the given contents to a newly allocated chain, returning the first cluster.
+func (f *fatImage) writeChain(contents []byte) uint32 {
+	clusters := (len(contents) + testFATSectorSize - 1) / testFATSectorSize
+	if clusters == 0 {
+		return 0
+	}
+	first := f.alloc(clusters)
+	for cluster, i := first, 0; i < clusters; cluster, i = cluster+2, i+1 {
+		copy(f.cluster(cluster), contents[i*testFATSectorSize:])
+	}
+	return first
+}
+
+// rootDir returns the space for root directory entries (either the fixed region or the root cluster).
+func (f *fatImage) rootDir() []byte {
+	if f.bits == 32 {
+		return f.cluster(f.rootCluster)
+	}
+	return f.data[f.rootStart:f.dataStart]
+}
+
+type fatTestEntry struct {
+	shortName string // 11 bytes, space padded
+	longName  string
+	attr      byte
+	ntFlags   byte
+	deleted   bool
+	cluster   uint32
+	size      uint32
+}
+
+func (e fatTestEntry) encode() []byte {
+	var out []byte
+	if e.longName != "" {
+		units := append(utf16.Encode([]rune(e.longName)), 0)
+		for len(units)%13 != 0 {
+			units = append(units, 0xffff)
+		}
+		checksum := fatShortNameChecksum([]byte(e.shortName))
+
+		// long name entries are stored in reverse order, with the last one flagged
+		parts := len(units) / 13
+		for i := parts; i >= 1; i-- {
+			entry := make([]byte, fatDirEntrySize)
+			entry[0] = byte(i)
+			if i == parts {
+				entry[0] |= 0x40
+			}
+			entry[11] = fatAttrLongName
+			entry[13] = checksum
+			chunk := units[(i-1)*13 : i*13]
+			pos := 0
+			for _, r := range [][2]int{{1, 11}, {14, 26}, {28, 32}} {
+				for j := r[0]; j < r[1]; j += 2 {
+					binary.LittleEndian.PutUint16(entry[j:j+2], chunk[pos])
+					pos++
+				}
+			}
+			out = append(out, entry...)
+		}
+	}
+
+	entry := make([]byte, fatDirEntrySize)
+	copy(entry[0:11], e.shortName)
+	if e.deleted {
+		entry[0] = 0xe5
+	}
+	entry[11] = e.attr
+	entry[12] = e.ntFlags
+	binary.LittleEndian.PutUint16(entry[20:22], uint16(e.cluster>>16))
+	binary.LittleEndian.PutUint16(entry[22:24], 6<<11)             // 06:00:00
+	binary.LittleEndian.PutUint16(entry[24:26], (43<<9)|(3<<5)|15) // 2023-03-15
+	binary.LittleEndian.PutUint16(entry[26:28], uint16(e.cluster))
+	binary.LittleEndian.PutUint32(entry[28:32], e.size)
+	return append(out, entry...)
+}
+
+// writeFATDir writes the given entries to the start of a directory, returning the number of bytes written.
+func writeFATDir(dir []byte, entries ...fatTestEntry) int {
+	var offset int
+	for _, e := range entries {
+		offset += copy(dir[offset:], e.encode())
+	}
+	return offset
+}
+
+// findFATEntry returns the (short name) entry with the given name in a directory.
+func findFATEntry(dir []byte, shortName string) []byte {
+	for offset := 0; offset+fatDirEntrySize <= len(dir); offset += fatDirEntrySize {
+		if string(dir[offset:offset+11]) == shortName {
+			return dir[offset : offset+fatDirEntrySize]
+		}
+	}
+	panic(fmt.Sprintf("no FAT entry named %q", shortName))
+}
+
+// testFATBootLoader spans multiple (fragmented) clusters
+var testFATBootLoader = bytes.Repeat([]byte("boot loader "), 200)
+
+// newTestFAT returns a FAT filesystem with the following contents:
+//
+//	/EFI/BOOT/BOOTX64.EFI       (read-only, multiple fragmented clusters)
+//	/EMPTY                      (no clusters)
+//	/README.TXT
+//	/grubenv-long-name.cfg      (long file name)
+//	/notes.md                   (lowercase short name)
+//
+// along with a volume label, a deleted file, and an entry following the end of the root directory (none of which
+// should be read).
+func newTestFAT(bits int) *fatImage {
+	f := newFATImage(bits)
+
+	f.named["EFI/BOOT/BOOTX64.EFI"] = f.writeChain(testFATBootLoader)
+	f.named["EFI/BOOT"] = f.alloc(1)
+	f.named["EFI"] = f.alloc(1)
+	parent := uint32(0)
+	if bits == 32 {
+		parent = f.rootCluster
+	}
+
+	writeFATDir(f.cluster(f.named["EFI/BOOT"]),
+		fatTestEntry{shortName: ".          ", attr: fatAttrDirectory, cluster: f.named["EFI/BOOT"]},
+		fatTestEntry{shortName: "..         ", attr: fatAttrDirectory, cluster: f.named["EFI"]},
+		fatTestEntry{shortName: "BOOTX64 EFI", attr: fatAttrReadOnly, cluster: f.named["EFI/BOOT/BOOTX64.EFI"], size: uint32(len(testFATBootLoader))},
+	)
+	writeFATDir(f.cluster(f.named["EFI"]),
+		fatTestEntry{shortName: ".          ", attr: fatAttrDirectory, cluster: f.named["EFI"]},
+		fatTestEntry{shortName: "..         ", attr: fatAttrDirectory, cluster: parent},
+		fatTestEntry{shortName: "BOOT       ", attr: fatAttrDirectory, cluster: f.named["EFI/BOOT"]},
+	)
+
+	readme := []byte("hello from a FAT filesystem\n")
+	grubenv := []byte("# GRUB Environment Block\n")
+	notes := []byte("notes\n")
+	deleted := []byte("deleted\n")
+
+	root := f.rootDir()
+	n := writeFATDir(root,
+		fatTestEntry{shortName: "TESTVOL    ", attr: fatAttrVolumeID},
+		fatTestEntry{shortName: "EFI        ", attr: fatAttrDirectory, cluster: f.named["EFI"]},
+		fatTestEntry{shortName: "README  TXT", cluster: f.writeChain(readme), size: uint32(len(readme))},
+		fatTestEntry{shortName: "GRUBEN~1CFG", longName: "grubenv-long-name.cfg", cluster: f.writeChain(grubenv), size: uint32(len(grubenv))},
+		fatTestEntry{shortName: "NOTES   MD ", ntFlags: 0x18, cluster: f.writeChain(notes), size: uint32(len(notes))},
+		fatTestEntry{shortName: "DELETED TXT", deleted: true, cluster: f.writeChain(deleted), size: uint32(len(deleted))},
+		fatTestEntry{shortName: "EMPTY      "},
+	)
+	// entries after the first unused entry are never read
+	writeFATDir(root[n+fatDirEntrySize:], fatTestEntry{shortName: "UNREACH    "})
+
+	return f
+}
+
+func TestFATFilesystem(t *testing.T) {
+	for _, bits := range []int{12, 16, 32} {
+		t.Run(fmt.Sprintf("FAT%d", bits), func(t *testing.T) {
+			f := newTestFAT(bits)
+			fsys, err := OpenFilesystem(bytes.NewReader(f.data), int64(len(f.data)))
+			require.NoError(t, err)
+			assert.Equal(t, VFATFilesystem, fsys.Type)
+
+			driver, ok := fsys.driver.(*fatDriver)
+			require.True(t, ok)
+			assert.Equal(t, bits, driver.bits)
+
+			assert.Equal(t, []string{
+				".",
+				"EFI",
+				"EFI/BOOT",
+				"EFI/BOOT/BOOTX64.EFI",
+				"EMPTY",
+				"README.TXT",
+				"grubenv-long-name.cfg",
+				"notes.md",
+			}, walkPaths(t, fsys))
+
+			// FAT is case insensitive
+			for _, p := range []string{"EFI/BOOT/BOOTX64.EFI", "efi/boot/bootx64.efi"} {
+				f, err := fsys.Open(p)
+				require.NoError(t, err)
+				contents, err := io.ReadAll(f)
+				require.NoError(t, err)
+				assert.Equal(t, testFATBootLoader, contents)
+			}
+
+			info, err := fsys.Stat("efi/boot/bootx64.efi")
+			require.NoError(t, err)
+			assert.Equal(t, "BOOTX64.EFI", info.Name())
+			assert.Equal(t, fs.FileMode(0o444), info.Mode())
+			assert.Equal(t, "2023-03-15 06:00:00", info.ModTime().Format("2006-01-02 15:04:05"))
+
+			contents, err := fs.ReadFile(fsys, "grubenv-long-name.cfg")
+			require.NoError(t, err)
+			assert.Equal(t, "# GRUB Environment Block\n", string(contents))
+
+			contents, err = fs.ReadFile(fsys, "EMPTY")
+			require.NoError(t, err)
+			assert.Empty(t, contents)
+
+			_, err = fsys.ReadLink("README.TXT")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestFATFilesystem_Malformed(t *testing.T) {
+	all := []string{".", "EFI", "EFI/BOOT", "EFI/BOOT/BOOTX64.EFI", "EMPTY", "README.TXT", "grubenv-long-name.cfg", "notes.md"}
+
+	tests := []struct {
+		name    string
+		corrupt func(f *fatImage)
+		// whether the filesystem is readable at all (otherwise opening fails)
+		opens bool
+		// the expected paths when walking the filesystem, ignoring any errors
+		expected []string
+	}{
+		{
+			name: "invalid bytes per sector",
+			corrupt: func(f *fatImage) {
+				binary.LittleEndian.PutUint16(f.data[11:13], 0)
+			},
+		},
+		{
+			name: "geometry larger than the partition",
+			corrupt: func(f *fatImage) {
+				f.data = f.data[:len(f.data)/2]
+			},
+		},
+		{
+			name: "directory refers to the root directory",
+			corrupt: func(f *fatImage) {
+				boot := findFATEntry(f.cluster(f.named["EFI"]), "BOOT       ")
+				copy(boot, fatTestEntry{shortName: "ROOT       ", attr: fatAttrDirectory, cluster: f.rootCluster}.encode())
+			},
+			opens:    true,
+			expected: []string{".", "EFI", "EMPTY", "README.TXT", "grubenv-long-name.cfg", "notes.md"},
+		},
+		{
+			name: "directory refers to an ancestor",
+			corrupt: func(f *fatImage) {
+				efi := f.named["EFI"]
+				copy(f.cluster(efi)[3*fatDirEntrySize:], fatTestEntry{shortName: "LOOP       ", attr: fatAttrDirectory, cluster: efi}.encode())
+			},
+			opens:    true,
+			expected: all,
+		},
+		{
+			name: "cyclic directory chain",
+			corrupt: func(f *fatImage) {
+				f.setFAT(f.named["EFI"], f.named["EFI"])
+			},
+			opens: true,
+		},
+		{
+			name: "cyclic file chain",
+			corrupt: func(f *fatImage) {
+				bootLoader := f.named["EFI/BOOT/BOOTX64.EFI"]
+				f.setFAT(bootLoader, bootLoader)
+			},
+			opens:    true,
+			expected: all,
+		},
+		{
+			name: "file larger than its chain",
+			corrupt: func(f *fatImage) {
+				binary.LittleEndian.PutUint32(findFATEntry(f.rootDir(), "README  TXT")[28:32], 1<<31)
+			},
+			opens:    true,
+			expected: all,
+		},
+		{
+			name: "invalid path characters",
+			corrupt: func(f *fatImage) {
+				copy(findFATEntry(f.rootDir(), "EMPTY      "), fatTestEntry{shortName: "EMPTY/..   "}.encode())
+			},
+			opens:    true,
+			expected: []string{".", "EFI", "EFI/BOOT", "EFI/BOOT/BOOTX64.EFI", "README.TXT", "grubenv-long-name.cfg", "notes.md"},
+		},
+	}
+
+	for _, bits := range []int{12, 16, 32} {
+		for _, test := range tests {
+			t.Run(fmt.Sprintf("FAT%d %s", bits, test.name), func(t *testing.T) {
+				f := newTestFAT(bits)
+				test.corrupt(f)
+
+				fsys, err := OpenFilesystem(bytes.NewReader(f.data), int64(len(f.data)))
+				if !test.opens {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+
+				paths := readAll(fsys)
+				if test.expected != nil {
+					assert.Equal(t, test.expected, paths)
+				}
+				for _, p := range paths {
+					assert.False(t, strings.Contains(p, ".."), "path escapes the filesystem: %q", p)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package disk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	Ext4Filesystem = "ext4"
+	XFSFilesystem  = "xfs"
+	VFATFilesystem = "vfat"
+)
+
+var (
+	// ErrUnsupportedFilesystem is returned when a partition does not contain a filesystem that can be read.
+	ErrUnsupportedFilesystem = errors.New("unsupported filesystem")
+
+	_ fs.ReadDirFS = (*Filesystem)(nil)
+	_ fs.StatFS    = (*Filesystem)(nil)
+)
+
+// driver is the minimal set of operations a filesystem implementation must provide to be exposed as a Filesystem.
+type driver interface {
+	root() (*node, error)
+	readDir(dir *node) ([]*node, error)
+	open(file *node) (io.ReaderAt, error)
+	readLink(link *node) (string, error)
+}
+
+// node is a single file, directory, or symlink within a filesystem (it implements fs.FileInfo).
+type node struct {
+	name    string
+	mode    fs.FileMode
+	size    int64
+	modTime time.Time
+	// id uniquely identifies the file within the filesystem (e.g. an inode number)
+	id uint64
+	// ref is driver specific (e.g. an inode)
+	ref interface{}
+}
+
+func (n *node) Name() string       { return n.name }
+func (n *node) Size() int64        { return n.size }
+func (n *node) Mode() fs.FileMode  { return n.mode }
+func (n *node) ModTime() time.Time { return n.modTime }
+func (n *node) IsDir() bool        { return n.mode.IsDir() }
+func (n *node) Sys() interface{}   { return nil }
+
+// Filesystem is a read-only view of a filesystem found on a disk image. It implements fs.FS, however symlinks are
+// never followed: opening or stating a symlink describes the link itself, and ReadLink returns its target.
+type Filesystem struct {
+	// Type is the kind of filesystem (ext4, xfs, or vfat)
+	Type string
+
+	driver          driver
+	caseInsensitive bool
+	lock            sync.Mutex
+	dirCache        map[string][]*node
+	dirPaths        map[uint64]string
+}
+
+// OpenFilesystem detects and opens the filesystem within the given partition contents.
+func OpenFilesystem(r io.ReaderAt, size int64) (*Filesystem, error) {
+	switch {
+	case isExtFilesystem(r):
+		d, err := newExtDriver(r)
+		if err != nil {
+			return nil, err
+		}
+		return newFilesystem(Ext4Filesystem, d, false), nil
+	case isXFSFilesystem(r):
+		d, err := newXFSDriver(r)
+		if err != nil {
+			return nil, err
+		}
+		return newFilesystem(XFSFilesystem, d, false), nil
+	case isFATFilesystem(r):
+		d, err := newFATDriver(r, size)
+		if err != nil {
+			return nil, err
+		}
+		return newFilesystem(VFATFilesystem, d, true), nil
+	}
+	return nil, ErrUnsupportedFilesystem
+}
+
+func newFilesystem(kind string, d driver, caseInsensitive bool) *Filesystem {
+	return &Filesystem{
+		Type:            kind,
+		driver:          d,
+		caseInsensitive: caseInsensitive,
+		dirCache:        make(map[string][]*node),
+		dirPaths:        make(map[uint64]string),
+	}
+}
+
+// Open opens the named file for reading.
+func (f *Filesystem) Open(name string) (fs.File, error) {
+	n, err := f.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	var contents io.Reader
+	if n.mode.IsRegular() {
+		ra, err := f.driver.open(n)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		contents = io.NewSectionReader(ra, 0, n.size)
+	}
+
+	return &file{node: n, contents: contents}, nil
+}
+
+// Stat returns file info for the named file (without following symlinks).
+func (f *Filesystem) Stat(name string) (fs.FileInfo, error) {
+	return f.lookup("stat", name)
+}
+
+// ReadDir returns all entries within the named directory, sorted by name.
+func (f *Filesystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	n, err := f.lookup("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := f.children(name, n)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+
+	var entries []fs.DirEntry
+	for _, c := range children {
+		entries = append(entries, fs.FileInfoToDirEntry(c))
+	}
+	sortDirEntries(entries)
+	return entries, nil
+}
+
+// ReadLink returns the target of the named symlink.
+func (f *Filesystem) ReadLink(name string) (string, error) {
+	n, err := f.lookup("readlink", name)
+	if err != nil {
+		return "", err
+	}
+	if n.mode&fs.ModeSymlink == 0 {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fmt.Errorf("not a symlink")}
+	}
+	target, err := f.driver.readLink(n)
+	if err != nil {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: err}
+	}
+	return target, nil
+}
+
+func (f *Filesystem) lookup(op, name string) (*node, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	current, err := f.driver.root()
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	if name == "." {
+		return current, nil
+	}
+
+	currentPath := "."
+	for _, part := range strings.Split(name, "/") {
+		if !current.IsDir() {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		children, err := f.children(currentPath, current)
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+
+		var next *node
+		for _, c := range children {
+			if c.name == part || (f.caseInsensitive && strings.EqualFold(c.name, part)) {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		current = next
+		currentPath = path.Join(currentPath, next.name)
+	}
+	return current, nil
+}
+
+// children returns the (cached) entries of the given directory node.
+func (f *Filesystem) children(dirPath string, dir *node) ([]*node, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if children, ok := f.dirCache[dirPath]; ok {
+		return children, nil
+	}
+
+	entries, err := f.driver.readDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// a directory may only be reached through a single path, which prevents (corrupt or malicious) entries that refer
+	// to an ancestor or another directory from creating cycles or an unbounded number of paths
+	if _, ok := f.dirPaths[dir.id]; !ok {
+		f.dirPaths[dir.id] = dirPath
+	}
+
+	// drop any (corrupt or malicious) names that could escape the directory when joined into a path
+	var children []*node
+	for _, c := range entries {
+		if !fs.ValidPath(c.name) || strings.Contains(c.name, "/") {
+			continue
+		}
+		if c.IsDir() {
+			childPath := path.Join(dirPath, c.name)
+			if existing, ok := f.dirPaths[c.id]; ok && existing != childPath {
+				continue
+			}
+			f.dirPaths[c.id] = childPath
+		}
+		children = append(children, c)
+	}
+	f.dirCache[dirPath] = children
+	return children, nil
+}
+
+func sortDirEntries(entries []fs.DirEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+}
+
+// file is an open file, directory, or symlink within a Filesystem.
+type file struct {
+	node     *node
+	contents io.Reader
+}
+
+func (f *file) Stat() (fs.FileInfo, error) {
+	return f.node, nil
+}
+
+func (f *file) Read(p []byte) (int, error) {
+	if f.contents == nil {
+		return 0, &fs.PathError{Op: "read", Path: f.node.name, Err: fmt.Errorf("not a regular file")}
+	}
+	return f.contents.Read(p)
+}
+
+func (f *file) Close() error {
+	return nil
+}
+
+// blockRun maps a contiguous range of logical blocks within a file to physical blocks on the filesystem.
+type blockRun struct {
+	logical  int64
+	physical int64
+	length   int64
+	// zero indicates that the run is allocated but unwritten (reads as zeros)
+	zero bool
+}
+
+// blockRunReader reads file contents described by block runs; any blocks not covered by a run are holes.
+type blockRunReader struct {
+	r         io.ReaderAt
+	blockSize int64
+	runs      []blockRun
+}
+
+func newBlockRunReader(r io.ReaderAt, blockSize int64, runs []blockRun) *blockRunReader {
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].logical < runs[j].logical
+	})
+	return &blockRunReader{r: r, blockSize: blockSize, runs: runs}
+}
+
+func (b *blockRunReader) ReadAt(p []byte, off int64) (int, error) {
+	var n int
+	for n < len(p) {
+		pos := off + int64(n)
+		block := pos / b.blockSize
+		inBlock := pos % b.blockSize
+
+		run := b.find(block)
+		chunk := int64(len(p) - n)
+
+		switch {
+		case run == nil:
+			// a hole: read zeros until the next run starts
+			next := b.nextRunStart(block)
+			if next >= 0 {
+				if remaining := next*b.blockSize - pos; chunk > remaining {
+					chunk = remaining
+				}
+			}
+			zeroFill(p[n : n+int(chunk)])
+		default:
+			if remaining := (run.logical+run.length)*b.blockSize - pos; chunk > remaining {
+				chunk = remaining
+			}
+			if run.zero {
+				zeroFill(p[n : n+int(chunk)])
+				break
+			}
+			physical := (run.physical+block-run.logical)*b.blockSize + inBlock
+			if _, err := b.r.ReadAt(p[n:n+int(chunk)], physical); err != nil && err != io.EOF {
+				return n, err
+			}
+		}
+		n += int(chunk)
+	}
+	return n, nil
+}
+
+// find returns the run containing the given logical block (if any).
+func (b *blockRunReader) find(block int64) *blockRun {
+	idx := sort.Search(len(b.runs), func(i int) bool {
+		return b.runs[i].logical+b.runs[i].length > block
+	})
+	if idx < len(b.runs) && b.runs[idx].logical <= block {
+		return &b.runs[idx]
+	}
+	return nil
+}
+
+// nextRunStart returns the first logical block after the given block that is covered by a run (or -1 if none).
+func (b *blockRunReader) nextRunStart(block int64) int64 {
+	idx := sort.Search(len(b.runs), func(i int) bool {
+		return b.runs[i].logical > block
+	})
+	if idx < len(b.runs) {
+		return b.runs[idx].logical
+	}
+	return -1
+}
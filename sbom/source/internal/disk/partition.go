@@ -0,0 +1,298 @@
+package disk
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	MBRPartitionTable = "mbr"
+	GPTPartitionTable = "gpt"
+	// NoPartitionTable indicates that the filesystem spans the entire disk
+	NoPartitionTable = "none"
+
+	sectorSize = 512
+
+	mbrProtectiveType = 0xee
+	gptSignature      = "EFI PART"
+
+	// the max number of logical partitions to follow in an extended partition (protects against cycles)
+	maxLogicalPartitions = 128
+)
+
+// well known GPT partition type GUIDs, see https://en.wikipedia.org/wiki/GUID_Partition_Table#Partition_type_GUIDs
+var gptTypeNames = map[string]string{
+	"C12A7328-F81F-11D2-BA4B-00A0C93EC93B": "EFI System",
+	"21686148-6449-6E6F-744E-656564454649": "BIOS boot",
+	"0FC63DAF-8483-4772-8E79-3D69D8477DE4": "Linux filesystem",
+	"4F68BCE3-E8CD-4DB1-96E7-FBCAF984B709": "Linux root (x86-64)",
+	"44479540-F297-41B2-9AF7-D131D5F0458A": "Linux root (x86)",
+	"B921B045-1DF0-41C3-AF44-4C6F280D3FAE": "Linux root (ARM64)",
+	"69DAD710-2CE4-4E3C-B16C-21A1D49ABED3": "Linux root (ARM)",
+	"BC13C2FF-59E6-4262-A352-B275FD6F7172": "Linux extended boot",
+	"933AC7E1-2EB4-4F13-B844-0E14E2AEF915": "Linux home",
+	"0657FD6D-A4AB-43C4-84E5-0933C84B4F4F": "Linux swap",
+	"E6D6D379-F507-44C2-A23C-238F2A3DF928": "Linux LVM",
+	"A19D880F-05FC-4D3B-A006-743F0F84911E": "Linux RAID",
+	"EBD0A0A2-B9E5-4433-87C0-68B6B72699C7": "Microsoft basic data",
+}
+
+// well known MBR partition types, see https://en.wikipedia.org/wiki/Partition_type
+var mbrTypeNames = map[byte]string{
+	0x01: "FAT12",
+	0x04: "FAT16",
+	0x06: "FAT16",
+	0x07: "NTFS/exFAT",
+	0x0b: "FAT32",
+	0x0c: "FAT32 (LBA)",
+	0x0e: "FAT16 (LBA)",
+	0x82: "Linux swap",
+	0x83: "Linux",
+	0x8e: "Linux LVM",
+	0xef: "EFI System",
+	0xfd: "Linux RAID",
+}
+
+// Partition is a contiguous region of a disk described by a partition table entry.
+type Partition struct {
+	// Number is the 1-based partition number (as the kernel would enumerate it, e.g. sda1 or sda5 for logical partitions)
+	Number int
+	// Type is a human readable description of the partition type (or the raw type value if not well known)
+	Type string
+	// Name is the partition label (GPT only)
+	Name string
+	// UUID is the unique partition GUID (GPT only)
+	UUID string
+	// Start is the byte offset of the partition from the beginning of the disk
+	Start int64
+	// Size is the size of the partition in bytes
+	Size int64
+}
+
+// Reader returns a reader scoped to the contents of the partition.
+func (p Partition) Reader(r io.ReaderAt) *io.SectionReader {
+	return io.NewSectionReader(r, p.Start, p.Size)
+}
+
+// ReadPartitions returns the partition table type and the partitions found within the given disk. If there is no
+// partition table then a single partition spanning the entire disk is returned.
+func ReadPartitions(r io.ReaderAt, size int64) (string, []Partition, error) {
+	mbr := make([]byte, sectorSize)
+	if _, err := r.ReadAt(mbr, 0); err != nil && err != io.EOF {
+		return "", nil, fmt.Errorf("unable to read master boot record: %w", err)
+	}
+
+	wholeDisk := []Partition{{Number: 0, Type: "whole disk", Start: 0, Size: size}}
+
+	if mbr[510] != 0x55 || mbr[511] != 0xaa {
+		return NoPartitionTable, wholeDisk, nil
+	}
+
+	entries := mbrEntries(mbr)
+	for _, e := range entries {
+		if e.kind == mbrProtectiveType {
+			partitions, err := readGPT(r, size)
+			if err != nil {
+				return "", nil, err
+			}
+			return GPTPartitionTable, partitions, nil
+		}
+	}
+
+	if !isValidMBR(entries, size) {
+		// a boot signature without a valid partition table (e.g. a FAT boot sector) has no partitions
+		return NoPartitionTable, wholeDisk, nil
+	}
+
+	partitions, err := readMBR(r, entries, size)
+	if err != nil {
+		return "", nil, err
+	}
+	return MBRPartitionTable, partitions, nil
+}
+
+type mbrEntry struct {
+	status byte
+	kind   byte
+	start  uint32
+	count  uint32
+}
+
+func mbrEntries(sector []byte) []mbrEntry {
+	var entries []mbrEntry
+	for i := 0; i < 4; i++ {
+		raw := sector[446+i*16 : 446+(i+1)*16]
+		entries = append(entries, mbrEntry{
+			status: raw[0],
+			kind:   raw[4],
+			start:  binary.LittleEndian.Uint32(raw[8:12]),
+			count:  binary.LittleEndian.Uint32(raw[12:16]),
+		})
+	}
+	return entries
+}
+
+func isValidMBR(entries []mbrEntry, size int64) bool {
+	var found bool
+	for _, e := range entries {
+		if e.kind == 0 {
+			continue
+		}
+		if e.status != 0 && e.status != 0x80 {
+			return false
+		}
+		if e.start == 0 || int64(e.start)*sectorSize >= size {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+func isExtendedMBRType(kind byte) bool {
+	return kind == 0x05 || kind == 0x0f || kind == 0x85
+}
+
+func mbrTypeName(kind byte) string {
+	if name, ok := mbrTypeNames[kind]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%02x", kind)
+}
+
+func readMBR(r io.ReaderAt, entries []mbrEntry, size int64) ([]Partition, error) {
+	var partitions []Partition
+	for idx, e := range entries {
+		if e.kind == 0 || e.count == 0 {
+			continue
+		}
+		if isExtendedMBRType(e.kind) {
+			logical, err := readLogicalPartitions(r, int64(e.start), size)
+			if err != nil {
+				return nil, err
+			}
+			partitions = append(partitions, logical...)
+			continue
+		}
+		partitions = append(partitions, Partition{
+			Number: idx + 1,
+			Type:   mbrTypeName(e.kind),
+			Start:  int64(e.start) * sectorSize,
+			Size:   int64(e.count) * sectorSize,
+		})
+	}
+	return partitions, nil
+}
+
+// readLogicalPartitions follows the chain of extended boot records within an extended partition.
+func readLogicalPartitions(r io.ReaderAt, extendedStart, size int64) ([]Partition, error) {
+	var partitions []Partition
+	ebrStart := extendedStart
+	sector := make([]byte, sectorSize)
+	for number := 5; number < 5+maxLogicalPartitions; number++ {
+		if ebrStart*sectorSize >= size {
+			break
+		}
+		if _, err := r.ReadAt(sector, ebrStart*sectorSize); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("unable to read extended boot record: %w", err)
+		}
+		if sector[510] != 0x55 || sector[511] != 0xaa {
+			break
+		}
+
+		entries := mbrEntries(sector)
+		if entries[0].kind != 0 && entries[0].count != 0 {
+			partitions = append(partitions, Partition{
+				Number: number,
+				Type:   mbrTypeName(entries[0].kind),
+				Start:  (ebrStart + int64(entries[0].start)) * sectorSize,
+				Size:   int64(entries[0].count) * sectorSize,
+			})
+		}
+
+		// the next EBR is relative to the start of the extended partition (not the current EBR)
+		if !isExtendedMBRType(entries[1].kind) || entries[1].start == 0 {
+			break
+		}
+		ebrStart = extendedStart + int64(entries[1].start)
+	}
+	return partitions, nil
+}
+
+func readGPT(r io.ReaderAt, size int64) ([]Partition, error) {
+	header := make([]byte, 92)
+	if _, err := r.ReadAt(header, sectorSize); err != nil {
+		return nil, fmt.Errorf("unable to read GPT header: %w", err)
+	}
+	if string(header[:8]) != gptSignature {
+		return nil, fmt.Errorf("invalid GPT header signature")
+	}
+
+	entriesLBA := int64(binary.LittleEndian.Uint64(header[72:80]))
+	count := int64(binary.LittleEndian.Uint32(header[80:84]))
+	entrySize := int64(binary.LittleEndian.Uint32(header[84:88]))
+	if entrySize < 128 || count > 4096 {
+		return nil, fmt.Errorf("invalid GPT partition entry layout (count=%d, size=%d)", count, entrySize)
+	}
+
+	table := make([]byte, count*entrySize)
+	if _, err := r.ReadAt(table, entriesLBA*sectorSize); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to read GPT partition entries: %w", err)
+	}
+
+	var partitions []Partition
+	for i := int64(0); i < count; i++ {
+		raw := table[i*entrySize : (i+1)*entrySize]
+		typeGUID := formatGUID(raw[0:16])
+		if typeGUID == "00000000-0000-0000-0000-000000000000" {
+			continue
+		}
+
+		first := int64(binary.LittleEndian.Uint64(raw[32:40]))
+		last := int64(binary.LittleEndian.Uint64(raw[40:48]))
+		if last < first || first*sectorSize >= size {
+			continue
+		}
+
+		kind := typeGUID
+		if name, ok := gptTypeNames[typeGUID]; ok {
+			kind = name
+		}
+
+		partitions = append(partitions, Partition{
+			Number: int(i) + 1,
+			Type:   kind,
+			Name:   decodeUTF16Name(raw[56:128]),
+			UUID:   formatGUID(raw[16:32]),
+			Start:  first * sectorSize,
+			Size:   (last - first + 1) * sectorSize,
+		})
+	}
+	return partitions, nil
+}
+
+// formatGUID renders a mixed-endian GUID as found in GPT structures.
+func formatGUID(b []byte) string {
+	return strings.ToUpper(fmt.Sprintf("%08x-%04x-%04x-%x-%x",
+		binary.LittleEndian.Uint32(b[0:4]),
+		binary.LittleEndian.Uint16(b[4:6]),
+		binary.LittleEndian.Uint16(b[6:8]),
+		b[8:10],
+		b[10:16],
+	))
+}
+
+func decodeUTF16Name(b []byte) string {
+	var units []uint16
+	for i := 0; i+1 < len(b); i += 2 {
+		u := binary.LittleEndian.Uint16(b[i : i+2])
+		if u == 0 {
+			break
+		}
+		units = append(units, u)
+	}
+	return strings.TrimSpace(string(utf16.Decode(units)))
+}
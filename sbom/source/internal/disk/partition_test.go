@@ -0,0 +1,163 @@
+package disk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mbrEntryBytes(status, kind byte, start, count uint32) []byte {
+	entry := make([]byte, 16)
+	entry[0] = status
+	entry[4] = kind
+	binary.LittleEndian.PutUint32(entry[8:12], start)
+	binary.LittleEndian.PutUint32(entry[12:16], count)
+	return entry
+}
+
+func writeBootRecord(disk []byte, sector int64, entries ...[]byte) {
+	offset := sector * sectorSize
+	for i, e := range entries {
+		copy(disk[offset+446+int64(i)*16:], e)
+	}
+	disk[offset+510] = 0x55
+	disk[offset+511] = 0xaa
+}
+
+// guidBytes encodes a GUID string in the mixed-endian layout used by GPT.
+func guidBytes(t *testing.T, guid string) []byte {
+	raw, err := hex.DecodeString(strings.ReplaceAll(guid, "-", ""))
+	require.NoError(t, err)
+	require.Len(t, raw, 16)
+
+	// the first three fields are little-endian, the remainder are stored as-is
+	out := make([]byte, 16)
+	binary.LittleEndian.PutUint32(out[0:4], binary.BigEndian.Uint32(raw[0:4]))
+	binary.LittleEndian.PutUint16(out[4:6], binary.BigEndian.Uint16(raw[4:6]))
+	binary.LittleEndian.PutUint16(out[6:8], binary.BigEndian.Uint16(raw[6:8]))
+	copy(out[8:], raw[8:])
+	return out
+}
+
+func TestReadPartitions(t *testing.T) {
+	const diskSize = 8 * 1024 * 1024
+
+	tests := []struct {
+		name          string
+		disk          func(t *testing.T) []byte
+		expectedTable string
+		expected      []Partition
+	}{
+		{
+			name: "no partition table",
+			disk: func(t *testing.T) []byte {
+				return make([]byte, diskSize)
+			},
+			expectedTable: NoPartitionTable,
+			expected: []Partition{
+				{Number: 0, Type: "whole disk", Start: 0, Size: diskSize},
+			},
+		},
+		{
+			name: "boot sector without partitions",
+			disk: func(t *testing.T) []byte {
+				d := make([]byte, diskSize)
+				// e.g. a FAT boot sector has a boot signature but the partition entries are boot code
+				writeBootRecord(d, 0, []byte{0xeb, 0x3c, 0x90, 0x4d, 0x53, 0x57, 0x49, 0x4e, 0x34, 0x2e, 0x31, 0x00, 0x02, 0x04, 0x01, 0x00})
+				return d
+			},
+			expectedTable: NoPartitionTable,
+			expected: []Partition{
+				{Number: 0, Type: "whole disk", Start: 0, Size: diskSize},
+			},
+		},
+		{
+			name: "mbr with logical partitions",
+			disk: func(t *testing.T) []byte {
+				d := make([]byte, diskSize)
+				writeBootRecord(d, 0,
+					mbrEntryBytes(0x80, 0x83, 2048, 4096),
+					mbrEntryBytes(0x00, 0x05, 8192, 8192),
+				)
+				// the first logical partition and a link to the next EBR (relative to the extended partition start)
+				writeBootRecord(d, 8192,
+					mbrEntryBytes(0x00, 0x82, 2048, 1024),
+					mbrEntryBytes(0x00, 0x05, 4096, 4096),
+				)
+				writeBootRecord(d, 8192+4096,
+					mbrEntryBytes(0x00, 0x83, 2048, 2048),
+				)
+				return d
+			},
+			expectedTable: MBRPartitionTable,
+			expected: []Partition{
+				{Number: 1, Type: "Linux", Start: 2048 * sectorSize, Size: 4096 * sectorSize},
+				{Number: 5, Type: "Linux swap", Start: (8192 + 2048) * sectorSize, Size: 1024 * sectorSize},
+				{Number: 6, Type: "Linux", Start: (8192 + 4096 + 2048) * sectorSize, Size: 2048 * sectorSize},
+			},
+		},
+		{
+			name: "gpt",
+			disk: func(t *testing.T) []byte {
+				d := make([]byte, diskSize)
+				writeBootRecord(d, 0, mbrEntryBytes(0x00, mbrProtectiveType, 1, diskSize/sectorSize-1))
+
+				header := d[sectorSize : 2*sectorSize]
+				copy(header, gptSignature)
+				binary.LittleEndian.PutUint64(header[72:80], 2)
+				binary.LittleEndian.PutUint32(header[80:84], 128)
+				binary.LittleEndian.PutUint32(header[84:88], 128)
+
+				entry := func(i int, typeGUID, uniqueGUID string, first, last uint64, name string) {
+					raw := d[2*sectorSize+i*128 : 2*sectorSize+(i+1)*128]
+					copy(raw[0:16], guidBytes(t, typeGUID))
+					copy(raw[16:32], guidBytes(t, uniqueGUID))
+					binary.LittleEndian.PutUint64(raw[32:40], first)
+					binary.LittleEndian.PutUint64(raw[40:48], last)
+					for j, u := range utf16.Encode([]rune(name)) {
+						binary.LittleEndian.PutUint16(raw[56+j*2:], u)
+					}
+				}
+				entry(0, "C12A7328-F81F-11D2-BA4B-00A0C93EC93B", "0B1C2D3E-4F50-6172-8394-A5B6C7D8E9F0", 2048, 4095, "EFI")
+				// an empty slot between used entries should be skipped
+				entry(2, "4F68BCE3-E8CD-4DB1-96E7-FBCAF984B709", "1B1C2D3E-4F50-6172-8394-A5B6C7D8E9F0", 4096, 16383, "root")
+				return d
+			},
+			expectedTable: GPTPartitionTable,
+			expected: []Partition{
+				{
+					Number: 1,
+					Type:   "EFI System",
+					Name:   "EFI",
+					UUID:   "0B1C2D3E-4F50-6172-8394-A5B6C7D8E9F0",
+					Start:  2048 * sectorSize,
+					Size:   2048 * sectorSize,
+				},
+				{
+					Number: 3,
+					Type:   "Linux root (x86-64)",
+					Name:   "root",
+					UUID:   "1B1C2D3E-4F50-6172-8394-A5B6C7D8E9F0",
+					Start:  4096 * sectorSize,
+					Size:   12288 * sectorSize,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := test.disk(t)
+			table, partitions, err := ReadPartitions(bytes.NewReader(d), int64(len(d)))
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedTable, table)
+			assert.Equal(t, test.expected, partitions)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package disk
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// see https://gitlab.com/qemu-project/qemu/-/blob/master/docs/interop/qcow2.txt for the format specification
+
+const (
+	qcow2Magic = "QFI\xfb"
+
+	qcow2OffsetMask     = 0x00fffffffffffe00
+	qcow2CompressedFlag = uint64(1) << 62
+	qcow2ZeroFlag       = uint64(1)
+
+	// all other incompatible features (corrupt, external data file, extended L2 entries) are unsupported
+	qcow2IncompatDirty       = uint64(1) << 0
+	qcow2IncompatCompression = uint64(1) << 3
+
+	// the max number of backing files to follow (protects against cycles)
+	qcow2MaxBackingDepth = 16
+)
+
+var _ Image = (*qcow2Image)(nil)
+
+type qcow2Header struct {
+	Magic                 uint32
+	Version               uint32
+	BackingFileOffset     uint64
+	BackingFileSize       uint32
+	ClusterBits           uint32
+	Size                  uint64
+	CryptMethod           uint32
+	L1Size                uint32
+	L1TableOffset         uint64
+	RefcountTableOffset   uint64
+	RefcountTableClusters uint32
+	NbSnapshots           uint32
+	SnapshotsOffset       uint64
+}
+
+type qcow2HeaderV3 struct {
+	IncompatibleFeatures uint64
+	CompatibleFeatures   uint64
+	AutoclearFeatures    uint64
+	RefcountOrder        uint32
+	HeaderLength         uint32
+}
+
+// qcow2Image is a QEMU copy-on-write disk image (version 2 or 3), optionally backed by another image.
+type qcow2Image struct {
+	file        *os.File
+	header      qcow2Header
+	clusterSize int64
+	l1          []uint64
+	backing     Image
+
+	lock             sync.Mutex
+	l2Cache          map[uint64][]uint64
+	compressedOffset uint64
+	compressedData   []byte
+}
+
+func newQCOW2(f *os.File, path string) (*qcow2Image, error) {
+	return newQCOW2WithDepth(f, path, 0)
+}
+
+func newQCOW2WithDepth(f *os.File, path string, depth int) (*qcow2Image, error) {
+	var header qcow2Header
+	if err := binary.Read(io.NewSectionReader(f, 0, 72), binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("unable to read qcow2 header: %w", err)
+	}
+
+	if header.Version < 2 || header.Version > 3 {
+		return nil, fmt.Errorf("unsupported qcow2 version: %d", header.Version)
+	}
+
+	if header.CryptMethod != 0 {
+		return nil, fmt.Errorf("encrypted qcow2 images are not supported")
+	}
+
+	if header.ClusterBits < 9 || header.ClusterBits > 21 {
+		return nil, fmt.Errorf("invalid qcow2 cluster bits: %d", header.ClusterBits)
+	}
+
+	if header.Version == 3 {
+		var v3 qcow2HeaderV3
+		if err := binary.Read(io.NewSectionReader(f, 72, 32), binary.BigEndian, &v3); err != nil {
+			return nil, fmt.Errorf("unable to read qcow2 v3 header: %w", err)
+		}
+		unsupported := v3.IncompatibleFeatures &^ qcow2IncompatDirty
+		if unsupported&qcow2IncompatCompression != 0 {
+			// the compression type is only relevant if it is not zlib (the default)
+			compressionType := make([]byte, 1)
+			if _, err := f.ReadAt(compressionType, 104); err != nil {
+				return nil, fmt.Errorf("unable to read qcow2 compression type: %w", err)
+			}
+			if compressionType[0] == 0 {
+				unsupported &^= qcow2IncompatCompression
+			}
+		}
+		if unsupported != 0 {
+			return nil, fmt.Errorf("unsupported qcow2 incompatible features: %#x", unsupported)
+		}
+	}
+
+	img := &qcow2Image{
+		file:        f,
+		header:      header,
+		clusterSize: int64(1) << header.ClusterBits,
+		l2Cache:     make(map[uint64][]uint64),
+	}
+
+	l1, err := readUint64Table(f, int64(header.L1TableOffset), int(header.L1Size))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read qcow2 L1 table: %w", err)
+	}
+	img.l1 = l1
+
+	if header.BackingFileOffset != 0 && header.BackingFileSize != 0 {
+		backing, err := openQCOW2Backing(f, path, header, depth)
+		if err != nil {
+			return nil, err
+		}
+		img.backing = backing
+	}
+
+	return img, nil
+}
+
+func openQCOW2Backing(f *os.File, path string, header qcow2Header, depth int) (Image, error) {
+	if depth >= qcow2MaxBackingDepth {
+		return nil, fmt.Errorf("too many qcow2 backing files")
+	}
+
+	name := make([]byte, header.BackingFileSize)
+	if _, err := f.ReadAt(name, int64(header.BackingFileOffset)); err != nil {
+		return nil, fmt.Errorf("unable to read qcow2 backing file name: %w", err)
+	}
+
+	backingPath := string(name)
+	if !filepath.IsAbs(backingPath) {
+		backingPath = filepath.Join(filepath.Dir(path), backingPath)
+	}
+
+	bf, err := os.Open(backingPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open qcow2 backing file=%q: %w", backingPath, err)
+	}
+
+	magic := make([]byte, len(qcow2Magic))
+	if _, err := bf.ReadAt(magic, 0); err == nil && string(magic) == qcow2Magic {
+		img, err := newQCOW2WithDepth(bf, backingPath, depth+1)
+		if err != nil {
+			bf.Close()
+			return nil, fmt.Errorf("unable to read qcow2 backing file=%q: %w", backingPath, err)
+		}
+		return img, nil
+	}
+
+	img, err := newRaw(bf)
+	if err != nil {
+		bf.Close()
+		return nil, err
+	}
+	return img, nil
+}
+
+func (q *qcow2Image) Size() int64 {
+	return int64(q.header.Size)
+}
+
+func (q *qcow2Image) Format() string {
+	return QCOW2Format
+}
+
+func (q *qcow2Image) Close() error {
+	if q.backing != nil {
+		_ = q.backing.Close()
+	}
+	return q.file.Close()
+}
+
+func (q *qcow2Image) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset")
+	}
+
+	var n int
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= q.Size() {
+			return n, io.EOF
+		}
+
+		inCluster := pos % q.clusterSize
+		chunk := len(p) - n
+		if remaining := q.clusterSize - inCluster; int64(chunk) > remaining {
+			chunk = int(remaining)
+		}
+		if remaining := q.Size() - pos; int64(chunk) > remaining {
+			chunk = int(remaining)
+		}
+
+		if err := q.readCluster(p[n:n+chunk], pos, inCluster); err != nil {
+			return n, err
+		}
+		n += chunk
+	}
+	return n, nil
+}
+
+// readCluster fills the given buffer from a single guest cluster, starting at the given guest offset.
+func (q *qcow2Image) readCluster(p []byte, pos, inCluster int64) error {
+	entry, err := q.l2Entry(pos)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case entry&qcow2CompressedFlag != 0:
+		data, err := q.compressedCluster(entry)
+		if err != nil {
+			return err
+		}
+		copy(p, data[inCluster:])
+	case entry&qcow2ZeroFlag != 0:
+		// note: the host offset may still be set (describing preallocation), but the guest reads zeros
+		zeroFill(p)
+	case entry&qcow2OffsetMask != 0:
+		if _, err := q.file.ReadAt(p, int64(entry&qcow2OffsetMask)+inCluster); err != nil && err != io.EOF {
+			return fmt.Errorf("unable to read qcow2 cluster: %w", err)
+		}
+	case q.backing != nil:
+		// unallocated: fall through to the backing image (which may be smaller than this image)
+		zeroFill(p)
+		if pos < q.backing.Size() {
+			if _, err := q.backing.ReadAt(p, pos); err != nil && err != io.EOF {
+				return fmt.Errorf("unable to read qcow2 backing image: %w", err)
+			}
+		}
+	default:
+		zeroFill(p)
+	}
+	return nil
+}
+
+// l2Entry returns the (raw) L2 table entry for the cluster holding the given guest offset.
+func (q *qcow2Image) l2Entry(pos int64) (uint64, error) {
+	l2Entries := q.clusterSize / 8
+	clusterIndex := pos / q.clusterSize
+	l1Index := clusterIndex / l2Entries
+	l2Index := clusterIndex % l2Entries
+
+	if l1Index >= int64(len(q.l1)) {
+		return 0, nil
+	}
+
+	l2Offset := q.l1[l1Index] & qcow2OffsetMask
+	if l2Offset == 0 {
+		return 0, nil
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	table, ok := q.l2Cache[l2Offset]
+	if !ok {
+		var err error
+		table, err = readUint64Table(q.file, int64(l2Offset), int(l2Entries))
+		if err != nil {
+			return 0, fmt.Errorf("unable to read qcow2 L2 table: %w", err)
+		}
+		q.l2Cache[l2Offset] = table
+	}
+	return table[l2Index], nil
+}
+
+// compressedCluster returns the inflated contents of a compressed cluster described by the given L2 entry.
+func (q *qcow2Image) compressedCluster(entry uint64) ([]byte, error) {
+	shift := 62 - (q.header.ClusterBits - 8)
+	offset := entry & ((uint64(1) << shift) - 1)
+	sectors := (entry>>shift)&((uint64(1)<<(q.header.ClusterBits-8))-1) + 1
+	size := int64(sectors*512) - int64(offset&511)
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.compressedData != nil && q.compressedOffset == offset {
+		return q.compressedData, nil
+	}
+
+	raw := make([]byte, size)
+	n, err := q.file.ReadAt(raw, int64(offset))
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to read compressed qcow2 cluster: %w", err)
+	}
+
+	data := make([]byte, q.clusterSize)
+	// note: the compressed size is an upper bound, so reading less than a full cluster is an error but trailing
+	// data after the deflate stream is not
+	if _, err := io.ReadFull(flate.NewReader(bytes.NewReader(raw[:n])), data); err != nil {
+		return nil, fmt.Errorf("unable to inflate compressed qcow2 cluster: %w", err)
+	}
+
+	q.compressedOffset = offset
+	q.compressedData = data
+	return data, nil
+}
+
+func readUint64Table(r io.ReaderAt, offset int64, count int) ([]uint64, error) {
+	table := make([]uint64, count)
+	if err := binary.Read(io.NewSectionReader(r, offset, int64(count)*8), binary.BigEndian, table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
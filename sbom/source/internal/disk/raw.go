@@ -0,0 +1,32 @@
+package disk
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+var _ Image = (*rawImage)(nil)
+
+// rawImage is a disk image where the file contents are the disk contents (e.g. .img, .raw, or dd output).
+type rawImage struct {
+	*os.File
+	size int64
+}
+
+func newRaw(f *os.File) (*rawImage, error) {
+	// seeking (instead of stat-ing) allows for block devices to be read as raw images too
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine raw image size: %w", err)
+	}
+	return &rawImage{File: f, size: size}, nil
+}
+
+func (r *rawImage) Size() int64 {
+	return r.size
+}
+
+func (r *rawImage) Format() string {
+	return RawFormat
+}
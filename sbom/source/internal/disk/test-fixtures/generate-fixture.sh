@@ -0,0 +1,20 @@
+#!/usr/bin/env bash
+set -eux
+
+# creates a small GPT partitioned qcow2 disk image with an (unformatted) BIOS boot partition and an ext4 root
+# partition populated from the image-root directory. Requires mke2fs, sgdisk, and qemu-img.
+
+cd "$(dirname "$0")"
+
+function cleanup {
+  rm -f root.img disk.raw
+}
+trap cleanup EXIT
+
+mke2fs -q -t ext4 -O ^has_journal -b 1024 -d image-root root.img 2M
+
+truncate -s 4M disk.raw
+sgdisk -n 1:34:2047 -t 1:EF02 -c 1:bios -n 2:2048:6143 -t 2:8304 -c 2:root disk.raw
+dd if=root.img of=disk.raw bs=512 seek=2048 conv=notrunc
+
+qemu-img convert -c -f raw -O qcow2 -o cluster_size=4096 disk.raw gpt-ext4.qcow2
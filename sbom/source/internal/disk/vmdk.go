@@ -0,0 +1,422 @@
+package disk
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// see https://www.vmware.com/app/vmdk/?src=vmdk ("Virtual Disk Format 1.1") for the format specification
+
+const (
+	vmdkSparseMagic      = "KDMV"
+	vmdkDescriptorMarker = "# Disk DescriptorFile"
+
+	vmdkSectorSize = 512
+	// the max number of sectors that can be addressed (so byte offsets never overflow)
+	vmdkMaxSectors = int64(1) << 54
+	// the max grain size (in sectors) and grain table size (in entries) to hold in memory
+	vmdkMaxGrainSize = 1 << 16
+	vmdkMaxGTEsPerGT = 1 << 16
+	// the max number of grain tables to cache (each is at most 256 KiB)
+	vmdkMaxCachedGTs = 64
+
+	vmdkFlagCompressed = uint32(1) << 16
+
+	// indicates that the grain directory location is in the footer (stream optimized images)
+	vmdkGDAtEnd = uint64(0xffffffffffffffff)
+)
+
+var (
+	_ Image = (*vmdkSparseImage)(nil)
+	_ Image = (*multiExtentImage)(nil)
+
+	// e.g. RW 4192256 SPARSE "disk-s001.vmdk" or RW 41943040 FLAT "disk-flat.vmdk" 0
+	vmdkExtentPattern = regexp.MustCompile(`^(RW|RDONLY|NOACCESS)\s+(\d+)\s+(\w+)(?:\s+"([^"]+)"(?:\s+(\d+))?)?`)
+)
+
+type vmdkSparseHeader struct {
+	MagicNumber        uint32
+	Version            uint32
+	Flags              uint32
+	Capacity           uint64
+	GrainSize          uint64
+	DescriptorOffset   uint64
+	DescriptorSize     uint64
+	NumGTEsPerGT       uint32
+	RGDOffset          uint64
+	GDOffset           uint64
+	OverHead           uint64
+	UncleanShutdown    uint8
+	SingleEndLineChar  uint8
+	NonEndLineChar     uint8
+	DoubleEndLineChar1 uint8
+	DoubleEndLineChar2 uint8
+	CompressAlgorithm  uint16
+}
+
+// vmdkSparseImage is a single hosted sparse extent (monolithicSparse, twoGbMaxExtentSparse parts, or streamOptimized).
+type vmdkSparseImage struct {
+	file      *os.File
+	header    vmdkSparseHeader
+	grainSize int64
+	gd        []uint32
+
+	lock        sync.Mutex
+	gtCache     map[uint32][]uint32
+	grainSector uint32
+	grainData   []byte
+}
+
+func newVMDKSparse(f *os.File) (*vmdkSparseImage, error) {
+	header, err := readVMDKSparseHeader(f, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("unable to stat vmdk: %w", err)
+	}
+
+	if header.GDOffset == vmdkGDAtEnd {
+		// stream optimized images keep the real header in a footer (the second to last sector of the file)
+		header, err = readVMDKSparseHeader(f, info.Size()-2*vmdkSectorSize)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read vmdk footer: %w", err)
+		}
+	}
+
+	if header.GrainSize == 0 || header.GrainSize > vmdkMaxGrainSize || header.NumGTEsPerGT == 0 ||
+		header.NumGTEsPerGT > vmdkMaxGTEsPerGT || header.Capacity > uint64(vmdkMaxSectors) {
+		return nil, fmt.Errorf("invalid vmdk sparse header")
+	}
+
+	if header.Flags&vmdkFlagCompressed != 0 && header.CompressAlgorithm != 1 {
+		return nil, fmt.Errorf("unsupported vmdk compression algorithm: %d", header.CompressAlgorithm)
+	}
+
+	img := &vmdkSparseImage{
+		file:      f,
+		header:    header,
+		grainSize: int64(header.GrainSize) * vmdkSectorSize,
+		gtCache:   make(map[uint32][]uint32),
+	}
+
+	// the grain directory must be within the file
+	gtCoverage := header.GrainSize * uint64(header.NumGTEsPerGT)
+	gdEntries := (header.Capacity + gtCoverage - 1) / gtCoverage
+	if int64(gdEntries)*4 > info.Size() {
+		return nil, fmt.Errorf("invalid vmdk grain directory size: %d entries", gdEntries)
+	}
+	img.gd = make([]uint32, gdEntries)
+	if err := binary.Read(io.NewSectionReader(f, int64(header.GDOffset)*vmdkSectorSize, int64(gdEntries)*4), binary.LittleEndian, img.gd); err != nil {
+		return nil, fmt.Errorf("unable to read vmdk grain directory: %w", err)
+	}
+
+	return img, nil
+}
+
+func readVMDKSparseHeader(f *os.File, offset int64) (vmdkSparseHeader, error) {
+	var header vmdkSparseHeader
+	if err := binary.Read(io.NewSectionReader(f, offset, 79), binary.LittleEndian, &header); err != nil {
+		return header, fmt.Errorf("unable to read vmdk sparse header: %w", err)
+	}
+	if header.MagicNumber != binary.LittleEndian.Uint32([]byte(vmdkSparseMagic)) {
+		return header, fmt.Errorf("invalid vmdk sparse header magic")
+	}
+	return header, nil
+}
+
+func (v *vmdkSparseImage) Size() int64 {
+	return int64(v.header.Capacity) * vmdkSectorSize
+}
+
+func (v *vmdkSparseImage) Format() string {
+	return VMDKFormat
+}
+
+func (v *vmdkSparseImage) Close() error {
+	return v.file.Close()
+}
+
+func (v *vmdkSparseImage) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset")
+	}
+
+	var n int
+	for n < len(p) {
+		pos := off + int64(n)
+		if pos >= v.Size() {
+			return n, io.EOF
+		}
+
+		inGrain := pos % v.grainSize
+		chunk := len(p) - n
+		if remaining := v.grainSize - inGrain; int64(chunk) > remaining {
+			chunk = int(remaining)
+		}
+		if remaining := v.Size() - pos; int64(chunk) > remaining {
+			chunk = int(remaining)
+		}
+
+		if err := v.readGrain(p[n:n+chunk], pos/v.grainSize, inGrain); err != nil {
+			return n, err
+		}
+		n += chunk
+	}
+	return n, nil
+}
+
+func (v *vmdkSparseImage) readGrain(p []byte, grain, inGrain int64) error {
+	sector, err := v.grainTableEntry(grain)
+	if err != nil {
+		return err
+	}
+
+	// sector 0 is an unallocated grain and sector 1 is an explicitly zeroed grain
+	if sector <= 1 {
+		zeroFill(p)
+		return nil
+	}
+
+	if v.header.Flags&vmdkFlagCompressed == 0 {
+		n, err := v.file.ReadAt(p, int64(sector)*vmdkSectorSize+inGrain)
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("unable to read vmdk grain: %w", err)
+		}
+		// grains that extend past the end of the file (i.e. truncated images) read as zeros
+		zeroFill(p[n:])
+		return nil
+	}
+
+	data, err := v.compressedGrain(sector)
+	if err != nil {
+		return err
+	}
+	copy(p, data[inGrain:])
+	return nil
+}
+
+func (v *vmdkSparseImage) grainTableEntry(grain int64) (uint32, error) {
+	gdIndex := grain / int64(v.header.NumGTEsPerGT)
+	gtIndex := grain % int64(v.header.NumGTEsPerGT)
+	if gdIndex >= int64(len(v.gd)) {
+		return 0, nil
+	}
+
+	gtSector := v.gd[gdIndex]
+	if gtSector == 0 {
+		return 0, nil
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	table, ok := v.gtCache[gtSector]
+	if !ok {
+		table = make([]uint32, v.header.NumGTEsPerGT)
+		if err := binary.Read(io.NewSectionReader(v.file, int64(gtSector)*vmdkSectorSize, int64(len(table))*4), binary.LittleEndian, table); err != nil {
+			return 0, fmt.Errorf("unable to read vmdk grain table: %w", err)
+		}
+		if len(v.gtCache) >= vmdkMaxCachedGTs {
+			v.gtCache = make(map[uint32][]uint32)
+		}
+		v.gtCache[gtSector] = table
+	}
+	return table[gtIndex], nil
+}
+
+// compressedGrain returns the inflated contents of a compressed grain which starts at the given sector.
+func (v *vmdkSparseImage) compressedGrain(sector uint32) ([]byte, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.grainData != nil && v.grainSector == sector {
+		return v.grainData, nil
+	}
+
+	// each compressed grain is prefixed with the LBA (uint64) and the compressed size (uint32)
+	header := make([]byte, 12)
+	if _, err := v.file.ReadAt(header, int64(sector)*vmdkSectorSize); err != nil {
+		return nil, fmt.Errorf("unable to read vmdk compressed grain header: %w", err)
+	}
+	size := binary.LittleEndian.Uint32(header[8:])
+
+	zr, err := zlib.NewReader(io.NewSectionReader(v.file, int64(sector)*vmdkSectorSize+12, int64(size)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read vmdk compressed grain: %w", err)
+	}
+	defer zr.Close()
+
+	data := make([]byte, v.grainSize)
+	if _, err := io.ReadFull(zr, data); err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("unable to inflate vmdk compressed grain: %w", err)
+	}
+
+	v.grainSector = sector
+	v.grainData = data
+	return data, nil
+}
+
+// extent is a region of a larger virtual disk that is backed by a separate image (or by nothing).
+type extent struct {
+	image  Image // nil for zero extents
+	offset int64 // offset within the backing image
+	size   int64
+}
+
+// multiExtentImage is a virtual disk made up of one or more extents (described by a vmdk descriptor file).
+type multiExtentImage struct {
+	format  string
+	extents []extent
+	size    int64
+}
+
+func newVMDKFromDescriptor(path string) (*multiExtentImage, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open vmdk descriptor=%q: %w", path, err)
+	}
+	defer f.Close()
+
+	img := &multiExtentImage{format: VMDKFormat}
+	scanner := bufio.NewScanner(io.LimitReader(f, 1024*1024))
+	for scanner.Scan() {
+		match := vmdkExtentPattern.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+
+		sectors, err := strconv.ParseInt(match[2], 10, 64)
+		if err != nil || sectors > vmdkMaxSectors {
+			img.Close()
+			return nil, fmt.Errorf("invalid vmdk extent size: %q", match[2])
+		}
+
+		e := extent{size: sectors * vmdkSectorSize}
+		switch match[3] {
+		case "ZERO":
+		case "FLAT", "SPARSE":
+			extentPath := match[4]
+			if !filepath.IsAbs(extentPath) {
+				extentPath = filepath.Join(filepath.Dir(path), extentPath)
+			}
+			if e.image, err = openVMDKExtent(extentPath); err != nil {
+				img.Close()
+				return nil, err
+			}
+			if match[5] != "" {
+				offset, err := strconv.ParseInt(match[5], 10, 64)
+				if err != nil || offset > vmdkMaxSectors {
+					img.Close()
+					return nil, fmt.Errorf("invalid vmdk extent offset: %q", match[5])
+				}
+				e.offset = offset * vmdkSectorSize
+			}
+		default:
+			img.Close()
+			return nil, fmt.Errorf("unsupported vmdk extent type: %q", match[3])
+		}
+
+		img.extents = append(img.extents, e)
+		img.size += e.size
+	}
+
+	if err := scanner.Err(); err != nil {
+		img.Close()
+		return nil, fmt.Errorf("unable to read vmdk descriptor=%q: %w", path, err)
+	}
+
+	if len(img.extents) == 0 {
+		return nil, fmt.Errorf("no extents found in vmdk descriptor=%q", path)
+	}
+
+	return img, nil
+}
+
+func openVMDKExtent(path string) (Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open vmdk extent=%q: %w", path, err)
+	}
+
+	magic := make([]byte, len(vmdkSparseMagic))
+	var img Image
+	if _, err := f.ReadAt(magic, 0); err == nil && bytes.Equal(magic, []byte(vmdkSparseMagic)) {
+		img, err = newVMDKSparse(f)
+	} else {
+		img, err = newRaw(f)
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("unable to read vmdk extent=%q: %w", path, err)
+	}
+	return img, nil
+}
+
+func (m *multiExtentImage) Size() int64 {
+	return m.size
+}
+
+func (m *multiExtentImage) Format() string {
+	return m.format
+}
+
+func (m *multiExtentImage) Close() error {
+	for _, e := range m.extents {
+		if e.image != nil {
+			_ = e.image.Close()
+		}
+	}
+	return nil
+}
+
+func (m *multiExtentImage) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset")
+	}
+
+	var n int
+	var start int64
+	for _, e := range m.extents {
+		end := start + e.size
+		pos := off + int64(n)
+		if n >= len(p) {
+			break
+		}
+		if pos >= end {
+			start = end
+			continue
+		}
+
+		chunk := len(p) - n
+		if remaining := end - pos; int64(chunk) > remaining {
+			chunk = int(remaining)
+		}
+
+		buf := p[n : n+chunk]
+		if e.image == nil {
+			zeroFill(buf)
+		} else if _, err := e.image.ReadAt(buf, e.offset+pos-start); err != nil && err != io.EOF {
+			return n, err
+		}
+		n += chunk
+		start = end
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
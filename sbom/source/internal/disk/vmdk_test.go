@@ -0,0 +1,402 @@
+package disk
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testVMDKGrainSectors = 8
+	testVMDKGTEsPerGT    = 512
+
+	// the first sector of the partition holding the xfs filesystem
+	testVMDKPartitionStart = 8
+)
+
+// newTestVMDKDisk returns the raw contents of an MBR partitioned disk with a single xfs partition (see newTestXFS).
+func newTestVMDKDisk() []byte {
+	xfs := newTestXFS().data
+	disk := make([]byte, testVMDKPartitionStart*sectorSize+len(xfs))
+	writeBootRecord(disk, 0, mbrEntryBytes(0x80, 0x83, testVMDKPartitionStart, uint32(len(xfs)/sectorSize)))
+	copy(disk[testVMDKPartitionStart*sectorSize:], xfs)
+	return disk
+}
+
+func encodeVMDKHeader(header vmdkSparseHeader) []byte {
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, header)
+	sector := make([]byte, vmdkSectorSize)
+	copy(sector, buf.Bytes())
+	return sector
+}
+
+func padVMDKSector(b []byte) []byte {
+	if rem := len(b) % vmdkSectorSize; rem != 0 {
+		b = append(b, make([]byte, vmdkSectorSize-rem)...)
+	}
+	return b
+}
+
+// newSparseVMDK returns a hosted sparse extent with the given contents (grains that are entirely zero are left
+// unallocated). Stream optimized extents compress each grain and keep the grain directory location in a footer.
+func newSparseVMDK(contents []byte, streamOptimized bool) []byte {
+	capacity := uint64((len(contents) + vmdkSectorSize - 1) / vmdkSectorSize)
+	header := vmdkSparseHeader{
+		MagicNumber:        binary.LittleEndian.Uint32([]byte(vmdkSparseMagic)),
+		Version:            1,
+		Flags:              1,
+		Capacity:           capacity,
+		GrainSize:          testVMDKGrainSectors,
+		NumGTEsPerGT:       testVMDKGTEsPerGT,
+		SingleEndLineChar:  '\n',
+		NonEndLineChar:     ' ',
+		DoubleEndLineChar1: '\r',
+		DoubleEndLineChar2: '\n',
+	}
+	if streamOptimized {
+		header.Version = 3
+		header.Flags |= vmdkFlagCompressed
+		header.CompressAlgorithm = 1
+	}
+
+	grainBytes := testVMDKGrainSectors * vmdkSectorSize
+	grains := (len(contents) + grainBytes - 1) / grainBytes
+	gtCoverage := testVMDKGrainSectors * testVMDKGTEsPerGT
+	gdEntries := (int(capacity) + gtCoverage - 1) / gtCoverage
+
+	// grains are written after the header and embedded descriptor, followed by the grain tables and directory (grains
+	// are never at sector 0 or 1, since those values mark unallocated and zeroed grains)
+	header.DescriptorOffset = 1
+	header.DescriptorSize = 1
+	out := make([]byte, 2*vmdkSectorSize)
+	copy(out[vmdkSectorSize:], fmt.Sprintf("%s\nversion=1\nRW %d SPARSE \"disk.vmdk\"\n", vmdkDescriptorMarker, capacity))
+	gt := make([]uint32, gdEntries*testVMDKGTEsPerGT)
+	for i := 0; i < grains; i++ {
+		grain := make([]byte, grainBytes)
+		copy(grain, contents[i*grainBytes:])
+		if bytes.Equal(grain, make([]byte, grainBytes)) {
+			continue
+		}
+
+		gt[i] = uint32(len(out) / vmdkSectorSize)
+		if !streamOptimized {
+			out = append(out, grain...)
+			continue
+		}
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		_, _ = zw.Write(grain)
+		_ = zw.Close()
+
+		marker := make([]byte, 12)
+		binary.LittleEndian.PutUint64(marker[0:8], uint64(i*testVMDKGrainSectors))
+		binary.LittleEndian.PutUint32(marker[8:12], uint32(compressed.Len()))
+		out = padVMDKSector(append(append(out, marker...), compressed.Bytes()...))
+	}
+
+	gtStart := len(out) / vmdkSectorSize
+	var tables bytes.Buffer
+	_ = binary.Write(&tables, binary.LittleEndian, gt)
+	out = padVMDKSector(append(out, tables.Bytes()...))
+
+	gd := make([]uint32, gdEntries)
+	for i := range gd {
+		gd[i] = uint32(gtStart + i*testVMDKGTEsPerGT*4/vmdkSectorSize)
+	}
+	header.GDOffset = uint64(len(out) / vmdkSectorSize)
+	var directory bytes.Buffer
+	_ = binary.Write(&directory, binary.LittleEndian, gd)
+	out = padVMDKSector(append(out, directory.Bytes()...))
+
+	if !streamOptimized {
+		copy(out, encodeVMDKHeader(header))
+		return out
+	}
+
+	// the footer is followed by an end-of-stream marker
+	footer := header
+	header.GDOffset = vmdkGDAtEnd
+	copy(out, encodeVMDKHeader(header))
+	out = append(out, encodeVMDKHeader(footer)...)
+	return append(out, make([]byte, vmdkSectorSize)...)
+}
+
+func writeTestFile(t *testing.T, dir, name string, contents []byte) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(p, contents, 0o600))
+	return p
+}
+
+// assertTestVMDK checks that the image at the given path presents the given disk, and that the filesystem within can
+// be read through it.
+func assertTestVMDK(t *testing.T, path string, disk []byte) {
+	t.Helper()
+
+	img, err := Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, img.Close())
+	})
+
+	assert.Equal(t, VMDKFormat, img.Format())
+	assert.Equal(t, int64(len(disk)), img.Size())
+
+	contents := make([]byte, img.Size())
+	_, err = img.ReadAt(contents, 0)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(disk, contents), "image contents differ from the disk")
+
+	// reads that span grains and extents, and reads past the end of the disk
+	buf := make([]byte, 3*testVMDKGrainSectors*vmdkSectorSize)
+	_, err = img.ReadAt(buf, 1234)
+	require.NoError(t, err)
+	assert.Equal(t, disk[1234:1234+len(buf)], buf)
+
+	n, err := img.ReadAt(buf, img.Size()-10)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 10, n)
+
+	table, partitions, err := ReadPartitions(img, img.Size())
+	require.NoError(t, err)
+	assert.Equal(t, MBRPartitionTable, table)
+	require.Len(t, partitions, 1)
+
+	fsys, err := OpenFilesystem(partitions[0].Reader(img), partitions[0].Size)
+	require.NoError(t, err)
+	assert.Equal(t, XFSFilesystem, fsys.Type)
+	assert.Contains(t, walkPaths(t, fsys), "usr/lib/os-release")
+
+	contents, err = fs.ReadFile(fsys, "usr/lib/os-release")
+	require.NoError(t, err)
+	assert.Equal(t, []byte(osRelease), contents[:len(osRelease)])
+}
+
+func TestVMDK(t *testing.T) {
+	disk := newTestVMDKDisk()
+
+	// the last 4 KiB of the disk are unused by the filesystem
+	zeroSectors := 8
+	require.Equal(t, make([]byte, zeroSectors*vmdkSectorSize), disk[len(disk)-zeroSectors*vmdkSectorSize:])
+	diskSectors := len(disk) / vmdkSectorSize
+	flatSectors := diskSectors / 2
+
+	tests := []struct {
+		name  string
+		write func(dir string) string
+	}{
+		{
+			name: "monolithicSparse",
+			write: func(dir string) string {
+				return writeTestFile(t, dir, "disk.vmdk", newSparseVMDK(disk, false))
+			},
+		},
+		{
+			name: "streamOptimized",
+			write: func(dir string) string {
+				return writeTestFile(t, dir, "disk.vmdk", newSparseVMDK(disk, true))
+			},
+		},
+		{
+			name: "monolithicFlat",
+			write: func(dir string) string {
+				// the flat extent starts one sector into the file
+				writeTestFile(t, dir, "disk-flat.vmdk", append(make([]byte, vmdkSectorSize), disk[:len(disk)-zeroSectors*vmdkSectorSize]...))
+				return writeTestFile(t, dir, "disk.vmdk", []byte(fmt.Sprintf(`# Disk DescriptorFile
+version=1
+CID=fffffffe
+parentCID=ffffffff
+createType="monolithicFlat"
+
+# Extent description
+RW %d FLAT "disk-flat.vmdk" 1
+RW %d ZERO
+
+ddb.virtualHWVersion = "4"
+`, diskSectors-zeroSectors, zeroSectors)))
+			},
+		},
+		{
+			name: "twoGbMaxExtentSparse",
+			write: func(dir string) string {
+				writeTestFile(t, dir, "disk-s001.vmdk", newSparseVMDK(disk[:flatSectors*vmdkSectorSize], false))
+				writeTestFile(t, dir, "disk-s002.vmdk", newSparseVMDK(disk[flatSectors*vmdkSectorSize:], false))
+				return writeTestFile(t, dir, "disk.vmdk", []byte(fmt.Sprintf(`# Disk DescriptorFile
+version=1
+createType="twoGbMaxExtentSparse"
+
+RW %d SPARSE "disk-s001.vmdk"
+RW %d SPARSE "%s"
+`, flatSectors, diskSectors-flatSectors, filepath.Join(dir, "disk-s002.vmdk"))))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertTestVMDK(t, test.write(t.TempDir()), disk)
+		})
+	}
+}
+
+func TestVMDK_Malformed(t *testing.T) {
+	disk := newTestVMDKDisk()
+
+	// sparse rewrites the header of a sparse extent
+	sparse := func(modify func(h *vmdkSparseHeader)) func(dir string) string {
+		return func(dir string) string {
+			image := newSparseVMDK(disk, false)
+			var header vmdkSparseHeader
+			require.NoError(t, binary.Read(bytes.NewReader(image), binary.LittleEndian, &header))
+			modify(&header)
+			copy(image, encodeVMDKHeader(header))
+			return writeTestFile(t, dir, "disk.vmdk", image)
+		}
+	}
+
+	descriptor := func(extents string) func(dir string) string {
+		return func(dir string) string {
+			writeTestFile(t, dir, "disk-flat.vmdk", disk)
+			return writeTestFile(t, dir, "disk.vmdk", []byte("# Disk DescriptorFile\nversion=1\n"+extents))
+		}
+	}
+
+	tests := []struct {
+		name  string
+		write func(dir string) string
+	}{
+		{
+			name: "capacity beyond the max addressable size",
+			write: sparse(func(h *vmdkSparseHeader) {
+				h.Capacity = 1 << 62
+			}),
+		},
+		{
+			name: "zero grain size",
+			write: sparse(func(h *vmdkSparseHeader) {
+				h.GrainSize = 0
+			}),
+		},
+		{
+			name: "huge grain size",
+			write: sparse(func(h *vmdkSparseHeader) {
+				h.GrainSize = 1 << 40
+			}),
+		},
+		{
+			name: "huge grain tables",
+			write: sparse(func(h *vmdkSparseHeader) {
+				h.NumGTEsPerGT = 1 << 30
+			}),
+		},
+		{
+			name: "grain directory larger than the file",
+			write: sparse(func(h *vmdkSparseHeader) {
+				h.Capacity = uint64(vmdkMaxSectors)
+				h.GrainSize = 1
+				h.NumGTEsPerGT = 1
+			}),
+		},
+		{
+			name: "grain directory beyond the end of the file",
+			write: sparse(func(h *vmdkSparseHeader) {
+				h.GDOffset = 1 << 40
+			}),
+		},
+		{
+			name: "unsupported compression",
+			write: sparse(func(h *vmdkSparseHeader) {
+				h.Flags |= vmdkFlagCompressed
+				h.CompressAlgorithm = 2
+			}),
+		},
+		{
+			name: "missing stream optimized footer",
+			write: func(dir string) string {
+				image := newSparseVMDK(disk, true)
+				return writeTestFile(t, dir, "disk.vmdk", image[:len(image)-2*vmdkSectorSize])
+			},
+		},
+		{
+			name:  "descriptor without extents",
+			write: descriptor(""),
+		},
+		{
+			name:  "missing extent file",
+			write: descriptor(`RW 520 FLAT "missing.vmdk" 0` + "\n"),
+		},
+		{
+			name:  "unsupported extent type",
+			write: descriptor(`RW 520 VMFS "disk-flat.vmdk"` + "\n"),
+		},
+		{
+			name:  "extent beyond the max addressable size",
+			write: descriptor(`RW 99999999999999999 FLAT "disk-flat.vmdk" 0` + "\n"),
+		},
+		{
+			name:  "extent offset beyond the max addressable size",
+			write: descriptor(`RW 520 FLAT "disk-flat.vmdk" 99999999999999999` + "\n"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			img, err := Open(test.write(t.TempDir()))
+			if err == nil {
+				img.Close()
+			}
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestVMDK_CorruptGrainTables(t *testing.T) {
+	disk := newTestVMDKDisk()
+
+	for _, streamOptimized := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streamOptimized=%v", streamOptimized), func(t *testing.T) {
+			image := newSparseVMDK(disk, streamOptimized)
+
+			// point every allocated grain beyond the end of the file
+			var header vmdkSparseHeader
+			headerOffset := 0
+			if streamOptimized {
+				headerOffset = len(image) - 2*vmdkSectorSize
+			}
+			require.NoError(t, binary.Read(bytes.NewReader(image[headerOffset:]), binary.LittleEndian, &header))
+			gtStart := binary.LittleEndian.Uint32(image[header.GDOffset*vmdkSectorSize:])
+			gt := image[gtStart*vmdkSectorSize : gtStart*vmdkSectorSize+testVMDKGTEsPerGT*4]
+			for i := 0; i < len(gt); i += 4 {
+				if binary.LittleEndian.Uint32(gt[i:]) != 0 {
+					binary.LittleEndian.PutUint32(gt[i:], 0xffffffff)
+				}
+			}
+
+			img, err := Open(writeTestFile(t, t.TempDir(), "disk.vmdk", image))
+			require.NoError(t, err)
+			defer img.Close()
+
+			contents := bytes.Repeat([]byte{0xff}, int(img.Size()))
+			_, err = img.ReadAt(contents, 0)
+			if streamOptimized {
+				// compressed grains cannot be read
+				require.Error(t, err)
+				return
+			}
+			// uncompressed grains beyond the end of the file read as zeros (never as stale buffer contents)
+			require.NoError(t, err)
+			assert.Equal(t, make([]byte, len(contents)), contents)
+		})
+	}
+}
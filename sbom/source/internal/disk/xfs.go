@@ -0,0 +1,544 @@
+package disk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+)
+
+// see https://www.kernel.org/pub/linux/utils/fs/xfs/docs/xfs_filesystem_structure.pdf for the on-disk format (all
+// values are big-endian)
+
+const (
+	xfsMagic      = "XFSB"
+	xfsInodeMagic = 0x494e // "IN"
+
+	xfsVersionMask = 0x000f
+	xfsVersion5    = 5
+
+	xfsFeatures2FileType = 0x0200
+	xfsIncompatFileType  = 0x0001
+	xfsIncompatBigTime   = 0x0008
+	xfsIncompatNRExt64   = 0x0020
+
+	xfsInodeFlagRealtime   = 0x0001
+	xfsInodeFlag2BigTime   = 0x0008
+	xfsInodeFlag2NRExt64   = 0x0010
+	xfsInodeCoreSizeV2     = 100
+	xfsInodeCoreSizeV3     = 176
+	xfsBigTimeEpochOffset  = 1 << 31
+	xfsMaxBtreeDepth       = 8
+	xfsBtreeHeaderSizeV4   = 24
+	xfsBtreeHeaderSizeV5   = 72
+	xfsDirHeaderSizeV4     = 16
+	xfsDirHeaderSizeV5     = 64
+	xfsSymlinkHeaderSizeV5 = 56
+	xfsMaxSymlinkSize      = 1024
+
+	xfsFormatLocal   = 1
+	xfsFormatExtents = 2
+	xfsFormatBtree   = 3
+
+	// directory data blocks are stored below this byte offset (leaf and free index blocks are stored above it)
+	xfsDirLeafOffset = 32 << 30
+	xfsDirFreeTag    = 0xffff
+)
+
+var _ driver = (*xfsDriver)(nil)
+
+type xfsDriver struct {
+	r            io.ReaderAt
+	v5           bool
+	blockSize    int64
+	dirBlockSize int64
+	inodeSize    int64
+	rootInode    uint64
+	agBlocks     int64
+	agBlockLog   uint8
+	inopBlockLog uint8
+	fileType     bool
+	bigTime      bool
+	nrext64      bool
+}
+
+type xfsInode struct {
+	number   uint64
+	mode     uint16
+	format   uint8
+	size     int64
+	mtime    time.Time
+	extents  uint64
+	flags    uint16
+	dataFork []byte
+}
+
+func isXFSFilesystem(r io.ReaderAt) bool {
+	magic := make([]byte, 4)
+	if _, err := r.ReadAt(magic, 0); err != nil {
+		return false
+	}
+	return string(magic) == xfsMagic
+}
+
+func newXFSDriver(r io.ReaderAt) (*xfsDriver, error) {
+	sb := make([]byte, 512)
+	if _, err := r.ReadAt(sb, 0); err != nil {
+		return nil, fmt.Errorf("unable to read xfs superblock: %w", err)
+	}
+
+	version := binary.BigEndian.Uint16(sb[100:102]) & xfsVersionMask
+	if version < 4 || version > xfsVersion5 {
+		return nil, fmt.Errorf("unsupported xfs version: %d", version)
+	}
+
+	d := &xfsDriver{
+		r:            r,
+		v5:           version == xfsVersion5,
+		blockSize:    int64(binary.BigEndian.Uint32(sb[4:8])),
+		rootInode:    binary.BigEndian.Uint64(sb[56:64]),
+		agBlocks:     int64(binary.BigEndian.Uint32(sb[84:88])),
+		inodeSize:    int64(binary.BigEndian.Uint16(sb[104:106])),
+		inopBlockLog: sb[123],
+		agBlockLog:   sb[124],
+	}
+
+	if d.blockSize < 512 || d.blockSize > 65536 || d.inodeSize < 256 || d.agBlocks == 0 || sb[192] > 16 {
+		return nil, fmt.Errorf("invalid xfs superblock geometry")
+	}
+	d.dirBlockSize = d.blockSize << sb[192]
+
+	if d.v5 {
+		incompat := binary.BigEndian.Uint32(sb[216:220])
+		d.fileType = incompat&xfsIncompatFileType != 0
+		d.bigTime = incompat&xfsIncompatBigTime != 0
+		d.nrext64 = incompat&xfsIncompatNRExt64 != 0
+	} else {
+		d.fileType = binary.BigEndian.Uint32(sb[200:204])&xfsFeatures2FileType != 0
+	}
+
+	return d, nil
+}
+
+func (d *xfsDriver) root() (*node, error) {
+	in, err := d.inode(d.rootInode)
+	if err != nil {
+		return nil, err
+	}
+	return d.node("", in), nil
+}
+
+func (d *xfsDriver) node(name string, in *xfsInode) *node {
+	return &node{
+		name:    name,
+		mode:    unixMode(uint32(in.mode)),
+		size:    in.size,
+		modTime: in.mtime,
+		id:      in.number,
+		ref:     in,
+	}
+}
+
+// blockOffset converts a filesystem block number (which encodes the allocation group) into a byte offset.
+func (d *xfsDriver) blockOffset(fsBlock uint64) int64 {
+	return d.linearBlock(fsBlock) * d.blockSize
+}
+
+// linearBlock converts a filesystem block number (which encodes the allocation group) into a linear block number.
+func (d *xfsDriver) linearBlock(fsBlock uint64) int64 {
+	ag := int64(fsBlock >> d.agBlockLog)
+	agBlock := int64(fsBlock & (1<<d.agBlockLog - 1))
+	return ag*d.agBlocks + agBlock
+}
+
+func (d *xfsDriver) inode(number uint64) (*xfsInode, error) {
+	// inode numbers encode the allocation group, the block within the group, and the index within the block
+	fsBlock := number >> d.inopBlockLog
+	index := int64(number & (1<<d.inopBlockLog - 1))
+
+	raw := make([]byte, d.inodeSize)
+	if _, err := d.r.ReadAt(raw, d.blockOffset(fsBlock)+index*d.inodeSize); err != nil {
+		return nil, fmt.Errorf("unable to read xfs inode=%d: %w", number, err)
+	}
+	if binary.BigEndian.Uint16(raw[0:2]) != xfsInodeMagic {
+		return nil, fmt.Errorf("invalid xfs inode=%d magic", number)
+	}
+	if int64(binary.BigEndian.Uint64(raw[56:64])) < 0 {
+		return nil, fmt.Errorf("invalid xfs inode=%d size", number)
+	}
+
+	coreSize := xfsInodeCoreSizeV2
+	var flags2 uint64
+	if raw[4] >= 3 {
+		coreSize = xfsInodeCoreSizeV3
+		flags2 = binary.BigEndian.Uint64(raw[120:128])
+	}
+
+	// the data fork fills the remainder of the inode unless there is an attribute fork (whose offset is in 8 byte units)
+	forkEnd := len(raw)
+	if forkOffset := int(raw[82]); forkOffset != 0 && coreSize+forkOffset*8 <= len(raw) {
+		forkEnd = coreSize + forkOffset*8
+	}
+
+	extents := uint64(binary.BigEndian.Uint32(raw[76:80]))
+	if d.nrext64 && flags2&xfsInodeFlag2NRExt64 != 0 {
+		extents = binary.BigEndian.Uint64(raw[24:32])
+	}
+
+	in := &xfsInode{
+		number:   number,
+		mode:     binary.BigEndian.Uint16(raw[2:4]),
+		format:   raw[5],
+		size:     int64(binary.BigEndian.Uint64(raw[56:64])),
+		extents:  extents,
+		flags:    binary.BigEndian.Uint16(raw[90:92]),
+		dataFork: raw[coreSize:forkEnd],
+	}
+
+	if d.bigTime && flags2&xfsInodeFlag2BigTime != 0 {
+		// big timestamps are nanoseconds since the minimum 32 bit timestamp (December 1901)
+		nanos := binary.BigEndian.Uint64(raw[40:48])
+		in.mtime = time.Unix(int64(nanos/1e9)-xfsBigTimeEpochOffset, int64(nanos%1e9))
+	} else {
+		in.mtime = time.Unix(int64(int32(binary.BigEndian.Uint32(raw[40:44]))), int64(binary.BigEndian.Uint32(raw[44:48])))
+	}
+
+	return in, nil
+}
+
+func (d *xfsDriver) readDir(dir *node) ([]*node, error) {
+	in, ok := dir.ref.(*xfsInode)
+	if !ok {
+		return nil, fmt.Errorf("invalid xfs directory reference")
+	}
+
+	var entries []xfsDirEntry
+	var err error
+	switch in.format {
+	case xfsFormatLocal:
+		entries, err = d.shortformEntries(in)
+	case xfsFormatExtents, xfsFormatBtree:
+		entries, err = d.blockEntries(in)
+	default:
+		err = fmt.Errorf("unsupported xfs directory format=%d", in.format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read xfs directory inode=%d: %w", in.number, err)
+	}
+
+	var results []*node
+	for _, e := range entries {
+		if e.name == "." || e.name == ".." || e.name == "" {
+			continue
+		}
+		child, err := d.inode(e.inode)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, d.node(e.name, child))
+	}
+	return results, nil
+}
+
+type xfsDirEntry struct {
+	name  string
+	inode uint64
+}
+
+// shortformEntries reads a directory small enough to be stored within the inode itself.
+func (d *xfsDriver) shortformEntries(in *xfsInode) ([]xfsDirEntry, error) {
+	data := in.dataFork
+	if len(data) < 2 {
+		return nil, fmt.Errorf("xfs shortform directory is truncated")
+	}
+
+	count := int(data[0])
+	inodeSize := 4
+	if data[1] != 0 {
+		// i8count is non-zero when any inode number needs 8 bytes (then all of them are stored as 8 bytes)
+		inodeSize = 8
+	}
+
+	readInode := func(b []byte) uint64 {
+		if inodeSize == 8 {
+			return binary.BigEndian.Uint64(b)
+		}
+		return uint64(binary.BigEndian.Uint32(b))
+	}
+
+	// skip the header (counts and the parent inode number)
+	offset := 2 + inodeSize
+	var entries []xfsDirEntry
+	for i := 0; i < count; i++ {
+		if offset+3 > len(data) {
+			return nil, fmt.Errorf("xfs shortform directory entries exceed inode")
+		}
+		nameLen := int(data[offset])
+		nameStart := offset + 3
+		inodeStart := nameStart + nameLen
+		if d.fileType {
+			inodeStart++
+		}
+		if inodeStart+inodeSize > len(data) {
+			return nil, fmt.Errorf("xfs shortform directory entries exceed inode")
+		}
+		entries = append(entries, xfsDirEntry{
+			name:  string(data[nameStart : nameStart+nameLen]),
+			inode: readInode(data[inodeStart : inodeStart+inodeSize]),
+		})
+		offset = inodeStart + inodeSize
+	}
+	return entries, nil
+}
+
+// blockEntries reads the data blocks of a block, leaf, or node directory (the hash indexes are not needed to list
+// every entry).
+func (d *xfsDriver) blockEntries(in *xfsInode) ([]xfsDirEntry, error) {
+	runs, err := d.runs(in)
+	if err != nil {
+		return nil, err
+	}
+	contents := newBlockRunReader(d.r, d.blockSize, runs)
+
+	headerSize := int64(xfsDirHeaderSizeV4)
+	if d.v5 {
+		headerSize = xfsDirHeaderSizeV5
+	}
+
+	var entries []xfsDirEntry
+	block := make([]byte, d.dirBlockSize)
+	for _, offset := range d.dirBlockOffsets(runs) {
+		if _, err := contents.ReadAt(block, offset); err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		end := d.dirBlockSize
+		switch string(block[0:4]) {
+		case "XD2D", "XDD3":
+		case "XD2B", "XDB3":
+			// single block directories end with the leaf entries (8 bytes each) followed by a tail with the count
+			leafCount := int64(binary.BigEndian.Uint32(block[end-8 : end-4]))
+			end -= 8 + leafCount*8
+		default:
+			return nil, fmt.Errorf("invalid xfs directory block magic at offset=%d", offset)
+		}
+
+		for pos := headerSize; pos+8 <= end; {
+			if binary.BigEndian.Uint16(block[pos:pos+2]) == xfsDirFreeTag {
+				length := int64(binary.BigEndian.Uint16(block[pos+2 : pos+4]))
+				if length < 8 {
+					break
+				}
+				pos += length
+				continue
+			}
+
+			nameLen := int64(block[pos+8])
+			if pos+9+nameLen > end {
+				break
+			}
+			entries = append(entries, xfsDirEntry{
+				name:  string(block[pos+9 : pos+9+nameLen]),
+				inode: binary.BigEndian.Uint64(block[pos : pos+8]),
+			})
+
+			// entries are the inode number, name length, name, file type (optional), and a 2 byte tag padded to 8 bytes
+			length := 8 + 1 + nameLen + 2
+			if d.fileType {
+				length++
+			}
+			pos += (length + 7) &^ 7
+		}
+	}
+	return entries, nil
+}
+
+// dirBlockOffsets returns the byte offsets of all allocated directory data blocks.
+func (d *xfsDriver) dirBlockOffsets(runs []blockRun) []int64 {
+	var offsets []int64
+	seen := make(map[int64]struct{})
+	for _, run := range runs {
+		for b := run.logical; b < run.logical+run.length; b++ {
+			offset := b * d.blockSize
+			if offset >= xfsDirLeafOffset {
+				break
+			}
+			offset -= offset % d.dirBlockSize
+			if _, ok := seen[offset]; ok {
+				continue
+			}
+			seen[offset] = struct{}{}
+			offsets = append(offsets, offset)
+		}
+	}
+	return offsets
+}
+
+func (d *xfsDriver) open(file *node) (io.ReaderAt, error) {
+	in, ok := file.ref.(*xfsInode)
+	if !ok {
+		return nil, fmt.Errorf("invalid xfs file reference")
+	}
+	if in.flags&xfsInodeFlagRealtime != 0 {
+		return nil, fmt.Errorf("xfs realtime inode=%d is not supported", in.number)
+	}
+
+	runs, err := d.runs(in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read xfs inode=%d blocks: %w", in.number, err)
+	}
+	return newBlockRunReader(d.r, d.blockSize, runs), nil
+}
+
+func (d *xfsDriver) readLink(link *node) (string, error) {
+	in, ok := link.ref.(*xfsInode)
+	if !ok {
+		return "", fmt.Errorf("invalid xfs symlink reference")
+	}
+	if in.size > xfsMaxSymlinkSize {
+		return "", fmt.Errorf("xfs symlink inode=%d is too large: %d bytes", in.number, in.size)
+	}
+
+	if in.format == xfsFormatLocal {
+		if in.size > int64(len(in.dataFork)) {
+			return "", fmt.Errorf("xfs symlink inode=%d is larger than the inode", in.number)
+		}
+		return string(in.dataFork[:in.size]), nil
+	}
+
+	runs, err := d.runs(in)
+	if err != nil {
+		return "", fmt.Errorf("unable to read xfs symlink inode=%d: %w", in.number, err)
+	}
+	contents := newBlockRunReader(d.r, d.blockSize, runs)
+
+	if !d.v5 {
+		target := make([]byte, in.size)
+		if _, err := contents.ReadAt(target, 0); err != nil && err != io.EOF {
+			return "", fmt.Errorf("unable to read xfs symlink inode=%d: %w", in.number, err)
+		}
+		return string(target), nil
+	}
+
+	// v5 symlink blocks each start with a header describing the bytes of the target stored within the block
+	var target bytes.Buffer
+	block := make([]byte, d.blockSize)
+	for offset := int64(0); int64(target.Len()) < in.size; offset += d.blockSize {
+		if _, err := contents.ReadAt(block, offset); err != nil && err != io.EOF {
+			return "", fmt.Errorf("unable to read xfs symlink inode=%d: %w", in.number, err)
+		}
+		if string(block[0:4]) != "XSLM" {
+			return "", fmt.Errorf("invalid xfs symlink inode=%d block magic", in.number)
+		}
+		length := int64(binary.BigEndian.Uint32(block[8:12]))
+		if length == 0 || xfsSymlinkHeaderSizeV5+length > d.blockSize {
+			return "", fmt.Errorf("invalid xfs symlink inode=%d block length", in.number)
+		}
+		target.Write(block[xfsSymlinkHeaderSizeV5 : xfsSymlinkHeaderSizeV5+length])
+	}
+	return target.String(), nil
+}
+
+// runs returns the block mapping of the data fork of an extents or btree format inode.
+func (d *xfsDriver) runs(in *xfsInode) ([]blockRun, error) {
+	switch in.format {
+	case xfsFormatExtents:
+		count := int(in.extents)
+		if count*16 > len(in.dataFork) {
+			return nil, fmt.Errorf("xfs extent list exceeds inode")
+		}
+		return d.extentRecords(in.dataFork, count), nil
+	case xfsFormatBtree:
+		return d.btreeRootRuns(in.dataFork)
+	}
+	return nil, fmt.Errorf("unsupported xfs data fork format=%d", in.format)
+}
+
+// extentRecords decodes packed 128 bit extent records (unwritten flag, logical offset, physical block, and length).
+func (d *xfsDriver) extentRecords(data []byte, count int) []blockRun {
+	var runs []blockRun
+	for i := 0; i < count && (i+1)*16 <= len(data); i++ {
+		hi := binary.BigEndian.Uint64(data[i*16 : i*16+8])
+		lo := binary.BigEndian.Uint64(data[i*16+8 : i*16+16])
+		runs = append(runs, blockRun{
+			logical:  int64((hi & (1<<63 - 1)) >> 9),
+			physical: d.linearBlock((hi&(1<<9-1))<<43 | lo>>21),
+			length:   int64(lo & (1<<21 - 1)),
+			zero:     hi>>63 != 0,
+		})
+	}
+	return runs
+}
+
+// btreeRootRuns walks an extent btree whose root is stored within the inode.
+func (d *xfsDriver) btreeRootRuns(fork []byte) ([]blockRun, error) {
+	if len(fork) < 4 {
+		return nil, fmt.Errorf("xfs btree root is truncated")
+	}
+	level := binary.BigEndian.Uint16(fork[0:2])
+	count := int(binary.BigEndian.Uint16(fork[2:4]))
+	if level == 0 {
+		return nil, fmt.Errorf("invalid xfs btree root level")
+	}
+
+	// the root has room for the max number of keys (8 bytes) and pointers (8 bytes) that fit in the fork
+	maxRecords := (len(fork) - 4) / 16
+	if count > maxRecords {
+		return nil, fmt.Errorf("xfs btree root entries exceed inode")
+	}
+	pointers := fork[4+maxRecords*8:]
+
+	var runs []blockRun
+	for i := 0; i < count; i++ {
+		if err := d.btreeRuns(binary.BigEndian.Uint64(pointers[i*8:i*8+8]), 1, &runs); err != nil {
+			return nil, err
+		}
+	}
+	return runs, nil
+}
+
+func (d *xfsDriver) btreeRuns(fsBlock uint64, depth int, runs *[]blockRun) error {
+	if depth > xfsMaxBtreeDepth {
+		return fmt.Errorf("xfs btree is too deep")
+	}
+
+	block := make([]byte, d.blockSize)
+	if _, err := d.r.ReadAt(block, d.blockOffset(fsBlock)); err != nil {
+		return fmt.Errorf("unable to read xfs btree block: %w", err)
+	}
+
+	headerSize := xfsBtreeHeaderSizeV4
+	magic := string(block[0:4])
+	switch {
+	case magic == "BMAP" && !d.v5:
+	case magic == "BMA3" && d.v5:
+		headerSize = xfsBtreeHeaderSizeV5
+	default:
+		return fmt.Errorf("invalid xfs btree block magic")
+	}
+
+	level := binary.BigEndian.Uint16(block[4:6])
+	count := int(binary.BigEndian.Uint16(block[6:8]))
+	records := block[headerSize:]
+
+	if level == 0 {
+		if count*16 > len(records) {
+			return fmt.Errorf("xfs btree leaf entries exceed block")
+		}
+		*runs = append(*runs, d.extentRecords(records, count)...)
+		return nil
+	}
+
+	maxRecords := len(records) / 16
+	if count > maxRecords {
+		return fmt.Errorf("xfs btree node entries exceed block")
+	}
+	pointers := records[maxRecords*8:]
+	for i := 0; i < count; i++ {
+		if err := d.btreeRuns(binary.BigEndian.Uint64(pointers[i*8:i*8+8]), depth+1, runs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+package disk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testXFSBlockSize = 4096
+	testXFSInodeSize = 512
+	testXFSBlocks    = 64
+	testXFSRootInode = 128
+
+	// the first inode of the second inode block (inode numbers are the block number shifted by log2(inodes per block))
+	testXFSSecondInodeBlock = 136
+)
+
+// testXFSLongTarget is stored in a remote symlink block (it is too long to fit within the inode)
+var testXFSLongTarget = "/usr/share/" + strings.Repeat("a", 400)
+
+// xfsImage builds a minimal v5 xfs filesystem (a single allocation group) in memory.
+type xfsImage struct {
+	data []byte
+}
+
+func newXFSImage() *xfsImage {
+	x := &xfsImage{data: make([]byte, testXFSBlocks*testXFSBlockSize)}
+	sb := x.data[0:512]
+	copy(sb[0:4], xfsMagic)
+	binary.BigEndian.PutUint32(sb[4:8], testXFSBlockSize)
+	binary.BigEndian.PutUint64(sb[56:64], testXFSRootInode)
+	binary.BigEndian.PutUint32(sb[84:88], testXFSBlocks)
+	binary.BigEndian.PutUint16(sb[100:102], xfsVersion5)
+	binary.BigEndian.PutUint16(sb[104:106], testXFSInodeSize)
+	sb[123] = 3 // 8 inodes per block
+	sb[124] = 6 // 64 blocks per allocation group
+	binary.BigEndian.PutUint32(sb[216:220], xfsIncompatFileType)
+	return x
+}
+
+// inode writes a v3 inode with the given data fork, returning the raw inode.
+func (x *xfsImage) inode(number uint64, mode uint16, format uint8, size int64, extents int, fork []byte) []byte {
+	offset := int64(number>>3)*testXFSBlockSize + int64(number&7)*testXFSInodeSize
+	raw := x.data[offset : offset+testXFSInodeSize]
+	binary.BigEndian.PutUint16(raw[0:2], xfsInodeMagic)
+	binary.BigEndian.PutUint16(raw[2:4], mode)
+	raw[4] = 3
+	raw[5] = format
+	binary.BigEndian.PutUint32(raw[40:44], 1680000000)
+	binary.BigEndian.PutUint64(raw[56:64], uint64(size))
+	binary.BigEndian.PutUint32(raw[76:80], uint32(extents))
+	copy(raw[xfsInodeCoreSizeV3:], fork)
+	return raw
+}
+
+type xfsTestEntry struct {
+	name  string
+	inode uint64
+}
+
+func (x *xfsImage) shortformDir(number, parent uint64, entries ...xfsTestEntry) []byte {
+	fork := []byte{byte(len(entries)), 0}
+	fork = binary.BigEndian.AppendUint32(fork, uint32(parent))
+	for _, e := range entries {
+		fork = append(fork, byte(len(e.name)), 0, 0)
+		fork = append(fork, e.name...)
+		fork = append(fork, 0) // file type
+		fork = binary.BigEndian.AppendUint32(fork, uint32(e.inode))
+	}
+	return x.inode(number, 0o40755, xfsFormatLocal, int64(len(fork)), 0, fork)
+}
+
+// blockDir writes a single block directory (at the given block) for the given entries.
+func (x *xfsImage) blockDir(number uint64, block uint64, entries ...xfsTestEntry) []byte {
+	data := x.data[block*testXFSBlockSize : (block+1)*testXFSBlockSize]
+	copy(data[0:4], "XDB3")
+
+	end := len(data) - 8 // no leaf entries, only the tail
+	pos := xfsDirHeaderSizeV5
+	for _, e := range entries {
+		binary.BigEndian.PutUint64(data[pos:pos+8], e.inode)
+		data[pos+8] = byte(len(e.name))
+		copy(data[pos+9:], e.name)
+		pos += (8 + 1 + len(e.name) + 1 + 2 + 7) &^ 7
+	}
+	binary.BigEndian.PutUint16(data[pos:pos+2], xfsDirFreeTag)
+	binary.BigEndian.PutUint16(data[pos+2:pos+4], uint16(end-pos))
+
+	return x.inode(number, 0o40755, xfsFormatExtents, testXFSBlockSize, 1, xfsExtent(0, block, 1, false))
+}
+
+func xfsExtent(logical, physical, length uint64, unwritten bool) []byte {
+	hi := logical<<9 | physical>>43
+	if unwritten {
+		hi |= 1 << 63
+	}
+	lo := physical<<21 | length
+	return binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, hi), lo)
+}
+
+// newTestXFS returns an xfs filesystem with the following contents:
+//
+//	/bin -> usr/bin                  (local symlink)
+//	/etc/os-release -> ../usr/lib/os-release
+//	/usr/lib/os-release              (extents with a hole and an unwritten extent)
+//	/usr/lib/long-link -> /usr/share/aaa...  (remote symlink)
+//	/usr/share/                      (empty)
+func newTestXFS() *xfsImage {
+	x := newXFSImage()
+
+	const (
+		etc           = testXFSRootInode + 1
+		usr           = testXFSRootInode + 2
+		bin           = testXFSRootInode + 3
+		etcOS         = testXFSRootInode + 4
+		lib           = testXFSRootInode + 5
+		share         = testXFSRootInode + 6
+		osReleaseFile = testXFSRootInode + 7
+		longLink      = testXFSSecondInodeBlock
+	)
+
+	x.shortformDir(testXFSRootInode, testXFSRootInode,
+		xfsTestEntry{name: "bin", inode: bin},
+		xfsTestEntry{name: "etc", inode: etc},
+		xfsTestEntry{name: "usr", inode: usr},
+	)
+	x.inode(bin, 0o120777, xfsFormatLocal, 7, 0, []byte("usr/bin"))
+	x.shortformDir(etc, testXFSRootInode, xfsTestEntry{name: "os-release", inode: etcOS})
+	x.inode(etcOS, 0o120777, xfsFormatLocal, 21, 0, []byte("../usr/lib/os-release"))
+
+	x.blockDir(usr, 20,
+		xfsTestEntry{name: ".", inode: usr},
+		xfsTestEntry{name: "..", inode: testXFSRootInode},
+		xfsTestEntry{name: "lib", inode: lib},
+		xfsTestEntry{name: "share", inode: share},
+	)
+	x.shortformDir(share, usr)
+	x.shortformDir(lib, usr,
+		xfsTestEntry{name: "long-link", inode: longLink},
+		xfsTestEntry{name: "os-release", inode: osReleaseFile},
+	)
+
+	// the file spans 4 blocks: written, hole, unwritten (reads as zeros), written
+	copy(x.data[21*testXFSBlockSize:], osRelease)
+	copy(x.data[22*testXFSBlockSize:], "unwritten data must not be read")
+	copy(x.data[23*testXFSBlockSize:], "end")
+	var extents []byte
+	extents = append(extents, xfsExtent(0, 21, 1, false)...)
+	extents = append(extents, xfsExtent(2, 22, 1, true)...)
+	extents = append(extents, xfsExtent(3, 23, 1, false)...)
+	x.inode(osReleaseFile, 0o100644, xfsFormatExtents, 3*testXFSBlockSize+3, 3, extents)
+
+	symlink := x.data[24*testXFSBlockSize : 25*testXFSBlockSize]
+	copy(symlink[0:4], "XSLM")
+	binary.BigEndian.PutUint32(symlink[8:12], uint32(len(testXFSLongTarget)))
+	copy(symlink[xfsSymlinkHeaderSizeV5:], testXFSLongTarget)
+	x.inode(longLink, 0o120777, xfsFormatExtents, int64(len(testXFSLongTarget)), 1, xfsExtent(0, 24, 1, false))
+
+	return x
+}
+
+func (x *xfsImage) open(t *testing.T) *Filesystem {
+	t.Helper()
+	fsys, err := OpenFilesystem(bytes.NewReader(x.data), int64(len(x.data)))
+	require.NoError(t, err)
+	assert.Equal(t, XFSFilesystem, fsys.Type)
+	return fsys
+}
+
+func TestXFSFilesystem(t *testing.T) {
+	fsys := newTestXFS().open(t)
+
+	assert.Equal(t, []string{
+		".",
+		"bin",
+		"etc",
+		"etc/os-release",
+		"usr",
+		"usr/lib",
+		"usr/lib/long-link",
+		"usr/lib/os-release",
+		"usr/share",
+	}, walkPaths(t, fsys))
+
+	target, err := fsys.ReadLink("bin")
+	require.NoError(t, err)
+	assert.Equal(t, "usr/bin", target)
+
+	target, err = fsys.ReadLink("etc/os-release")
+	require.NoError(t, err)
+	assert.Equal(t, "../usr/lib/os-release", target)
+
+	target, err = fsys.ReadLink("usr/lib/long-link")
+	require.NoError(t, err)
+	assert.Equal(t, testXFSLongTarget, target)
+
+	info, err := fsys.Stat("usr/lib/os-release")
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0o644), info.Mode())
+	assert.Equal(t, int64(3*testXFSBlockSize+3), info.Size())
+
+	f, err := fsys.Open("usr/lib/os-release")
+	require.NoError(t, err)
+	contents, err := io.ReadAll(f)
+	require.NoError(t, err)
+
+	expected := make([]byte, 3*testXFSBlockSize+3)
+	copy(expected, osRelease)
+	copy(expected[3*testXFSBlockSize:], "end")
+	assert.Equal(t, expected, contents)
+
+	// xfs is case sensitive
+	_, err = fsys.Stat("USR/lib")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestXFSFilesystem_Malformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(x *xfsImage)
+		// whether the filesystem is readable at all (otherwise opening fails)
+		opens bool
+		// the expected paths when walking the filesystem, ignoring any errors
+		expected []string
+	}{
+		{
+			name: "invalid block size",
+			corrupt: func(x *xfsImage) {
+				binary.BigEndian.PutUint32(x.data[4:8], 0)
+			},
+		},
+		{
+			name: "unsupported version",
+			corrupt: func(x *xfsImage) {
+				binary.BigEndian.PutUint16(x.data[100:102], 2)
+			},
+		},
+		{
+			name: "truncated",
+			corrupt: func(x *xfsImage) {
+				x.data = x.data[:2*testXFSBlockSize]
+			},
+			opens: true,
+		},
+		{
+			name: "invalid root inode magic",
+			corrupt: func(x *xfsImage) {
+				x.inode(testXFSRootInode, 0o40755, xfsFormatLocal, 0, 0, nil)[0] = 0
+			},
+			opens: true,
+		},
+		{
+			name: "directory entry refers to an ancestor",
+			corrupt: func(x *xfsImage) {
+				x.shortformDir(testXFSRootInode+1, testXFSRootInode,
+					xfsTestEntry{name: "loop", inode: testXFSRootInode},
+					xfsTestEntry{name: "os-release", inode: testXFSRootInode + 4},
+				)
+			},
+			opens:    true,
+			expected: []string{".", "bin", "etc", "etc/os-release", "usr", "usr/lib", "usr/lib/long-link", "usr/lib/os-release", "usr/share"},
+		},
+		{
+			name: "directory entry refers to another directory",
+			corrupt: func(x *xfsImage) {
+				x.shortformDir(testXFSRootInode+6, testXFSRootInode+2, xfsTestEntry{name: "lib", inode: testXFSRootInode + 5})
+			},
+			opens:    true,
+			expected: []string{".", "bin", "etc", "etc/os-release", "usr", "usr/lib", "usr/lib/long-link", "usr/lib/os-release", "usr/share"},
+		},
+		{
+			name: "shortform entries exceed the inode",
+			corrupt: func(x *xfsImage) {
+				x.inode(testXFSRootInode+1, 0o40755, xfsFormatLocal, 0, 0, []byte{200, 0, 0, 0, 0, 128})
+			},
+			opens:    true,
+			expected: []string{".", "bin", "etc", "usr", "usr/lib", "usr/lib/long-link", "usr/lib/os-release", "usr/share"},
+		},
+		{
+			name: "invalid directory block magic",
+			corrupt: func(x *xfsImage) {
+				copy(x.data[20*testXFSBlockSize:], "XXXX")
+			},
+			opens:    true,
+			expected: []string{".", "bin", "etc", "etc/os-release", "usr"},
+		},
+		{
+			name: "negative file size",
+			corrupt: func(x *xfsImage) {
+				raw := x.inode(testXFSRootInode+7, 0o100644, xfsFormatExtents, 0, 0, nil)
+				binary.BigEndian.PutUint64(raw[56:64], 1<<63)
+			},
+			opens:    true,
+			expected: []string{".", "bin", "etc", "etc/os-release", "usr", "usr/lib", "usr/share"},
+		},
+		{
+			name: "extent count exceeds the inode",
+			corrupt: func(x *xfsImage) {
+				x.inode(testXFSRootInode+7, 0o100644, xfsFormatExtents, 10, 1000, nil)
+			},
+			opens:    true,
+			expected: []string{".", "bin", "etc", "etc/os-release", "usr", "usr/lib", "usr/lib/long-link", "usr/lib/os-release", "usr/share"},
+		},
+		{
+			name: "oversized symlink",
+			corrupt: func(x *xfsImage) {
+				x.inode(testXFSRootInode+3, 0o120777, xfsFormatLocal, 1<<40, 0, nil)
+			},
+			opens:    true,
+			expected: []string{".", "bin", "etc", "etc/os-release", "usr", "usr/lib", "usr/lib/long-link", "usr/lib/os-release", "usr/share"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x := newTestXFS()
+			test.corrupt(x)
+
+			fsys, err := OpenFilesystem(bytes.NewReader(x.data), int64(len(x.data)))
+			if !test.opens {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			paths := readAll(fsys)
+			if test.expected != nil {
+				assert.Equal(t, test.expected, paths)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package source
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nextlinux/sbom/internal/log"
+)
+
+const mountInfoPath = "/proc/self/mountinfo"
+
+// mountInfo is a single entry from a mountinfo file (see proc(5) for the format).
+type mountInfo struct {
+	MountPoint   string
+	FSType       string
+	Source       string
+	SuperOptions []string
+}
+
+// getRootFSExclusionFunctions returns path visitors that keep indexing of a root filesystem to the filesystem the root
+// is on (like "find -xdev"): runtime paths (relative to the root), all other mount points, and overlay layer directories
+// (e.g. the upper dirs of running containers) are skipped. Mount points listed in includedMounts (relative to the root,
+// e.g. a separate /usr or /var) are indexed, unless they are pseudo, container or network filesystems.
+func getRootFSExclusionFunctions(root string, includedMounts []string) ([]pathIndexVisitor, error) {
+	root, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, fmt.Errorf("could not evaluate root=%q symlinks: %w", root, err)
+	}
+
+	// this is what directoryResolver.indexTree is doing to get the absolute path:
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var excluded []string
+	for _, prefix := range unixSystemRuntimePrefixes {
+		excluded = append(excluded, filepath.Join(root, prefix))
+	}
+
+	mounts, err := readMountInfoFile(mountInfoPath)
+	if err != nil {
+		log.WithFields("path", mountInfoPath, "error", err).Debug("unable to read mount info, other filesystems will not be excluded")
+	}
+
+	var included []string
+	for _, m := range includedMounts {
+		included = append(included, filepath.Join(root, m))
+	}
+
+	for _, p := range rootFSMountExclusions(root, mounts, included) {
+		log.WithFields("path", p).Trace("excluding mounted path from root filesystem")
+		excluded = append(excluded, p)
+	}
+
+	return []pathIndexVisitor{
+		func(path string, info os.FileInfo, _ error) error {
+			for _, exclusion := range excluded {
+				if path != exclusion && !strings.HasPrefix(path, exclusion+"/") {
+					continue
+				}
+				if info != nil && info.IsDir() {
+					return fs.SkipDir
+				}
+				return errSkipPath
+			}
+			return nil
+		},
+	}, nil
+}
+
+// excludedMountFSTypes are the filesystem types that do not hold installed files: pseudo and virtual filesystems
+// (which describe the running system) and network filesystems (which are shared with other hosts).
+var excludedMountFSTypes = map[string]bool{
+	// pseudo and virtual filesystems
+	"autofs": true, "binfmt_misc": true, "bpf": true, "cgroup": true, "cgroup2": true, "configfs": true,
+	"debugfs": true, "devpts": true, "devtmpfs": true, "efivarfs": true, "fusectl": true, "hugetlbfs": true,
+	"mqueue": true, "nsfs": true, "proc": true, "pstore": true, "ramfs": true, "rpc_pipefs": true,
+	"securityfs": true, "selinuxfs": true, "sysfs": true, "tmpfs": true, "tracefs": true,
+	// container filesystems
+	"overlay": true, "aufs": true,
+	// network filesystems
+	"9p": true, "afs": true, "ceph": true, "cifs": true, "glusterfs": true, "lustre": true, "ncpfs": true,
+	"nfs": true, "nfs4": true, "smb3": true, "smbfs": true,
+}
+
+// isExcludedMount returns whether the files of the given mount must never be indexed as part of a root filesystem, even
+// when the mount has been explicitly included. Userspace filesystems (fuse.*, e.g. sshfs or lxcfs) are excluded, except
+// for those backed by a block device (fuseblk).
+func isExcludedMount(m mountInfo) bool {
+	return excludedMountFSTypes[m.FSType] || strings.HasPrefix(m.FSType, "fuse.")
+}
+
+// rootFSMountExclusions returns all paths strictly under the given root that are either mount points (other than the
+// included block device mounts) or are directories backing an overlay mount.
+func rootFSMountExclusions(root string, mounts []mountInfo, included []string) []string {
+	var results []string
+	add := func(p string) {
+		p = filepath.Clean(p)
+		if p == root || !isUnderPath(root, p) {
+			return
+		}
+		results = append(results, p)
+	}
+
+	for _, m := range mounts {
+		if isExcludedMount(m) || !containsPath(included, m.MountPoint) {
+			add(m.MountPoint)
+		}
+
+		if m.FSType != "overlay" {
+			continue
+		}
+
+		for _, opt := range m.SuperOptions {
+			key, value, found := strings.Cut(opt, "=")
+			if !found {
+				continue
+			}
+			switch key {
+			case "upperdir", "workdir":
+				add(value)
+			case "lowerdir":
+				for _, lower := range strings.Split(value, ":") {
+					add(lower)
+				}
+			}
+		}
+	}
+	return results
+}
+
+func containsPath(paths []string, p string) bool {
+	p = filepath.Clean(p)
+	for _, candidate := range paths {
+		if filepath.Clean(candidate) == p {
+			return true
+		}
+	}
+	return false
+}
+
+func isUnderPath(root, p string) bool {
+	if root == "/" {
+		return strings.HasPrefix(p, "/")
+	}
+	return strings.HasPrefix(p, root+"/")
+}
+
+func readMountInfoFile(path string) ([]mountInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseMountInfo(f)
+}
+
+// parseMountInfo reads mountinfo entries, for example:
+//
+//	36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+func parseMountInfo(reader io.Reader) ([]mountInfo, error) {
+	var results []mountInfo
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		entry := mountInfo{
+			MountPoint: unescapeMountInfoField(fields[4]),
+		}
+
+		// optional fields are terminated by a single "-", after which the filesystem type, source and super
+		// options are listed
+		for idx := 5; idx < len(fields); idx++ {
+			if fields[idx] != "-" {
+				continue
+			}
+			rest := fields[idx+1:]
+			if len(rest) > 0 {
+				entry.FSType = rest[0]
+			}
+			if len(rest) > 1 {
+				entry.Source = unescapeMountInfoField(rest[1])
+			}
+			if len(rest) > 2 {
+				for _, opt := range strings.Split(rest[2], ",") {
+					entry.SuperOptions = append(entry.SuperOptions, unescapeMountInfoField(opt))
+				}
+			}
+			break
+		}
+
+		results = append(results, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read mount info: %w", err)
+	}
+
+	return results, nil
+}
+
+// unescapeMountInfoField replaces the octal escapes the kernel uses for whitespace and backslashes (e.g. "\040").
+func unescapeMountInfoField(field string) string {
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+4 <= len(field) {
+			if v, err := strconv.ParseUint(field[i+1:i+4], 8, 8); err == nil {
+				sb.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(field[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,135 @@
+package source
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMountInfo(t *testing.T) {
+	f, err := os.Open("test-fixtures/mountinfo/host")
+	require.NoError(t, err)
+	defer f.Close()
+
+	mounts, err := parseMountInfo(f)
+	require.NoError(t, err)
+	require.Len(t, mounts, 11)
+
+	assert.Equal(t, mountInfo{
+		MountPoint:   "/",
+		FSType:       "ext4",
+		Source:       "/dev/mapper/root",
+		SuperOptions: []string{"rw", "errors=remount-ro"},
+	}, mounts[0])
+
+	assert.Equal(t, "/mnt/shared drive", mounts[4].MountPoint)
+	assert.Equal(t, "nfs4", mounts[4].FSType)
+	assert.Equal(t, "overlay", mounts[5].FSType)
+}
+
+func TestRootFSMountExclusions(t *testing.T) {
+	f, err := os.Open("test-fixtures/mountinfo/host")
+	require.NoError(t, err)
+	defer f.Close()
+
+	mounts, err := parseMountInfo(f)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		root     string
+		included []string
+		expected []string
+	}{
+		{
+			name: "host root skips all other filesystems",
+			root: "/",
+			expected: []string{
+				"/proc",
+				"/sys",
+				"/boot",
+				"/mnt/shared drive",
+				"/var/lib/docker/overlay2/abc/merged",
+				"/var/lib/docker/overlay2/l/L1",
+				"/var/lib/docker/overlay2/l/L2",
+				"/var/lib/docker/overlay2/abc/diff",
+				"/var/lib/docker/overlay2/abc/work",
+				"/usr",
+				"/var",
+				"/dev",
+				"/run",
+				"/home/user/remote",
+			},
+		},
+		{
+			name:     "host root with included mounts",
+			root:     "/",
+			included: []string{"/usr", "/var/", "/run", "/mnt/shared drive"},
+			expected: []string{
+				"/proc",
+				"/sys",
+				"/boot",
+				"/mnt/shared drive",
+				"/var/lib/docker/overlay2/abc/merged",
+				"/var/lib/docker/overlay2/l/L1",
+				"/var/lib/docker/overlay2/l/L2",
+				"/var/lib/docker/overlay2/abc/diff",
+				"/var/lib/docker/overlay2/abc/work",
+				"/dev",
+				"/run",
+				"/home/user/remote",
+			},
+		},
+		{
+			name: "nested root only excludes paths beneath it",
+			root: "/var/lib/docker",
+			expected: []string{
+				"/var/lib/docker/overlay2/abc/merged",
+				"/var/lib/docker/overlay2/l/L1",
+				"/var/lib/docker/overlay2/l/L2",
+				"/var/lib/docker/overlay2/abc/diff",
+				"/var/lib/docker/overlay2/abc/work",
+			},
+		},
+		{
+			name: "exported rootfs without mounts",
+			root: "/srv/exported-rootfs",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, rootFSMountExclusions(test.root, mounts, test.included))
+		})
+	}
+}
+
+func TestIsExcludedMount(t *testing.T) {
+	f, err := os.Open("test-fixtures/mountinfo/host")
+	require.NoError(t, err)
+	defer f.Close()
+
+	mounts, err := parseMountInfo(f)
+	require.NoError(t, err)
+
+	excluded := map[string]bool{}
+	for _, m := range mounts {
+		excluded[m.MountPoint] = isExcludedMount(m)
+	}
+
+	// block device filesystems can be included
+	assert.False(t, excluded["/"])
+	assert.False(t, excluded["/boot"])
+	assert.False(t, excluded["/usr"])
+	assert.False(t, excluded["/var"])
+
+	// pseudo, container and network filesystems never are
+	assert.True(t, excluded["/proc"])
+	assert.True(t, excluded["/sys"])
+	assert.True(t, excluded["/dev"])
+	assert.True(t, excluded["/run"])
+	assert.True(t, excluded["/var/lib/docker/overlay2/abc/merged"])
+	assert.True(t, excluded["/mnt/shared drive"])
+	assert.True(t, excluded["/home/user/remote"])
+}
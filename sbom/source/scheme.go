@@ -22,12 +22,18 @@ const (
 	ImageScheme Scheme = "ImageScheme"
 	// FileScheme indicates the source being cataloged is a single file
 	FileScheme Scheme = "FileScheme"
+	// RootFSScheme indicates the source being cataloged is the root filesystem of a host, VM, or exported container
+	RootFSScheme Scheme = "RootFSScheme"
+	// DiskImageScheme indicates the source being cataloged is a virtual machine disk image (raw, qcow2, or vmdk)
+	DiskImageScheme Scheme = "DiskImageScheme"
 )
 
 var AllSchemes = []Scheme{
 	DirectoryScheme,
 	ImageScheme,
 	FileScheme,
+	RootFSScheme,
+	DiskImageScheme,
 }
 
 func DetectScheme(fs afero.Fs, imageDetector sourceDetector, userInput string) (Scheme, image.Source, string, error) {
@@ -45,6 +51,20 @@ func DetectScheme(fs afero.Fs, imageDetector sourceDetector, userInput string) (
 			return UnknownScheme, image.UnknownSource, "", fmt.Errorf("unable to expand directory path: %w", err)
 		}
 		return FileScheme, image.UnknownSource, fileLocation, nil
+
+	case strings.HasPrefix(userInput, "rootfs:"):
+		rootLocation, err := homedir.Expand(strings.TrimPrefix(userInput, "rootfs:"))
+		if err != nil {
+			return UnknownScheme, image.UnknownSource, "", fmt.Errorf("unable to expand root filesystem path: %w", err)
+		}
+		return RootFSScheme, image.UnknownSource, rootLocation, nil
+
+	case strings.HasPrefix(userInput, "disk:"):
+		diskLocation, err := homedir.Expand(strings.TrimPrefix(userInput, "disk:"))
+		if err != nil {
+			return UnknownScheme, image.UnknownSource, "", fmt.Errorf("unable to expand disk image path: %w", err)
+		}
+		return DiskImageScheme, image.UnknownSource, diskLocation, nil
 	}
 
 	// try the most specific sources first and move out towards more generic sources.
@@ -175,6 +175,28 @@ func TestDetectScheme(t *testing.T) {
 			expectedScheme:   FileScheme,
 			expectedLocation: "some/path-to-file",
 		},
+		{
+			name:      "explicit-rootfs",
+			userInput: "rootfs:some/path-to-rootfs",
+			detection: detectorResult{
+				src: image.UnknownSource,
+				ref: "",
+			},
+			dirs:             []string{"some/path-to-rootfs"},
+			expectedScheme:   RootFSScheme,
+			expectedLocation: "some/path-to-rootfs",
+		},
+		{
+			name:      "explicit-disk-image",
+			userInput: "disk:some/vm.qcow2",
+			detection: detectorResult{
+				src: image.UnknownSource,
+				ref: "",
+			},
+			files:            []string{"some/vm.qcow2"},
+			expectedScheme:   DiskImageScheme,
+			expectedLocation: "some/vm.qcow2",
+		},
 		{
 			name:      "implicit-file",
 			userInput: "some/path-to-file",
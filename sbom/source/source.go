@@ -22,6 +22,7 @@ import (
 	"github.com/nextlinux/stereoscope/pkg/image"
 	"github.com/nextlinux/sbom/internal/log"
 	"github.com/nextlinux/sbom/sbom/artifact"
+	"github.com/nextlinux/sbom/sbom/source/internal/disk"
 )
 
 // Source is an object that captures the data source to be cataloged, configuration, and a specific resolver used
@@ -31,10 +32,13 @@ type Source struct {
 	Image             *image.Image `hash:"ignore"` // the image object to be cataloged (image only)
 	Metadata          Metadata
 	directoryResolver *directoryResolver `hash:"ignore"`
+	diskFilesystem    *disk.Filesystem   `hash:"ignore"` // the partition filesystem to be cataloged (disk image only)
+	diskImageResolver *diskImageResolver `hash:"ignore"`
 	path              string
 	base              string
 	mutex             *sync.Mutex
 	Exclusions        []string `hash:"ignore"`
+	IncludedMounts    []string `hash:"ignore"` // mount points (relative to the root) to index, all others are skipped (root filesystem only)
 }
 
 // Input is an object that captures the detected user input regarding source location, scheme, and provider type.
@@ -133,6 +137,10 @@ func New(in Input, registryOptions *image.RegistryOptions, exclusions []string)
 		source, cleanupFn, err = generateFileSource(fs, in)
 	case DirectoryScheme:
 		source, cleanupFn, err = generateDirectorySource(fs, in)
+	case RootFSScheme:
+		source, cleanupFn, err = generateRootFSSource(fs, in)
+	case DiskImageScheme:
+		source, cleanupFn, err = generateDiskImageSource(fs, in)
 	case ImageScheme:
 		source, cleanupFn, err = generateImageSource(in, registryOptions)
 	default:
@@ -243,6 +251,42 @@ func generateDirectorySource(fs afero.Fs, in Input) (*Source, func(), error) {
 	return &s, func() {}, nil
 }
 
+func generateRootFSSource(fs afero.Fs, in Input) (*Source, func(), error) {
+	fileMeta, err := fs.Stat(in.Location)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("unable to stat root filesystem=%q: %w", in.Location, err)
+	}
+
+	if !fileMeta.IsDir() {
+		return nil, func() {}, fmt.Errorf("given root filesystem path is not a directory (path=%q)", in.Location)
+	}
+
+	s, err := NewFromRootFSWithName(in.Location, in.Name)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("could not populate source from root filesystem=%q: %w", in.Location, err)
+	}
+
+	return &s, func() {}, nil
+}
+
+func generateDiskImageSource(fs afero.Fs, in Input) (*Source, func(), error) {
+	fileMeta, err := fs.Stat(in.Location)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("unable to stat disk image=%q: %w", in.Location, err)
+	}
+
+	if fileMeta.IsDir() {
+		return nil, func() {}, fmt.Errorf("given disk image path is a directory (path=%q)", in.Location)
+	}
+
+	s, cleanupFn, err := NewFromDiskImageWithName(in.Location, in.Name)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("could not populate source from disk image=%q: %w", in.Location, err)
+	}
+
+	return &s, cleanupFn, nil
+}
+
 func generateFileSource(fs afero.Fs, in Input) (*Source, func(), error) {
 	fileMeta, err := fs.Stat(in.Location)
 	if err != nil {
@@ -300,6 +344,30 @@ func NewFromDirectoryRootWithName(path string, name string) (Source, error) {
 	return s, nil
 }
 
+// NewFromRootFS creates a new source object tailored to catalog the root filesystem of a host, VM, or exported
+// container. All paths are resolved relative to the given root and other mounted filesystems are not traversed.
+func NewFromRootFS(path string) (Source, error) {
+	return NewFromRootFSWithName(path, "")
+}
+
+// NewFromRootFSWithName creates a new source object tailored to catalog the root filesystem of a host, VM, or exported
+// container, with an explicitly provided name.
+func NewFromRootFSWithName(path string, name string) (Source, error) {
+	s := Source{
+		mutex: &sync.Mutex{},
+		Metadata: Metadata{
+			Name:   name,
+			Scheme: RootFSScheme,
+			Path:   path,
+			Base:   path,
+		},
+		path: path,
+		base: path,
+	}
+	s.SetID()
+	return s, nil
+}
+
 // NewFromFile creates a new source object tailored to catalog a file.
 func NewFromFile(path string) (Source, func()) {
 	return NewFromFileWithName(path, "")
@@ -390,7 +458,7 @@ func (s *Source) ID() artifact.ID {
 func (s *Source) SetID() {
 	var d string
 	switch s.Metadata.Scheme {
-	case DirectoryScheme:
+	case DirectoryScheme, RootFSScheme, DiskImageScheme:
 		d = digest.FromString(s.Metadata.Path).String()
 	case FileScheme:
 		// attempt to use the digest of the contents of the file as the ID
@@ -469,6 +537,36 @@ func (s *Source) FileResolver(scope Scope) (FileResolver, error) {
 			s.directoryResolver = resolver
 		}
 		return s.directoryResolver, nil
+	case RootFSScheme:
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if s.directoryResolver == nil {
+			exclusionFunctions, err := getDirectoryExclusionFunctions(s.path, s.Exclusions)
+			if err != nil {
+				return nil, err
+			}
+			mountFunctions, err := getRootFSExclusionFunctions(s.path, s.IncludedMounts)
+			if err != nil {
+				return nil, err
+			}
+			resolver, err := newDirectoryResolver(s.path, s.base, append(mountFunctions, exclusionFunctions...)...)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create root filesystem resolver: %w", err)
+			}
+			s.directoryResolver = resolver
+		}
+		return s.directoryResolver, nil
+	case DiskImageScheme:
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if s.diskImageResolver == nil {
+			resolver, err := newDiskImageResolver(s.Metadata.Path, s.diskFilesystem, s.Exclusions)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create disk image resolver: %w", err)
+			}
+			s.diskImageResolver = resolver
+		}
+		return s.diskImageResolver, nil
 	case ImageScheme:
 		var resolver FileResolver
 		var err error
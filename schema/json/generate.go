@@ -30,35 +30,54 @@ can be extended to include specific package metadata struct shapes in the future
 
 // TODO: this should be generated from reflection of whats in the pkg package
 type artifactMetadataContainer struct {
-	Alpm               pkg.AlpmMetadata
-	Apk                pkg.ApkMetadata
-	Binary             pkg.BinaryMetadata
-	Cocopods           pkg.CocoapodsMetadata
-	Conan              pkg.ConanMetadata
-	ConanLock          pkg.ConanLockMetadata
-	Dart               pkg.DartPubMetadata
-	Dotnet             pkg.DotnetDepsMetadata
-	Dpkg               pkg.DpkgMetadata
-	Gem                pkg.GemMetadata
-	GoBin              pkg.GolangBinMetadata
-	GoMod              pkg.GolangModMetadata
-	Hackage            pkg.HackageMetadata
-	Java               pkg.JavaMetadata
-	KbPackage          pkg.KbPackageMetadata
-	LinuxKernel        pkg.LinuxKernelMetadata
-	LinuxKernelModule  pkg.LinuxKernelModuleMetadata
-	Nix                pkg.NixStoreMetadata
-	NpmPackage         pkg.NpmPackageJSONMetadata
-	NpmPackageLock     pkg.NpmPackageLockJSONMetadata
-	MixLock            pkg.MixLockMetadata
-	Php                pkg.PhpComposerJSONMetadata
-	Portage            pkg.PortageMetadata
-	PythonPackage      pkg.PythonPackageMetadata
-	PythonPipfilelock  pkg.PythonPipfileLockMetadata
-	PythonRequirements pkg.PythonRequirementsMetadata
-	Rebar              pkg.RebarLockMetadata
-	Rpm                pkg.RpmMetadata
-	RustCargo          pkg.CargoPackageMetadata
+	Alpm                pkg.AlpmMetadata
+	AndroidApp          pkg.AndroidAppMetadata
+	AndroidNativeLib    pkg.AndroidNativeLibraryMetadata
+	Apk                 pkg.ApkMetadata
+	Binary              pkg.BinaryMetadata
+	Cocopods            pkg.CocoapodsMetadata
+	Conan               pkg.ConanMetadata
+	ConanLock           pkg.ConanLockMetadata
+	Cpan                pkg.CpanMetadata
+	Dart                pkg.DartPubMetadata
+	Dotnet              pkg.DotnetDepsMetadata
+	DotnetPE            pkg.DotnetPortableExecutableMetadata
+	Dpkg                pkg.DpkgMetadata
+	Drupal              pkg.DrupalMetadata
+	Gem                 pkg.GemMetadata
+	GemfileLock         pkg.GemfileLockMetadata
+	GithubActionsUse    pkg.GithubActionsUseMetadata
+	GoBin               pkg.GolangBinMetadata
+	GoMod               pkg.GolangModMetadata
+	Hackage             pkg.HackageMetadata
+	Java                pkg.JavaMetadata
+	Julia               pkg.JuliaPackageMetadata
+	KbPackage           pkg.KbPackageMetadata
+	LinuxKernel         pkg.LinuxKernelMetadata
+	LinuxKernelModule   pkg.LinuxKernelModuleMetadata
+	LuaRocks            pkg.LuaRocksMetadata
+	Nix                 pkg.NixStoreMetadata
+	NpmPackage          pkg.NpmPackageJSONMetadata
+	NpmPackageLock      pkg.NpmPackageLockJSONMetadata
+	MixLock             pkg.MixLockMetadata
+	Opam                pkg.OpamMetadata
+	Php                 pkg.PhpComposerJSONMetadata
+	Portage             pkg.PortageMetadata
+	PythonPackage       pkg.PythonPackageMetadata
+	PythonPipfilelock   pkg.PythonPipfileLockMetadata
+	PythonPoetryLock    pkg.PythonPoetryLockMetadata
+	PythonLockfile      pkg.PythonLockfileMetadata
+	PythonRequirements  pkg.PythonRequirementsMetadata
+	R                   pkg.RPackageMetadata
+	Rebar               pkg.RebarLockMetadata
+	Rpm                 pkg.RpmMetadata
+	RustCargo           pkg.CargoPackageMetadata
+	SwiftPackageManager pkg.SwiftPackageManagerMetadata
+	TerraformLock       pkg.TerraformLockProviderMetadata
+	TerraformProvider   pkg.TerraformProviderBinaryMetadata
+	VcpkgInstalled      pkg.VcpkgInstalledMetadata
+	VcpkgManifest       pkg.VcpkgManifestMetadata
+	Wordpress           pkg.WordpressMetadata
 }
 
 func main() {